@@ -1,27 +1,50 @@
 package main
 
 import (
+	"context"
+	"time"
+
 	"luizalabs-technical-test/internal/config"
 	"luizalabs-technical-test/internal/dependencies"
 	"luizalabs-technical-test/internal/pkg/cors"
+	"luizalabs-technical-test/internal/pkg/middleware"
+	"luizalabs-technical-test/pkg/env"
 	"luizalabs-technical-test/pkg/logger"
 	"luizalabs-technical-test/pkg/postgres"
 	"luizalabs-technical-test/pkg/server"
 	"luizalabs-technical-test/pkg/shutdown"
 )
 
+const (
+	// defaultShutdownDelay defines how long the server answers as unready before closing its listener when
+	// SERVER_SHUTDOWN_DELAY is not set.
+	defaultShutdownDelay = 5 * time.Second
+	// defaultShutdownTimeout defines how long in-flight requests are drained on shutdown when SERVER_SHUTDOWN_TIMEOUT is not set.
+	defaultShutdownTimeout = 30 * time.Second
+)
+
 func main() {
+	srv := server.NewGinServer()
+
+	// Note: the server turns unready, stops accepting requests once the delay is over and drains the in-flight ones
+	// before the cache and the database they use are closed.
 	cleanup := func() {
 		logger.Warn("service stop running...")
+		delay := env.ParseDuration(config.ServerConfig.ShutdownDelay, defaultShutdownDelay)
+		ctx, cancel := context.WithTimeout(context.Background(), delay+env.ParseDuration(config.ServerConfig.ShutdownTimeout, defaultShutdownTimeout))
+		defer cancel()
+		if err := srv.Shutdown(ctx, delay); err != nil {
+			logger.Error(err)
+		}
+		dependencies.Close()
 		postgres.Close()
 		logger.Warn("server stoped correctly.")
 	}
 
 	runnapp := func() {
-		srv := server.NewGinServer()
-
 		srv.SetupCustom(cors.RouteSettings)
-		srv.SetupHandlers("v1", dependencies.Load()...)
+		srv.SetupMiddleware(middleware.NewCorrelationMiddleware().Middleware())
+		srv.SetupHandlers("v1", dependencies.Load(srv)...)
 		srv.SetupMiddleware(cors.Middleware())
 		logger.Warn("starting server on port: " + config.ServerConfig.Port)
 
@@ -31,15 +31,26 @@ const docTemplate = `{
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Authorization token",
+                        "description": "Authorization token (required when X-API-Key is not provided)",
                         "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key granted with the address:read scope",
+                        "name": "X-API-Key",
+                        "in": "header"
                     },
                     {
                         "type": "string",
-                        "description": "Cache control directive (e.g., 'no-cache')",
-                        "name": "X-Cache-Control",
+                        "description": "Cache directives: no-cache, no-store or max-age (e.g., 'no-cache')",
+                        "name": "Cache-Control",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previously received response",
+                        "name": "If-None-Match",
                         "in": "header"
                     },
                     {
@@ -57,6 +68,9 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_features_zipcode.swagGetAddressByZipCodeResponse"
                         }
                     },
+                    "304": {
+                        "description": "Not modified, the response matching If-None-Match is still fresh"
+                    },
                     "400": {
                         "description": "Invalid ZIP code format",
                         "schema": {
@@ -68,39 +82,213 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
                         }
+                    },
+                    "429": {
+                        "description": "Daily request quota of the organization exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/v1/auth/login": {
-            "post": {
-                "description": "Authenticates the user with the provided credentials and returns a JWT token.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/v1/admin/audit-events": {
+            "get": {
+                "description": "Lists the recorded authentication events, newest first, optionally filtered by organization, actor, action, outcome, IP, correlation ID and date. Restricted to administrators.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Authenticate user and return a JWT token",
+                "summary": "List audit events",
                 "parameters": [
                     {
-                        "description": "User credentials",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Events per page (max. 500, defaults to 50)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Organization of the actor (forced for administrators of an organization)",
+                        "name": "tenant_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID of the actor",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Email or client ID presented by the actor, case insensitive",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Action (e.g. login, register, password_change, token_rejected)",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Outcome (success, failure or challenged)",
+                        "name": "outcome",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client IP",
+                        "name": "ip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correlation ID of the request",
+                        "name": "correlation_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimum date (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum date (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Audit events",
                         "schema": {
-                            "$ref": "#/definitions/internal_features_auth.PostLoginPayload"
+                            "$ref": "#/definitions/internal_features_audit.swagListEventsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/audit-events/export": {
+            "get": {
+                "description": "Exports every recorded authentication event matching the filters as CSV, oldest first. Pagination is ignored. Restricted to administrators.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Export audit events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Organization of the actor (forced for administrators of an organization)",
+                        "name": "tenant_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID of the actor",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Email or client ID presented by the actor, case insensitive",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Action (e.g. login, register, password_change, token_rejected)",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Outcome (success, failure or challenged)",
+                        "name": "outcome",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client IP",
+                        "name": "ip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correlation ID of the request",
+                        "name": "correlation_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimum date (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum date (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": "Token generated successfully",
+                    "200": {
+                        "description": "Audit events as CSV",
                         "schema": {
-                            "$ref": "#/definitions/internal_features_auth.swagAuthenticateUserResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -114,13 +302,85 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/v1/auth/register": {
+        "/v1/admin/cache/entries": {
+            "get": {
+                "description": "Returns the entry cached under the key, with the seconds left before it expires. JSON values are returned as is, other values as base64. Only cached responses (response:) and addresses (zipcode:) can be inspected. Restricted to administrators without organization.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Inspect a cache entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cache key (e.g. zipcode:01001000)",
+                        "name": "key",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cache entry",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_cacheadmin.swagEntryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or unmanaged key",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Key not cached",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/cache/purge": {
             "post": {
-                "description": "Registers a new user with the provided information.",
+                "description": "Removes one key, the keys starting with a prefix, the responses cached for a user (by email) or all the cached responses and addresses. Keys and prefixes must start with response: or zipcode:. Exactly one target must be given. Restricted to administrators without organization.",
                 "consumes": [
                     "application/json"
                 ],
@@ -128,23 +388,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Register a new user",
+                "summary": "Purge the cache",
                 "parameters": [
                     {
-                        "description": "User registration data",
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Purge target",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_features_auth.PostRegisterPayload"
+                            "$ref": "#/definitions/internal_features_cacheadmin.PostPurgePayload"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "User successfully registered"
+                    "200": {
+                        "description": "Purged entries",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_cacheadmin.swagPurgeResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad request",
@@ -152,8 +422,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
                         }
@@ -161,83 +437,2940 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/health/metrics": {
+        "/v1/admin/cache/stats": {
             "get": {
-                "description": "Returns the Prometheus metrics for monitoring",
+                "description": "Returns the hits and misses counted by this replica, and the entries, bytes and evictions of the cache backend. Restricted to administrators without organization.",
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "admin"
+                ],
+                "summary": "Get cache statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
                 ],
-                "summary": "Expose Prometheus metrics",
                 "responses": {
                     "200": {
-                        "description": "Metrics",
+                        "description": "Cache statistics",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/internal_features_cacheadmin.swagStatsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/health/ping": {
+        "/v1/admin/organizations": {
             "get": {
-                "description": "Responds with a \"pong\" message to indicate that the service is healthy.",
+                "description": "Lists every organization, ordered by name. Restricted to administrators without organization.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "admin"
                 ],
-                "summary": "Health check",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/internal_features_health.swagHealthResponse"
-                        }
-                    }
-                }
+                "summary": "List organizations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Organizations",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_organization.swagListOrganizationsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates an organization (tenant). Its daily request quota limits the requests its members may make to the address route, zero meaning unlimited. Restricted to administrators without organization.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Create an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Organization data",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_organization.PostOrganizationPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Organization created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_organization.swagOrganizationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Name already in use",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/organizations/{id}": {
+            "get": {
+                "description": "Returns an organization. Restricted to administrators without organization.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Organization",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_organization.swagOrganizationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid organization ID",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Permanently removes an organization. Its members must be removed first. Restricted to administrators without organization.",
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Organization deleted"
+                    },
+                    "400": {
+                        "description": "Invalid organization ID",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Organization still has members",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Renames an organization and/or changes its daily request quota. Omitted fields are left untouched. Restricted to administrators without organization.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Update an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Changed fields",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_organization.PatchOrganizationPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Organization updated",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_organization.swagOrganizationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Name already in use",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/organizations/{id}/members": {
+            "get": {
+                "description": "Lists the users belonging to an organization, oldest first. Restricted to administrators without organization.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List the members of an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Members",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_organization.swagListMembersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid organization ID",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/organizations/{id}/members/{user_id}": {
+            "put": {
+                "description": "Moves a user into the organization, leaving any organization it belonged to. Its access tokens are revoked, so the new tenant applies on the next login. Restricted to administrators without organization.",
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Add a member to an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Member added"
+                    },
+                    "400": {
+                        "description": "Invalid organization or user ID",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization or user not found",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes a user from the organization and revokes its access tokens. Restricted to administrators without organization.",
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Remove a member from an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Member removed"
+                    },
+                    "400": {
+                        "description": "Invalid organization or user ID",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found in the organization",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/users": {
+            "get": {
+                "description": "Lists the user accounts, oldest first, optionally filtered by email and creation date. Deleted accounts are only listed when requested. Restricted to administrators.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List user accounts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Users per page (max. 100, defaults to 20)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the email, case insensitive",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimum creation date (RFC 3339)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum creation date (RFC 3339)",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether deleted accounts are listed",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User accounts",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_auth.swagListUsersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/users/{id}": {
+            "delete": {
+                "description": "Soft deletes a user account, revoking its access tokens. The account can be restored later. Restricted to administrators.",
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete a user account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Account successfully deleted"
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Own account",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/users/{id}/disable": {
+            "post": {
+                "description": "Blocks the logins of a user account and revokes its access tokens until it is enabled again. Restricted to administrators.",
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Disable a user account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Account successfully disabled"
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Own account",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/users/{id}/enable": {
+            "post": {
+                "description": "Allows the logins of a disabled user account again. Restricted to administrators.",
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Enable a user account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Account successfully enabled"
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Own account",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/users/{id}/password-reset": {
+            "post": {
+                "description": "Blocks the logins of a user account until its password is reset, revoking its access tokens and sending a password reset email. Restricted to administrators.",
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Force a password reset",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Password reset successfully required"
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Own account",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/users/{id}/restore": {
+            "post": {
+                "description": "Reverts the deletion of a user account. Restricted to administrators.",
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Restore a user account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Account successfully restored"
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Deleted user not found",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Own account",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/users/{id}/role": {
+            "put": {
+                "description": "Changes the role of a user account, revoking the access tokens carrying the previous role. Restricted to administrators.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Change the role of a user account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_auth.PutRolePayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Role successfully changed"
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Own account",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/users/{id}/sessions": {
+            "get": {
+                "description": "Lists where a user is logged in, most recently seen first. Restricted to administrators.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List the sessions of a user account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Active sessions",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_auth.swagListSessionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/users/{id}/unlock": {
+            "post": {
+                "description": "Clears the failed login counter and the temporary lockout of a user account. Restricted to administrators.",
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Unlock a user account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Account successfully unlocked"
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api-keys": {
+            "get": {
+                "description": "Lists every API key owned by the authenticated user, without secret material.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "List API keys",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "API keys",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_apikey.swagListAPIKeysResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a named API key for machine-to-machine access. The plain key is only returned in this response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Create an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "API key data",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_apikey.PostAPIKeyPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "API key successfully created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_apikey.swagCreatedAPIKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api-keys/{id}": {
+            "delete": {
+                "description": "Revokes the given API key so it can no longer be used.",
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Revoke an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "API key successfully revoked"
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "API key not found",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api-keys/{id}/rotate": {
+            "post": {
+                "description": "Revokes the given API key and issues a new one with the same name, scopes and lifetime.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Rotate an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "API key successfully rotated",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_apikey.swagCreatedAPIKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "API key not found",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/login": {
+            "post": {
+                "description": "Authenticates the user with the provided credentials and returns a JWT token. When MFA is enabled, a short-lived MFA challenge token is returned instead, to be exchanged at /v1/auth/login/mfa. When user enumeration is prevented, unknown emails and locked accounts fail with invalid credentials.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Authenticate user and return a JWT token",
+                "parameters": [
+                    {
+                        "description": "User credentials",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_auth.PostLoginPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Token generated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_auth.swagAuthenticateUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email not verified, account disabled or password reset required",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Account temporarily locked",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts from this client",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/login/mfa": {
+            "post": {
+                "description": "Exchanges the MFA challenge token returned by the login, along with a TOTP or recovery code, for a JWT token. Each recovery code can only be used once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Complete the login with the second factor",
+                "parameters": [
+                    {
+                        "description": "MFA challenge token and code",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_auth.PostLoginMFAPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Token generated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_auth.swagAuthenticateUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid code or challenge token",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Account disabled or password reset required",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Account temporarily locked",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts from this client",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/mfa/confirm": {
+            "post": {
+                "description": "Enables MFA once the first code generated by the authenticator app is provided. The returned recovery codes are only shown once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Confirm the MFA enrolment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "TOTP code",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_auth.PostConfirmMFAPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "MFA enabled",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_auth.swagConfirmMFAResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload or code",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "MFA already enabled or not enrolled",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/mfa/enroll": {
+            "post": {
+                "description": "Generates a TOTP secret for the authenticated user, returned along with the otpauth URI to be added to an authenticator app. MFA is only enabled after the confirmation.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Start the MFA enrolment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pending enrolment",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_auth.swagEnrollMFAResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "MFA already enabled",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/oidc/authorize": {
+            "get": {
+                "description": "Redirects the user to the OpenID Connect provider, following the authorization code flow with PKCE. The provider redirects back to /v1/auth/oidc/callback once the user authenticates.",
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Start a login at the identity provider",
+                "responses": {
+                    "302": {
+                        "description": "Redirect to the identity provider"
+                    },
+                    "404": {
+                        "description": "No identity provider configured",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Identity provider unreachable",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/oidc/callback": {
+            "get": {
+                "description": "Exchanges the authorization code sent by the OpenID Connect provider for a JWT token. The identity is linked to the account with the same email when the provider verified it, or to a new account otherwise. When MFA is enabled, a short-lived MFA challenge token is returned instead, to be exchanged at /v1/auth/login/mfa.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Complete a login at the identity provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "State of the login",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Error reported by the identity provider",
+                        "name": "error",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Token generated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_auth.swagAuthenticateUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unknown state or code rejected by the identity provider",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email not verified, account disabled or password reset required",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No identity provider configured",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email of an existing account not verified by the identity provider",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts from this client",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/password/forgot": {
+            "post": {
+                "description": "Sends a single-use password reset token to the informed email. The response is the same whether or not the email is registered.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Request a password reset",
+                "parameters": [
+                    {
+                        "description": "Account email",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_auth.PostForgotPasswordPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Request accepted"
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/password/reset": {
+            "post": {
+                "description": "Consumes the password reset token and stores the new password. Every session opened before the reset is revoked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Reset the password of a user",
+                "parameters": [
+                    {
+                        "description": "Reset token and new password",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_auth.PostResetPasswordPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Password successfully reset"
+                    },
+                    "400": {
+                        "description": "Invalid payload, token or password policy violation",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/register": {
+            "post": {
+                "description": "Registers a new user with the provided information. The account starts unverified and a verification link is sent by email. When user enumeration is prevented, a taken email gets the same response and its owner is warned by email.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "User registration data",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_auth.PostRegisterPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User successfully registered"
+                    },
+                    "400": {
+                        "description": "Bad request or password policy violation",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/verify": {
+            "get": {
+                "description": "Validates the token sent by email on registration and marks the account as verified.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Verify the email address of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Verification token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Email successfully verified",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_auth.swagVerifyEmailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or expired token",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/health/metrics": {
+            "get": {
+                "description": "Returns the Prometheus metrics for monitoring",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Expose Prometheus metrics",
+                "responses": {
+                    "200": {
+                        "description": "Metrics",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/health/ping": {
+            "get": {
+                "description": "Responds with a \"pong\" message to indicate that the service is healthy.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_health.swagHealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/health/ready": {
+            "get": {
+                "description": "Responds with a \"ready\" message while the service accepts requests, and with 503 once it is shutting down.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_health.swagHealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_health.swagHealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/oauth/clients": {
+            "get": {
+                "description": "Lists every OAuth2 client registered by the authenticated user, without secrets.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "oauth"
+                ],
+                "summary": "List OAuth2 clients",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OAuth2 clients",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_oauth.swagListClientsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registers a client allowed to use the client credentials grant. The client secret is only returned in this response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "oauth"
+                ],
+                "summary": "Register an OAuth2 client",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Client data",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_oauth.PostClientPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Client successfully registered",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_oauth.swagRegisteredClientResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/oauth/clients/{client-id}": {
+            "delete": {
+                "description": "Deletes the given OAuth2 client so it can no longer obtain access tokens.",
+                "tags": [
+                    "oauth"
+                ],
+                "summary": "Delete an OAuth2 client",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client ID",
+                        "name": "client-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Client successfully deleted"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Client not found",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/oauth/token": {
+            "post": {
+                "description": "Issues an access token for the client credentials grant (RFC 6749, section 4.4). Client credentials may be sent via HTTP Basic authentication or in the form body.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "oauth"
+                ],
+                "summary": "Issue an OAuth2 access token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "HTTP Basic client credentials",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Must be client_credentials",
+                        "name": "grant_type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client ID (when not using HTTP Basic)",
+                        "name": "client_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client secret (when not using HTTP Basic)",
+                        "name": "client_secret",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Space-delimited list of scopes",
+                        "name": "scope",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Token generated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_oauth.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, grant type or scope",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_oauth.TokenErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Client authentication failed",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_oauth.TokenErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_oauth.TokenErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/me": {
+            "get": {
+                "description": "Returns the account of the authenticated user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get the authenticated user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User account",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_auth.swagUserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes the account of the authenticated user, revoking every access token previously issued.",
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete the authenticated user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Account successfully deleted"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates the account of the authenticated user. Changing the email address marks the account as unverified and sends a verification link to the new address.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update the authenticated user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Account data",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_auth.PatchProfilePayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated user account",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_auth.swagUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email already in use",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/me/password": {
+            "post": {
+                "description": "Replaces the password once the current one is confirmed. Every access token issued before the change is revoked, so a new one is returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Change the password of the authenticated user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Current and new passwords",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_auth.PostChangePasswordPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password changed, new token generated",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_auth.swagAuthenticateUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload or password policy violation",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Incorrect current password",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/me/sessions": {
+            "get": {
+                "description": "Lists where the authenticated user is logged in, most recently seen first. The session of the token in use is marked as current.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List the sessions of the authenticated user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Active sessions",
+                        "schema": {
+                            "$ref": "#/definitions/internal_features_auth.swagListSessionsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/me/sessions/{id}": {
+            "delete": {
+                "description": "Terminates a session of the authenticated user, revoking the access token bound to it. Terminating the current session logs the user out.",
+                "tags": [
+                    "users"
+                ],
+                "summary": "Terminate a session of the authenticated user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Session successfully terminated"
+                    },
+                    "400": {
+                        "description": "Invalid session ID",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/luizalabs-technical-test_pkg_server.APIErrorResponse"
+                        }
+                    }
+                }
             }
         }
     },
     "definitions": {
+        "internal_features_apikey.APIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_features_apikey.CreatedAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_features_apikey.PostAPIKeyPayload": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "expires_in_days": {
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_features_apikey.swagCreatedAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_features_apikey.CreatedAPIKeyResponse"
+                }
+            }
+        },
+        "internal_features_apikey.swagListAPIKeysResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_features_apikey.APIKeyResponse"
+                    }
+                }
+            }
+        },
+        "internal_features_audit.EventResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "actor_id": {
+                    "type": "integer"
+                },
+                "correlation_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "outcome": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "integer"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_features_audit.ListEventsResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_features_audit.EventResponse"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_features_audit.swagListEventsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_features_audit.ListEventsResponse"
+                }
+            }
+        },
+        "internal_features_auth.AdminUserResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "disabled": {
+                    "type": "boolean"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "email_verified": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "locked_until": {
+                    "type": "string"
+                },
+                "mfa_enabled": {
+                    "type": "boolean"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "password_reset_required": {
+                    "type": "boolean"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
         "internal_features_auth.AuthenticateUserResponse": {
             "type": "object",
             "properties": {
-                "token": {
+                "mfa_required": {
+                    "type": "boolean"
+                },
+                "mfa_token": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_features_auth.ConfirmMFAResponse": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_features_auth.EnrollMFAResponse": {
+            "type": "object",
+            "properties": {
+                "otpauth_uri": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_features_auth.ListUsersResponse": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_features_auth.AdminUserResponse"
+                    }
+                }
+            }
+        },
+        "internal_features_auth.PatchProfilePayload": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_features_auth.PostChangePasswordPayload": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_features_auth.PostConfirmMFAPayload": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_features_auth.PostForgotPasswordPayload": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_features_auth.PostLoginMFAPayload": {
+            "type": "object",
+            "required": [
+                "code",
+                "mfa_token"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "mfa_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_features_auth.PostLoginPayload": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_features_auth.PostRegisterPayload": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_features_auth.PostResetPasswordPayload": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_features_auth.PutRolePayload": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "user",
+                        "admin"
+                    ]
+                }
+            }
+        },
+        "internal_features_auth.SessionResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current": {
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "user_agent": {
                     "type": "string"
                 }
             }
         },
-        "internal_features_auth.PostLoginPayload": {
+        "internal_features_auth.UserResponse": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
+                "created_at": {
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
-                "password": {
+                "email_verified": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mfa_enabled": {
+                    "type": "boolean"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "role": {
                     "type": "string"
                 }
             }
         },
-        "internal_features_auth.PostRegisterPayload": {
+        "internal_features_auth.VerifyEmailResponse": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
                 "email": {
                     "type": "string"
-                },
-                "password": {
-                    "type": "string"
                 }
             }
         },
@@ -249,6 +3382,171 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_features_auth.swagConfirmMFAResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_features_auth.ConfirmMFAResponse"
+                }
+            }
+        },
+        "internal_features_auth.swagEnrollMFAResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_features_auth.EnrollMFAResponse"
+                }
+            }
+        },
+        "internal_features_auth.swagListSessionsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_features_auth.SessionResponse"
+                    }
+                }
+            }
+        },
+        "internal_features_auth.swagListUsersResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_features_auth.ListUsersResponse"
+                }
+            }
+        },
+        "internal_features_auth.swagUserResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_features_auth.UserResponse"
+                }
+            }
+        },
+        "internal_features_auth.swagVerifyEmailResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_features_auth.VerifyEmailResponse"
+                }
+            }
+        },
+        "internal_features_cacheadmin.EntryResponse": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "raw": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "ttl_seconds": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "object"
+                }
+            }
+        },
+        "internal_features_cacheadmin.EvictionsResponse": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "expired": {
+                    "type": "integer"
+                },
+                "memory": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_features_cacheadmin.PostPurgePayload": {
+            "type": "object",
+            "properties": {
+                "all": {
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string",
+                    "maxLength": 400
+                },
+                "prefix": {
+                    "type": "string",
+                    "maxLength": 400
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_features_cacheadmin.PurgeResponse": {
+            "type": "object",
+            "properties": {
+                "purged": {
+                    "type": "integer"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_features_cacheadmin.StatsResponse": {
+            "type": "object",
+            "properties": {
+                "bytes": {
+                    "type": "integer"
+                },
+                "entries": {
+                    "type": "integer"
+                },
+                "evictions": {
+                    "$ref": "#/definitions/internal_features_cacheadmin.EvictionsResponse"
+                },
+                "hit_ratio": {
+                    "type": "number"
+                },
+                "hits": {
+                    "type": "integer"
+                },
+                "misses": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_features_cacheadmin.swagEntryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_features_cacheadmin.EntryResponse"
+                }
+            }
+        },
+        "internal_features_cacheadmin.swagPurgeResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_features_cacheadmin.PurgeResponse"
+                }
+            }
+        },
+        "internal_features_cacheadmin.swagStatsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_features_cacheadmin.StatsResponse"
+                }
+            }
+        },
         "internal_features_health.healthResponse": {
             "type": "object",
             "properties": {
@@ -265,6 +3563,208 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_features_oauth.ClientResponse": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_features_oauth.PostClientPayload": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_features_oauth.RegisteredClientResponse": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string"
+                },
+                "client_secret": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_features_oauth.TokenErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "error_description": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_features_oauth.TokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "type": "integer"
+                },
+                "scope": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_features_oauth.swagListClientsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_features_oauth.ClientResponse"
+                    }
+                }
+            }
+        },
+        "internal_features_oauth.swagRegisteredClientResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_features_oauth.RegisteredClientResponse"
+                }
+            }
+        },
+        "internal_features_organization.MemberResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_features_organization.OrganizationResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "daily_request_quota": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_features_organization.PatchOrganizationPayload": {
+            "type": "object",
+            "properties": {
+                "daily_request_quota": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                }
+            }
+        },
+        "internal_features_organization.PostOrganizationPayload": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "daily_request_quota": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "internal_features_organization.swagListMembersResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_features_organization.MemberResponse"
+                    }
+                }
+            }
+        },
+        "internal_features_organization.swagListOrganizationsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_features_organization.OrganizationResponse"
+                    }
+                }
+            }
+        },
+        "internal_features_organization.swagOrganizationResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/internal_features_organization.OrganizationResponse"
+                }
+            }
+        },
         "internal_features_zipcode.GetAddressByZipCodeResponse": {
             "type": "object",
             "properties": {
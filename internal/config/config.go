@@ -12,6 +12,12 @@ var (
 	ServerConfig   serverConfig
 	GeneralConfig  generalConfig
 	PostgresConfig postgresConfig
+	AuthConfig     authConfig
+	MailConfig     mailConfig
+	AuditConfig    auditConfig
+	OIDCConfig     oidcConfig
+	CacheConfig    cacheConfig
+	QuotaConfig    quotaConfig
 )
 
 // init loads environment variables into the configuration structures using "env" tags.
@@ -19,7 +25,7 @@ func init() {
 	const tagName = "env"
 
 	godotenv.Load(".env")
-	env.LoadStructWithEnvVars(tagName, &ServerConfig, &GeneralConfig, &PostgresConfig)
+	env.LoadStructWithEnvVars(tagName, &ServerConfig, &GeneralConfig, &PostgresConfig, &AuthConfig, &MailConfig, &AuditConfig, &OIDCConfig, &CacheConfig, &QuotaConfig)
 }
 
 // Structure to load database configurations (connection string).
@@ -36,10 +42,94 @@ type generalConfig struct {
 	SecretAuthTokenKey string `env:"SECRET_AUTH_TOKEN_KEY"`
 }
 
+// Structure to load authentication settings (login throttling and lockout).
+type authConfig struct {
+	MaxFailedAttempts      string `env:"AUTH_MAX_FAILED_ATTEMPTS"`
+	MaxFailedAttemptsPerIP string `env:"AUTH_MAX_FAILED_ATTEMPTS_PER_IP"`
+	LockoutDuration        string `env:"AUTH_LOCKOUT_DURATION"`
+	MaxLockoutDuration     string `env:"AUTH_MAX_LOCKOUT_DURATION"`
+	RequireVerifiedEmail   string `env:"AUTH_REQUIRE_VERIFIED_EMAIL"`
+	VerificationURL        string `env:"AUTH_VERIFICATION_URL"`
+	ResetTokenExpiration   string `env:"AUTH_RESET_TOKEN_EXPIRATION"`
+	PasswordResetURL       string `env:"AUTH_PASSWORD_RESET_URL"`
+	PasswordMinLength      string `env:"AUTH_PASSWORD_MIN_LENGTH"`
+	PasswordMaxLength      string `env:"AUTH_PASSWORD_MAX_LENGTH"`
+	PasswordRequireUpper   string `env:"AUTH_PASSWORD_REQUIRE_UPPER"`
+	PasswordRequireLower   string `env:"AUTH_PASSWORD_REQUIRE_LOWER"`
+	PasswordRequireDigit   string `env:"AUTH_PASSWORD_REQUIRE_DIGIT"`
+	PasswordRequireSymbol  string `env:"AUTH_PASSWORD_REQUIRE_SYMBOL"`
+	PasswordForbidEmail    string `env:"AUTH_PASSWORD_FORBID_EMAIL"`
+	BreachedPasswordsPath  string `env:"AUTH_BREACHED_PASSWORDS_PATH"`
+	PasswordHasher         string `env:"AUTH_PASSWORD_HASHER"`
+	BcryptCost             string `env:"AUTH_BCRYPT_COST"`
+	Argon2Memory           string `env:"AUTH_ARGON2_MEMORY"`
+	Argon2Iterations       string `env:"AUTH_ARGON2_ITERATIONS"`
+	Argon2Parallelism      string `env:"AUTH_ARGON2_PARALLELISM"`
+	MFAIssuer              string `env:"AUTH_MFA_ISSUER"`
+	MFAChallengeExpiration string `env:"AUTH_MFA_CHALLENGE_EXPIRATION"`
+	PreventEnumeration     string `env:"AUTH_PREVENT_ENUMERATION"`
+}
+
+// Structure to load mail delivery settings (SMTP server or local outbox).
+type mailConfig struct {
+	Driver       string `env:"MAIL_DRIVER"`
+	From         string `env:"MAIL_FROM"`
+	OutboxPath   string `env:"MAIL_OUTBOX_PATH"`
+	SMTPHost     string `env:"SMTP_HOST"`
+	SMTPPort     string `env:"SMTP_PORT"`
+	SMTPUsername string `env:"SMTP_USERNAME"`
+	SMTPPassword string `env:"SMTP_PASSWORD"`
+}
+
+// Structure to load audit log settings (retention of the recorded events).
+type auditConfig struct {
+	Retention string `env:"AUDIT_RETENTION"`
+}
+
+// Structure to load request quota settings (retention of the daily request counters).
+type quotaConfig struct {
+	UsageRetention string `env:"QUOTA_USAGE_RETENTION"`
+}
+
+// Structure to load the client registration at the OpenID Connect identity provider (disabled without issuer).
+type oidcConfig struct {
+	Issuer       string `env:"OIDC_ISSUER"`
+	ClientID     string `env:"OIDC_CLIENT_ID"`
+	ClientSecret string `env:"OIDC_CLIENT_SECRET"`
+	RedirectURL  string `env:"OIDC_REDIRECT_URL"`
+	Scopes       string `env:"OIDC_SCOPES"`
+}
+
+// Structure to load the cache backend settings (in-memory, Redis or Postgres table).
+type cacheConfig struct {
+	Driver               string `env:"CACHE_DRIVER"`
+	RedisAddress         string `env:"CACHE_REDIS_ADDRESS"`
+	RedisPassword        string `env:"CACHE_REDIS_PASSWORD"`
+	RedisDB              string `env:"CACHE_REDIS_DB"`
+	RedisTimeout         string `env:"CACHE_REDIS_TIMEOUT"`
+	MaxEntries           string `env:"CACHE_MAX_ENTRIES"`
+	MaxBytes             string `env:"CACHE_MAX_BYTES"`
+	Eviction             string `env:"CACHE_EVICTION"`
+	Shards               string `env:"CACHE_SHARDS"`
+	StaleWhileRevalidate string `env:"CACHE_STALE_WHILE_REVALIDATE"`
+	ResponseExpiration   string `env:"CACHE_RESPONSE_EXPIRATION"`
+	NegativeExpiration   string `env:"CACHE_NEGATIVE_EXPIRATION"`
+	StatusClasses        string `env:"CACHE_STATUS_CLASSES"`
+	ReplayedHeaders      string `env:"CACHE_REPLAYED_HEADERS"`
+	AddressScope         string `env:"CACHE_ADDRESS_SCOPE"`
+	AddressExpiration    string `env:"CACHE_ADDRESS_EXPIRATION"`
+	SnapshotPath         string `env:"CACHE_SNAPSHOT_PATH"`
+	SnapshotInterval     string `env:"CACHE_SNAPSHOT_INTERVAL"`
+	WarmUpZipCodes       string `env:"CACHE_WARMUP_ZIPCODES"`
+	WarmUpInterval       string `env:"CACHE_WARMUP_INTERVAL"`
+}
+
 // Structure to load server configurations (port and host).
 type serverConfig struct {
-	Port string `env:"SERVER_PORT"`
-	Host string `env:"SERVER_HOST"`
+	Port            string `env:"SERVER_PORT"`
+	Host            string `env:"SERVER_HOST"`
+	ShutdownDelay   string `env:"SERVER_SHUTDOWN_DELAY"`
+	ShutdownTimeout string `env:"SERVER_SHUTDOWN_TIMEOUT"`
 }
 
 // ToPostgresDSN fromats provided data into postgres db dsn.
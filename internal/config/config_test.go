@@ -13,21 +13,87 @@ import (
 func TestInit(t *testing.T) {
 	// ARRANGE & ACT
 	envVars := map[string]string{
-		"PG_HOST":               "localhost",
-		"PG_PORT":               "5432",
-		"PG_USER":               "user",
-		"PG_PASSWORD":           "password",
-		"PG_DATABASE":           "testdb",
-		"SECRET_AUTH_TOKEN_KEY": "test-secret",
-		"SERVER_PORT":           "8080",
-		"SERVER_HOST":           "localhost",
+		"PG_HOST":                 "localhost",
+		"PG_PORT":                 "5432",
+		"PG_USER":                 "user",
+		"PG_PASSWORD":             "password",
+		"PG_DATABASE":             "testdb",
+		"SECRET_AUTH_TOKEN_KEY":   "test-secret",
+		"SERVER_PORT":             "8080",
+		"SERVER_HOST":             "localhost",
+		"SERVER_SHUTDOWN_DELAY":   "5s",
+		"SERVER_SHUTDOWN_TIMEOUT": "15s",
+
+		"AUTH_MAX_FAILED_ATTEMPTS":        "5",
+		"AUTH_MAX_FAILED_ATTEMPTS_PER_IP": "20",
+		"AUTH_LOCKOUT_DURATION":           "1m",
+		"AUTH_MAX_LOCKOUT_DURATION":       "1h",
+		"AUTH_REQUIRE_VERIFIED_EMAIL":     "true",
+		"AUTH_VERIFICATION_URL":           "http://localhost:8080/v1/auth/verify",
+		"AUTH_RESET_TOKEN_EXPIRATION":     "30m",
+		"AUTH_PASSWORD_RESET_URL":         "http://localhost:3000/reset-password",
+		"AUTH_PASSWORD_MIN_LENGTH":        "8",
+		"AUTH_PASSWORD_MAX_LENGTH":        "64",
+		"AUTH_PASSWORD_REQUIRE_UPPER":     "true",
+		"AUTH_PASSWORD_REQUIRE_LOWER":     "true",
+		"AUTH_PASSWORD_REQUIRE_DIGIT":     "true",
+		"AUTH_PASSWORD_REQUIRE_SYMBOL":    "false",
+		"AUTH_PASSWORD_FORBID_EMAIL":      "true",
+		"AUTH_BREACHED_PASSWORDS_PATH":    "/etc/breached-passwords.txt",
+		"AUTH_PASSWORD_HASHER":            "argon2id",
+		"AUTH_BCRYPT_COST":                "12",
+		"AUTH_ARGON2_MEMORY":              "65536",
+		"AUTH_ARGON2_ITERATIONS":          "3",
+		"AUTH_ARGON2_PARALLELISM":         "2",
+		"AUTH_MFA_ISSUER":                 "Luizalabs",
+		"AUTH_MFA_CHALLENGE_EXPIRATION":   "5m",
+		"AUTH_PREVENT_ENUMERATION":        "true",
+
+		"MAIL_DRIVER":      "smtp",
+		"MAIL_FROM":        "no-reply@example.com",
+		"MAIL_OUTBOX_PATH": "outbox.jsonl",
+		"SMTP_HOST":        "localhost",
+		"SMTP_PORT":        "1025",
+		"SMTP_USERNAME":    "mailer",
+		"SMTP_PASSWORD":    "secret",
+
+		"AUDIT_RETENTION": "720h",
+
+		"QUOTA_USAGE_RETENTION": "168h",
+
+		"OIDC_ISSUER":        "https://accounts.example.com",
+		"OIDC_CLIENT_ID":     "client-id",
+		"OIDC_CLIENT_SECRET": "client-secret",
+		"OIDC_REDIRECT_URL":  "http://localhost:8080/v1/auth/oidc/callback",
+		"OIDC_SCOPES":        "openid email",
+
+		"CACHE_DRIVER":                 "redis",
+		"CACHE_REDIS_ADDRESS":          "localhost:6379",
+		"CACHE_REDIS_PASSWORD":         "secret",
+		"CACHE_REDIS_DB":               "1",
+		"CACHE_REDIS_TIMEOUT":          "250ms",
+		"CACHE_MAX_ENTRIES":            "10000",
+		"CACHE_MAX_BYTES":              "67108864",
+		"CACHE_EVICTION":               "lfu",
+		"CACHE_SHARDS":                 "32",
+		"CACHE_STALE_WHILE_REVALIDATE": "5m",
+		"CACHE_RESPONSE_EXPIRATION":    "30m",
+		"CACHE_NEGATIVE_EXPIRATION":    "1m",
+		"CACHE_STATUS_CLASSES":         "2xx,4xx",
+		"CACHE_REPLAYED_HEADERS":       "Content-Type,Content-Language",
+		"CACHE_ADDRESS_SCOPE":          "tenant",
+		"CACHE_ADDRESS_EXPIRATION":     "12h",
+		"CACHE_SNAPSHOT_PATH":          "/var/lib/app/cache.jsonl",
+		"CACHE_SNAPSHOT_INTERVAL":      "1m",
+		"CACHE_WARMUP_ZIPCODES":        "01001000,20040002",
+		"CACHE_WARMUP_INTERVAL":        "6h",
 	}
 
 	for key, value := range envVars {
 		err := os.Setenv(key, value)
 		assert.NoError(t, err, "failed to set environment variable")
 	}
-	env.LoadStructWithEnvVars("env", &ServerConfig, &GeneralConfig, &PostgresConfig)
+	env.LoadStructWithEnvVars("env", &ServerConfig, &GeneralConfig, &PostgresConfig, &AuthConfig, &MailConfig, &AuditConfig, &OIDCConfig, &CacheConfig, &QuotaConfig)
 
 	// ASSERT
 	assert.Equal(t, envVars["PG_HOST"], PostgresConfig.Host)
@@ -38,6 +104,67 @@ func TestInit(t *testing.T) {
 	assert.Equal(t, envVars["SECRET_AUTH_TOKEN_KEY"], GeneralConfig.SecretAuthTokenKey)
 	assert.Equal(t, envVars["SERVER_PORT"], ServerConfig.Port)
 	assert.Equal(t, envVars["SERVER_HOST"], ServerConfig.Host)
+	assert.Equal(t, envVars["SERVER_SHUTDOWN_DELAY"], ServerConfig.ShutdownDelay)
+	assert.Equal(t, envVars["SERVER_SHUTDOWN_TIMEOUT"], ServerConfig.ShutdownTimeout)
+	assert.Equal(t, envVars["AUTH_MAX_FAILED_ATTEMPTS"], AuthConfig.MaxFailedAttempts)
+	assert.Equal(t, envVars["AUTH_MAX_FAILED_ATTEMPTS_PER_IP"], AuthConfig.MaxFailedAttemptsPerIP)
+	assert.Equal(t, envVars["AUTH_LOCKOUT_DURATION"], AuthConfig.LockoutDuration)
+	assert.Equal(t, envVars["AUTH_MAX_LOCKOUT_DURATION"], AuthConfig.MaxLockoutDuration)
+	assert.Equal(t, envVars["AUTH_REQUIRE_VERIFIED_EMAIL"], AuthConfig.RequireVerifiedEmail)
+	assert.Equal(t, envVars["AUTH_VERIFICATION_URL"], AuthConfig.VerificationURL)
+	assert.Equal(t, envVars["AUTH_RESET_TOKEN_EXPIRATION"], AuthConfig.ResetTokenExpiration)
+	assert.Equal(t, envVars["AUTH_PASSWORD_RESET_URL"], AuthConfig.PasswordResetURL)
+	assert.Equal(t, envVars["AUTH_PASSWORD_MIN_LENGTH"], AuthConfig.PasswordMinLength)
+	assert.Equal(t, envVars["AUTH_PASSWORD_MAX_LENGTH"], AuthConfig.PasswordMaxLength)
+	assert.Equal(t, envVars["AUTH_PASSWORD_REQUIRE_UPPER"], AuthConfig.PasswordRequireUpper)
+	assert.Equal(t, envVars["AUTH_PASSWORD_REQUIRE_LOWER"], AuthConfig.PasswordRequireLower)
+	assert.Equal(t, envVars["AUTH_PASSWORD_REQUIRE_DIGIT"], AuthConfig.PasswordRequireDigit)
+	assert.Equal(t, envVars["AUTH_PASSWORD_REQUIRE_SYMBOL"], AuthConfig.PasswordRequireSymbol)
+	assert.Equal(t, envVars["AUTH_PASSWORD_FORBID_EMAIL"], AuthConfig.PasswordForbidEmail)
+	assert.Equal(t, envVars["AUTH_BREACHED_PASSWORDS_PATH"], AuthConfig.BreachedPasswordsPath)
+	assert.Equal(t, envVars["AUTH_PASSWORD_HASHER"], AuthConfig.PasswordHasher)
+	assert.Equal(t, envVars["AUTH_BCRYPT_COST"], AuthConfig.BcryptCost)
+	assert.Equal(t, envVars["AUTH_ARGON2_MEMORY"], AuthConfig.Argon2Memory)
+	assert.Equal(t, envVars["AUTH_ARGON2_ITERATIONS"], AuthConfig.Argon2Iterations)
+	assert.Equal(t, envVars["AUTH_ARGON2_PARALLELISM"], AuthConfig.Argon2Parallelism)
+	assert.Equal(t, envVars["AUTH_MFA_ISSUER"], AuthConfig.MFAIssuer)
+	assert.Equal(t, envVars["AUTH_MFA_CHALLENGE_EXPIRATION"], AuthConfig.MFAChallengeExpiration)
+	assert.Equal(t, envVars["AUTH_PREVENT_ENUMERATION"], AuthConfig.PreventEnumeration)
+	assert.Equal(t, envVars["MAIL_DRIVER"], MailConfig.Driver)
+	assert.Equal(t, envVars["MAIL_FROM"], MailConfig.From)
+	assert.Equal(t, envVars["MAIL_OUTBOX_PATH"], MailConfig.OutboxPath)
+	assert.Equal(t, envVars["SMTP_HOST"], MailConfig.SMTPHost)
+	assert.Equal(t, envVars["SMTP_PORT"], MailConfig.SMTPPort)
+	assert.Equal(t, envVars["SMTP_USERNAME"], MailConfig.SMTPUsername)
+	assert.Equal(t, envVars["SMTP_PASSWORD"], MailConfig.SMTPPassword)
+
+	assert.Equal(t, envVars["AUDIT_RETENTION"], AuditConfig.Retention)
+	assert.Equal(t, envVars["QUOTA_USAGE_RETENTION"], QuotaConfig.UsageRetention)
+	assert.Equal(t, envVars["OIDC_ISSUER"], OIDCConfig.Issuer)
+	assert.Equal(t, envVars["OIDC_CLIENT_ID"], OIDCConfig.ClientID)
+	assert.Equal(t, envVars["OIDC_CLIENT_SECRET"], OIDCConfig.ClientSecret)
+	assert.Equal(t, envVars["OIDC_REDIRECT_URL"], OIDCConfig.RedirectURL)
+	assert.Equal(t, envVars["OIDC_SCOPES"], OIDCConfig.Scopes)
+	assert.Equal(t, envVars["CACHE_DRIVER"], CacheConfig.Driver)
+	assert.Equal(t, envVars["CACHE_REDIS_ADDRESS"], CacheConfig.RedisAddress)
+	assert.Equal(t, envVars["CACHE_REDIS_PASSWORD"], CacheConfig.RedisPassword)
+	assert.Equal(t, envVars["CACHE_REDIS_DB"], CacheConfig.RedisDB)
+	assert.Equal(t, envVars["CACHE_REDIS_TIMEOUT"], CacheConfig.RedisTimeout)
+	assert.Equal(t, envVars["CACHE_MAX_ENTRIES"], CacheConfig.MaxEntries)
+	assert.Equal(t, envVars["CACHE_MAX_BYTES"], CacheConfig.MaxBytes)
+	assert.Equal(t, envVars["CACHE_EVICTION"], CacheConfig.Eviction)
+	assert.Equal(t, envVars["CACHE_SHARDS"], CacheConfig.Shards)
+	assert.Equal(t, envVars["CACHE_STALE_WHILE_REVALIDATE"], CacheConfig.StaleWhileRevalidate)
+	assert.Equal(t, envVars["CACHE_RESPONSE_EXPIRATION"], CacheConfig.ResponseExpiration)
+	assert.Equal(t, envVars["CACHE_NEGATIVE_EXPIRATION"], CacheConfig.NegativeExpiration)
+	assert.Equal(t, envVars["CACHE_STATUS_CLASSES"], CacheConfig.StatusClasses)
+	assert.Equal(t, envVars["CACHE_REPLAYED_HEADERS"], CacheConfig.ReplayedHeaders)
+	assert.Equal(t, envVars["CACHE_ADDRESS_SCOPE"], CacheConfig.AddressScope)
+	assert.Equal(t, envVars["CACHE_ADDRESS_EXPIRATION"], CacheConfig.AddressExpiration)
+	assert.Equal(t, envVars["CACHE_SNAPSHOT_PATH"], CacheConfig.SnapshotPath)
+	assert.Equal(t, envVars["CACHE_SNAPSHOT_INTERVAL"], CacheConfig.SnapshotInterval)
+	assert.Equal(t, envVars["CACHE_WARMUP_ZIPCODES"], CacheConfig.WarmUpZipCodes)
+	assert.Equal(t, envVars["CACHE_WARMUP_INTERVAL"], CacheConfig.WarmUpInterval)
 }
 
 // Test ToPostgresDSN method in order to correct generate connection string.
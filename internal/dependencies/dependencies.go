@@ -1,55 +1,204 @@
 package dependencies
 
 import (
+	"fmt"
 	"luizalabs-technical-test/internal/config"
+	"luizalabs-technical-test/internal/features/apikey"
+	"luizalabs-technical-test/internal/features/audit"
 	"luizalabs-technical-test/internal/features/auth"
+	"luizalabs-technical-test/internal/features/cacheadmin"
 	"luizalabs-technical-test/internal/features/health"
+	"luizalabs-technical-test/internal/features/oauth"
+	"luizalabs-technical-test/internal/features/organization"
 	"luizalabs-technical-test/internal/features/swagger"
 	"luizalabs-technical-test/internal/features/zipcode"
 	"luizalabs-technical-test/internal/pkg/entity"
 	"luizalabs-technical-test/internal/pkg/middleware"
+	"luizalabs-technical-test/internal/pkg/scope"
 	"luizalabs-technical-test/pkg/cache"
 	"luizalabs-technical-test/pkg/crypt"
+	"luizalabs-technical-test/pkg/env"
 	"luizalabs-technical-test/pkg/http"
 	"luizalabs-technical-test/pkg/logger"
+	"luizalabs-technical-test/pkg/mail"
+	"luizalabs-technical-test/pkg/oidc"
+	"luizalabs-technical-test/pkg/password"
 	"luizalabs-technical-test/pkg/postgres"
 	"luizalabs-technical-test/pkg/shutdown"
+	"math"
+	"strings"
 	"time"
 
 	netHttp "net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
 const cleanupInterval = 1 * time.Minute
 
-// Load sets up and returns a list of handler registration functions
-func Load() []func(*gin.RouterGroup) {
+// Cache backends selected by CACHE_DRIVER; any other value keeps the cache in memory.
+const (
+	redisCacheDriver    = "redis"
+	postgresCacheDriver = "postgres"
+	defaultRedisAddress = "localhost:6379"
+	defaultRedisTimeout = 250 * time.Millisecond
+)
+
+// Default bounds of the in-memory cache, used when the related environment variables are not set.
+const (
+	defaultCacheMaxEntries = 100000
+	defaultCacheMaxBytes   = 64 << 20
+)
+
+// Default cache settings of the address lookups. Addresses don't depend on the caller, so their responses are shared.
+const (
+	defaultAddressCacheScope      = middleware.GlobalCacheScope
+	defaultAddressCacheExpiration = 24 * time.Hour
+)
+
+// Default intervals of the cache snapshots and of the address warm-up. The warm-up runs well within the address
+// expiration, so the warmed addresses never expire.
+const (
+	defaultCacheSnapshotInterval = 5 * time.Minute
+	defaultAddressWarmUpInterval = 12 * time.Hour
+)
+
+// Default audit log settings. Expired events are purged every auditPurgeInterval.
+const (
+	defaultAuditRetention = 90 * 24 * time.Hour
+	auditPurgeInterval    = 1 * time.Hour
+)
+
+// Default retention of the daily request counters of the organizations. Expired counters are purged every
+// quotaUsagePurgeInterval.
+const (
+	defaultQuotaUsageRetention = 30 * 24 * time.Hour
+	quotaUsagePurgeInterval    = 1 * time.Hour
+)
+
+// Default login throttling settings, used when the related environment variables are not set.
+const (
+	defaultMaxFailedAttempts      = 5
+	defaultMaxFailedAttemptsPerIP = 20
+	defaultLockoutDuration        = 1 * time.Minute
+	defaultMaxLockoutDuration     = 1 * time.Hour
+)
+
+// Default email verification and password reset settings, used when the related environment variables are not set.
+const (
+	defaultVerificationTokenExpiration = 24 * time.Hour
+	defaultResetTokenExpiration        = 30 * time.Minute
+	defaultMailFrom                    = "no-reply@luizalabs-technical-test.local"
+	smtpMailDriver                     = "smtp"
+)
+
+// Default MFA settings, used when the related environment variables are not set.
+const (
+	defaultMFAIssuer              = "luizalabs-technical-test"
+	defaultMFAChallengeExpiration = 5 * time.Minute
+)
+
+// Default OpenID Connect settings, used when the related environment variables are not set.
+const (
+	defaultOIDCScopes       = "openid email profile"
+	identityProviderTimeout = 10 * time.Second
+)
+
+// Default argon2id settings (64 MiB, 3 passes, 2 lanes), used when the related environment variables are not set.
+const (
+	defaultArgon2Memory      = 64 * 1024
+	defaultArgon2Iterations  = 3
+	defaultArgon2Parallelism = 2
+)
+
+// Default password policy settings, used when the related environment variables are not set.
+const (
+	defaultPasswordMinLength = 8
+	defaultPasswordMaxLength = 64
+)
+
+// openedCache is the cache backend created by Load, released by Close.
+var openedCache cache.Manager
+
+// Load sets up and returns a list of handler registration functions.
+// The readiness is the one of the server, reported by the health feature.
+func Load(readiness health.Readiness) []func(*gin.RouterGroup) {
 	db := loadPostgresDepencies()
 	httpClient := http.NewClient(&netHttp.Client{})
-	cryptHasher := crypt.NewPasswordHasher()
+	cryptHasher := loadPasswordHasher()
+	mailer := loadMailer()
+	passwordValidator := loadPasswordValidator()
+	identityProvider := loadIdentityProvider()
 	logger.Debug("Instanciate internal dependencies...")
 
-	cacheManager := cache.NewManager(cleanupInterval)
-	cacheMiddleware := middleware.NewCacheMiddleware(cacheManager)
-	tokenMiddleware := middleware.NewTokenMiddleware()
+	cacheManager := loadCacheManager(db)
+	openedCache = cacheManager
+	restoreCacheSnapshot(cacheManager)
+
+	// Note: the audit, apikey, auth and organization services are needed ahead of the middlewares, as they record
+	// rejected tokens, validate keys for the api key middleware, revoke tokens for the token middleware and
+	// provide the quotas of the quota middleware.
+	auditRep := audit.NewRepository(db)
+	auditSrv := audit.NewService(auditRep, loadAuditPolicy())
+	go purgeAuditEvents(auditSrv)
+	apiKeyRep := apikey.NewRepository(db)
+	apiKeySrv := apikey.NewService(apiKeyRep)
+	authRep := auth.NewRepository(db)
+	authSrv := auth.NewService(authRep, cryptHasher, passwordValidator, cacheManager, mailer, identityProvider, loadAuthPolicy())
+	organizationRep := organization.NewRepository(db)
+	organizationSrv := organization.NewService(organizationRep, loadOrganizationPolicy())
+	go purgeRequestUsage(organizationSrv)
+
+	addressCacheMiddleware := middleware.NewCacheMiddleware(cacheManager, loadCachePolicy(loadAddressCacheScope()))
+	tokenMiddleware := middleware.NewTokenMiddleware(authSrv, auditSrv)
+	// Note: the address routes are the only ones open to OAuth2 clients, granted with the address read scope.
+	addressTokenMiddleware := middleware.NewScopedTokenMiddleware(authSrv, auditSrv, scope.AddressRead)
+	adminMiddleware := middleware.NewAdminMiddleware()
+	// Note: organizations and the cache are shared between organizations, so only global administrators manage them.
+	globalAdminMiddleware := middleware.NewGlobalAdminMiddleware()
+	apiKeyMiddleware := middleware.NewAPIKeyMiddleware(apiKeySrv, scope.AddressRead)
+	quotaMiddleware := middleware.NewQuotaMiddleware(organizationSrv, cacheManager)
 	logger.Debug("Instanciate middleware dependencies...")
 
 	// auth feature
-	authRep := auth.NewRepository(db)
-	authSrv := auth.NewService(authRep, cryptHasher)
-	authHandler := auth.NewHandler(authSrv)
+	authHandler := auth.NewHandler(authSrv, tokenMiddleware, adminMiddleware, auditSrv)
 	logger.Debug("Instanciate auth use-case dependencies...")
 
+	// audit feature
+	auditHandler := audit.NewHandler(auditSrv, tokenMiddleware, adminMiddleware)
+	logger.Debug("Instanciate audit use-case dependencies...")
+
 	// zipcode feature
 	zipCodeRep := zipcode.NewRepository(httpClient)
-	zipCodeSrv := zipcode.NewService(zipCodeRep)
-	zipCodeHandler := zipcode.NewHandler(zipCodeSrv, cacheMiddleware, tokenMiddleware)
+	zipCodeSrv := zipcode.NewService(zipCodeRep, cacheManager, env.ParseDuration(config.CacheConfig.AddressExpiration, defaultAddressCacheExpiration))
+	go warmUpAddresses(zipCodeSrv)
+	zipCodeHandler := zipcode.NewHandler(zipCodeSrv, addressCacheMiddleware, addressTokenMiddleware, apiKeyMiddleware, quotaMiddleware)
 	logger.Debug("Instanciate zipcode use-case dependencies...")
 
+	// apikey feature
+	apiKeyHandler := apikey.NewHandler(apiKeySrv, tokenMiddleware)
+	logger.Debug("Instanciate apikey use-case dependencies...")
+
+	// oauth feature
+	oauthRep := oauth.NewRepository(db)
+	oauthSrv := oauth.NewService(oauthRep)
+	oauthHandler := oauth.NewHandler(oauthSrv, tokenMiddleware)
+	logger.Debug("Instanciate oauth use-case dependencies...")
+
+	// organization feature
+	organizationHandler := organization.NewHandler(organizationSrv, tokenMiddleware, globalAdminMiddleware)
+	logger.Debug("Instanciate organization use-case dependencies...")
+
+	// cacheadmin feature
+	cacheAdminSrv := cacheadmin.NewService(cacheManager, middleware.ResponseCacheKeyPrefix, zipcode.AddressCacheKeyPrefix)
+	cacheAdminHandler := cacheadmin.NewHandler(cacheAdminSrv, tokenMiddleware, globalAdminMiddleware, auditSrv)
+	logger.Debug("Instanciate cacheadmin use-case dependencies...")
+
 	// health feature
-	healthHandler := health.NewHandler()
+	healthHandler := health.NewHandler(readiness)
 	logger.Debug("Instanciate health use-case dependencies...")
 
 	// swagger feature
@@ -61,9 +210,316 @@ func Load() []func(*gin.RouterGroup) {
 		healthHandler.Register,
 		zipCodeHandler.Register,
 		authHandler.Register,
+		apiKeyHandler.Register,
+		oauthHandler.Register,
+		auditHandler.Register,
+		organizationHandler.Register,
+		cacheAdminHandler.Register,
+	}
+}
+
+func loadAuthPolicy() auth.Policy {
+	verificationURL := config.AuthConfig.VerificationURL
+	if verificationURL == "" {
+		verificationURL = fmt.Sprintf("http://%s:%s/v1/auth/verify", config.ServerConfig.Host, config.ServerConfig.Port)
+	}
+
+	mfaIssuer := config.AuthConfig.MFAIssuer
+	if mfaIssuer == "" {
+		mfaIssuer = defaultMFAIssuer
+	}
+
+	return auth.Policy{
+		Lockout: auth.LockoutPolicy{
+			MaxAttempts:        env.ParseInt(config.AuthConfig.MaxFailedAttempts, defaultMaxFailedAttempts),
+			MaxAttemptsPerIP:   env.ParseInt(config.AuthConfig.MaxFailedAttemptsPerIP, defaultMaxFailedAttemptsPerIP),
+			LockoutDuration:    env.ParseDuration(config.AuthConfig.LockoutDuration, defaultLockoutDuration),
+			MaxLockoutDuration: env.ParseDuration(config.AuthConfig.MaxLockoutDuration, defaultMaxLockoutDuration),
+		},
+		Verification: auth.VerificationPolicy{
+			Required:        env.ParseBool(config.AuthConfig.RequireVerifiedEmail, false),
+			TokenExpiration: defaultVerificationTokenExpiration,
+			URL:             verificationURL,
+		},
+		PasswordReset: auth.PasswordResetPolicy{
+			TokenExpiration: env.ParseDuration(config.AuthConfig.ResetTokenExpiration, defaultResetTokenExpiration),
+			URL:             config.AuthConfig.PasswordResetURL,
+		},
+		MFA: auth.MFAPolicy{
+			Issuer:              mfaIssuer,
+			ChallengeExpiration: env.ParseDuration(config.AuthConfig.MFAChallengeExpiration, defaultMFAChallengeExpiration),
+		},
+		Enumeration: auth.EnumerationPolicy{
+			Hardened: env.ParseBool(config.AuthConfig.PreventEnumeration, false),
+		},
+	}
+}
+
+func loadAuditPolicy() audit.Policy {
+	return audit.Policy{
+		Retention: env.ParseDuration(config.AuditConfig.Retention, defaultAuditRetention),
+	}
+}
+
+// purgeAuditEvents periodically removes the audit events older than the retention.
+func purgeAuditEvents(auditSrv audit.ServiceImp) {
+	ticker := time.NewTicker(auditPurgeInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		purged, err := auditSrv.PurgeExpiredEvents()
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
+		if purged > 0 {
+			logger.Debug(fmt.Sprintf("%d expired audit events purged", purged))
+		}
+	}
+}
+
+func loadOrganizationPolicy() organization.Policy {
+	return organization.Policy{
+		UsageRetention: env.ParseDuration(config.QuotaConfig.UsageRetention, defaultQuotaUsageRetention),
 	}
 }
 
+// purgeRequestUsage periodically removes the daily request counters older than the retention.
+func purgeRequestUsage(organizationSrv organization.ServiceImp) {
+	ticker := time.NewTicker(quotaUsagePurgeInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		purged, err := organizationSrv.PurgeExpiredUsage()
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
+		if purged > 0 {
+			logger.Debug(fmt.Sprintf("%d expired request counters purged", purged))
+		}
+	}
+}
+
+func loadPasswordHasher() crypt.PasswordHasher {
+	if config.AuthConfig.PasswordHasher == crypt.AlgorithmArgon2id {
+		// Note: the parallelism is stored in a byte, so out of range values would silently wrap around.
+		parallelism := env.ParseInt(config.AuthConfig.Argon2Parallelism, defaultArgon2Parallelism)
+		if parallelism < 1 || parallelism > math.MaxUint8 {
+			logger.Error(fmt.Errorf("invalid argon2 parallelism %d, must be between 1 and %d", parallelism, math.MaxUint8))
+			shutdown.Now()
+		}
+
+		return crypt.NewArgon2idHasher(crypt.Argon2idParams{
+			Memory:      uint32(env.ParseInt(config.AuthConfig.Argon2Memory, defaultArgon2Memory)),
+			Iterations:  uint32(env.ParseInt(config.AuthConfig.Argon2Iterations, defaultArgon2Iterations)),
+			Parallelism: uint8(parallelism),
+		})
+	}
+	return crypt.NewBcryptHasher(env.ParseInt(config.AuthConfig.BcryptCost, bcrypt.DefaultCost))
+}
+
+func loadPasswordValidator() password.Validator {
+	breachedList, err := password.LoadBreachedList(config.AuthConfig.BreachedPasswordsPath)
+	if err != nil {
+		logger.Error(err)
+		shutdown.Now()
+	}
+
+	// Note: bcrypt rejects passwords longer than 72 bytes, which the maximum length in characters doesn't prevent.
+	maxBytes := 0
+	if config.AuthConfig.PasswordHasher != crypt.AlgorithmArgon2id {
+		maxBytes = crypt.BcryptMaxPasswordLength
+	}
+
+	return password.NewValidator(password.Policy{
+		MinLength:     env.ParseInt(config.AuthConfig.PasswordMinLength, defaultPasswordMinLength),
+		MaxLength:     env.ParseInt(config.AuthConfig.PasswordMaxLength, defaultPasswordMaxLength),
+		MaxBytes:      maxBytes,
+		RequireUpper:  env.ParseBool(config.AuthConfig.PasswordRequireUpper, true),
+		RequireLower:  env.ParseBool(config.AuthConfig.PasswordRequireLower, true),
+		RequireDigit:  env.ParseBool(config.AuthConfig.PasswordRequireDigit, true),
+		RequireSymbol: env.ParseBool(config.AuthConfig.PasswordRequireSymbol, false),
+		ForbidEmail:   env.ParseBool(config.AuthConfig.PasswordForbidEmail, true),
+	}, breachedList)
+}
+
+func loadMailer() mail.Mailer {
+	from := config.MailConfig.From
+	if from == "" {
+		from = defaultMailFrom
+	}
+
+	if config.MailConfig.Driver == smtpMailDriver {
+		return mail.NewSMTPMailer(
+			config.MailConfig.SMTPHost,
+			config.MailConfig.SMTPPort,
+			config.MailConfig.SMTPUsername,
+			config.MailConfig.SMTPPassword,
+			from,
+		)
+	}
+	return mail.NewOutboxMailer(config.MailConfig.OutboxPath)
+}
+
+// Close releases the resources opened by Load. The database connection is closed by the postgres package,
+// after the cache, since the Postgres cache backend writes to it.
+// The in-memory cache is saved to its snapshot first, so the next start isn't cold.
+func Close() {
+	if openedCache == nil {
+		return
+	}
+	if snapshotter, ok := openedCache.(cache.Snapshotter); ok && config.CacheConfig.SnapshotPath != "" {
+		saveCacheSnapshot(snapshotter)
+	}
+	if err := openedCache.Close(); err != nil {
+		logger.Error(err)
+	}
+}
+
+// loadCacheManager returns the cache backend selected by configuration.
+// Note: only the Redis and Postgres backends are shared between replicas, the in-memory one is local to each and bounded.
+func loadCacheManager(db *gorm.DB) cache.Manager {
+	switch config.CacheConfig.Driver {
+	case redisCacheDriver:
+		address := config.CacheConfig.RedisAddress
+		if address == "" {
+			address = defaultRedisAddress
+		}
+
+		// Note: the cache is best-effort, so a slow or unreachable server must fail fast rather than hold the requests.
+		timeout := env.ParseDuration(config.CacheConfig.RedisTimeout, defaultRedisTimeout)
+		return cache.NewRedisManager(redis.NewClient(&redis.Options{
+			Addr:                  address,
+			Password:              config.CacheConfig.RedisPassword,
+			DB:                    env.ParseInt(config.CacheConfig.RedisDB, 0),
+			DialTimeout:           timeout,
+			ReadTimeout:           timeout,
+			WriteTimeout:          timeout,
+			ContextTimeoutEnabled: true,
+		}), timeout)
+	case postgresCacheDriver:
+		cacheManager, err := cache.NewPostgresManager(db, cleanupInterval)
+		if err != nil {
+			logger.Error(err)
+			shutdown.Now()
+		}
+		return cacheManager
+	}
+	return cache.NewBoundedManager(cleanupInterval, cache.Limits{
+		MaxEntries: env.ParseInt(config.CacheConfig.MaxEntries, defaultCacheMaxEntries),
+		MaxBytes:   int64(env.ParseInt(config.CacheConfig.MaxBytes, defaultCacheMaxBytes)),
+		Eviction:   cache.EvictionPolicy(strings.ToLower(config.CacheConfig.Eviction)),
+		Shards:     env.ParseInt(config.CacheConfig.Shards, cache.DefaultShards),
+	})
+}
+
+// restoreCacheSnapshot loads the snapshot of the in-memory cache saved by the previous run, and saves it periodically
+// from then on, so a crash loses at most an interval of entries. The shared backends outlive the process, so they
+// aren't snapshotted.
+func restoreCacheSnapshot(cacheManager cache.Manager) {
+	snapshotter, ok := cacheManager.(cache.Snapshotter)
+	if !ok || config.CacheConfig.SnapshotPath == "" {
+		return
+	}
+
+	restored, err := cache.LoadSnapshot(snapshotter, config.CacheConfig.SnapshotPath)
+	if err != nil {
+		logger.Error(err)
+	}
+	logger.Debug(fmt.Sprintf("%d cache entries restored from the snapshot", restored))
+
+	go func() {
+		ticker := time.NewTicker(env.ParseDuration(config.CacheConfig.SnapshotInterval, defaultCacheSnapshotInterval))
+		defer ticker.Stop()
+
+		for range ticker.C {
+			saveCacheSnapshot(snapshotter)
+		}
+	}()
+}
+
+// saveCacheSnapshot writes the snapshot of the in-memory cache to the configured file.
+func saveCacheSnapshot(snapshotter cache.Snapshotter) {
+	saved, err := cache.SaveSnapshot(snapshotter, config.CacheConfig.SnapshotPath)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	logger.Debug(fmt.Sprintf("%d cache entries saved to the snapshot", saved))
+}
+
+// warmUpAddresses periodically fetches the configured zip codes, usually the most requested ones, into the cache.
+func warmUpAddresses(zipCodeSrv zipcode.ServiceImp) {
+	zipCodes := strings.Fields(strings.ReplaceAll(config.CacheConfig.WarmUpZipCodes, ",", " "))
+	if len(zipCodes) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(env.ParseDuration(config.CacheConfig.WarmUpInterval, defaultAddressWarmUpInterval))
+	defer ticker.Stop()
+
+	for {
+		warmed := zipCodeSrv.WarmUp(zipCodes)
+		logger.Debug(fmt.Sprintf("%d of %d zip codes warmed up", warmed, len(zipCodes)))
+		<-ticker.C
+	}
+}
+
+// loadCachePolicy returns the settings of the response cache of a route, sharing its responses within the scope.
+// Settings left empty fall back to the middleware defaults.
+func loadCachePolicy(scope middleware.CacheScope) middleware.CachePolicy {
+	var statusClasses []int
+	for _, class := range strings.Fields(strings.ReplaceAll(config.CacheConfig.StatusClasses, ",", " ")) {
+		// Note: classes are written as "2xx" or "2".
+		if value := env.ParseInt(strings.TrimSuffix(strings.ToLower(class), "xx"), 0); value > 0 {
+			statusClasses = append(statusClasses, value)
+		}
+	}
+
+	return middleware.CachePolicy{
+		Expiration:           env.ParseDuration(config.CacheConfig.ResponseExpiration, 0),
+		NegativeExpiration:   env.ParseDuration(config.CacheConfig.NegativeExpiration, 0),
+		StatusClasses:        statusClasses,
+		Headers:              strings.Fields(strings.ReplaceAll(config.CacheConfig.ReplayedHeaders, ",", " ")),
+		StaleWhileRevalidate: env.ParseDuration(config.CacheConfig.StaleWhileRevalidate, 0),
+		Scope:                scope,
+	}
+}
+
+// loadAddressCacheScope returns the scope of the cached address lookups, global unless configured otherwise.
+func loadAddressCacheScope() middleware.CacheScope {
+	if config.CacheConfig.AddressScope == "" {
+		return defaultAddressCacheScope
+	}
+	return middleware.CacheScope(strings.ToLower(config.CacheConfig.AddressScope))
+}
+
+// loadIdentityProvider returns the OpenID Connect provider logins can be federated to, or nil when none is configured.
+func loadIdentityProvider() oidc.Provider {
+	if config.OIDCConfig.Issuer == "" {
+		return nil
+	}
+
+	redirectURL := config.OIDCConfig.RedirectURL
+	if redirectURL == "" {
+		redirectURL = fmt.Sprintf("http://%s:%s/v1/auth/oidc/callback", config.ServerConfig.Host, config.ServerConfig.Port)
+	}
+
+	scopes := config.OIDCConfig.Scopes
+	if scopes == "" {
+		scopes = defaultOIDCScopes
+	}
+
+	return oidc.NewProvider(oidc.Config{
+		Issuer:       config.OIDCConfig.Issuer,
+		ClientID:     config.OIDCConfig.ClientID,
+		ClientSecret: config.OIDCConfig.ClientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       strings.Fields(strings.ReplaceAll(scopes, ",", " ")),
+	}, &netHttp.Client{Timeout: identityProviderTimeout})
+}
+
 func loadPostgresDepencies() *gorm.DB {
 	postgres.SetConnectionString(config.PostgresConfig.ToPostgresDSN())
 	db, err := postgres.GetInstance()
@@ -72,6 +528,6 @@ func loadPostgresDepencies() *gorm.DB {
 		shutdown.Now()
 	}
 
-	postgres.Migrate(entity.User{})
+	postgres.Migrate(entity.User{}, entity.APIKey{}, entity.OAuthClient{}, entity.PasswordResetToken{}, entity.MFARecoveryCode{}, entity.Session{}, entity.AuditEvent{}, entity.Organization{}, entity.FederatedIdentity{}, entity.RequestUsage{})
 	return db
 }
@@ -0,0 +1,216 @@
+package apikey
+
+import (
+	"luizalabs-technical-test/pkg/errors"
+	"luizalabs-technical-test/pkg/middleware"
+	"luizalabs-technical-test/pkg/server"
+	"luizalabs-technical-test/pkg/token"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// swagCreatedAPIKeyResponse is used to work around Swagger's lack of support for Go generics.
+type swagCreatedAPIKeyResponse = server.APIResponse[CreatedAPIKeyResponse]
+
+// swagListAPIKeysResponse is used to work around Swagger's lack of support for Go generics.
+type swagListAPIKeysResponse = server.APIResponse[[]APIKeyResponse]
+
+// HandlerImp defines the interface for handling server operations.
+// It embeds the server.HandlerImp interface, allowing for extended functionality and custom implementations.
+type HandlerImp interface {
+	server.HandlerImp
+}
+
+// handler struct holds a reference to the service layer.
+type handler struct {
+	service    ServiceImp
+	tokenLayer middleware.Middleware
+}
+
+// NewHandler creates and returns a new handler instance with the injected service.
+func NewHandler(service ServiceImp, tokenMiddleware middleware.Middleware) HandlerImp {
+	return &handler{service, tokenMiddleware}
+}
+
+// Register sets up the routes for managing API keys.
+func (h *handler) Register(r *gin.RouterGroup) {
+	g := r.Group("/api-keys", h.tokenLayer.Middleware())
+	g.POST("", h.postAPIKey)
+	g.GET("", h.getAPIKeys)
+	g.POST("/:id/rotate", h.postRotateAPIKey)
+	g.DELETE("/:id", h.deleteAPIKey)
+}
+
+// postAPIKey creates a new API key for the authenticated user.
+//
+//	@Summary		Create an API key
+//	@Description	Creates a named API key for machine-to-machine access. The plain key is only returned in this response.
+//	@Tags			api-keys
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Authorization token"
+//	@Param			payload			body		PostAPIKeyPayload			true	"API key data"
+//	@Success		201				{object}	swagCreatedAPIKeyResponse	"API key successfully created"
+//	@Failure		400				{object}	server.APIErrorResponse		"Bad request"
+//	@Failure		401				{object}	server.APIErrorResponse		"Unauthorized"
+//	@Failure		500				{object}	server.APIErrorResponse		"Internal server error"
+//	@Router			/v1/api-keys [post]
+func (h *handler) postAPIKey(c *gin.Context) {
+	userID, ok := h.authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var payload PostAPIKeyPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, server.APIErrorResponse{
+			Error: ErrInvalidPayload.WithErr(err).Error(),
+			Code:  ErrInvalidPayload.Code,
+		})
+		return
+	}
+
+	response, err := h.service.CreateAPIKey(payload.ToCreateAPIKeyInput(userID))
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, swagCreatedAPIKeyResponse{Data: *response})
+}
+
+// getAPIKeys lists the API keys of the authenticated user.
+//
+//	@Summary		List API keys
+//	@Description	Lists every API key owned by the authenticated user, without secret material.
+//	@Tags			api-keys
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Authorization token"
+//	@Success		200				{object}	swagListAPIKeysResponse	"API keys"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		500				{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/api-keys [get]
+func (h *handler) getAPIKeys(c *gin.Context) {
+	userID, ok := h.authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.service.ListAPIKeys(userID)
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, swagListAPIKeysResponse{Data: response})
+}
+
+// postRotateAPIKey revokes an API key and issues a replacement.
+//
+//	@Summary		Rotate an API key
+//	@Description	Revokes the given API key and issues a new one with the same name, scopes and lifetime.
+//	@Tags			api-keys
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Authorization token"
+//	@Param			id				path		int							true	"API key ID"
+//	@Success		201				{object}	swagCreatedAPIKeyResponse	"API key successfully rotated"
+//	@Failure		400				{object}	server.APIErrorResponse		"Bad request"
+//	@Failure		401				{object}	server.APIErrorResponse		"Unauthorized"
+//	@Failure		404				{object}	server.APIErrorResponse		"API key not found"
+//	@Router			/v1/api-keys/{id}/rotate [post]
+func (h *handler) postRotateAPIKey(c *gin.Context) {
+	userID, ok := h.authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	keyID, ok := h.keyIDFromPath(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.service.RotateAPIKey(userID, keyID)
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, swagCreatedAPIKeyResponse{Data: *response})
+}
+
+// deleteAPIKey revokes an API key.
+//
+//	@Summary		Revoke an API key
+//	@Description	Revokes the given API key so it can no longer be used.
+//	@Tags			api-keys
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@Param			id				path	int		true	"API key ID"
+//	@Success		204				"API key successfully revoked"
+//	@Failure		400				{object}	server.APIErrorResponse	"Bad request"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		404				{object}	server.APIErrorResponse	"API key not found"
+//	@Router			/v1/api-keys/{id} [delete]
+func (h *handler) deleteAPIKey(c *gin.Context) {
+	userID, ok := h.authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	keyID, ok := h.keyIDFromPath(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.RevokeAPIKey(userID, keyID); err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// authenticatedUserID extracts the user ID from the claims set by the token middleware.
+func (h *handler) authenticatedUserID(c *gin.Context) (uint, bool) {
+	claims, err := token.ClaimsFromContext(c)
+	if err != nil || claims.UintKey("ID") == 0 {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, server.APIErrorResponse{
+			Error: ErrUnauthorizedUser.WithErr(err).Error(),
+			Code:  ErrUnauthorizedUser.Code,
+		})
+		return 0, false
+	}
+	return claims.UintKey("ID"), true
+}
+
+// keyIDFromPath parses the API key ID from the route parameters.
+func (h *handler) keyIDFromPath(c *gin.Context) (uint, bool) {
+	keyID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, server.APIErrorResponse{
+			Error: ErrInvalidPayload.WithErr(err).Error(),
+			Code:  ErrInvalidPayload.Code,
+		})
+		return 0, false
+	}
+	return uint(keyID), true
+}
+
+// abortWithError maps service errors to their HTTP status codes.
+func (h *handler) abortWithError(c *gin.Context, err error) {
+	code := err.(errors.ErrorImp).CodeStr()
+
+	status := http.StatusInternalServerError
+	switch code {
+	case ErrCodeInvalidScope:
+		status = http.StatusBadRequest
+	case ErrCodeNotFound:
+		status = http.StatusNotFound
+	}
+
+	c.AbortWithStatusJSON(status, server.APIErrorResponse{
+		Error: err.Error(),
+		Code:  code,
+	})
+}
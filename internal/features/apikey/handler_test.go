@@ -0,0 +1,161 @@
+package apikey_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"luizalabs-technical-test/internal/features/apikey"
+	"luizalabs-technical-test/internal/features/apikey/mock"
+	middlewareMock "luizalabs-technical-test/internal/pkg/middleware/mock"
+	"luizalabs-technical-test/pkg/token"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// HandlerTestSuite is the struct for the test suite
+type HandlerTestSuite struct {
+	suite.Suite
+	ctrl            *gomock.Controller
+	router          *gin.Engine
+	mockSvc         *mock.MockServiceImp
+	tokenMiddleware *middlewareMock.MockTokenMiddleware
+}
+
+// SetupTest initializes the test suite
+func (s *HandlerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	gin.SetMode(gin.TestMode)
+	s.router = gin.Default()
+	s.mockSvc = mock.NewMockServiceImp(s.ctrl)
+	s.tokenMiddleware = middlewareMock.NewMockTokenMiddleware(s.ctrl)
+
+	// Note: simulates an authenticated user, unless the request asks otherwise.
+	s.tokenMiddleware.EXPECT().
+		Middleware().
+		Return(func(c *gin.Context) {
+			if c.GetHeader("Authorization") != "" {
+				c.Set(token.ClaimsHeaderName, &token.CustomClaims{CustomKeys: map[string]any{"ID": float64(1)}})
+			}
+			c.Next()
+		}).
+		AnyTimes()
+
+	apikey.NewHandler(s.mockSvc, s.tokenMiddleware).Register(s.router.Group("/v1"))
+}
+
+// TearDownTest cleans up after the test suite
+func (s *HandlerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// request performs a request against the router, optionally as an authenticated user.
+func (s *HandlerTestSuite) request(method, path, body string, authenticated bool) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	if authenticated {
+		req.Header.Set("Authorization", "Bearer token")
+	}
+
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// TestPostAPIKey_Unauthorized tests the creation of a key without an authenticated user
+func (s *HandlerTestSuite) TestPostAPIKey_Unauthorized() {
+	w := s.request(http.MethodPost, "/v1/api-keys", `{"name":"batch"}`, false)
+	assert.Equal(s.T(), http.StatusUnauthorized, w.Code)
+}
+
+// TestPostAPIKey_BadRequestError tests the error in parse payload params
+func (s *HandlerTestSuite) TestPostAPIKey_BadRequestError() {
+	w := s.request(http.MethodPost, "/v1/api-keys", `{}`, true)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+// TestPostAPIKey_InvalidScope tests the creation of a key with an unsupported scope
+func (s *HandlerTestSuite) TestPostAPIKey_InvalidScope() {
+	s.mockSvc.EXPECT().
+		CreateAPIKey(gomock.Any()).
+		Return(nil, &apikey.ErrInvalidScope)
+
+	w := s.request(http.MethodPost, "/v1/api-keys", `{"name":"batch","scopes":["admin"]}`, true)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+// TestPostAPIKey_Success tests the successful creation of a key
+func (s *HandlerTestSuite) TestPostAPIKey_Success() {
+	s.mockSvc.EXPECT().
+		CreateAPIKey(apikey.CreateAPIKeyInput{UserID: 1, Name: "batch"}).
+		Return(&apikey.CreatedAPIKeyResponse{Key: "lzk_key"}, nil)
+
+	w := s.request(http.MethodPost, "/v1/api-keys", `{"name":"batch"}`, true)
+	assert.Equal(s.T(), http.StatusCreated, w.Code)
+	assert.Contains(s.T(), w.Body.String(), "lzk_key")
+}
+
+// TestGetAPIKeys_Success tests the listing of keys
+func (s *HandlerTestSuite) TestGetAPIKeys_Success() {
+	s.mockSvc.EXPECT().
+		ListAPIKeys(uint(1)).
+		Return([]apikey.APIKeyResponse{{Name: "batch"}}, nil)
+
+	w := s.request(http.MethodGet, "/v1/api-keys", "", true)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), "batch")
+}
+
+// TestPostRotateAPIKey_BadRequestError tests the rotation with an invalid key ID
+func (s *HandlerTestSuite) TestPostRotateAPIKey_BadRequestError() {
+	w := s.request(http.MethodPost, "/v1/api-keys/abc/rotate", "", true)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+// TestPostRotateAPIKey_NotFoundError tests the rotation of an unknown key
+func (s *HandlerTestSuite) TestPostRotateAPIKey_NotFoundError() {
+	s.mockSvc.EXPECT().
+		RotateAPIKey(uint(1), uint(2)).
+		Return(nil, &apikey.ErrNotFound)
+
+	w := s.request(http.MethodPost, "/v1/api-keys/2/rotate", "", true)
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+}
+
+// TestPostRotateAPIKey_Success tests the successful rotation of a key
+func (s *HandlerTestSuite) TestPostRotateAPIKey_Success() {
+	s.mockSvc.EXPECT().
+		RotateAPIKey(uint(1), uint(2)).
+		Return(&apikey.CreatedAPIKeyResponse{Key: "lzk_new"}, nil)
+
+	w := s.request(http.MethodPost, "/v1/api-keys/2/rotate", "", true)
+	assert.Equal(s.T(), http.StatusCreated, w.Code)
+}
+
+// TestDeleteAPIKey_Success tests the successful revocation of a key
+func (s *HandlerTestSuite) TestDeleteAPIKey_Success() {
+	s.mockSvc.EXPECT().
+		RevokeAPIKey(uint(1), uint(2)).
+		Return(nil)
+
+	w := s.request(http.MethodDelete, "/v1/api-keys/2", "", true)
+	assert.Equal(s.T(), http.StatusNoContent, w.Code)
+}
+
+// TestDeleteAPIKey_InternalServerError tests a failure while revoking a key
+func (s *HandlerTestSuite) TestDeleteAPIKey_InternalServerError() {
+	s.mockSvc.EXPECT().
+		RevokeAPIKey(uint(1), uint(2)).
+		Return(&apikey.ErrOperationFailed)
+
+	w := s.request(http.MethodDelete, "/v1/api-keys/2", "", true)
+	assert.Equal(s.T(), http.StatusInternalServerError, w.Code)
+}
+
+// TestHandlerTestSuite is the entry point for the test suite
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
@@ -0,0 +1,58 @@
+package apikey
+
+import "luizalabs-technical-test/pkg/errors"
+
+// Constants representing error codes related to API key management operations.
+const (
+	ErrCodeInvalidPayload     = "ERR_API_KEY_INVALID_PAYLOAD"   // malformed request payload.
+	ErrCodeInvalidScope       = "ERR_API_KEY_INVALID_SCOPE"     // unknown scope requested.
+	ErrCodeCreationFailed     = "ERR_API_KEY_CREATION_FAILED"   // failure generating or storing the key.
+	ErrCodeNotFound           = "ERR_API_KEY_NOT_FOUND"         // key not found for the user.
+	ErrCodeInvalidKey         = "ERR_API_KEY_INVALID"           // unknown, revoked or expired key.
+	ErrCodeOperationFailed    = "ERR_API_KEY_OPERATION_FAILED"  // failure listing, rotating or revoking keys.
+	ErrCodeUnauthorizedClient = "ERR_API_KEY_UNAUTHORIZED_USER" // no authenticated user in the request.
+)
+
+var (
+	// ErrInvalidPayload is triggered when the request payload cannot be parsed.
+	ErrInvalidPayload = errors.Error{
+		Code:    ErrCodeInvalidPayload,
+		Message: "Os dados informados para a chave de API são inválidos. Verifique o payload e tente novamente.",
+	}
+
+	// ErrInvalidScope is triggered when a scope outside of the supported list is requested.
+	ErrInvalidScope = errors.Error{
+		Code:    ErrCodeInvalidScope,
+		Message: "Um ou mais escopos solicitados não são suportados. Verifique os escopos informados.",
+	}
+
+	// ErrCreationFailed is triggered when the system fails to generate or persist a new key.
+	ErrCreationFailed = errors.Error{
+		Code:    ErrCodeCreationFailed,
+		Message: "Não foi possível criar a chave de API. Por favor, tente novamente mais tarde.",
+	}
+
+	// ErrNotFound is triggered when the requested key does not exist or belongs to another user.
+	ErrNotFound = errors.Error{
+		Code:    ErrCodeNotFound,
+		Message: "Chave de API não encontrada.",
+	}
+
+	// ErrInvalidKey is triggered when the provided key is unknown, revoked or expired.
+	ErrInvalidKey = errors.Error{
+		Code:    ErrCodeInvalidKey,
+		Message: "Chave de API inválida, revogada ou expirada.",
+	}
+
+	// ErrOperationFailed is triggered when listing, rotating or revoking keys fails.
+	ErrOperationFailed = errors.Error{
+		Code:    ErrCodeOperationFailed,
+		Message: "Não foi possível concluir a operação com a chave de API. Por favor, tente novamente mais tarde.",
+	}
+
+	// ErrUnauthorizedUser is triggered when no authenticated user is found in the request context.
+	ErrUnauthorizedUser = errors.Error{
+		Code:    ErrCodeUnauthorizedClient,
+		Message: "Usuário não autenticado.",
+	}
+)
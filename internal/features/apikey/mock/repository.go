@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/features/apikey/repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	apikey "luizalabs-technical-test/internal/features/apikey"
+	entity "luizalabs-technical-test/internal/pkg/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepositoryImp is a mock of RepositoryImp interface.
+type MockRepositoryImp struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryImpMockRecorder
+}
+
+// MockRepositoryImpMockRecorder is the mock recorder for MockRepositoryImp.
+type MockRepositoryImpMockRecorder struct {
+	mock *MockRepositoryImp
+}
+
+// NewMockRepositoryImp creates a new mock instance.
+func NewMockRepositoryImp(ctrl *gomock.Controller) *MockRepositoryImp {
+	mock := &MockRepositoryImp{ctrl: ctrl}
+	mock.recorder = &MockRepositoryImpMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepositoryImp) EXPECT() *MockRepositoryImpMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockRepositoryImp) CreateAPIKey(key *entity.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockRepositoryImpMockRecorder) CreateAPIKey(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockRepositoryImp)(nil).CreateAPIKey), key)
+}
+
+// GetAPIKey mocks base method.
+func (m *MockRepositoryImp) GetAPIKey(filter apikey.GetAPIKeyFilter) (*entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKey", filter)
+	ret0, _ := ret[0].(*entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKey indicates an expected call of GetAPIKey.
+func (mr *MockRepositoryImpMockRecorder) GetAPIKey(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKey", reflect.TypeOf((*MockRepositoryImp)(nil).GetAPIKey), filter)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockRepositoryImp) ListAPIKeys(userID uint) ([]entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", userID)
+	ret0, _ := ret[0].([]entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockRepositoryImpMockRecorder) ListAPIKeys(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockRepositoryImp)(nil).ListAPIKeys), userID)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockRepositoryImp) RevokeAPIKey(id uint, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", id, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockRepositoryImpMockRecorder) RevokeAPIKey(id, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockRepositoryImp)(nil).RevokeAPIKey), id, revokedAt)
+}
+
+// TouchAPIKey mocks base method.
+func (m *MockRepositoryImp) TouchAPIKey(id uint, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchAPIKey", id, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchAPIKey indicates an expected call of TouchAPIKey.
+func (mr *MockRepositoryImpMockRecorder) TouchAPIKey(id, usedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchAPIKey", reflect.TypeOf((*MockRepositoryImp)(nil).TouchAPIKey), id, usedAt)
+}
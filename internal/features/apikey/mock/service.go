@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/features/apikey/service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	apikey "luizalabs-technical-test/internal/features/apikey"
+	token "luizalabs-technical-test/pkg/token"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockServiceImp is a mock of ServiceImp interface.
+type MockServiceImp struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceImpMockRecorder
+}
+
+// MockServiceImpMockRecorder is the mock recorder for MockServiceImp.
+type MockServiceImpMockRecorder struct {
+	mock *MockServiceImp
+}
+
+// NewMockServiceImp creates a new mock instance.
+func NewMockServiceImp(ctrl *gomock.Controller) *MockServiceImp {
+	mock := &MockServiceImp{ctrl: ctrl}
+	mock.recorder = &MockServiceImpMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceImp) EXPECT() *MockServiceImpMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockServiceImp) CreateAPIKey(input apikey.CreateAPIKeyInput) (*apikey.CreatedAPIKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", input)
+	ret0, _ := ret[0].(*apikey.CreatedAPIKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockServiceImpMockRecorder) CreateAPIKey(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockServiceImp)(nil).CreateAPIKey), input)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockServiceImp) ListAPIKeys(userID uint) ([]apikey.APIKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", userID)
+	ret0, _ := ret[0].([]apikey.APIKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockServiceImpMockRecorder) ListAPIKeys(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockServiceImp)(nil).ListAPIKeys), userID)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockServiceImp) RevokeAPIKey(userID, keyID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", userID, keyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockServiceImpMockRecorder) RevokeAPIKey(userID, keyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockServiceImp)(nil).RevokeAPIKey), userID, keyID)
+}
+
+// RotateAPIKey mocks base method.
+func (m *MockServiceImp) RotateAPIKey(userID, keyID uint) (*apikey.CreatedAPIKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateAPIKey", userID, keyID)
+	ret0, _ := ret[0].(*apikey.CreatedAPIKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateAPIKey indicates an expected call of RotateAPIKey.
+func (mr *MockServiceImpMockRecorder) RotateAPIKey(userID, keyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateAPIKey", reflect.TypeOf((*MockServiceImp)(nil).RotateAPIKey), userID, keyID)
+}
+
+// ValidateAPIKey mocks base method.
+func (m *MockServiceImp) ValidateAPIKey(rawKey string) (*token.CustomClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateAPIKey", rawKey)
+	ret0, _ := ret[0].(*token.CustomClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateAPIKey indicates an expected call of ValidateAPIKey.
+func (mr *MockServiceImpMockRecorder) ValidateAPIKey(rawKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAPIKey", reflect.TypeOf((*MockServiceImp)(nil).ValidateAPIKey), rawKey)
+}
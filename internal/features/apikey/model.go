@@ -0,0 +1,73 @@
+package apikey
+
+import (
+	"luizalabs-technical-test/internal/pkg/entity"
+	"time"
+)
+
+// PostAPIKeyPayload represents the payload for creating a new API key.
+type PostAPIKeyPayload struct {
+	Name          string   `json:"name"            binding:"required,max=100"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expires_in_days" binding:"omitempty,min=1,max=365"`
+}
+
+// CreateAPIKeyInput represents the input structure in service layer for creating an API key.
+type CreateAPIKeyInput struct {
+	UserID    uint
+	Name      string
+	Scopes    []string
+	ExpiresIn time.Duration
+}
+
+// APIKeyResponse represents the public view of an API key, without any secret material.
+type APIKeyResponse struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedAPIKeyResponse represents a newly issued key. The plain key is only returned once.
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+// GetAPIKeyFilter represents the filter criteria for querying API keys.
+type GetAPIKeyFilter struct {
+	ID        uint
+	UserID    uint
+	HashedKey string
+}
+
+// ToCreateAPIKeyInput maps PostAPIKeyPayload to CreateAPIKeyInput.
+func (p *PostAPIKeyPayload) ToCreateAPIKeyInput(userID uint) CreateAPIKeyInput {
+	input := CreateAPIKeyInput{
+		UserID: userID,
+		Name:   p.Name,
+		Scopes: p.Scopes,
+	}
+	if p.ExpiresInDays > 0 {
+		input.ExpiresIn = time.Duration(p.ExpiresInDays) * 24 * time.Hour
+	}
+	return input
+}
+
+// ToAPIKeyResponse converts an APIKey entity to its public representation.
+func ToAPIKeyResponse(key entity.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.ScopeList(),
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
@@ -0,0 +1,48 @@
+package apikey
+
+import (
+	"testing"
+	"time"
+
+	"luizalabs-technical-test/internal/pkg/entity"
+	"luizalabs-technical-test/internal/pkg/scope"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// TestToCreateAPIKeyInput tests the ToCreateAPIKeyInput method of PostAPIKeyPayload.
+func TestToCreateAPIKeyInput(t *testing.T) {
+	payload := &PostAPIKeyPayload{
+		Name:          "batch",
+		Scopes:        []string{scope.AddressRead},
+		ExpiresInDays: 2,
+	}
+
+	input := payload.ToCreateAPIKeyInput(1)
+
+	assert.Equal(t, uint(1), input.UserID)
+	assert.Equal(t, payload.Name, input.Name)
+	assert.Equal(t, payload.Scopes, input.Scopes)
+	assert.Equal(t, 48*time.Hour, input.ExpiresIn)
+
+	payload.ExpiresInDays = 0
+	assert.Zero(t, payload.ToCreateAPIKeyInput(1).ExpiresIn, "Expected default expiration to be left to the service")
+}
+
+// TestToAPIKeyResponse tests the conversion of an APIKey entity into its public view.
+func TestToAPIKeyResponse(t *testing.T) {
+	key := entity.APIKey{
+		Model:     gorm.Model{ID: 3},
+		Name:      "batch",
+		Prefix:    "lzk_abcdefgh",
+		HashedKey: "secret-hash",
+		Scopes:    scope.AddressRead,
+	}
+
+	response := ToAPIKeyResponse(key)
+
+	assert.Equal(t, key.ID, response.ID)
+	assert.Equal(t, key.Prefix, response.Prefix)
+	assert.Equal(t, []string{scope.AddressRead}, response.Scopes)
+}
@@ -0,0 +1,84 @@
+package apikey
+
+import (
+	"luizalabs-technical-test/internal/pkg/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RepositoryImp defines the interface for the repository layer,
+// which abstracts data access operations.
+type RepositoryImp interface {
+	CreateAPIKey(key *entity.APIKey) error
+	ListAPIKeys(userID uint) ([]entity.APIKey, error)
+	GetAPIKey(filter GetAPIKeyFilter) (*entity.APIKey, error)
+	RevokeAPIKey(id uint, revokedAt time.Time) error
+	TouchAPIKey(id uint, usedAt time.Time) error
+}
+
+// repository struct implements the repositoryImp interface,
+// that interacts with external entities such as databases or external APIs.
+type repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates and returns a new instance of the repository.
+func NewRepository(db *gorm.DB) RepositoryImp {
+	return &repository{db}
+}
+
+// CreateAPIKey adds a new API key to the database.
+func (r *repository) CreateAPIKey(key *entity.APIKey) error {
+	tx := r.db.Table(entity.TbAPIKey).Create(key)
+	if err := tx.Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListAPIKeys retrieves every API key owned by the given user, newest first.
+func (r *repository) ListAPIKeys(userID uint) ([]entity.APIKey, error) {
+	var keys []entity.APIKey
+
+	tx := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&keys)
+	if err := tx.Error; err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// GetAPIKey retrieves a single API key, along with its owner, matching the provided filter.
+func (r *repository) GetAPIKey(filter GetAPIKeyFilter) (*entity.APIKey, error) {
+	fetchedKey := new(entity.APIKey)
+
+	tx := r.db.Preload("User").Where(&entity.APIKey{
+		Model:     gorm.Model{ID: filter.ID},
+		UserID:    filter.UserID,
+		HashedKey: filter.HashedKey,
+	}).First(fetchedKey)
+	if err := tx.Error; err != nil {
+		return nil, err
+	}
+
+	return fetchedKey, nil
+}
+
+// RevokeAPIKey marks the key as revoked so it can no longer authenticate requests.
+func (r *repository) RevokeAPIKey(id uint, revokedAt time.Time) error {
+	tx := r.db.Model(&entity.APIKey{}).Where("id = ?", id).Update("revoked_at", revokedAt)
+	if err := tx.Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// TouchAPIKey records the last time the key was used.
+func (r *repository) TouchAPIKey(id uint, usedAt time.Time) error {
+	tx := r.db.Model(&entity.APIKey{}).Where("id = ?", id).Update("last_used_at", usedAt)
+	if err := tx.Error; err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package apikey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"luizalabs-technical-test/internal/pkg/entity"
+	"luizalabs-technical-test/internal/pkg/scope"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	ctx  context.Context
+	user entity.User
+}
+
+func (s *APIKeyRepositoryTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+
+	// Start PostgreSQL container
+	req := testcontainers.ContainerRequest{
+		Image:        "postgres:latest",
+		ExposedPorts: []string{"5432/tcp"},
+		Env: map[string]string{
+			"POSTGRES_USER":     "testuser",
+			"POSTGRES_PASSWORD": "testpass",
+			"POSTGRES_DB":       "testdb",
+		},
+		WaitingFor: wait.ForListeningPort("5432/tcp"),
+	}
+
+	// Create and start the container
+	postgresContainer, err := testcontainers.GenericContainer(s.ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	s.Require().NoError(err)
+
+	// Get the port and create the database connection
+	host, _ := postgresContainer.Host(s.ctx)
+	port, _ := postgresContainer.MappedPort(s.ctx, "5432")
+
+	dsn := "host=" + host + " port=" + port.Port() + " user=testuser password=testpass dbname=testdb sslmode=disable"
+	s.db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	s.Require().NoError(err)
+
+	// Auto-migrate the User and APIKey tables
+	s.Require().NoError(s.db.AutoMigrate(&entity.User{}, &entity.APIKey{}))
+
+	s.user = entity.User{Email: "owner@example.com"}
+	s.Require().NoError(s.db.Create(&s.user).Error)
+}
+
+func (s *APIKeyRepositoryTestSuite) TearDownSuite() {
+	// Clean up the database connection
+	db, err := s.db.DB()
+	s.Require().NoError(err)
+	db.Close()
+}
+
+func (s *APIKeyRepositoryTestSuite) TestCreateAndGetAPIKey() {
+	repo := NewRepository(s.db)
+
+	key := entity.APIKey{
+		UserID:    s.user.ID,
+		Name:      "batch",
+		HashedKey: "hash-create",
+		Scopes:    scope.AddressRead,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	s.NoError(repo.CreateAPIKey(&key))
+
+	// Attempt to store the same hash again; this should fail due to the unique index constraint.
+	duplicated := key
+	duplicated.ID = 0
+	s.Error(repo.CreateAPIKey(&duplicated))
+
+	// Verify that the key is fetched along with its owner
+	fetchedKey, err := repo.GetAPIKey(GetAPIKeyFilter{HashedKey: key.HashedKey})
+	s.NoError(err)
+	s.Equal(key.ID, fetchedKey.ID)
+	s.Equal(s.user.Email, fetchedKey.User.Email)
+
+	// Verify that keys from other users are not fetched
+	_, err = repo.GetAPIKey(GetAPIKeyFilter{ID: key.ID, UserID: s.user.ID + 1})
+	s.Error(err)
+}
+
+func (s *APIKeyRepositoryTestSuite) TestListRevokeAndTouchAPIKey() {
+	repo := NewRepository(s.db)
+
+	key := entity.APIKey{
+		UserID:    s.user.ID,
+		Name:      "rotate",
+		HashedKey: "hash-list",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	s.Require().NoError(repo.CreateAPIKey(&key))
+
+	keys, err := repo.ListAPIKeys(s.user.ID)
+	s.NoError(err)
+	s.NotEmpty(keys)
+
+	now := time.Now()
+	s.NoError(repo.RevokeAPIKey(key.ID, now))
+	s.NoError(repo.TouchAPIKey(key.ID, now))
+
+	fetchedKey, err := repo.GetAPIKey(GetAPIKeyFilter{ID: key.ID})
+	s.NoError(err)
+	s.NotNil(fetchedKey.RevokedAt)
+	s.NotNil(fetchedKey.LastUsedAt)
+}
+
+func TestAPIKeyRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyRepositoryTestSuite))
+}
@@ -0,0 +1,180 @@
+package apikey
+
+import (
+	"luizalabs-technical-test/internal/pkg/entity"
+	"luizalabs-technical-test/internal/pkg/scope"
+	"luizalabs-technical-test/pkg/constants/str"
+	"luizalabs-technical-test/pkg/crypt"
+	"luizalabs-technical-test/pkg/logger"
+	"luizalabs-technical-test/pkg/token"
+	"time"
+)
+
+const (
+	// keyPrefix identifies the credential type in logs and secret scanners.
+	keyPrefix = "lzk_"
+
+	// keyRandomBytes defines the amount of random bytes used to build the key.
+	keyRandomBytes = 32
+
+	// visiblePrefixLen defines how many characters of the key are stored in plain text for identification.
+	visiblePrefixLen = 12
+
+	// defaultExpiration is applied when the client does not provide an expiration.
+	defaultExpiration = 90 * 24 * time.Hour
+)
+
+// ServiceImp defines the interface for the service layer, with methods to manage API keys.
+type ServiceImp interface {
+	CreateAPIKey(input CreateAPIKeyInput) (*CreatedAPIKeyResponse, error)
+	ListAPIKeys(userID uint) ([]APIKeyResponse, error)
+	RotateAPIKey(userID, keyID uint) (*CreatedAPIKeyResponse, error)
+	RevokeAPIKey(userID, keyID uint) error
+	ValidateAPIKey(rawKey string) (*token.CustomClaims, error)
+}
+
+// service struct implements the serviceImp interface and holds a reference to the repository.
+type service struct {
+	repository RepositoryImp
+}
+
+// NewService creates and returns a new service instance, injecting the repository dependency.
+func NewService(repository RepositoryImp) ServiceImp {
+	return &service{repository}
+}
+
+// CreateAPIKey generates a new key for the user and stores only its hash.
+func (s *service) CreateAPIKey(input CreateAPIKeyInput) (*CreatedAPIKeyResponse, error) {
+	scopes, err := s.normalizeScopes(input.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresIn := input.ExpiresIn
+	if expiresIn <= 0 {
+		expiresIn = defaultExpiration
+	}
+
+	rawKey, err := s.generateKey()
+	if err != nil {
+		return nil, ErrCreationFailed.WithErr(err)
+	}
+
+	key := entity.APIKey{
+		UserID:    input.UserID,
+		Name:      input.Name,
+		Prefix:    rawKey[:visiblePrefixLen],
+		HashedKey: crypt.HashToken(rawKey),
+		ExpiresAt: time.Now().Add(expiresIn),
+	}
+	key.SetScopes(scopes)
+
+	if err := s.repository.CreateAPIKey(&key); err != nil {
+		return nil, ErrCreationFailed.WithErr(err)
+	}
+
+	return &CreatedAPIKeyResponse{
+		APIKeyResponse: ToAPIKeyResponse(key),
+		Key:            rawKey,
+	}, nil
+}
+
+// ListAPIKeys returns every key owned by the user, including revoked and expired ones.
+func (s *service) ListAPIKeys(userID uint) ([]APIKeyResponse, error) {
+	keys, err := s.repository.ListAPIKeys(userID)
+	if err != nil {
+		return nil, ErrOperationFailed.WithErr(err)
+	}
+
+	response := make([]APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		response = append(response, ToAPIKeyResponse(key))
+	}
+	return response, nil
+}
+
+// RotateAPIKey revokes the given key and issues a replacement with the same name, scopes and lifetime.
+func (s *service) RotateAPIKey(userID, keyID uint) (*CreatedAPIKeyResponse, error) {
+	key, err := s.repository.GetAPIKey(GetAPIKeyFilter{ID: keyID, UserID: userID})
+	if err != nil {
+		return nil, ErrNotFound.WithErr(err)
+	}
+
+	if key.RevokedAt == nil {
+		if err := s.repository.RevokeAPIKey(key.ID, time.Now()); err != nil {
+			return nil, ErrOperationFailed.WithErr(err)
+		}
+	}
+
+	return s.CreateAPIKey(CreateAPIKeyInput{
+		UserID:    userID,
+		Name:      key.Name,
+		Scopes:    key.ScopeList(),
+		ExpiresIn: key.ExpiresAt.Sub(key.CreatedAt),
+	})
+}
+
+// RevokeAPIKey revokes the given key, preventing any further use.
+func (s *service) RevokeAPIKey(userID, keyID uint) error {
+	key, err := s.repository.GetAPIKey(GetAPIKeyFilter{ID: keyID, UserID: userID})
+	if err != nil {
+		return ErrNotFound.WithErr(err)
+	}
+
+	if key.RevokedAt != nil {
+		return nil
+	}
+
+	if err := s.repository.RevokeAPIKey(key.ID, time.Now()); err != nil {
+		return ErrOperationFailed.WithErr(err)
+	}
+	return nil
+}
+
+// ValidateAPIKey resolves a plain key into the claims of its owner, as long as it is active.
+func (s *service) ValidateAPIKey(rawKey string) (*token.CustomClaims, error) {
+	key, err := s.repository.GetAPIKey(GetAPIKeyFilter{HashedKey: crypt.HashToken(rawKey)})
+	if err != nil {
+		return nil, ErrInvalidKey.WithErr(err)
+	}
+
+	now := time.Now()
+	if !key.IsActive(now) {
+		return nil, ErrInvalidKey.WithStrErr("api key %s is revoked or expired", key.Prefix)
+	}
+
+	// Note: the preload skips soft-deleted users, leaving the owner empty once the account is deleted.
+	if key.User.ID == 0 || key.User.IsDisabled() {
+		return nil, ErrInvalidKey.WithStrErr("api key %s belongs to a deleted or disabled user", key.Prefix)
+	}
+
+	// Note: failing to record the usage must not block an otherwise valid request.
+	if err := s.repository.TouchAPIKey(key.ID, now); err != nil {
+		logger.Error(err)
+	}
+
+	return &token.CustomClaims{CustomKeys: key.ToJSONClaims()}, nil
+}
+
+// normalizeScopes validates the requested scopes, falling back to read-only access when none is provided.
+func (*service) normalizeScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return scope.Default(), nil
+	}
+
+	for _, requested := range scopes {
+		if !scope.IsSupported(requested) {
+			return nil, ErrInvalidScope.WithStrErr("unsupported scope: %s", requested)
+		}
+	}
+	return scopes, nil
+}
+
+// generateKey builds a new random plain key.
+func (*service) generateKey() (string, error) {
+	random, err := crypt.GenerateRandomToken(keyRandomBytes)
+	if err != nil {
+		return str.EmptyString, err
+	}
+	return keyPrefix + random, nil
+}
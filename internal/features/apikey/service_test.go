@@ -0,0 +1,223 @@
+package apikey_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"luizalabs-technical-test/internal/features/apikey"
+	apiKeyMock "luizalabs-technical-test/internal/features/apikey/mock"
+	"luizalabs-technical-test/internal/pkg/entity"
+	"luizalabs-technical-test/internal/pkg/scope"
+	"luizalabs-technical-test/pkg/crypt"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+// APIKeyServiceTestSuite is a test suite for the API key service.
+type APIKeyServiceTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	repoMock *apiKeyMock.MockRepositoryImp
+	service  apikey.ServiceImp
+}
+
+// SetupTest initializes the test suite, creating a new mock controller and instances of mocks.
+func (suite *APIKeyServiceTestSuite) SetupTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.repoMock = apiKeyMock.NewMockRepositoryImp(suite.ctrl)
+	suite.service = apikey.NewService(suite.repoMock)
+}
+
+// TearDownTest cleans up the mock controller after each test.
+func (suite *APIKeyServiceTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+// TestCreateAPIKey_InvalidScope tests the scenario where an unsupported scope is requested.
+func (suite *APIKeyServiceTestSuite) TestCreateAPIKey_InvalidScope() {
+	_, err := suite.service.CreateAPIKey(apikey.CreateAPIKeyInput{
+		UserID: 1,
+		Name:   "batch",
+		Scopes: []string{"admin:all"},
+	})
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), apikey.ErrInvalidScope.Error(), err.Error())
+}
+
+// TestCreateAPIKey_FailedToStore tests the scenario where the key cannot be persisted.
+func (suite *APIKeyServiceTestSuite) TestCreateAPIKey_FailedToStore() {
+	suite.repoMock.EXPECT().
+		CreateAPIKey(gomock.Any()).
+		Return(errors.New("failed to store key"))
+
+	_, err := suite.service.CreateAPIKey(apikey.CreateAPIKeyInput{UserID: 1, Name: "batch"})
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), apikey.ErrCreationFailed.Error(), err.Error())
+}
+
+// TestCreateAPIKey_Success tests that only the hash is stored and the plain key is returned once.
+func (suite *APIKeyServiceTestSuite) TestCreateAPIKey_Success() {
+	var stored *entity.APIKey
+	suite.repoMock.EXPECT().
+		CreateAPIKey(gomock.Any()).
+		DoAndReturn(func(key *entity.APIKey) error {
+			stored = key
+			return nil
+		})
+
+	response, err := suite.service.CreateAPIKey(apikey.CreateAPIKeyInput{UserID: 1, Name: "batch"})
+
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), strings.HasPrefix(response.Key, "lzk_"))
+	assert.Equal(suite.T(), crypt.HashToken(response.Key), stored.HashedKey)
+	assert.Equal(suite.T(), response.Key[:len(stored.Prefix)], stored.Prefix)
+	assert.Equal(suite.T(), []string{scope.AddressRead}, response.Scopes)
+	assert.WithinDuration(suite.T(), time.Now().Add(90*24*time.Hour), stored.ExpiresAt, time.Minute)
+}
+
+// TestListAPIKeys_Success tests the listing of the user keys.
+func (suite *APIKeyServiceTestSuite) TestListAPIKeys_Success() {
+	suite.repoMock.EXPECT().
+		ListAPIKeys(uint(1)).
+		Return([]entity.APIKey{{Name: "batch", Scopes: scope.AddressRead}}, nil)
+
+	response, err := suite.service.ListAPIKeys(1)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), response, 1)
+	assert.Equal(suite.T(), "batch", response[0].Name)
+}
+
+// TestRotateAPIKey_NotFound tests the rotation of a key that does not belong to the user.
+func (suite *APIKeyServiceTestSuite) TestRotateAPIKey_NotFound() {
+	suite.repoMock.EXPECT().
+		GetAPIKey(apikey.GetAPIKeyFilter{ID: 2, UserID: 1}).
+		Return(nil, errors.New("record not found"))
+
+	_, err := suite.service.RotateAPIKey(1, 2)
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), apikey.ErrNotFound.Error(), err.Error())
+}
+
+// TestRotateAPIKey_Success tests that rotation revokes the old key and keeps its name, scopes and lifetime.
+func (suite *APIKeyServiceTestSuite) TestRotateAPIKey_Success() {
+	createdAt := time.Now().Add(-24 * time.Hour)
+	current := &entity.APIKey{
+		Model:     gorm.Model{ID: 2, CreatedAt: createdAt},
+		Name:      "batch",
+		Scopes:    scope.AddressRead,
+		ExpiresAt: createdAt.Add(30 * 24 * time.Hour),
+	}
+
+	suite.repoMock.EXPECT().GetAPIKey(gomock.Any()).Return(current, nil)
+	suite.repoMock.EXPECT().RevokeAPIKey(uint(2), gomock.Any()).Return(nil)
+	suite.repoMock.EXPECT().
+		CreateAPIKey(gomock.Any()).
+		DoAndReturn(func(key *entity.APIKey) error {
+			assert.Equal(suite.T(), "batch", key.Name)
+			assert.WithinDuration(suite.T(), time.Now().Add(30*24*time.Hour), key.ExpiresAt, time.Minute)
+			return nil
+		})
+
+	response, err := suite.service.RotateAPIKey(1, 2)
+
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), response.Key)
+}
+
+// TestRevokeAPIKey_Success tests the revocation of an active key.
+func (suite *APIKeyServiceTestSuite) TestRevokeAPIKey_Success() {
+	suite.repoMock.EXPECT().GetAPIKey(gomock.Any()).Return(&entity.APIKey{Model: gorm.Model{ID: 2}}, nil)
+	suite.repoMock.EXPECT().RevokeAPIKey(uint(2), gomock.Any()).Return(nil)
+
+	assert.NoError(suite.T(), suite.service.RevokeAPIKey(1, 2))
+}
+
+// TestValidateAPIKey_Unknown tests the validation of a key that does not exist.
+func (suite *APIKeyServiceTestSuite) TestValidateAPIKey_Unknown() {
+	suite.repoMock.EXPECT().
+		GetAPIKey(apikey.GetAPIKeyFilter{HashedKey: crypt.HashToken("lzk_unknown")}).
+		Return(nil, errors.New("record not found"))
+
+	_, err := suite.service.ValidateAPIKey("lzk_unknown")
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), apikey.ErrInvalidKey.Error(), err.Error())
+}
+
+// TestValidateAPIKey_Revoked tests the validation of a revoked key.
+func (suite *APIKeyServiceTestSuite) TestValidateAPIKey_Revoked() {
+	revokedAt := time.Now()
+	suite.repoMock.EXPECT().
+		GetAPIKey(gomock.Any()).
+		Return(&entity.APIKey{ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}, nil)
+
+	_, err := suite.service.ValidateAPIKey("lzk_revoked")
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), apikey.ErrInvalidKey.Error(), err.Error())
+}
+
+// TestValidateAPIKey_DeletedUser tests the validation of a key whose owner was deleted.
+func (suite *APIKeyServiceTestSuite) TestValidateAPIKey_DeletedUser() {
+	suite.repoMock.EXPECT().
+		GetAPIKey(gomock.Any()).
+		Return(&entity.APIKey{Model: gorm.Model{ID: 2}, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+
+	_, err := suite.service.ValidateAPIKey("lzk_deleted")
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), apikey.ErrInvalidKey.Error(), err.Error())
+}
+
+// TestValidateAPIKey_DisabledUser tests the validation of a key whose owner was disabled.
+func (suite *APIKeyServiceTestSuite) TestValidateAPIKey_DisabledUser() {
+	disabledAt := time.Now()
+	suite.repoMock.EXPECT().
+		GetAPIKey(gomock.Any()).
+		Return(&entity.APIKey{
+			Model:     gorm.Model{ID: 2},
+			UserID:    1,
+			User:      entity.User{Model: gorm.Model{ID: 1}, DisabledAt: &disabledAt},
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+
+	_, err := suite.service.ValidateAPIKey("lzk_disabled")
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), apikey.ErrInvalidKey.Error(), err.Error())
+}
+
+// TestValidateAPIKey_Success tests that a valid key resolves into its owner claims.
+func (suite *APIKeyServiceTestSuite) TestValidateAPIKey_Success() {
+	suite.repoMock.EXPECT().
+		GetAPIKey(gomock.Any()).
+		Return(&entity.APIKey{
+			Model:     gorm.Model{ID: 2},
+			UserID:    1,
+			User:      entity.User{Model: gorm.Model{ID: 1}, Email: "user@example.com"},
+			Scopes:    scope.AddressRead,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+	suite.repoMock.EXPECT().TouchAPIKey(uint(2), gomock.Any()).Return(nil)
+
+	claims, err := suite.service.ValidateAPIKey("lzk_valid")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), uint(1), claims.UintKey("ID"))
+	assert.Equal(suite.T(), "user@example.com", claims.StringKey("Email"))
+	assert.Equal(suite.T(), []string{scope.AddressRead}, claims.StringSliceKey("Scopes"))
+}
+
+// TestAPIKeyServiceTestSuite runs the test suite for the API key service.
+func TestAPIKeyServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyServiceTestSuite))
+}
@@ -0,0 +1,160 @@
+package audit
+
+import (
+	"luizalabs-technical-test/pkg/errors"
+	"luizalabs-technical-test/pkg/logger"
+	"luizalabs-technical-test/pkg/middleware"
+	"luizalabs-technical-test/pkg/server"
+	"luizalabs-technical-test/pkg/token"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// swagListEventsResponse is used to work around Swagger's lack of support for Go generics.
+type swagListEventsResponse = server.APIResponse[ListEventsResponse]
+
+// HandlerImp defines the interface for handling server operations.
+// It embeds the server.HandlerImp interface, allowing for extended functionality and custom implementations.
+type HandlerImp interface {
+	server.HandlerImp
+}
+
+// handler struct holds a reference to the service layer.
+type handler struct {
+	service    ServiceImp
+	tokenLayer middleware.Middleware
+	adminLayer middleware.Middleware
+}
+
+// NewHandler creates and returns a new handler instance.
+func NewHandler(service ServiceImp, tokenMiddleware, adminMiddleware middleware.Middleware) HandlerImp {
+	return &handler{service, tokenMiddleware, adminMiddleware}
+}
+
+// Register sets up the routes for querying the audit log, restricted to administrators.
+func (h *handler) Register(r *gin.RouterGroup) {
+	g := r.Group("/admin/audit-events", h.tokenLayer.Middleware(), h.adminLayer.Middleware())
+	g.GET("", h.getEvents)
+	g.GET("/export", h.getEventsExport)
+}
+
+// getEvents lists the audit log events.
+//
+//	@Summary		List audit events
+//	@Description	Lists the recorded authentication events, newest first, optionally filtered by organization, actor, action, outcome, IP, correlation ID and date. Restricted to administrators.
+//	@Tags			admin
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Authorization token"
+//	@Param			page			query		int						false	"Page number, starting at 1"
+//	@Param			page_size		query		int						false	"Events per page (max. 500, defaults to 50)"
+//	@Param			tenant_id		query		int						false	"Organization of the actor (forced for administrators of an organization)"
+//	@Param			actor_id		query		int						false	"User ID of the actor"
+//	@Param			actor			query		string					false	"Email or client ID presented by the actor, case insensitive"
+//	@Param			action			query		string					false	"Action (e.g. login, register, password_change, token_rejected)"
+//	@Param			outcome			query		string					false	"Outcome (success, failure or challenged)"
+//	@Param			ip				query		string					false	"Client IP"
+//	@Param			correlation_id	query		string					false	"Correlation ID of the request"
+//	@Param			from			query		string					false	"Minimum date (RFC 3339)"
+//	@Param			to				query		string					false	"Maximum date (RFC 3339)"
+//	@Success		200				{object}	swagListEventsResponse	"Audit events"
+//	@Failure		400				{object}	server.APIErrorResponse	"Bad request"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse	"Forbidden"
+//	@Failure		500				{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/admin/audit-events [get]
+func (h *handler) getEvents(c *gin.Context) {
+	query, ok := h.bindQuery(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.service.ListEvents(query.ToListEventsFilter())
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, swagListEventsResponse{Data: *response})
+}
+
+// getEventsExport exports the audit log events as CSV.
+//
+//	@Summary		Export audit events
+//	@Description	Exports every recorded authentication event matching the filters as CSV, oldest first. Pagination is ignored. Restricted to administrators.
+//	@Tags			admin
+//	@Produce		text/csv
+//	@Param			Authorization	header		string					true	"Authorization token"
+//	@Param			tenant_id		query		int						false	"Organization of the actor (forced for administrators of an organization)"
+//	@Param			actor_id		query		int						false	"User ID of the actor"
+//	@Param			actor			query		string					false	"Email or client ID presented by the actor, case insensitive"
+//	@Param			action			query		string					false	"Action (e.g. login, register, password_change, token_rejected)"
+//	@Param			outcome			query		string					false	"Outcome (success, failure or challenged)"
+//	@Param			ip				query		string					false	"Client IP"
+//	@Param			correlation_id	query		string					false	"Correlation ID of the request"
+//	@Param			from			query		string					false	"Minimum date (RFC 3339)"
+//	@Param			to				query		string					false	"Maximum date (RFC 3339)"
+//	@Success		200				{file}		file					"Audit events as CSV"
+//	@Failure		400				{object}	server.APIErrorResponse	"Bad request"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse	"Forbidden"
+//	@Failure		500				{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/admin/audit-events/export [get]
+func (h *handler) getEventsExport(c *gin.Context) {
+	query, ok := h.bindQuery(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", "attachment; filename=audit-events.csv")
+
+	if err := h.service.ExportEvents(query.ToListEventsFilter(), c.Writer); err != nil {
+		// Note: once the first rows were streamed the status can no longer be changed, so the export is just cut short.
+		if c.Writer.Written() {
+			logger.Error(err)
+			return
+		}
+		c.Header("Content-Type", "")
+		c.Header("Content-Disposition", "")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// bindQuery parses the filter and pagination parameters.
+// Note: administrators of an organization are restricted to the events of its members.
+func (h *handler) bindQuery(c *gin.Context) (*ListEventsQuery, bool) {
+	var query ListEventsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, server.APIErrorResponse{
+			Error: ErrInvalidQuery.WithErr(err).Error(),
+			Code:  ErrInvalidQuery.Code,
+		})
+		return nil, false
+	}
+
+	if claims, err := token.ClaimsFromContext(c); err == nil {
+		if tenantID := claims.UintKey("TenantID"); tenantID != 0 {
+			query.TenantID = tenantID
+		}
+	}
+	return &query, true
+}
+
+// abortWithError maps service errors to their HTTP status codes.
+func (h *handler) abortWithError(c *gin.Context, err error) {
+	code := err.(errors.ErrorImp).CodeStr()
+
+	status := http.StatusInternalServerError
+	if code == ErrCodeInvalidQuery {
+		status = http.StatusBadRequest
+	}
+
+	c.AbortWithStatusJSON(status, server.APIErrorResponse{
+		Error: err.Error(),
+		Code:  code,
+	})
+}
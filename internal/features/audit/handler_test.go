@@ -0,0 +1,161 @@
+package audit_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"luizalabs-technical-test/internal/features/audit"
+	"luizalabs-technical-test/internal/features/audit/mock"
+	middlewareMock "luizalabs-technical-test/internal/pkg/middleware/mock"
+	"luizalabs-technical-test/pkg/token"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// HandlerTestSuite is the struct for the test suite
+type HandlerTestSuite struct {
+	suite.Suite
+	ctrl    *gomock.Controller
+	router  *gin.Engine
+	mockSvc *mock.MockServiceImp
+}
+
+// SetupTest initializes the test suite
+func (s *HandlerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	gin.SetMode(gin.TestMode)
+	s.router = gin.Default()
+	s.mockSvc = mock.NewMockServiceImp(s.ctrl)
+
+	passThrough := gin.HandlerFunc(func(c *gin.Context) { c.Next() })
+
+	tokenMiddleware := middlewareMock.NewMockTokenMiddleware(s.ctrl)
+	tokenMiddleware.EXPECT().Middleware().Return(passThrough).AnyTimes()
+
+	adminMiddleware := middlewareMock.NewMockAdminMiddleware(s.ctrl)
+	adminMiddleware.EXPECT().Middleware().Return(passThrough).AnyTimes()
+
+	audit.NewHandler(s.mockSvc, tokenMiddleware, adminMiddleware).Register(s.router.Group("/v1"))
+}
+
+// TearDownTest cleans up after the test suite
+func (s *HandlerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// request performs a GET request against the router.
+func (s *HandlerTestSuite) request(path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// TestGetEvents_InvalidQuery tests the listing with malformed parameters
+func (s *HandlerTestSuite) TestGetEvents_InvalidQuery() {
+	for _, path := range []string{
+		"/v1/admin/audit-events?page=-1",
+		"/v1/admin/audit-events?page_size=501",
+		"/v1/admin/audit-events?from=yesterday",
+	} {
+		w := s.request(path)
+		assert.Equal(s.T(), http.StatusBadRequest, w.Code, path)
+		assert.Contains(s.T(), w.Body.String(), audit.ErrCodeInvalidQuery, path)
+	}
+}
+
+// TestGetEvents_Success tests the listing of the events with filters
+func (s *HandlerTestSuite) TestGetEvents_Success() {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.mockSvc.EXPECT().
+		ListEvents(audit.ListEventsFilter{Page: 1, PageSize: 50, Action: "login", Outcome: "failure", From: from}).
+		Return(&audit.ListEventsResponse{
+			Events:   []audit.EventResponse{{ID: 1, Action: "login", Outcome: "failure", IP: "192.0.2.1"}},
+			Page:     1,
+			PageSize: 50,
+			Total:    1,
+		}, nil)
+
+	w := s.request("/v1/admin/audit-events?action=login&outcome=failure&from=2024-01-01T00:00:00Z")
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.JSONEq(s.T(), `{"data":{"events":[{"id":1,"created_at":"0001-01-01T00:00:00Z","action":"login","outcome":"failure",`+
+		`"ip":"192.0.2.1","user_agent":"","correlation_id":""}],"page":1,"page_size":50,"total":1}}`, w.Body.String())
+}
+
+// TestGetEvents_Organization tests that administrators of an organization only list the events of its members
+func (s *HandlerTestSuite) TestGetEvents_Organization() {
+	tokenMiddleware := middlewareMock.NewMockTokenMiddleware(s.ctrl)
+	tokenMiddleware.EXPECT().Middleware().Return(gin.HandlerFunc(func(c *gin.Context) {
+		c.Set(token.ClaimsHeaderName, &token.CustomClaims{CustomKeys: map[string]any{"ID": float64(1), "TenantID": float64(3)}})
+		c.Next()
+	})).AnyTimes()
+
+	adminMiddleware := middlewareMock.NewMockAdminMiddleware(s.ctrl)
+	adminMiddleware.EXPECT().Middleware().Return(gin.HandlerFunc(func(c *gin.Context) { c.Next() })).AnyTimes()
+
+	router := gin.New()
+	audit.NewHandler(s.mockSvc, tokenMiddleware, adminMiddleware).Register(router.Group("/v1"))
+
+	s.mockSvc.EXPECT().
+		ListEvents(audit.ListEventsFilter{Page: 1, PageSize: 50, TenantID: 3}).
+		Return(&audit.ListEventsResponse{Events: []audit.EventResponse{}, Page: 1, PageSize: 50}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/admin/audit-events?tenant_id=5", nil)
+
+	router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+}
+
+// TestGetEvents_InternalServerError tests the listing when the events cannot be retrieved
+func (s *HandlerTestSuite) TestGetEvents_InternalServerError() {
+	s.mockSvc.EXPECT().
+		ListEvents(gomock.Any()).
+		Return(nil, &audit.ErrOperationFailed)
+
+	w := s.request("/v1/admin/audit-events")
+	assert.Equal(s.T(), http.StatusInternalServerError, w.Code)
+}
+
+// TestGetEventsExport_Success tests the CSV export of the events
+func (s *HandlerTestSuite) TestGetEventsExport_Success() {
+	s.mockSvc.EXPECT().
+		ExportEvents(audit.ListEventsFilter{Page: 1, PageSize: 50, ActorID: 7}, gomock.Any()).
+		DoAndReturn(func(_ audit.ListEventsFilter, w io.Writer) error {
+			_, err := w.Write([]byte("id\n1\n"))
+			return err
+		})
+
+	w := s.request("/v1/admin/audit-events/export?actor_id=7")
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Equal(s.T(), "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(s.T(), "attachment; filename=audit-events.csv", w.Header().Get("Content-Disposition"))
+	assert.Equal(s.T(), "id\n1\n", w.Body.String())
+}
+
+// TestGetEventsExport_InternalServerError tests the export when the events cannot be retrieved
+func (s *HandlerTestSuite) TestGetEventsExport_InternalServerError() {
+	s.mockSvc.EXPECT().
+		ExportEvents(gomock.Any(), gomock.Any()).
+		Return(&audit.ErrOperationFailed)
+
+	w := s.request("/v1/admin/audit-events/export")
+
+	assert.Equal(s.T(), http.StatusInternalServerError, w.Code)
+	assert.Empty(s.T(), w.Header().Get("Content-Disposition"))
+	assert.Contains(s.T(), w.Header().Get("Content-Type"), "application/json")
+}
+
+// TestHandlerTestSuite is the entry point for the test suite
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
@@ -0,0 +1,23 @@
+package audit
+
+import "luizalabs-technical-test/pkg/errors"
+
+// Constants representing error codes related to audit log queries.
+const (
+	ErrCodeInvalidQuery    = "ERR_AUDIT_INVALID_QUERY"    // malformed filter or pagination parameters.
+	ErrCodeOperationFailed = "ERR_AUDIT_OPERATION_FAILED" // failure listing or exporting events.
+)
+
+var (
+	// ErrInvalidQuery is triggered when the filter or pagination parameters cannot be parsed.
+	ErrInvalidQuery = errors.Error{
+		Code:    ErrCodeInvalidQuery,
+		Message: "Os filtros informados para o log de auditoria são inválidos. Verifique os parâmetros e tente novamente.",
+	}
+
+	// ErrOperationFailed is triggered when listing or exporting events fails.
+	ErrOperationFailed = errors.Error{
+		Code:    ErrCodeOperationFailed,
+		Message: "Não foi possível consultar o log de auditoria. Por favor, tente novamente mais tarde.",
+	}
+)
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/features/audit/repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	audit "luizalabs-technical-test/internal/features/audit"
+	entity "luizalabs-technical-test/internal/pkg/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepositoryImp is a mock of RepositoryImp interface.
+type MockRepositoryImp struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryImpMockRecorder
+}
+
+// MockRepositoryImpMockRecorder is the mock recorder for MockRepositoryImp.
+type MockRepositoryImpMockRecorder struct {
+	mock *MockRepositoryImp
+}
+
+// NewMockRepositoryImp creates a new mock instance.
+func NewMockRepositoryImp(ctrl *gomock.Controller) *MockRepositoryImp {
+	mock := &MockRepositoryImp{ctrl: ctrl}
+	mock.recorder = &MockRepositoryImpMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepositoryImp) EXPECT() *MockRepositoryImpMockRecorder {
+	return m.recorder
+}
+
+// CreateEvent mocks base method.
+func (m *MockRepositoryImp) CreateEvent(event *entity.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEvent", event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEvent indicates an expected call of CreateEvent.
+func (mr *MockRepositoryImpMockRecorder) CreateEvent(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockRepositoryImp)(nil).CreateEvent), event)
+}
+
+// DeleteEventsBefore mocks base method.
+func (m *MockRepositoryImp) DeleteEventsBefore(cutoff time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEventsBefore", cutoff)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteEventsBefore indicates an expected call of DeleteEventsBefore.
+func (mr *MockRepositoryImpMockRecorder) DeleteEventsBefore(cutoff interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEventsBefore", reflect.TypeOf((*MockRepositoryImp)(nil).DeleteEventsBefore), cutoff)
+}
+
+// ExportEvents mocks base method.
+func (m *MockRepositoryImp) ExportEvents(filter audit.ListEventsFilter, export func([]entity.AuditEvent) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportEvents", filter, export)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportEvents indicates an expected call of ExportEvents.
+func (mr *MockRepositoryImpMockRecorder) ExportEvents(filter, export interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportEvents", reflect.TypeOf((*MockRepositoryImp)(nil).ExportEvents), filter, export)
+}
+
+// ListEvents mocks base method.
+func (m *MockRepositoryImp) ListEvents(filter audit.ListEventsFilter) ([]entity.AuditEvent, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvents", filter)
+	ret0, _ := ret[0].([]entity.AuditEvent)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListEvents indicates an expected call of ListEvents.
+func (mr *MockRepositoryImpMockRecorder) ListEvents(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockRepositoryImp)(nil).ListEvents), filter)
+}
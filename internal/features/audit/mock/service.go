@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/features/audit/service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	io "io"
+	audit "luizalabs-technical-test/internal/features/audit"
+	audit0 "luizalabs-technical-test/internal/pkg/audit"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockServiceImp is a mock of ServiceImp interface.
+type MockServiceImp struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceImpMockRecorder
+}
+
+// MockServiceImpMockRecorder is the mock recorder for MockServiceImp.
+type MockServiceImpMockRecorder struct {
+	mock *MockServiceImp
+}
+
+// NewMockServiceImp creates a new mock instance.
+func NewMockServiceImp(ctrl *gomock.Controller) *MockServiceImp {
+	mock := &MockServiceImp{ctrl: ctrl}
+	mock.recorder = &MockServiceImpMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceImp) EXPECT() *MockServiceImpMockRecorder {
+	return m.recorder
+}
+
+// ExportEvents mocks base method.
+func (m *MockServiceImp) ExportEvents(filter audit.ListEventsFilter, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportEvents", filter, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportEvents indicates an expected call of ExportEvents.
+func (mr *MockServiceImpMockRecorder) ExportEvents(filter, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportEvents", reflect.TypeOf((*MockServiceImp)(nil).ExportEvents), filter, w)
+}
+
+// ListEvents mocks base method.
+func (m *MockServiceImp) ListEvents(filter audit.ListEventsFilter) (*audit.ListEventsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvents", filter)
+	ret0, _ := ret[0].(*audit.ListEventsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvents indicates an expected call of ListEvents.
+func (mr *MockServiceImpMockRecorder) ListEvents(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockServiceImp)(nil).ListEvents), filter)
+}
+
+// PurgeExpiredEvents mocks base method.
+func (m *MockServiceImp) PurgeExpiredEvents() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredEvents")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpiredEvents indicates an expected call of PurgeExpiredEvents.
+func (mr *MockServiceImpMockRecorder) PurgeExpiredEvents() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredEvents", reflect.TypeOf((*MockServiceImp)(nil).PurgeExpiredEvents))
+}
+
+// Record mocks base method.
+func (m *MockServiceImp) Record(c *gin.Context, event audit0.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", c, event)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockServiceImpMockRecorder) Record(c, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockServiceImp)(nil).Record), c, event)
+}
@@ -0,0 +1,150 @@
+package audit
+
+import (
+	"luizalabs-technical-test/internal/pkg/entity"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultPageSize defines how many events are listed per page when no page size is requested.
+const defaultPageSize = 50
+
+// csvHeader lists the columns of the exported events, matching the fields of EventResponse.
+var csvHeader = []string{
+	"id", "created_at", "tenant_id", "actor_id", "actor", "action", "outcome", "reason", "ip", "user_agent", "correlation_id",
+}
+
+// ListEventsQuery represents the query parameters for listing or exporting events.
+// Dates follow RFC 3339 (e.g. 2024-01-31T00:00:00Z).
+type ListEventsQuery struct {
+	Page          int       `form:"page"           binding:"omitempty,min=1"`
+	PageSize      int       `form:"page_size"      binding:"omitempty,min=1,max=500"`
+	TenantID      uint      `form:"tenant_id"`
+	ActorID       uint      `form:"actor_id"`
+	Actor         string    `form:"actor"`
+	Action        string    `form:"action"`
+	Outcome       string    `form:"outcome"`
+	IP            string    `form:"ip"`
+	CorrelationID string    `form:"correlation_id"`
+	From          time.Time `form:"from"           time_format:"2006-01-02T15:04:05Z07:00"`
+	To            time.Time `form:"to"             time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// ListEventsFilter represents the filter criteria for listing events. Zero values are ignored.
+type ListEventsFilter struct {
+	Page          int
+	PageSize      int
+	TenantID      uint
+	ActorID       uint
+	Actor         string
+	Action        string
+	Outcome       string
+	IP            string
+	CorrelationID string
+	From          time.Time
+	To            time.Time
+}
+
+// EventResponse represents a recorded event.
+type EventResponse struct {
+	ID            uint      `json:"id"`
+	CreatedAt     time.Time `json:"created_at"`
+	TenantID      uint      `json:"tenant_id,omitempty"`
+	ActorID       uint      `json:"actor_id,omitempty"`
+	Actor         string    `json:"actor,omitempty"`
+	Action        string    `json:"action"`
+	Outcome       string    `json:"outcome"`
+	Reason        string    `json:"reason,omitempty"`
+	IP            string    `json:"ip"`
+	UserAgent     string    `json:"user_agent"`
+	CorrelationID string    `json:"correlation_id"`
+}
+
+// ListEventsResponse represents a page of events, newest first.
+type ListEventsResponse struct {
+	Events   []EventResponse `json:"events"`
+	Page     int             `json:"page"`
+	PageSize int             `json:"page_size"`
+	Total    int64           `json:"total"`
+}
+
+// Policy defines how long events are kept. A zero retention keeps them forever.
+type Policy struct {
+	Retention time.Duration
+}
+
+// ToListEventsFilter maps ListEventsQuery to ListEventsFilter, applying the default pagination.
+func (q *ListEventsQuery) ToListEventsFilter() ListEventsFilter {
+	filter := ListEventsFilter{
+		Page:          q.Page,
+		PageSize:      q.PageSize,
+		TenantID:      q.TenantID,
+		ActorID:       q.ActorID,
+		Actor:         q.Actor,
+		Action:        q.Action,
+		Outcome:       q.Outcome,
+		IP:            q.IP,
+		CorrelationID: q.CorrelationID,
+		From:          q.From,
+		To:            q.To,
+	}
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize == 0 {
+		filter.PageSize = defaultPageSize
+	}
+	return filter
+}
+
+// ToEventResponse converts an AuditEvent entity to its public representation.
+func ToEventResponse(event entity.AuditEvent) EventResponse {
+	return EventResponse{
+		ID:            event.ID,
+		CreatedAt:     event.CreatedAt,
+		TenantID:      event.TenantID,
+		ActorID:       event.ActorID,
+		Actor:         event.Actor,
+		Action:        event.Action,
+		Outcome:       event.Outcome,
+		Reason:        event.Reason,
+		IP:            event.IP,
+		UserAgent:     event.UserAgent,
+		CorrelationID: event.CorrelationID,
+	}
+}
+
+// toCSVRecord formats an AuditEvent entity as a CSV record, following csvHeader.
+// Note: the actor, user agent and correlation ID may come straight from the client, so every text cell is neutralized.
+func toCSVRecord(event entity.AuditEvent) []string {
+	return []string{
+		strconv.FormatUint(uint64(event.ID), 10),
+		event.CreatedAt.UTC().Format(time.RFC3339),
+		formatOptionalID(event.TenantID),
+		formatOptionalID(event.ActorID),
+		escapeCSVCell(event.Actor),
+		escapeCSVCell(event.Action),
+		escapeCSVCell(event.Outcome),
+		escapeCSVCell(event.Reason),
+		escapeCSVCell(event.IP),
+		escapeCSVCell(event.UserAgent),
+		escapeCSVCell(event.CorrelationID),
+	}
+}
+
+// escapeCSVCell prefixes the cells spreadsheets would evaluate as formulas with a quote, so they are shown as text.
+func escapeCSVCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// formatOptionalID formats an ID for the CSV export, leaving unknown (zero) IDs empty.
+func formatOptionalID(id uint) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatUint(uint64(id), 10)
+}
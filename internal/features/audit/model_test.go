@@ -0,0 +1,120 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"luizalabs-technical-test/internal/pkg/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestToListEventsFilter tests the mapping of the query parameters, including the default pagination.
+func TestToListEventsFilter(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	query := &ListEventsQuery{
+		ActorID:       7,
+		Actor:         "user@example.com",
+		Action:        "login",
+		Outcome:       "failure",
+		IP:            "192.0.2.1",
+		CorrelationID: "abc",
+		From:          from,
+	}
+
+	filter := query.ToListEventsFilter()
+
+	assert.Equal(t, ListEventsFilter{
+		Page:          1,
+		PageSize:      defaultPageSize,
+		ActorID:       7,
+		Actor:         "user@example.com",
+		Action:        "login",
+		Outcome:       "failure",
+		IP:            "192.0.2.1",
+		CorrelationID: "abc",
+		From:          from,
+	}, filter)
+
+	query.Page, query.PageSize = 3, 10
+	filter = query.ToListEventsFilter()
+	assert.Equal(t, 3, filter.Page)
+	assert.Equal(t, 10, filter.PageSize)
+}
+
+// TestToEventResponse tests the conversion of an AuditEvent entity into its public view.
+func TestToEventResponse(t *testing.T) {
+	createdAt := time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+	event := entity.AuditEvent{
+		ID:            5,
+		CreatedAt:     createdAt,
+		ActorID:       7,
+		Actor:         "user@example.com",
+		Action:        "login",
+		Outcome:       "failure",
+		Reason:        "ERR_INVALID_CREDENTIALS",
+		IP:            "192.0.2.1",
+		UserAgent:     "curl/8.0",
+		CorrelationID: "abc",
+	}
+
+	assert.Equal(t, EventResponse{
+		ID:            5,
+		CreatedAt:     createdAt,
+		ActorID:       7,
+		Actor:         "user@example.com",
+		Action:        "login",
+		Outcome:       "failure",
+		Reason:        "ERR_INVALID_CREDENTIALS",
+		IP:            "192.0.2.1",
+		UserAgent:     "curl/8.0",
+		CorrelationID: "abc",
+	}, ToEventResponse(event))
+}
+
+// TestToCSVRecord tests the CSV formatting of an event, following the header columns.
+func TestToCSVRecord(t *testing.T) {
+	event := entity.AuditEvent{
+		ID:            5,
+		CreatedAt:     time.Date(2024, 1, 31, 7, 0, 0, 0, time.FixedZone("BRT", -3*60*60)),
+		Action:        "token_rejected",
+		Outcome:       "failure",
+		Reason:        "invalid_token",
+		IP:            "192.0.2.1",
+		CorrelationID: "abc",
+	}
+
+	record := toCSVRecord(event)
+
+	assert.Len(t, record, len(csvHeader))
+	assert.Equal(t, []string{
+		"5", "2024-01-31T10:00:00Z", "", "", "", "token_rejected", "failure", "invalid_token", "192.0.2.1", "", "abc",
+	}, record)
+
+	event.TenantID = 3
+	event.ActorID = 7
+	assert.Equal(t, "3", toCSVRecord(event)[2])
+	assert.Equal(t, "7", toCSVRecord(event)[3])
+}
+
+// TestToCSVRecord_Formulas tests that client-provided values starting like formulas are exported as text.
+func TestToCSVRecord_Formulas(t *testing.T) {
+	event := entity.AuditEvent{
+		Actor:     "=HYPERLINK(\"http://attacker.example\")",
+		UserAgent: "=cmd|' /C calc'!A0",
+		Reason:    "@SUM(1+1)",
+		IP:        "-1+1",
+	}
+
+	record := toCSVRecord(event)
+
+	assert.Equal(t, "'=HYPERLINK(\"http://attacker.example\")", record[4])
+	assert.Equal(t, "'@SUM(1+1)", record[7])
+	assert.Equal(t, "'-1+1", record[8])
+	assert.Equal(t, "'=cmd|' /C calc'!A0", record[9])
+
+	for _, value := range []string{"+1", "\t=1", "\r=1"} {
+		assert.Equal(t, "'"+value, escapeCSVCell(value))
+	}
+	assert.Equal(t, "curl/8.0", escapeCSVCell("curl/8.0"))
+}
@@ -0,0 +1,101 @@
+package audit
+
+import (
+	"luizalabs-technical-test/internal/pkg/entity"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// exportBatchSize defines how many events are loaded at once while exporting.
+const exportBatchSize = 500
+
+// RepositoryImp defines the interface for the repository layer,
+// which abstracts data access operations.
+type RepositoryImp interface {
+	CreateEvent(event *entity.AuditEvent) error
+	ListEvents(filter ListEventsFilter) ([]entity.AuditEvent, int64, error)
+	ExportEvents(filter ListEventsFilter, export func(events []entity.AuditEvent) error) error
+	DeleteEventsBefore(cutoff time.Time) (int64, error)
+}
+
+// repository struct implements the repositoryImp interface,
+// that interacts with external entities such as databases or external APIs.
+type repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates and returns a new instance of the repository.
+func NewRepository(db *gorm.DB) RepositoryImp {
+	return &repository{db}
+}
+
+// CreateEvent appends an event to the audit log.
+func (r *repository) CreateEvent(event *entity.AuditEvent) error {
+	tx := r.db.Table(entity.TbAuditEvent).Create(event)
+	if err := tx.Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListEvents retrieves a page of events matching the filter, newest first, along with the total of matching events.
+func (r *repository) ListEvents(filter ListEventsFilter) ([]entity.AuditEvent, int64, error) {
+	query := r.filtered(filter)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var events []entity.AuditEvent
+	tx := query.Order("id DESC").Offset((filter.Page - 1) * filter.PageSize).Limit(filter.PageSize).Find(&events)
+	if err := tx.Error; err != nil {
+		return nil, 0, err
+	}
+
+	return events, total, nil
+}
+
+// ExportEvents loads every event matching the filter in batches, oldest first, handing each batch to export.
+// Note: the pagination of the filter is ignored.
+func (r *repository) ExportEvents(filter ListEventsFilter, export func(events []entity.AuditEvent) error) error {
+	var events []entity.AuditEvent
+
+	tx := r.filtered(filter).FindInBatches(&events, exportBatchSize, func(_ *gorm.DB, _ int) error {
+		return export(events)
+	})
+	return tx.Error
+}
+
+// DeleteEventsBefore removes the events recorded before the cutoff, returning how many were removed.
+func (r *repository) DeleteEventsBefore(cutoff time.Time) (int64, error) {
+	tx := r.db.Where("created_at < ?", cutoff).Delete(&entity.AuditEvent{})
+	if err := tx.Error; err != nil {
+		return 0, err
+	}
+	return tx.RowsAffected, nil
+}
+
+// filtered builds the query selecting the events matching the filter.
+func (r *repository) filtered(filter ListEventsFilter) *gorm.DB {
+	query := r.db.Model(&entity.AuditEvent{}).Where(&entity.AuditEvent{
+		TenantID:      filter.TenantID,
+		ActorID:       filter.ActorID,
+		Action:        filter.Action,
+		Outcome:       filter.Outcome,
+		IP:            filter.IP,
+		CorrelationID: filter.CorrelationID,
+	})
+	if filter.Actor != "" {
+		query = query.Where("LOWER(actor) = ?", strings.ToLower(filter.Actor))
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at <= ?", filter.To)
+	}
+	return query
+}
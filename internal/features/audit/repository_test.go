@@ -0,0 +1,160 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"luizalabs-technical-test/internal/pkg/entity"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type AuditRepositoryTestSuite struct {
+	suite.Suite
+	db  *gorm.DB
+	ctx context.Context
+}
+
+func (s *AuditRepositoryTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+
+	// Start PostgreSQL container
+	req := testcontainers.ContainerRequest{
+		Image:        "postgres:latest",
+		ExposedPorts: []string{"5432/tcp"},
+		Env: map[string]string{
+			"POSTGRES_USER":     "testuser",
+			"POSTGRES_PASSWORD": "testpass",
+			"POSTGRES_DB":       "testdb",
+		},
+		WaitingFor: wait.ForListeningPort("5432/tcp"),
+	}
+
+	// Create and start the container
+	postgresContainer, err := testcontainers.GenericContainer(s.ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	s.Require().NoError(err)
+
+	// Get the port and create the database connection
+	host, _ := postgresContainer.Host(s.ctx)
+	port, _ := postgresContainer.MappedPort(s.ctx, "5432")
+
+	dsn := "host=" + host + " port=" + port.Port() + " user=testuser password=testpass dbname=testdb sslmode=disable"
+	s.db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	s.Require().NoError(err)
+
+	// Auto-migrate the AuditEvent table
+	s.Require().NoError(s.db.AutoMigrate(&entity.AuditEvent{}))
+}
+
+func (s *AuditRepositoryTestSuite) TearDownSuite() {
+	// Clean up the database connection
+	db, err := s.db.DB()
+	s.Require().NoError(err)
+	db.Close()
+}
+
+func (s *AuditRepositoryTestSuite) SetupTest() {
+	s.Require().NoError(s.db.Where("1 = 1").Delete(&entity.AuditEvent{}).Error)
+}
+
+func (s *AuditRepositoryTestSuite) TestCreateAndListEvents() {
+	repo := NewRepository(s.db)
+	now := time.Now()
+
+	events := []entity.AuditEvent{
+		{CreatedAt: now.Add(-2 * time.Hour), ActorID: 7, Actor: "User@Example.com", Action: "login", Outcome: "failure", IP: "192.0.2.1"},
+		{CreatedAt: now.Add(-time.Hour), ActorID: 7, Actor: "user@example.com", Action: "login", Outcome: "success", IP: "192.0.2.1"},
+		{CreatedAt: now, TenantID: 3, Actor: "other@example.com", Action: "register", Outcome: "success", IP: "192.0.2.2", CorrelationID: "abc"},
+	}
+	for i := range events {
+		s.Require().NoError(repo.CreateEvent(&events[i]))
+		s.NotZero(events[i].ID)
+	}
+
+	listed, total, err := repo.ListEvents(ListEventsFilter{Page: 1, PageSize: 2})
+	s.NoError(err)
+	s.Equal(int64(3), total)
+	s.Require().Len(listed, 2)
+	s.Equal(events[2].ID, listed[0].ID, "Expected the newest event first")
+	s.Equal(events[1].ID, listed[1].ID)
+
+	listed, total, err = repo.ListEvents(ListEventsFilter{Page: 2, PageSize: 2})
+	s.NoError(err)
+	s.Equal(int64(3), total)
+	s.Require().Len(listed, 1)
+	s.Equal(events[0].ID, listed[0].ID)
+
+	listed, total, err = repo.ListEvents(ListEventsFilter{Page: 1, PageSize: 10, Actor: "USER@example.com"})
+	s.NoError(err)
+	s.Equal(int64(2), total)
+	s.Len(listed, 2)
+
+	listed, _, err = repo.ListEvents(ListEventsFilter{Page: 1, PageSize: 10, ActorID: 7, Outcome: "failure"})
+	s.NoError(err)
+	s.Require().Len(listed, 1)
+	s.Equal(events[0].ID, listed[0].ID)
+
+	listed, _, err = repo.ListEvents(ListEventsFilter{Page: 1, PageSize: 10, CorrelationID: "abc", IP: "192.0.2.2"})
+	s.NoError(err)
+	s.Require().Len(listed, 1)
+	s.Equal(events[2].ID, listed[0].ID)
+
+	listed, _, err = repo.ListEvents(ListEventsFilter{Page: 1, PageSize: 10, TenantID: 3})
+	s.NoError(err)
+	s.Require().Len(listed, 1)
+	s.Equal(events[2].ID, listed[0].ID)
+
+	listed, _, err = repo.ListEvents(ListEventsFilter{
+		Page: 1, PageSize: 10, From: now.Add(-90 * time.Minute), To: now.Add(-30 * time.Minute),
+	})
+	s.NoError(err)
+	s.Require().Len(listed, 1)
+	s.Equal(events[1].ID, listed[0].ID)
+}
+
+func (s *AuditRepositoryTestSuite) TestExportEvents() {
+	repo := NewRepository(s.db)
+
+	for i := 0; i < exportBatchSize+1; i++ {
+		s.Require().NoError(repo.CreateEvent(&entity.AuditEvent{CreatedAt: time.Now(), Action: "login", Outcome: "success"}))
+	}
+	s.Require().NoError(repo.CreateEvent(&entity.AuditEvent{CreatedAt: time.Now(), Action: "register", Outcome: "success"}))
+
+	var batches, exported int
+	err := repo.ExportEvents(ListEventsFilter{Action: "login", Page: 1, PageSize: 1}, func(events []entity.AuditEvent) error {
+		batches++
+		exported += len(events)
+		return nil
+	})
+	s.NoError(err)
+	s.Equal(2, batches)
+	s.Equal(exportBatchSize+1, exported, "Expected the pagination to be ignored")
+}
+
+func (s *AuditRepositoryTestSuite) TestDeleteEventsBefore() {
+	repo := NewRepository(s.db)
+	now := time.Now()
+
+	s.Require().NoError(repo.CreateEvent(&entity.AuditEvent{CreatedAt: now.Add(-48 * time.Hour), Action: "login", Outcome: "success"}))
+	s.Require().NoError(repo.CreateEvent(&entity.AuditEvent{CreatedAt: now, Action: "login", Outcome: "success"}))
+
+	purged, err := repo.DeleteEventsBefore(now.Add(-24 * time.Hour))
+	s.NoError(err)
+	s.Equal(int64(1), purged)
+
+	_, total, err := repo.ListEvents(ListEventsFilter{Page: 1, PageSize: 10})
+	s.NoError(err)
+	s.Equal(int64(1), total)
+}
+
+func TestAuditRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditRepositoryTestSuite))
+}
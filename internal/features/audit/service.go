@@ -0,0 +1,129 @@
+package audit
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"luizalabs-technical-test/internal/pkg/audit"
+	"luizalabs-technical-test/internal/pkg/entity"
+	"luizalabs-technical-test/internal/pkg/middleware"
+	"luizalabs-technical-test/pkg/logger"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// actorMaxLength and userAgentMaxLength match the column sizes of the audit event entity.
+	actorMaxLength     = 255
+	userAgentMaxLength = 512
+)
+
+// ServiceImp defines the interface for the service layer, with methods to record and query the audit log.
+type ServiceImp interface {
+	audit.Recorder
+	ListEvents(filter ListEventsFilter) (*ListEventsResponse, error)
+	ExportEvents(filter ListEventsFilter, w io.Writer) error
+	PurgeExpiredEvents() (int64, error)
+}
+
+// service struct implements the serviceImp interface and holds a reference to the repository.
+type service struct {
+	repository RepositoryImp
+	policy     Policy
+}
+
+// NewService creates and returns a new service instance, injecting the repository dependency and the retention policy.
+func NewService(repository RepositoryImp, policy Policy) ServiceImp {
+	return &service{repository, policy}
+}
+
+// Record appends the event to the audit log, along with the client IP, User-Agent and correlation ID of the request.
+func (s *service) Record(c *gin.Context, event audit.Event) {
+	auditEvent := entity.AuditEvent{
+		CreatedAt:     time.Now(),
+		ActorID:       event.ActorID,
+		TenantID:      event.TenantID,
+		Actor:         truncate(event.Actor, actorMaxLength),
+		Action:        event.Action,
+		Outcome:       event.Outcome,
+		Reason:        event.Reason,
+		IP:            c.ClientIP(),
+		UserAgent:     truncate(c.Request.UserAgent(), userAgentMaxLength),
+		CorrelationID: c.GetString(middleware.CorrelationIDKey),
+	}
+
+	if err := s.repository.CreateEvent(&auditEvent); err != nil {
+		logger.Error(fmt.Errorf("failed to record %s audit event: %w", event.Action, err))
+	}
+}
+
+// ListEvents retrieves a page of events matching the filter, newest first.
+func (s *service) ListEvents(filter ListEventsFilter) (*ListEventsResponse, error) {
+	events, total, err := s.repository.ListEvents(filter)
+	if err != nil {
+		return nil, ErrOperationFailed.WithErr(err)
+	}
+
+	response := ListEventsResponse{
+		Events:   make([]EventResponse, 0, len(events)),
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+		Total:    total,
+	}
+	for _, event := range events {
+		response.Events = append(response.Events, ToEventResponse(event))
+	}
+
+	return &response, nil
+}
+
+// ExportEvents writes every event matching the filter to w as CSV, oldest first.
+func (s *service) ExportEvents(filter ListEventsFilter, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return ErrOperationFailed.WithErr(err)
+	}
+
+	err := s.repository.ExportEvents(filter, func(events []entity.AuditEvent) error {
+		for _, event := range events {
+			if err := writer.Write(toCSVRecord(event)); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	})
+	if err != nil {
+		return ErrOperationFailed.WithErr(err)
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return ErrOperationFailed.WithErr(err)
+	}
+	return nil
+}
+
+// PurgeExpiredEvents removes the events older than the retention, returning how many were removed.
+// Note: nothing is removed when the retention is disabled.
+func (s *service) PurgeExpiredEvents() (int64, error) {
+	if s.policy.Retention <= 0 {
+		return 0, nil
+	}
+
+	purged, err := s.repository.DeleteEventsBefore(time.Now().Add(-s.policy.Retention))
+	if err != nil {
+		return 0, ErrOperationFailed.WithErr(err)
+	}
+	return purged, nil
+}
+
+// truncate limits the string to the given amount of characters, keeping multibyte characters intact.
+func truncate(value string, maxLength int) string {
+	runes := []rune(value)
+	if len(runes) <= maxLength {
+		return value
+	}
+	return string(runes[:maxLength])
+}
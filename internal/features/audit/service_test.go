@@ -0,0 +1,195 @@
+package audit_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"luizalabs-technical-test/internal/features/audit"
+	auditMock "luizalabs-technical-test/internal/features/audit/mock"
+	pkgAudit "luizalabs-technical-test/internal/pkg/audit"
+	"luizalabs-technical-test/internal/pkg/entity"
+	"luizalabs-technical-test/internal/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// AuditServiceTestSuite is a test suite for the audit service.
+type AuditServiceTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	repoMock *auditMock.MockRepositoryImp
+	service  audit.ServiceImp
+}
+
+// SetupTest initializes the test suite, creating a new mock controller and instances of mocks.
+func (suite *AuditServiceTestSuite) SetupTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.repoMock = auditMock.NewMockRepositoryImp(suite.ctrl)
+	suite.service = audit.NewService(suite.repoMock, audit.Policy{Retention: 24 * time.Hour})
+}
+
+// TearDownTest cleans up the mock controller after each test.
+func (suite *AuditServiceTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+// TestRecord tests that the request metadata is stored along with the event.
+func (suite *AuditServiceTestSuite) TestRecord() {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/auth/login", nil)
+	c.Request.RemoteAddr = "192.0.2.1:1234"
+	c.Request.Header.Set("User-Agent", strings.Repeat("a", 600))
+	c.Set(middleware.CorrelationIDKey, "abc")
+
+	var stored *entity.AuditEvent
+	suite.repoMock.EXPECT().
+		CreateEvent(gomock.Any()).
+		DoAndReturn(func(event *entity.AuditEvent) error {
+			stored = event
+			return nil
+		})
+
+	suite.service.Record(c, pkgAudit.Event{
+		ActorID:  7,
+		TenantID: 3,
+		Actor:    "user@example.com",
+		Action:   pkgAudit.ActionLogin,
+		Outcome:  pkgAudit.OutcomeFailure,
+		Reason:   "ERR_INVALID_CREDENTIALS",
+	})
+
+	assert.NotNil(suite.T(), stored)
+	assert.Equal(suite.T(), uint(7), stored.ActorID)
+	assert.Equal(suite.T(), uint(3), stored.TenantID)
+	assert.Equal(suite.T(), "user@example.com", stored.Actor)
+	assert.Equal(suite.T(), pkgAudit.ActionLogin, stored.Action)
+	assert.Equal(suite.T(), pkgAudit.OutcomeFailure, stored.Outcome)
+	assert.Equal(suite.T(), "ERR_INVALID_CREDENTIALS", stored.Reason)
+	assert.Equal(suite.T(), "192.0.2.1", stored.IP)
+	assert.Len(suite.T(), stored.UserAgent, 512)
+	assert.Equal(suite.T(), "abc", stored.CorrelationID)
+	assert.WithinDuration(suite.T(), time.Now(), stored.CreatedAt, time.Minute)
+}
+
+// TestRecord_FailedToStore tests that a failure to store the event does not panic nor propagate.
+func (suite *AuditServiceTestSuite) TestRecord_FailedToStore() {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/auth/register", nil)
+
+	suite.repoMock.EXPECT().
+		CreateEvent(gomock.Any()).
+		Return(errors.New("database unavailable"))
+
+	suite.service.Record(c, pkgAudit.Event{Action: pkgAudit.ActionRegister, Outcome: pkgAudit.OutcomeSuccess})
+}
+
+// TestListEvents tests the listing of a page of events.
+func (suite *AuditServiceTestSuite) TestListEvents() {
+	filter := audit.ListEventsFilter{Page: 2, PageSize: 1}
+	suite.repoMock.EXPECT().
+		ListEvents(filter).
+		Return([]entity.AuditEvent{{ID: 3, Action: pkgAudit.ActionLogin}}, int64(4), nil)
+
+	response, err := suite.service.ListEvents(filter)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), &audit.ListEventsResponse{
+		Events:   []audit.EventResponse{{ID: 3, Action: pkgAudit.ActionLogin}},
+		Page:     2,
+		PageSize: 1,
+		Total:    4,
+	}, response)
+}
+
+// TestListEvents_Failure tests the scenario where the events cannot be retrieved.
+func (suite *AuditServiceTestSuite) TestListEvents_Failure() {
+	suite.repoMock.EXPECT().
+		ListEvents(gomock.Any()).
+		Return(nil, int64(0), errors.New("database unavailable"))
+
+	_, err := suite.service.ListEvents(audit.ListEventsFilter{Page: 1, PageSize: 50})
+
+	assert.Equal(suite.T(), &audit.ErrOperationFailed, err)
+}
+
+// TestExportEvents tests the CSV export of the events, batch by batch.
+func (suite *AuditServiceTestSuite) TestExportEvents() {
+	createdAt := time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+	suite.repoMock.EXPECT().
+		ExportEvents(audit.ListEventsFilter{Action: pkgAudit.ActionLogin}, gomock.Any()).
+		DoAndReturn(func(_ audit.ListEventsFilter, export func([]entity.AuditEvent) error) error {
+			if err := export([]entity.AuditEvent{{ID: 1, CreatedAt: createdAt, TenantID: 3, ActorID: 7, Action: "login", Outcome: "success"}}); err != nil {
+				return err
+			}
+			return export([]entity.AuditEvent{{ID: 2, CreatedAt: createdAt, Actor: "a,b", Action: "login", Outcome: "failure"}})
+		})
+
+	var buf bytes.Buffer
+	err := suite.service.ExportEvents(audit.ListEventsFilter{Action: pkgAudit.ActionLogin}, &buf)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), ""+
+		"id,created_at,tenant_id,actor_id,actor,action,outcome,reason,ip,user_agent,correlation_id\n"+
+		"1,2024-01-31T10:00:00Z,3,7,,login,success,,,,\n"+
+		"2,2024-01-31T10:00:00Z,,,\"a,b\",login,failure,,,,\n", buf.String())
+}
+
+// TestExportEvents_Failure tests the scenario where the events cannot be retrieved.
+func (suite *AuditServiceTestSuite) TestExportEvents_Failure() {
+	suite.repoMock.EXPECT().
+		ExportEvents(gomock.Any(), gomock.Any()).
+		Return(errors.New("database unavailable"))
+
+	err := suite.service.ExportEvents(audit.ListEventsFilter{}, &bytes.Buffer{})
+
+	assert.Equal(suite.T(), &audit.ErrOperationFailed, err)
+}
+
+// TestPurgeExpiredEvents tests that the events older than the retention are removed.
+func (suite *AuditServiceTestSuite) TestPurgeExpiredEvents() {
+	suite.repoMock.EXPECT().
+		DeleteEventsBefore(gomock.Any()).
+		DoAndReturn(func(cutoff time.Time) (int64, error) {
+			assert.WithinDuration(suite.T(), time.Now().Add(-24*time.Hour), cutoff, time.Minute)
+			return 3, nil
+		})
+
+	purged, err := suite.service.PurgeExpiredEvents()
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(3), purged)
+}
+
+// TestPurgeExpiredEvents_RetentionDisabled tests that nothing is removed when the retention is disabled.
+func (suite *AuditServiceTestSuite) TestPurgeExpiredEvents_RetentionDisabled() {
+	service := audit.NewService(suite.repoMock, audit.Policy{})
+
+	purged, err := service.PurgeExpiredEvents()
+
+	assert.NoError(suite.T(), err)
+	assert.Zero(suite.T(), purged)
+}
+
+// TestPurgeExpiredEvents_Failure tests the scenario where the events cannot be removed.
+func (suite *AuditServiceTestSuite) TestPurgeExpiredEvents_Failure() {
+	suite.repoMock.EXPECT().
+		DeleteEventsBefore(gomock.Any()).
+		Return(int64(0), errors.New("database unavailable"))
+
+	_, err := suite.service.PurgeExpiredEvents()
+
+	assert.Equal(suite.T(), &audit.ErrOperationFailed, err)
+}
+
+// TestAuditServiceTestSuite runs the test suite.
+func TestAuditServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditServiceTestSuite))
+}
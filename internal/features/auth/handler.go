@@ -1,9 +1,14 @@
 package auth
 
 import (
+	"luizalabs-technical-test/internal/pkg/audit"
+	"luizalabs-technical-test/pkg/constants/str"
 	"luizalabs-technical-test/pkg/errors"
+	"luizalabs-technical-test/pkg/middleware"
 	"luizalabs-technical-test/pkg/server"
+	"luizalabs-technical-test/pkg/token"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -11,20 +16,42 @@ import (
 // swagAuthenticateUserResponse is used to work around Swagger's lack of support for Go generics.
 type swagAuthenticateUserResponse = server.APIResponse[AuthenticateUserResponse]
 
+// swagEnrollMFAResponse is used to work around Swagger's lack of support for Go generics.
+type swagEnrollMFAResponse = server.APIResponse[EnrollMFAResponse]
+
+// swagConfirmMFAResponse is used to work around Swagger's lack of support for Go generics.
+type swagConfirmMFAResponse = server.APIResponse[ConfirmMFAResponse]
+
+// swagUserResponse is used to work around Swagger's lack of support for Go generics.
+type swagUserResponse = server.APIResponse[UserResponse]
+
+// swagVerifyEmailResponse is used to work around Swagger's lack of support for Go generics.
+type swagVerifyEmailResponse = server.APIResponse[VerifyEmailResponse]
+
+// swagListUsersResponse is used to work around Swagger's lack of support for Go generics.
+type swagListUsersResponse = server.APIResponse[ListUsersResponse]
+
+// swagListSessionsResponse is used to work around Swagger's lack of support for Go generics.
+type swagListSessionsResponse = server.APIResponse[[]SessionResponse]
+
 // HandlerImp defines the interface for handling server operations.
 // It embeds the server.HandlerImp interface, allowing for extended functionality and custom implementations.
 type HandlerImp interface {
 	server.HandlerImp
 }
 
-// handler struct is an empty implementation of the Handler interface.
+// handler struct holds a reference to the service layer, the middlewares protecting admin routes
+// and the recorder of the audit log.
 type handler struct {
-	service ServiceImp
+	service    ServiceImp
+	tokenLayer middleware.Middleware
+	adminLayer middleware.Middleware
+	recorder   audit.Recorder
 }
 
 // NewHandler creates and returns a new handler instance.
-func NewHandler(service ServiceImp) HandlerImp {
-	return &handler{service}
+func NewHandler(service ServiceImp, tokenMiddleware, adminMiddleware middleware.Middleware, recorder audit.Recorder) HandlerImp {
+	return &handler{service, tokenMiddleware, adminMiddleware, recorder}
 }
 
 // Register sets up the route for retrieving auth information.
@@ -32,18 +59,47 @@ func (h *handler) Register(r *gin.RouterGroup) {
 	g := r.Group("/auth")
 	g.POST("/register", h.postRegister)
 	g.POST("/login", h.postLogin)
+	g.POST("/login/mfa", h.postLoginMFA)
+	g.GET("/verify", h.getVerify)
+	g.GET("/oidc/authorize", h.getFederatedAuthorize)
+	g.GET("/oidc/callback", h.getFederatedCallback)
+	g.POST("/password/forgot", h.postForgotPassword)
+	g.POST("/password/reset", h.postResetPassword)
+
+	mfa := g.Group("/mfa", h.tokenLayer.Middleware())
+	mfa.POST("/enroll", h.postEnrollMFA)
+	mfa.POST("/confirm", h.postConfirmMFA)
+
+	me := r.Group("/users/me", h.tokenLayer.Middleware())
+	me.GET("", h.getProfile)
+	me.PATCH("", h.patchProfile)
+	me.DELETE("", h.deleteAccount)
+	me.POST("/password", h.postChangePassword)
+	me.GET("/sessions", h.getSessions)
+	me.DELETE("/sessions/:id", h.deleteSession)
+
+	admin := r.Group("/admin/users", h.tokenLayer.Middleware(), h.adminLayer.Middleware())
+	admin.GET("", h.getUsers)
+	admin.DELETE("/:id", h.deleteUser)
+	admin.PUT("/:id/role", h.putUserRole)
+	admin.GET("/:id/sessions", h.getUserSessions)
+	admin.POST("/:id/unlock", h.postUnlockUser)
+	admin.POST("/:id/disable", h.postDisableUser)
+	admin.POST("/:id/enable", h.postEnableUser)
+	admin.POST("/:id/restore", h.postRestoreUser)
+	admin.POST("/:id/password-reset", h.postForcePasswordReset)
 }
 
 // postRegister registers a new user.
 //
 //	@Summary		Register a new user
-//	@Description	Registers a new user with the provided information.
+//	@Description	Registers a new user with the provided information. The account starts unverified and a verification link is sent by email. When user enumeration is prevented, a taken email gets the same response and its owner is warned by email.
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
 //	@Param			payload	body	PostRegisterPayload	true	"User registration data"
 //	@Success		201		"User successfully registered"
-//	@Failure		400		{object}	server.APIErrorResponse	"Bad request"
+//	@Failure		400		{object}	server.APIErrorResponse	"Bad request or password policy violation"
 //	@Failure		500		{object}	server.APIErrorResponse	"Internal server error"
 //	@Router			/v1/auth/register [post]
 func (h *handler) postRegister(c *gin.Context) {
@@ -57,11 +113,10 @@ func (h *handler) postRegister(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.RegisterUser(payload.ToUserEntity()); err != nil {
-		c.JSON(http.StatusInternalServerError, server.APIErrorResponse{
-			Error: err.Error(),
-			Code:  err.(errors.ErrorImp).CodeStr(),
-		})
+	err := h.service.RegisterUser(payload.ToUserEntity())
+	h.recordOutcome(c, audit.Event{Actor: payload.Email, Action: audit.ActionRegister}, err)
+	if err != nil {
+		h.abortWithError(c, err)
 		return
 	}
 
@@ -71,7 +126,7 @@ func (h *handler) postRegister(c *gin.Context) {
 // postLogin authenticates the user and returns a JWT token.
 //
 //	@Summary		Authenticate user and return a JWT token
-//	@Description	Authenticates the user with the provided credentials and returns a JWT token.
+//	@Description	Authenticates the user with the provided credentials and returns a JWT token. When MFA is enabled, a short-lived MFA challenge token is returned instead, to be exchanged at /v1/auth/login/mfa. When user enumeration is prevented, unknown emails and locked accounts fail with invalid credentials.
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
@@ -79,6 +134,9 @@ func (h *handler) postRegister(c *gin.Context) {
 //	@Success		202		{object}	swagAuthenticateUserResponse	"Token generated successfully"
 //	@Failure		400		{object}	server.APIErrorResponse			"Bad request"
 //	@Failure		401		{object}	server.APIErrorResponse			"Unauthorized"
+//	@Failure		403		{object}	server.APIErrorResponse			"Email not verified, account disabled or password reset required"
+//	@Failure		423		{object}	server.APIErrorResponse			"Account temporarily locked"
+//	@Failure		429		{object}	server.APIErrorResponse			"Too many failed attempts from this client"
 //	@Router			/v1/auth/login [post]
 func (h *handler) postLogin(c *gin.Context) {
 	var payload PostLoginPayload
@@ -91,16 +149,901 @@ func (h *handler) postLogin(c *gin.Context) {
 		return
 	}
 
-	jwt, err := h.service.AuthenticateUser(payload.ToPostLoginPayloadToInput())
+	input := payload.ToPostLoginPayloadToInput()
+	input.IP = c.ClientIP()
+	input.UserAgent = c.Request.UserAgent()
+
+	response, err := h.service.AuthenticateUser(input)
+	if err != nil {
+		h.recordOutcome(c, audit.Event{Actor: payload.Email, Action: audit.ActionLogin}, err)
+		h.abortWithLoginError(c, err)
+		return
+	}
+
+	event := audit.Event{ActorID: response.UserID, TenantID: response.TenantID, Actor: payload.Email, Action: audit.ActionLogin}
+	if response.MFARequired {
+		event.Outcome = audit.OutcomeChallenged
+	}
+	h.recordOutcome(c, event, nil)
+
+	c.JSON(http.StatusAccepted, swagAuthenticateUserResponse{Data: *response})
+}
+
+// postLoginMFA completes a login requiring MFA.
+//
+//	@Summary		Complete the login with the second factor
+//	@Description	Exchanges the MFA challenge token returned by the login, along with a TOTP or recovery code, for a JWT token. Each recovery code can only be used once.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		PostLoginMFAPayload				true	"MFA challenge token and code"
+//	@Success		202		{object}	swagAuthenticateUserResponse	"Token generated successfully"
+//	@Failure		400		{object}	server.APIErrorResponse			"Bad request"
+//	@Failure		401		{object}	server.APIErrorResponse			"Invalid code or challenge token"
+//	@Failure		403		{object}	server.APIErrorResponse			"Account disabled or password reset required"
+//	@Failure		423		{object}	server.APIErrorResponse			"Account temporarily locked"
+//	@Failure		429		{object}	server.APIErrorResponse			"Too many failed attempts from this client"
+//	@Router			/v1/auth/login/mfa [post]
+func (h *handler) postLoginMFA(c *gin.Context) {
+	var payload PostLoginMFAPayload
+
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, server.APIErrorResponse{
+			Error: ErrInvalidPayload.WithErr(err).Error(),
+			Code:  ErrInvalidPayload.Code,
+		})
+		return
+	}
+
+	input := payload.ToAuthenticateMFAInput()
+	input.IP = c.ClientIP()
+	input.UserAgent = c.Request.UserAgent()
+
+	response, err := h.service.AuthenticateMFA(input)
+	if err != nil {
+		h.recordOutcome(c, audit.Event{Action: audit.ActionLoginMFA}, err)
+		h.abortWithLoginError(c, err)
+		return
+	}
+	h.recordOutcome(c, audit.Event{ActorID: response.UserID, TenantID: response.TenantID, Action: audit.ActionLoginMFA}, nil)
+
+	c.JSON(http.StatusAccepted, swagAuthenticateUserResponse{Data: *response})
+}
+
+// getFederatedAuthorize starts a login at the identity provider.
+//
+//	@Summary		Start a login at the identity provider
+//	@Description	Redirects the user to the OpenID Connect provider, following the authorization code flow with PKCE. The provider redirects back to /v1/auth/oidc/callback once the user authenticates.
+//	@Tags			auth
+//	@Success		302	"Redirect to the identity provider"
+//	@Failure		404	{object}	server.APIErrorResponse	"No identity provider configured"
+//	@Failure		500	{object}	server.APIErrorResponse	"Identity provider unreachable"
+//	@Router			/v1/auth/oidc/authorize [get]
+func (h *handler) getFederatedAuthorize(c *gin.Context) {
+	authURL, err := h.service.BeginFederatedLogin()
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// getFederatedCallback completes a login at the identity provider and returns a JWT token.
+//
+//	@Summary		Complete a login at the identity provider
+//	@Description	Exchanges the authorization code sent by the OpenID Connect provider for a JWT token. The identity is linked to the account with the same email when the provider verified it, or to a new account otherwise. When MFA is enabled, a short-lived MFA challenge token is returned instead, to be exchanged at /v1/auth/login/mfa.
+//	@Tags			auth
+//	@Produce		json
+//	@Param			code	query		string							false	"Authorization code"
+//	@Param			state	query		string							true	"State of the login"
+//	@Param			error	query		string							false	"Error reported by the identity provider"
+//	@Success		202		{object}	swagAuthenticateUserResponse	"Token generated successfully"
+//	@Failure		400		{object}	server.APIErrorResponse			"Bad request"
+//	@Failure		401		{object}	server.APIErrorResponse			"Unknown state or code rejected by the identity provider"
+//	@Failure		403		{object}	server.APIErrorResponse			"Email not verified, account disabled or password reset required"
+//	@Failure		404		{object}	server.APIErrorResponse			"No identity provider configured"
+//	@Failure		409		{object}	server.APIErrorResponse			"Email of an existing account not verified by the identity provider"
+//	@Failure		429		{object}	server.APIErrorResponse			"Too many failed attempts from this client"
+//	@Router			/v1/auth/oidc/callback [get]
+func (h *handler) getFederatedCallback(c *gin.Context) {
+	var query FederatedLoginCallbackQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, server.APIErrorResponse{
+			Error: ErrInvalidPayload.WithErr(err).Error(),
+			Code:  ErrInvalidPayload.Code,
+		})
+		return
+	}
+
+	if query.Error != str.EmptyString {
+		err := ErrInvalidFederatedLogin.WithStrErr("identity provider error: %s", query.Error)
+		h.recordOutcome(c, audit.Event{Action: audit.ActionLoginFederated}, err)
+		h.abortWithLoginError(c, err)
+		return
+	}
+
+	input := query.ToCompleteFederatedLoginInput()
+	input.IP = c.ClientIP()
+	input.UserAgent = c.Request.UserAgent()
+
+	response, err := h.service.CompleteFederatedLogin(input)
+	if err != nil {
+		h.recordOutcome(c, audit.Event{Action: audit.ActionLoginFederated}, err)
+		h.abortWithLoginError(c, err)
+		return
+	}
+
+	event := audit.Event{ActorID: response.UserID, TenantID: response.TenantID, Action: audit.ActionLoginFederated}
+	if response.MFARequired {
+		event.Outcome = audit.OutcomeChallenged
+	}
+	h.recordOutcome(c, event, nil)
+
+	c.JSON(http.StatusAccepted, swagAuthenticateUserResponse{Data: *response})
+}
+
+// postEnrollMFA starts the MFA enrolment of the authenticated user.
+//
+//	@Summary		Start the MFA enrolment
+//	@Description	Generates a TOTP secret for the authenticated user, returned along with the otpauth URI to be added to an authenticator app. MFA is only enabled after the confirmation.
+//	@Tags			auth
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Authorization token"
+//	@Success		200				{object}	swagEnrollMFAResponse	"Pending enrolment"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		409				{object}	server.APIErrorResponse	"MFA already enabled"
+//	@Failure		500				{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/auth/mfa/enroll [post]
+func (h *handler) postEnrollMFA(c *gin.Context) {
+	userID, ok := h.authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.service.EnrollMFA(userID)
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, swagEnrollMFAResponse{Data: *response})
+}
+
+// postConfirmMFA enables MFA for the authenticated user.
+//
+//	@Summary		Confirm the MFA enrolment
+//	@Description	Enables MFA once the first code generated by the authenticator app is provided. The returned recovery codes are only shown once.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Authorization token"
+//	@Param			payload			body		PostConfirmMFAPayload	true	"TOTP code"
+//	@Success		200				{object}	swagConfirmMFAResponse	"MFA enabled"
+//	@Failure		400				{object}	server.APIErrorResponse	"Invalid payload or code"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		409				{object}	server.APIErrorResponse	"MFA already enabled or not enrolled"
+//	@Failure		500				{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/auth/mfa/confirm [post]
+func (h *handler) postConfirmMFA(c *gin.Context) {
+	userID, ok := h.authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var payload PostConfirmMFAPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, server.APIErrorResponse{
+			Error: ErrInvalidPayload.WithErr(err).Error(),
+			Code:  ErrInvalidPayload.Code,
+		})
+		return
+	}
+
+	response, err := h.service.ConfirmMFA(userID, payload.Code)
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, swagConfirmMFAResponse{Data: *response})
+}
+
+// getVerify confirms the ownership of the email address of a user.
+//
+//	@Summary		Verify the email address of a user
+//	@Description	Validates the token sent by email on registration and marks the account as verified.
+//	@Tags			auth
+//	@Produce		json
+//	@Param			token	query		string					true	"Verification token"
+//	@Success		200		{object}	swagVerifyEmailResponse	"Email successfully verified"
+//	@Failure		400		{object}	server.APIErrorResponse	"Invalid or expired token"
+//	@Failure		500		{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/auth/verify [get]
+func (h *handler) getVerify(c *gin.Context) {
+	var query VerifyEmailQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, server.APIErrorResponse{
+			Error: ErrInvalidVerificationToken.WithErr(err).Error(),
+			Code:  ErrInvalidVerificationToken.Code,
+		})
+		return
+	}
+
+	response, err := h.service.VerifyEmail(query.Token)
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, swagVerifyEmailResponse{Data: *response})
+}
+
+// postForgotPassword requests a password reset.
+//
+//	@Summary		Request a password reset
+//	@Description	Sends a single-use password reset token to the informed email. The response is the same whether or not the email is registered.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body	PostForgotPasswordPayload	true	"Account email"
+//	@Success		202		"Request accepted"
+//	@Failure		400		{object}	server.APIErrorResponse	"Bad request"
+//	@Router			/v1/auth/password/forgot [post]
+func (h *handler) postForgotPassword(c *gin.Context) {
+	var payload PostForgotPasswordPayload
+
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, server.APIErrorResponse{
+			Error: ErrInvalidPayload.WithErr(err).Error(),
+			Code:  ErrInvalidPayload.Code,
+		})
+		return
+	}
+
+	h.service.ForgotPassword(payload.Email)
+	c.Status(http.StatusAccepted)
+}
+
+// postResetPassword chooses a new password using a reset token.
+//
+//	@Summary		Reset the password of a user
+//	@Description	Consumes the password reset token and stores the new password. Every session opened before the reset is revoked.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body	PostResetPasswordPayload	true	"Reset token and new password"
+//	@Success		204		"Password successfully reset"
+//	@Failure		400		{object}	server.APIErrorResponse	"Invalid payload, token or password policy violation"
+//	@Failure		500		{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/auth/password/reset [post]
+func (h *handler) postResetPassword(c *gin.Context) {
+	var payload PostResetPasswordPayload
+
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, server.APIErrorResponse{
+			Error: ErrInvalidPayload.WithErr(err).Error(),
+			Code:  ErrInvalidPayload.Code,
+		})
+		return
+	}
+
+	err := h.service.ResetPassword(payload.ToResetPasswordInput())
+	h.recordOutcome(c, audit.Event{Action: audit.ActionPasswordReset}, err)
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// getProfile returns the account of the authenticated user.
+//
+//	@Summary		Get the authenticated user
+//	@Description	Returns the account of the authenticated user.
+//	@Tags			users
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Authorization token"
+//	@Success		200				{object}	swagUserResponse		"User account"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		404				{object}	server.APIErrorResponse	"User not found"
+//	@Router			/v1/users/me [get]
+func (h *handler) getProfile(c *gin.Context) {
+	userID, ok := h.authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.service.GetProfile(userID)
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, swagUserResponse{Data: *response})
+}
+
+// patchProfile updates the account of the authenticated user.
+//
+//	@Summary		Update the authenticated user
+//	@Description	Updates the account of the authenticated user. Changing the email address marks the account as unverified and sends a verification link to the new address.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Authorization token"
+//	@Param			payload			body		PatchProfilePayload		true	"Account data"
+//	@Success		200				{object}	swagUserResponse		"Updated user account"
+//	@Failure		400				{object}	server.APIErrorResponse	"Invalid payload"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		409				{object}	server.APIErrorResponse	"Email already in use"
+//	@Failure		500				{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/users/me [patch]
+func (h *handler) patchProfile(c *gin.Context) {
+	userID, ok := h.authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var payload PatchProfilePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, server.APIErrorResponse{
+			Error: ErrInvalidPayload.WithErr(err).Error(),
+			Code:  ErrInvalidPayload.Code,
+		})
+		return
+	}
+
+	response, err := h.service.UpdateProfile(payload.ToUpdateProfileInput(userID))
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, swagUserResponse{Data: *response})
+}
+
+// postChangePassword changes the password of the authenticated user.
+//
+//	@Summary		Change the password of the authenticated user
+//	@Description	Replaces the password once the current one is confirmed. Every access token issued before the change is revoked, so a new one is returned.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Authorization token"
+//	@Param			payload			body		PostChangePasswordPayload	true	"Current and new passwords"
+//	@Success		200				{object}	swagAuthenticateUserResponse	"Password changed, new token generated"
+//	@Failure		400				{object}	server.APIErrorResponse		"Invalid payload or password policy violation"
+//	@Failure		401				{object}	server.APIErrorResponse		"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse		"Incorrect current password"
+//	@Failure		500				{object}	server.APIErrorResponse		"Internal server error"
+//	@Router			/v1/users/me/password [post]
+func (h *handler) postChangePassword(c *gin.Context) {
+	userID, ok := h.authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var payload PostChangePasswordPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, server.APIErrorResponse{
+			Error: ErrInvalidPayload.WithErr(err).Error(),
+			Code:  ErrInvalidPayload.Code,
+		})
+		return
+	}
+
+	input := payload.ToChangePasswordInput(userID)
+	input.IP = c.ClientIP()
+	input.UserAgent = c.Request.UserAgent()
+
+	claims, _ := token.ClaimsFromContext(c)
+	response, err := h.service.ChangePassword(input)
+	h.recordOutcome(c, audit.Event{ActorID: userID, TenantID: claims.UintKey("TenantID"), Action: audit.ActionPasswordChange}, err)
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, swagAuthenticateUserResponse{Data: *response})
+}
+
+// getSessions lists the active sessions of the authenticated user.
+//
+//	@Summary		List the sessions of the authenticated user
+//	@Description	Lists where the authenticated user is logged in, most recently seen first. The session of the token in use is marked as current.
+//	@Tags			users
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Authorization token"
+//	@Success		200				{object}	swagListSessionsResponse	"Active sessions"
+//	@Failure		401				{object}	server.APIErrorResponse		"Unauthorized"
+//	@Failure		404				{object}	server.APIErrorResponse		"User not found"
+//	@Failure		500				{object}	server.APIErrorResponse		"Internal server error"
+//	@Router			/v1/users/me/sessions [get]
+func (h *handler) getSessions(c *gin.Context) {
+	userID, ok := h.authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	// Note: the claims were already checked by authenticatedUserID.
+	claims, _ := token.ClaimsFromContext(c)
+
+	response, err := h.service.ListSessions(userID, claims.Id)
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, swagListSessionsResponse{Data: response})
+}
+
+// deleteSession terminates a session of the authenticated user.
+//
+//	@Summary		Terminate a session of the authenticated user
+//	@Description	Terminates a session of the authenticated user, revoking the access token bound to it. Terminating the current session logs the user out.
+//	@Tags			users
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@Param			id				path	int		true	"Session ID"
+//	@Success		204				"Session successfully terminated"
+//	@Failure		400				{object}	server.APIErrorResponse	"Invalid session ID"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		404				{object}	server.APIErrorResponse	"Session not found"
+//	@Failure		500				{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/users/me/sessions/{id} [delete]
+func (h *handler) deleteSession(c *gin.Context) {
+	sessionID, err := strconv.ParseUint(c.Param("id"), 10, 0)
+	if err != nil || sessionID == 0 {
+		c.JSON(http.StatusBadRequest, server.APIErrorResponse{
+			Error: ErrInvalidSessionID.WithErr(err).Error(),
+			Code:  ErrInvalidSessionID.Code,
+		})
+		return
+	}
+
+	userID, ok := h.authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.RevokeSession(userID, uint(sessionID)); err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// deleteAccount deletes the account of the authenticated user.
+//
+//	@Summary		Delete the authenticated user
+//	@Description	Deletes the account of the authenticated user, revoking every access token previously issued.
+//	@Tags			users
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@Success		204				"Account successfully deleted"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		404				{object}	server.APIErrorResponse	"User not found"
+//	@Failure		500				{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/users/me [delete]
+func (h *handler) deleteAccount(c *gin.Context) {
+	userID, ok := h.authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteAccount(userID); err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// postUnlockUser clears the lockout of a user account.
+//
+//	@Summary		Unlock a user account
+//	@Description	Clears the failed login counter and the temporary lockout of a user account. Restricted to administrators.
+//	@Tags			admin
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@Param			id				path	int		true	"User ID"
+//	@Success		204				"Account successfully unlocked"
+//	@Failure		400				{object}	server.APIErrorResponse	"Invalid user ID"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse	"Forbidden"
+//	@Failure		404				{object}	server.APIErrorResponse	"User not found"
+//	@Failure		500				{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/admin/users/{id}/unlock [post]
+func (h *handler) postUnlockUser(c *gin.Context) {
+	admin, userID, ok := h.administeredUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.UnlockUser(admin, userID); err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// getUsers lists the user accounts.
+//
+//	@Summary		List user accounts
+//	@Description	Lists the user accounts, oldest first, optionally filtered by email and creation date. Deleted accounts are only listed when requested. Restricted to administrators.
+//	@Tags			admin
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Authorization token"
+//	@Param			page			query		int						false	"Page number, starting at 1"
+//	@Param			page_size		query		int						false	"Users per page (max. 100, defaults to 20)"
+//	@Param			email			query		string					false	"Part of the email, case insensitive"
+//	@Param			created_from	query		string					false	"Minimum creation date (RFC 3339)"
+//	@Param			created_to		query		string					false	"Maximum creation date (RFC 3339)"
+//	@Param			include_deleted	query		bool					false	"Whether deleted accounts are listed"
+//	@Success		200				{object}	swagListUsersResponse	"User accounts"
+//	@Failure		400				{object}	server.APIErrorResponse	"Bad request"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse	"Forbidden"
+//	@Failure		500				{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/admin/users [get]
+func (h *handler) getUsers(c *gin.Context) {
+	var query ListUsersQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, server.APIErrorResponse{
+			Error: ErrInvalidPayload.WithErr(err).Error(),
+			Code:  ErrInvalidPayload.Code,
+		})
+		return
+	}
+
+	admin, ok := h.administrator(c)
+	if !ok {
+		return
+	}
+
+	// Note: administrators of an organization only list its members.
+	filter := query.ToListUsersFilter()
+	if admin.TenantID != 0 {
+		filter.OrganizationID = admin.TenantID
+	}
+
+	response, err := h.service.ListUsers(filter)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, server.APIErrorResponse{
-			Error: err.Error(),
-			Code:  err.(errors.ErrorImp).CodeStr(),
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, swagListUsersResponse{Data: *response})
+}
+
+// postDisableUser disables a user account.
+//
+//	@Summary		Disable a user account
+//	@Description	Blocks the logins of a user account and revokes its access tokens until it is enabled again. Restricted to administrators.
+//	@Tags			admin
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@Param			id				path	int		true	"User ID"
+//	@Success		204				"Account successfully disabled"
+//	@Failure		400				{object}	server.APIErrorResponse	"Invalid user ID"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse	"Forbidden"
+//	@Failure		404				{object}	server.APIErrorResponse	"User not found"
+//	@Failure		409				{object}	server.APIErrorResponse	"Own account"
+//	@Failure		500				{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/admin/users/{id}/disable [post]
+func (h *handler) postDisableUser(c *gin.Context) {
+	admin, userID, ok := h.administeredUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DisableUser(admin, userID); err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// postEnableUser enables a disabled user account.
+//
+//	@Summary		Enable a user account
+//	@Description	Allows the logins of a disabled user account again. Restricted to administrators.
+//	@Tags			admin
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@Param			id				path	int		true	"User ID"
+//	@Success		204				"Account successfully enabled"
+//	@Failure		400				{object}	server.APIErrorResponse	"Invalid user ID"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse	"Forbidden"
+//	@Failure		404				{object}	server.APIErrorResponse	"User not found"
+//	@Failure		409				{object}	server.APIErrorResponse	"Own account"
+//	@Failure		500				{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/admin/users/{id}/enable [post]
+func (h *handler) postEnableUser(c *gin.Context) {
+	admin, userID, ok := h.administeredUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.EnableUser(admin, userID); err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// deleteUser deletes a user account.
+//
+//	@Summary		Delete a user account
+//	@Description	Soft deletes a user account, revoking its access tokens. The account can be restored later. Restricted to administrators.
+//	@Tags			admin
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@Param			id				path	int		true	"User ID"
+//	@Success		204				"Account successfully deleted"
+//	@Failure		400				{object}	server.APIErrorResponse	"Invalid user ID"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse	"Forbidden"
+//	@Failure		404				{object}	server.APIErrorResponse	"User not found"
+//	@Failure		409				{object}	server.APIErrorResponse	"Own account"
+//	@Failure		500				{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/admin/users/{id} [delete]
+func (h *handler) deleteUser(c *gin.Context) {
+	admin, userID, ok := h.administeredUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteUser(admin, userID); err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// postRestoreUser restores a deleted user account.
+//
+//	@Summary		Restore a user account
+//	@Description	Reverts the deletion of a user account. Restricted to administrators.
+//	@Tags			admin
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@Param			id				path	int		true	"User ID"
+//	@Success		204				"Account successfully restored"
+//	@Failure		400				{object}	server.APIErrorResponse	"Invalid user ID"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse	"Forbidden"
+//	@Failure		404				{object}	server.APIErrorResponse	"Deleted user not found"
+//	@Failure		409				{object}	server.APIErrorResponse	"Own account"
+//	@Failure		500				{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/admin/users/{id}/restore [post]
+func (h *handler) postRestoreUser(c *gin.Context) {
+	admin, userID, ok := h.administeredUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.RestoreUser(admin, userID); err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// postForcePasswordReset requires a user to reset their password.
+//
+//	@Summary		Force a password reset
+//	@Description	Blocks the logins of a user account until its password is reset, revoking its access tokens and sending a password reset email. Restricted to administrators.
+//	@Tags			admin
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@Param			id				path	int		true	"User ID"
+//	@Success		204				"Password reset successfully required"
+//	@Failure		400				{object}	server.APIErrorResponse	"Invalid user ID"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse	"Forbidden"
+//	@Failure		404				{object}	server.APIErrorResponse	"User not found"
+//	@Failure		409				{object}	server.APIErrorResponse	"Own account"
+//	@Failure		500				{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/admin/users/{id}/password-reset [post]
+func (h *handler) postForcePasswordReset(c *gin.Context) {
+	admin, userID, ok := h.administeredUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.ForcePasswordReset(admin, userID); err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// putUserRole changes the role of a user account.
+//
+//	@Summary		Change the role of a user account
+//	@Description	Changes the role of a user account, revoking the access tokens carrying the previous role. Restricted to administrators.
+//	@Tags			admin
+//	@Accept			json
+//	@Param			Authorization	header	string			true	"Authorization token"
+//	@Param			id				path	int				true	"User ID"
+//	@Param			payload			body	PutRolePayload	true	"New role"
+//	@Success		204				"Role successfully changed"
+//	@Failure		400				{object}	server.APIErrorResponse	"Bad request"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse	"Forbidden"
+//	@Failure		404				{object}	server.APIErrorResponse	"User not found"
+//	@Failure		409				{object}	server.APIErrorResponse	"Own account"
+//	@Failure		500				{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/admin/users/{id}/role [put]
+func (h *handler) putUserRole(c *gin.Context) {
+	admin, userID, ok := h.administeredUserID(c)
+	if !ok {
+		return
+	}
+
+	var payload PutRolePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, server.APIErrorResponse{
+			Error: ErrInvalidPayload.WithErr(err).Error(),
+			Code:  ErrInvalidPayload.Code,
 		})
 		return
 	}
 
-	c.JSON(http.StatusAccepted, swagAuthenticateUserResponse{
-		Data: AuthenticateUserResponse{jwt},
+	if err := h.service.ChangeRole(admin, userID, payload.Role); err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// getUserSessions lists the active sessions of a user account.
+//
+//	@Summary		List the sessions of a user account
+//	@Description	Lists where a user is logged in, most recently seen first. Restricted to administrators.
+//	@Tags			admin
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Authorization token"
+//	@Param			id				path		int							true	"User ID"
+//	@Success		200				{object}	swagListSessionsResponse	"Active sessions"
+//	@Failure		400				{object}	server.APIErrorResponse		"Invalid user ID"
+//	@Failure		401				{object}	server.APIErrorResponse		"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse		"Forbidden"
+//	@Failure		404				{object}	server.APIErrorResponse		"User not found"
+//	@Failure		500				{object}	server.APIErrorResponse		"Internal server error"
+//	@Router			/v1/admin/users/{id}/sessions [get]
+func (h *handler) getUserSessions(c *gin.Context) {
+	admin, userID, ok := h.administeredUserID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.service.ListUserSessions(admin, userID)
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, swagListSessionsResponse{Data: response})
+}
+
+// administeredUserID extracts the authenticated administrator and the ID of the user from the request path.
+func (h *handler) administeredUserID(c *gin.Context) (Administrator, uint, bool) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 0)
+	if err != nil || userID == 0 {
+		c.JSON(http.StatusBadRequest, server.APIErrorResponse{
+			Error: ErrInvalidUserID.WithErr(err).Error(),
+			Code:  ErrInvalidUserID.Code,
+		})
+		return Administrator{}, 0, false
+	}
+
+	admin, ok := h.administrator(c)
+	if !ok {
+		return Administrator{}, 0, false
+	}
+	return admin, uint(userID), true
+}
+
+// administrator extracts the authenticated administrator, along with its organization, from the claims.
+func (h *handler) administrator(c *gin.Context) (Administrator, bool) {
+	adminID, ok := h.authenticatedUserID(c)
+	if !ok {
+		return Administrator{}, false
+	}
+
+	claims, _ := token.ClaimsFromContext(c)
+	return Administrator{ID: adminID, TenantID: claims.UintKey("TenantID")}, true
+}
+
+// authenticatedUserID extracts the user ID from the claims set by the token middleware.
+func (h *handler) authenticatedUserID(c *gin.Context) (uint, bool) {
+	claims, err := token.ClaimsFromContext(c)
+	if err != nil || claims.UintKey("ID") == 0 {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, server.APIErrorResponse{
+			Error: ErrUnauthorizedUser.WithErr(err).Error(),
+			Code:  ErrUnauthorizedUser.Code,
+		})
+		return 0, false
+	}
+	return claims.UintKey("ID"), true
+}
+
+// recordOutcome records the event in the audit log. Failed events carry the error code as reason,
+// successful ones default to the success outcome.
+func (h *handler) recordOutcome(c *gin.Context, event audit.Event, err error) {
+	if err != nil {
+		event.Outcome = audit.OutcomeFailure
+		event.Reason = err.(errors.ErrorImp).CodeStr()
+	} else if event.Outcome == str.EmptyString {
+		event.Outcome = audit.OutcomeSuccess
+	}
+	h.recorder.Record(c, event)
+}
+
+// abortWithLoginError maps login errors to their HTTP status codes.
+func (h *handler) abortWithLoginError(c *gin.Context, err error) {
+	code := err.(errors.ErrorImp).CodeStr()
+
+	status := http.StatusUnauthorized
+	switch code {
+	case ErrCodeAccountLocked:
+		status = http.StatusLocked
+	case ErrCodeTooManyAttempts:
+		status = http.StatusTooManyRequests
+	case ErrCodeEmailNotVerified, ErrCodeAccountDisabled, ErrCodePasswordResetRequired:
+		status = http.StatusForbidden
+	case ErrCodeFederationDisabled:
+		status = http.StatusNotFound
+	case ErrCodeFederatedEmail:
+		status = http.StatusConflict
+	case ErrCodeOperationFailed:
+		status = http.StatusInternalServerError
+	}
+
+	c.JSON(status, server.APIErrorResponse{
+		Error: err.Error(),
+		Code:  code,
 	})
 }
+
+// abortWithError maps account management errors to their HTTP status codes.
+func (h *handler) abortWithError(c *gin.Context, err error) {
+	code := err.(errors.ErrorImp).CodeStr()
+
+	status := http.StatusInternalServerError
+	switch code {
+	case ErrCodeInvalidVerification, ErrCodeInvalidResetToken, ErrCodeInvalidMFACode:
+		status = http.StatusBadRequest
+	case ErrCodeUserNotFound, ErrCodeSessionNotFound, ErrCodeFederationDisabled:
+		status = http.StatusNotFound
+	case ErrCodeIncorrectPassword:
+		status = http.StatusForbidden
+	case ErrCodeEmailAlreadyInUse, ErrCodeMFAAlreadyEnabled, ErrCodeMFANotEnrolled, ErrCodeSelfAdministration:
+		status = http.StatusConflict
+	}
+	if isPasswordViolation(code) {
+		status = http.StatusBadRequest
+	}
+
+	c.AbortWithStatusJSON(status, server.APIErrorResponse{
+		Error: err.Error(),
+		Code:  code,
+	})
+}
+
+// isPasswordViolation reports whether the error code belongs to a password policy violation.
+func isPasswordViolation(code string) bool {
+	for _, violation := range passwordViolations {
+		if violation.Code == code {
+			return true
+		}
+	}
+	return false
+}
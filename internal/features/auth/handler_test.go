@@ -5,9 +5,15 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"luizalabs-technical-test/internal/features/auth"
 	"luizalabs-technical-test/internal/features/auth/mock"
+	"luizalabs-technical-test/internal/pkg/audit"
+	auditMock "luizalabs-technical-test/internal/pkg/audit/mock"
+	"luizalabs-technical-test/internal/pkg/entity"
+	middlewareMock "luizalabs-technical-test/internal/pkg/middleware/mock"
+	"luizalabs-technical-test/pkg/token"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -21,16 +27,31 @@ type TestSuite struct {
 	ctrl    *gomock.Controller
 	router  *gin.Engine
 	mockSvc *mock.MockServiceImp
+	mockRec *auditMock.MockRecorder
 	handler auth.HandlerImp
 }
 
+// passThrough simulates a middleware that authenticates an administrator.
+func passThrough(c *gin.Context) {
+	c.Set(token.ClaimsHeaderName, &token.CustomClaims{CustomKeys: map[string]any{"ID": float64(1)}})
+	c.Next()
+}
+
 // SetupSuite initializes the test suite
 func (s *TestSuite) SetupSuite() {
 	s.ctrl = gomock.NewController(s.T())
 	gin.SetMode(gin.TestMode)
 	s.router = gin.Default()
 	s.mockSvc = mock.NewMockServiceImp(s.ctrl)
-	s.handler = auth.NewHandler(s.mockSvc)
+	s.mockRec = auditMock.NewMockRecorder(s.ctrl)
+
+	tokenMiddleware := middlewareMock.NewMockTokenMiddleware(s.ctrl)
+	tokenMiddleware.EXPECT().Middleware().Return(passThrough).AnyTimes()
+
+	adminMiddleware := middlewareMock.NewMockAdminMiddleware(s.ctrl)
+	adminMiddleware.EXPECT().Middleware().Return(gin.HandlerFunc(func(c *gin.Context) { c.Next() })).AnyTimes()
+
+	s.handler = auth.NewHandler(s.mockSvc, tokenMiddleware, adminMiddleware, s.mockRec)
 
 	s.handler.Register(s.router.Group("/v1"))
 }
@@ -53,7 +74,10 @@ func (s *TestSuite) TestPostLogin_BadRequestError() {
 func (s *TestSuite) TestPostLogin_UnauthorizedError() {
 	s.mockSvc.EXPECT().
 		AuthenticateUser(gomock.Any()).
-		Return("", &auth.ErrInvalidCredentials).
+		Return(nil, &auth.ErrInvalidCredentials).
+		Times(1)
+	s.mockRec.EXPECT().
+		Record(gomock.Any(), audit.Event{Actor: "test@example.com", Action: audit.ActionLogin, Outcome: audit.OutcomeFailure, Reason: auth.ErrCodeInvalidCredentials}).
 		Times(1)
 
 	w := httptest.NewRecorder()
@@ -70,7 +94,30 @@ func (s *TestSuite) TestPostLogin_UnauthorizedError() {
 func (s *TestSuite) TestPostLogin_Success() {
 	s.mockSvc.EXPECT().
 		AuthenticateUser(gomock.Any()).
-		Return("mocked_jwt_token", nil).
+		Return(&auth.AuthenticateUserResponse{JWTToken: "mocked_jwt_token", UserID: 7}, nil).
+		Times(1)
+	s.mockRec.EXPECT().
+		Record(gomock.Any(), audit.Event{ActorID: 7, Actor: "test@example.com", Action: audit.ActionLogin, Outcome: audit.OutcomeSuccess}).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(
+		http.MethodPost, "/v1/auth/login",
+		bytes.NewBufferString(`{"email":"test@example.com","password":"XXXXXXXXXXX"}`),
+	)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusAccepted, w.Code)
+}
+
+// TestPostLogin_MFARequired tests the login of a user with MFA enabled
+func (s *TestSuite) TestPostLogin_MFARequired() {
+	s.mockSvc.EXPECT().
+		AuthenticateUser(gomock.Any()).
+		Return(&auth.AuthenticateUserResponse{MFARequired: true, MFAToken: "mocked_mfa_token", UserID: 7}, nil).
+		Times(1)
+	s.mockRec.EXPECT().
+		Record(gomock.Any(), audit.Event{ActorID: 7, Actor: "test@example.com", Action: audit.ActionLogin, Outcome: audit.OutcomeChallenged}).
 		Times(1)
 
 	w := httptest.NewRecorder()
@@ -81,6 +128,316 @@ func (s *TestSuite) TestPostLogin_Success() {
 
 	s.router.ServeHTTP(w, req)
 	assert.Equal(s.T(), http.StatusAccepted, w.Code)
+	assert.JSONEq(s.T(), `{"data":{"mfa_required":true,"mfa_token":"mocked_mfa_token"}}`, w.Body.String())
+}
+
+// TestPostLoginMFA_BadRequestError tests the second step of the login without code
+func (s *TestSuite) TestPostLoginMFA_BadRequestError() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/auth/login/mfa", bytes.NewBufferString(`{"mfa_token":"token"}`))
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+// TestPostLoginMFA_InvalidCodeError tests the second step of the login with a wrong code
+func (s *TestSuite) TestPostLoginMFA_InvalidCodeError() {
+	s.mockSvc.EXPECT().
+		AuthenticateMFA(auth.AuthenticateMFAInput{MFAToken: "token", Code: "000000", IP: "192.0.2.1"}).
+		Return(nil, &auth.ErrInvalidMFACode).
+		Times(1)
+	s.mockRec.EXPECT().
+		Record(gomock.Any(), audit.Event{Action: audit.ActionLoginMFA, Outcome: audit.OutcomeFailure, Reason: auth.ErrCodeInvalidMFACode}).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(
+		http.MethodPost, "/v1/auth/login/mfa",
+		bytes.NewBufferString(`{"mfa_token":"token","code":"000000"}`),
+	)
+	req.RemoteAddr = "192.0.2.1:1234"
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusUnauthorized, w.Code)
+}
+
+// TestPostLoginMFA_Success tests the successful second step of the login
+func (s *TestSuite) TestPostLoginMFA_Success() {
+	s.mockSvc.EXPECT().
+		AuthenticateMFA(gomock.Any()).
+		Return(&auth.AuthenticateUserResponse{JWTToken: "mocked_jwt_token", UserID: 7}, nil).
+		Times(1)
+	s.mockRec.EXPECT().
+		Record(gomock.Any(), audit.Event{ActorID: 7, Action: audit.ActionLoginMFA, Outcome: audit.OutcomeSuccess}).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(
+		http.MethodPost, "/v1/auth/login/mfa",
+		bytes.NewBufferString(`{"mfa_token":"token","code":"123456"}`),
+	)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusAccepted, w.Code)
+	assert.JSONEq(s.T(), `{"data":{"token":"mocked_jwt_token"}}`, w.Body.String())
+}
+
+// TestGetFederatedAuthorize tests the redirect to the identity provider, and the error when none is configured
+func (s *TestSuite) TestGetFederatedAuthorize() {
+	s.mockSvc.EXPECT().
+		BeginFederatedLogin().
+		Return("https://accounts.example.com/authorize?state=state", nil).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/auth/oidc/authorize", nil)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusFound, w.Code)
+	assert.Equal(s.T(), "https://accounts.example.com/authorize?state=state", w.Header().Get("Location"))
+
+	s.mockSvc.EXPECT().
+		BeginFederatedLogin().
+		Return("", &auth.ErrFederationDisabled).
+		Times(1)
+
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+}
+
+// TestGetFederatedCallback tests the status codes of the callback of the identity provider
+func (s *TestSuite) TestGetFederatedCallback() {
+	tests := []struct {
+		name     string
+		query    string
+		mock     func()
+		expected int
+	}{
+		{"missing state", "?code=code", func() {}, http.StatusBadRequest},
+		{"login denied at the provider", "?state=state&error=access_denied", func() {
+			s.mockRec.EXPECT().
+				Record(gomock.Any(), audit.Event{Action: audit.ActionLoginFederated, Outcome: audit.OutcomeFailure, Reason: auth.ErrCodeInvalidFederatedLogin})
+		}, http.StatusUnauthorized},
+		{"unverified email", "?state=state&code=code", func() {
+			s.mockSvc.EXPECT().
+				CompleteFederatedLogin(auth.CompleteFederatedLoginInput{Code: "code", State: "state", IP: "192.0.2.1"}).
+				Return(nil, &auth.ErrFederatedEmailUnverified)
+			s.mockRec.EXPECT().
+				Record(gomock.Any(), audit.Event{Action: audit.ActionLoginFederated, Outcome: audit.OutcomeFailure, Reason: auth.ErrCodeFederatedEmail})
+		}, http.StatusConflict},
+		{"success", "?state=state&code=code", func() {
+			s.mockSvc.EXPECT().
+				CompleteFederatedLogin(gomock.Any()).
+				Return(&auth.AuthenticateUserResponse{JWTToken: "mocked_jwt_token", UserID: 7, TenantID: 3}, nil)
+			s.mockRec.EXPECT().
+				Record(gomock.Any(), audit.Event{ActorID: 7, TenantID: 3, Action: audit.ActionLoginFederated, Outcome: audit.OutcomeSuccess})
+		}, http.StatusAccepted},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mock()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/v1/auth/oidc/callback"+tt.query, nil)
+			req.RemoteAddr = "192.0.2.1:1234"
+
+			s.router.ServeHTTP(w, req)
+			assert.Equal(s.T(), tt.expected, w.Code)
+		})
+	}
+}
+
+// TestPostEnrollMFA_AlreadyEnabledError tests the enrolment of a user with MFA enabled
+func (s *TestSuite) TestPostEnrollMFA_AlreadyEnabledError() {
+	s.mockSvc.EXPECT().
+		EnrollMFA(uint(1)).
+		Return(nil, &auth.ErrMFAAlreadyEnabled).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/auth/mfa/enroll", nil)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusConflict, w.Code)
+}
+
+// TestPostEnrollMFA_Success tests the successful start of the enrolment
+func (s *TestSuite) TestPostEnrollMFA_Success() {
+	s.mockSvc.EXPECT().
+		EnrollMFA(uint(1)).
+		Return(&auth.EnrollMFAResponse{Secret: "JBSWY3DPEHPK3PXP", URI: "otpauth://totp/issuer:user"}, nil).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/auth/mfa/enroll", nil)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.JSONEq(s.T(), `{"data":{"secret":"JBSWY3DPEHPK3PXP","otpauth_uri":"otpauth://totp/issuer:user"}}`, w.Body.String())
+}
+
+// TestPostConfirmMFA_InvalidCodeError tests the confirmation with a wrong code
+func (s *TestSuite) TestPostConfirmMFA_InvalidCodeError() {
+	s.mockSvc.EXPECT().
+		ConfirmMFA(uint(1), "000000").
+		Return(nil, &auth.ErrInvalidMFACode).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/auth/mfa/confirm", bytes.NewBufferString(`{"code":"000000"}`))
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+// TestPostConfirmMFA_Success tests the successful confirmation of the enrolment
+func (s *TestSuite) TestPostConfirmMFA_Success() {
+	s.mockSvc.EXPECT().
+		ConfirmMFA(uint(1), "123456").
+		Return(&auth.ConfirmMFAResponse{RecoveryCodes: []string{"abcd-efgh-ijkl-mnop"}}, nil).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/auth/mfa/confirm", bytes.NewBufferString(`{"code":"123456"}`))
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.JSONEq(s.T(), `{"data":{"recovery_codes":["abcd-efgh-ijkl-mnop"]}}`, w.Body.String())
+}
+
+// TestGetProfile_Success tests the profile of the authenticated user
+func (s *TestSuite) TestGetProfile_Success() {
+	s.mockSvc.EXPECT().
+		GetProfile(uint(1)).
+		Return(&auth.UserResponse{ID: 1, Email: "test@example.com", Role: "user"}, nil).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/users/me", nil)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"email":"test@example.com"`)
+}
+
+// TestPatchProfile_BadRequestError tests the update with an invalid email address
+func (s *TestSuite) TestPatchProfile_BadRequestError() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPatch, "/v1/users/me", bytes.NewBufferString(`{"email":"invalid"}`))
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+// TestPatchProfile_EmailAlreadyInUseError tests the change to the email address of another account
+func (s *TestSuite) TestPatchProfile_EmailAlreadyInUseError() {
+	s.mockSvc.EXPECT().
+		UpdateProfile(auth.UpdateProfileInput{UserID: 1, Email: "taken@example.com"}).
+		Return(nil, &auth.ErrEmailAlreadyInUse).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPatch, "/v1/users/me", bytes.NewBufferString(`{"email":"taken@example.com"}`))
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusConflict, w.Code)
+}
+
+// TestPostChangePassword_IncorrectPasswordError tests the change with a wrong current password
+func (s *TestSuite) TestPostChangePassword_IncorrectPasswordError() {
+	s.mockSvc.EXPECT().
+		ChangePassword(gomock.Any()).
+		Return(nil, &auth.ErrIncorrectPassword).
+		Times(1)
+	s.mockRec.EXPECT().
+		Record(gomock.Any(), audit.Event{ActorID: 1, Action: audit.ActionPasswordChange, Outcome: audit.OutcomeFailure, Reason: auth.ErrCodeIncorrectPassword}).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(
+		http.MethodPost, "/v1/users/me/password",
+		bytes.NewBufferString(`{"current_password":"wrong","new_password":"XXXXXXXXXXX"}`),
+	)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusForbidden, w.Code)
+}
+
+// TestPostChangePassword_Success tests the successful change of the password
+func (s *TestSuite) TestPostChangePassword_Success() {
+	s.mockSvc.EXPECT().
+		ChangePassword(auth.ChangePasswordInput{UserID: 1, CurrentPassword: "current", NewPassword: "XXXXXXXXXXX"}).
+		Return(&auth.AuthenticateUserResponse{JWTToken: "mocked_jwt_token"}, nil).
+		Times(1)
+	s.mockRec.EXPECT().
+		Record(gomock.Any(), audit.Event{ActorID: 1, Action: audit.ActionPasswordChange, Outcome: audit.OutcomeSuccess}).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(
+		http.MethodPost, "/v1/users/me/password",
+		bytes.NewBufferString(`{"current_password":"current","new_password":"XXXXXXXXXXX"}`),
+	)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.JSONEq(s.T(), `{"data":{"token":"mocked_jwt_token"}}`, w.Body.String())
+}
+
+// TestDeleteAccount_Success tests the deletion of the account of the authenticated user
+func (s *TestSuite) TestDeleteAccount_Success() {
+	s.mockSvc.EXPECT().
+		DeleteAccount(uint(1)).
+		Return(nil).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/users/me", nil)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusNoContent, w.Code)
+}
+
+// TestPostLogin_AccountLockedError tests the login of a locked account
+func (s *TestSuite) TestPostLogin_AccountLockedError() {
+	s.mockSvc.EXPECT().
+		AuthenticateUser(gomock.Any()).
+		Return(nil, &auth.ErrAccountLocked).
+		Times(1)
+	s.mockRec.EXPECT().
+		Record(gomock.Any(), audit.Event{Actor: "test@example.com", Action: audit.ActionLogin, Outcome: audit.OutcomeFailure, Reason: auth.ErrCodeAccountLocked}).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(
+		http.MethodPost, "/v1/auth/login",
+		bytes.NewBufferString(`{"email":"test@example.com","password":"XXXXXXXXXXX"}`),
+	)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusLocked, w.Code)
+}
+
+// TestPostLogin_TooManyAttemptsError tests the login from a throttled client IP
+func (s *TestSuite) TestPostLogin_TooManyAttemptsError() {
+	s.mockSvc.EXPECT().
+		AuthenticateUser(gomock.Any()).
+		Return(nil, &auth.ErrTooManyAttempts).
+		Times(1)
+	s.mockRec.EXPECT().
+		Record(gomock.Any(), audit.Event{Actor: "test@example.com", Action: audit.ActionLogin, Outcome: audit.OutcomeFailure, Reason: auth.ErrCodeTooManyAttempts}).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(
+		http.MethodPost, "/v1/auth/login",
+		bytes.NewBufferString(`{"email":"test@example.com","password":"XXXXXXXXXXX"}`),
+	)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusTooManyRequests, w.Code)
 }
 
 // TestPostRegister_BadRequestError tests the error in parse payload params
@@ -98,6 +455,9 @@ func (s *TestSuite) TestPostRegister_InternalServerError() {
 		RegisterUser(gomock.Any()).
 		Return(&auth.ErrUserAlreadyExists).
 		Times(1)
+	s.mockRec.EXPECT().
+		Record(gomock.Any(), audit.Event{Actor: "test@example.com", Action: audit.ActionRegister, Outcome: audit.OutcomeFailure, Reason: auth.ErrCodeUserAlreadyExists}).
+		Times(1)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(
@@ -109,12 +469,36 @@ func (s *TestSuite) TestPostRegister_InternalServerError() {
 	assert.Equal(s.T(), http.StatusInternalServerError, w.Code)
 }
 
+// TestPostRegister_PasswordPolicyError tests the rejection of a password violating the policy
+func (s *TestSuite) TestPostRegister_PasswordPolicyError() {
+	s.mockSvc.EXPECT().
+		RegisterUser(gomock.Any()).
+		Return(&auth.ErrPasswordBreached).
+		Times(1)
+	s.mockRec.EXPECT().
+		Record(gomock.Any(), audit.Event{Actor: "test@example.com", Action: audit.ActionRegister, Outcome: audit.OutcomeFailure, Reason: auth.ErrCodePasswordBreached}).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(
+		http.MethodPost, "/v1/auth/register",
+		bytes.NewBufferString(`{"email":"test@example.com","password":"XXXXXXXXXXX"}`),
+	)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+	assert.Contains(s.T(), w.Body.String(), auth.ErrCodePasswordBreached)
+}
+
 // TestPostRegister_Success tests the successful register of a user
 func (s *TestSuite) TestPostRegister_Success() {
 	s.mockSvc.EXPECT().
 		RegisterUser(gomock.Any()).
 		Return(nil).
 		Times(1)
+	s.mockRec.EXPECT().
+		Record(gomock.Any(), audit.Event{Actor: "test@example.com", Action: audit.ActionRegister, Outcome: audit.OutcomeSuccess}).
+		Times(1)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(
@@ -126,6 +510,327 @@ func (s *TestSuite) TestPostRegister_Success() {
 	assert.Equal(s.T(), http.StatusCreated, w.Code)
 }
 
+// TestGetVerify_BadRequestError tests the verification without token
+func (s *TestSuite) TestGetVerify_BadRequestError() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/auth/verify", nil)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+// TestGetVerify_InvalidTokenError tests the verification with an expired token
+func (s *TestSuite) TestGetVerify_InvalidTokenError() {
+	s.mockSvc.EXPECT().
+		VerifyEmail("expired").
+		Return(nil, &auth.ErrInvalidVerificationToken).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/auth/verify?token=expired", nil)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+// TestGetVerify_Success tests the successful verification of an email
+func (s *TestSuite) TestGetVerify_Success() {
+	s.mockSvc.EXPECT().
+		VerifyEmail("valid").
+		Return(&auth.VerifyEmailResponse{Email: "test@example.com"}, nil).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/auth/verify?token=valid", nil)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.JSONEq(s.T(), `{"data":{"email":"test@example.com"}}`, w.Body.String())
+}
+
+// TestPostForgotPassword_BadRequestError tests the error in parse payload params
+func (s *TestSuite) TestPostForgotPassword_BadRequestError() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/auth/password/forgot", bytes.NewBufferString(`{"email":"invalid"}`))
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+// TestPostForgotPassword_Accepted tests that the request is always accepted
+func (s *TestSuite) TestPostForgotPassword_Accepted() {
+	s.mockSvc.EXPECT().
+		ForgotPassword("test@example.com").
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(
+		http.MethodPost, "/v1/auth/password/forgot",
+		bytes.NewBufferString(`{"email":"test@example.com"}`),
+	)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusAccepted, w.Code)
+}
+
+// TestPostResetPassword_InvalidTokenError tests the reset with an invalid token
+func (s *TestSuite) TestPostResetPassword_InvalidTokenError() {
+	s.mockSvc.EXPECT().
+		ResetPassword(auth.ResetPasswordInput{Token: "expired", Password: "XXXXXXXXXXX"}).
+		Return(&auth.ErrInvalidResetToken).
+		Times(1)
+	s.mockRec.EXPECT().
+		Record(gomock.Any(), audit.Event{Action: audit.ActionPasswordReset, Outcome: audit.OutcomeFailure, Reason: auth.ErrCodeInvalidResetToken}).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(
+		http.MethodPost, "/v1/auth/password/reset",
+		bytes.NewBufferString(`{"token":"expired","password":"XXXXXXXXXXX"}`),
+	)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+// TestPostResetPassword_Success tests the successful reset of a password
+func (s *TestSuite) TestPostResetPassword_Success() {
+	s.mockSvc.EXPECT().
+		ResetPassword(gomock.Any()).
+		Return(nil).
+		Times(1)
+	s.mockRec.EXPECT().
+		Record(gomock.Any(), audit.Event{Action: audit.ActionPasswordReset, Outcome: audit.OutcomeSuccess}).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(
+		http.MethodPost, "/v1/auth/password/reset",
+		bytes.NewBufferString(`{"token":"valid","password":"XXXXXXXXXXX"}`),
+	)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusNoContent, w.Code)
+}
+
+// TestPostUnlockUser_BadRequestError tests the unlock of an invalid user ID
+func (s *TestSuite) TestPostUnlockUser_BadRequestError() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/admin/users/abc/unlock", nil)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+// TestPostUnlockUser_NotFoundError tests the unlock of an unknown user
+func (s *TestSuite) TestPostUnlockUser_NotFoundError() {
+	s.mockSvc.EXPECT().
+		UnlockUser(auth.Administrator{ID: 1}, uint(42)).
+		Return(&auth.ErrUserNotFound).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/admin/users/42/unlock", nil)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+}
+
+// TestPostUnlockUser_Success tests the successful unlock of a user
+func (s *TestSuite) TestPostUnlockUser_Success() {
+	s.mockSvc.EXPECT().
+		UnlockUser(auth.Administrator{ID: 1}, uint(42)).
+		Return(nil).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/admin/users/42/unlock", nil)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusNoContent, w.Code)
+}
+
+// TestGetUsers_BadRequestError tests the listing with invalid query parameters
+func (s *TestSuite) TestGetUsers_BadRequestError() {
+	for _, query := range []string{"page=-1", "page_size=101", "created_from=yesterday"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/v1/admin/users?"+query, nil)
+
+		s.router.ServeHTTP(w, req)
+		assert.Equal(s.T(), http.StatusBadRequest, w.Code, query)
+	}
+}
+
+// TestGetUsers_Success tests the listing of users with the default pagination
+func (s *TestSuite) TestGetUsers_Success() {
+	s.mockSvc.EXPECT().
+		ListUsers(auth.ListUsersFilter{
+			Page:        1,
+			PageSize:    20,
+			Email:       "example.com",
+			CreatedFrom: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		}).
+		Return(&auth.ListUsersResponse{Users: []auth.AdminUserResponse{}, Page: 1, PageSize: 20}, nil).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/admin/users?email=example.com&created_from=2024-01-01T00:00:00Z", nil)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.JSONEq(s.T(), `{"data":{"users":[],"page":1,"page_size":20,"total":0}}`, w.Body.String())
+}
+
+// TestGetUsers_Organization tests that administrators of an organization only list its members
+func (s *TestSuite) TestGetUsers_Organization() {
+	tokenMiddleware := middlewareMock.NewMockTokenMiddleware(s.ctrl)
+	tokenMiddleware.EXPECT().Middleware().Return(gin.HandlerFunc(func(c *gin.Context) {
+		c.Set(token.ClaimsHeaderName, &token.CustomClaims{CustomKeys: map[string]any{"ID": float64(1), "TenantID": float64(3)}})
+		c.Next()
+	})).AnyTimes()
+
+	adminMiddleware := middlewareMock.NewMockAdminMiddleware(s.ctrl)
+	adminMiddleware.EXPECT().Middleware().Return(gin.HandlerFunc(func(c *gin.Context) { c.Next() })).AnyTimes()
+
+	router := gin.New()
+	auth.NewHandler(s.mockSvc, tokenMiddleware, adminMiddleware, s.mockRec).Register(router.Group("/v1"))
+
+	s.mockSvc.EXPECT().
+		ListUsers(auth.ListUsersFilter{Page: 1, PageSize: 20, OrganizationID: 3}).
+		Return(&auth.ListUsersResponse{Users: []auth.AdminUserResponse{}, Page: 1, PageSize: 20}, nil).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/admin/users?organization_id=5", nil)
+
+	router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+}
+
+// TestAdministerUser tests the status codes of the administrative actions on a user
+func (s *TestSuite) TestAdministerUser() {
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		mock     func()
+		expected int
+	}{
+		{"disable", http.MethodPost, "/v1/admin/users/42/disable", "", func() {
+			s.mockSvc.EXPECT().DisableUser(auth.Administrator{ID: 1}, uint(42)).Return(nil)
+		}, http.StatusNoContent},
+		{"disable own account", http.MethodPost, "/v1/admin/users/1/disable", "", func() {
+			s.mockSvc.EXPECT().DisableUser(auth.Administrator{ID: 1}, uint(1)).Return(&auth.ErrSelfAdministration)
+		}, http.StatusConflict},
+		{"enable", http.MethodPost, "/v1/admin/users/42/enable", "", func() {
+			s.mockSvc.EXPECT().EnableUser(auth.Administrator{ID: 1}, uint(42)).Return(nil)
+		}, http.StatusNoContent},
+		{"delete", http.MethodDelete, "/v1/admin/users/42", "", func() {
+			s.mockSvc.EXPECT().DeleteUser(auth.Administrator{ID: 1}, uint(42)).Return(nil)
+		}, http.StatusNoContent},
+		{"restore unknown user", http.MethodPost, "/v1/admin/users/42/restore", "", func() {
+			s.mockSvc.EXPECT().RestoreUser(auth.Administrator{ID: 1}, uint(42)).Return(&auth.ErrUserNotFound)
+		}, http.StatusNotFound},
+		{"force password reset", http.MethodPost, "/v1/admin/users/42/password-reset", "", func() {
+			s.mockSvc.EXPECT().ForcePasswordReset(auth.Administrator{ID: 1}, uint(42)).Return(nil)
+		}, http.StatusNoContent},
+		{"change role", http.MethodPut, "/v1/admin/users/42/role", `{"role":"admin"}`, func() {
+			s.mockSvc.EXPECT().ChangeRole(auth.Administrator{ID: 1}, uint(42), entity.RoleAdmin).Return(nil)
+		}, http.StatusNoContent},
+		{"change to unknown role", http.MethodPut, "/v1/admin/users/42/role", `{"role":"root"}`, func() {}, http.StatusBadRequest},
+		{"invalid user ID", http.MethodPost, "/v1/admin/users/abc/disable", "", func() {}, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mock()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+
+			s.router.ServeHTTP(w, req)
+			assert.Equal(s.T(), tt.expected, w.Code)
+		})
+	}
+}
+
+// TestPostLogin_AccountDisabled tests the login of a disabled account
+func (s *TestSuite) TestPostLogin_AccountDisabled() {
+	s.mockSvc.EXPECT().
+		AuthenticateUser(gomock.Any()).
+		Return(nil, &auth.ErrAccountDisabled).
+		Times(1)
+	s.mockRec.EXPECT().
+		Record(gomock.Any(), audit.Event{Actor: "user@example.com", Action: audit.ActionLogin, Outcome: audit.OutcomeFailure, Reason: auth.ErrCodeAccountDisabled}).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/auth/login", bytes.NewBufferString(`{"email":"user@example.com","password":"password"}`))
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusForbidden, w.Code)
+}
+
+// TestGetSessions_Success tests the listing of the sessions of the authenticated user
+func (s *TestSuite) TestGetSessions_Success() {
+	s.mockSvc.EXPECT().
+		ListSessions(uint(1), "").
+		Return([]auth.SessionResponse{{ID: 3, IP: "203.0.113.10"}}, nil).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/users/me/sessions", nil)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"ip":"203.0.113.10"`)
+}
+
+// TestDeleteSession tests the status codes of the termination of a session
+func (s *TestSuite) TestDeleteSession() {
+	tests := []struct {
+		name     string
+		path     string
+		mock     func()
+		expected int
+	}{
+		{"invalid session ID", "/v1/users/me/sessions/abc", func() {}, http.StatusBadRequest},
+		{"unknown session", "/v1/users/me/sessions/3", func() {
+			s.mockSvc.EXPECT().RevokeSession(uint(1), uint(3)).Return(&auth.ErrSessionNotFound)
+		}, http.StatusNotFound},
+		{"terminated", "/v1/users/me/sessions/3", func() {
+			s.mockSvc.EXPECT().RevokeSession(uint(1), uint(3)).Return(nil)
+		}, http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mock()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodDelete, tt.path, nil)
+
+			s.router.ServeHTTP(w, req)
+			assert.Equal(s.T(), tt.expected, w.Code)
+		})
+	}
+}
+
+// TestGetUserSessions_Success tests the listing of the sessions of a user by an administrator
+func (s *TestSuite) TestGetUserSessions_Success() {
+	s.mockSvc.EXPECT().
+		ListUserSessions(auth.Administrator{ID: 1}, uint(42)).
+		Return([]auth.SessionResponse{}, nil).
+		Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/admin/users/42/sessions", nil)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+}
+
 // TestMain is the entry point for the test suite
 func TestMain(t *testing.T) {
 	suite.Run(t, new(TestSuite))
@@ -1,14 +1,48 @@
 package auth
 
-import "luizalabs-technical-test/pkg/errors"
+import (
+	"luizalabs-technical-test/pkg/errors"
+	"luizalabs-technical-test/pkg/password"
+)
 
 // Constants representing error codes related to user authentication and registration operations.
 const (
-	ErrCodeTimeoutExcid        = "ERR_AUTH_TIMEOUT"          // login or registration operation timeout.
-	ErrCodeInvalidCredentials  = "ERR_INVALID_CREDENTIALS"   // invalid username or password.
-	ErrCodeUserAlreadyExists   = "ERR_USER_ALREADY_EXISTS"   // user already exists during registration.
-	ErrCodeUserNotFound        = "ERR_USER_NOT_FOUND"        // user not found during login.
-	ErrCodeJWTGenerationFailed = "ERR_JWT_GENERATION_FAILED" // failure during JWT generation.
+	ErrCodeTimeoutExcid          = "ERR_AUTH_TIMEOUT"               // login or registration operation timeout.
+	ErrCodeInvalidCredentials    = "ERR_INVALID_CREDENTIALS"        // invalid username or password.
+	ErrCodeUserAlreadyExists     = "ERR_USER_ALREADY_EXISTS"        // user already exists during registration.
+	ErrCodeUserNotFound          = "ERR_USER_NOT_FOUND"             // user not found during login.
+	ErrCodeJWTGenerationFailed   = "ERR_JWT_GENERATION_FAILED"      // failure during JWT generation.
+	ErrCodeAccountLocked         = "ERR_ACCOUNT_LOCKED"             // account temporarily locked after repeated failed logins.
+	ErrCodeTooManyAttempts       = "ERR_TOO_MANY_LOGIN_ATTEMPTS"    // client IP throttled after repeated failed logins.
+	ErrCodeInvalidUserID         = "ERR_INVALID_USER_ID"            // malformed user ID in the request path.
+	ErrCodeOperationFailed       = "ERR_AUTH_OPERATION_FAILED"      // failure updating the user account.
+	ErrCodeUnauthorizedUser      = "ERR_AUTH_UNAUTHORIZED_USER"     // no authenticated user in the request.
+	ErrCodeEmailNotVerified      = "ERR_EMAIL_NOT_VERIFIED"         // login blocked until the email is verified.
+	ErrCodeInvalidVerification   = "ERR_INVALID_VERIFICATION_TOKEN" // malformed, expired or tampered verification token.
+	ErrCodeInvalidPayload        = "ERR_AUTH_INVALID_PAYLOAD"       // malformed request payload.
+	ErrCodeInvalidResetToken     = "ERR_INVALID_RESET_TOKEN"        // unknown, used or expired password reset token.
+	ErrCodeEmailAlreadyInUse     = "ERR_EMAIL_ALREADY_IN_USE"       // email change to an address of another account.
+	ErrCodeIncorrectPassword     = "ERR_INCORRECT_PASSWORD"         // wrong current password on password change.
+	ErrCodeMFAAlreadyEnabled     = "ERR_MFA_ALREADY_ENABLED"        // enrolment requested while MFA is enabled.
+	ErrCodeMFANotEnrolled        = "ERR_MFA_NOT_ENROLLED"           // confirmation requested without a pending enrolment.
+	ErrCodeInvalidMFACode        = "ERR_INVALID_MFA_CODE"           // wrong TOTP or recovery code.
+	ErrCodeInvalidMFAToken       = "ERR_INVALID_MFA_TOKEN"          // malformed, expired or tampered MFA challenge token.
+	ErrCodeAccountDisabled       = "ERR_ACCOUNT_DISABLED"           // login blocked because the account was disabled by an administrator.
+	ErrCodePasswordResetRequired = "ERR_PASSWORD_RESET_REQUIRED"    // login blocked until the password is reset.
+	ErrCodeSelfAdministration    = "ERR_SELF_ADMINISTRATION"        // administrative action targeting the administrator's own account.
+	ErrCodeSessionNotFound       = "ERR_SESSION_NOT_FOUND"          // session not found, already revoked or of another user.
+	ErrCodeInvalidSessionID      = "ERR_INVALID_SESSION_ID"         // malformed session ID in the request path.
+	ErrCodeFederationDisabled    = "ERR_FEDERATION_DISABLED"        // login at an identity provider requested while none is configured.
+	ErrCodeInvalidFederatedLogin = "ERR_INVALID_FEDERATED_LOGIN"    // unknown or expired state, or code rejected by the identity provider.
+	ErrCodeFederatedEmail        = "ERR_FEDERATED_EMAIL_UNVERIFIED" // identity provider email matching an account without being verified.
+	ErrCodePasswordTooShort      = "ERR_PASSWORD_TOO_SHORT"         // password shorter than the policy minimum.
+	ErrCodePasswordTooLong       = "ERR_PASSWORD_TOO_LONG"          // password longer than the policy maximum.
+	ErrCodePasswordMissingUpper  = "ERR_PASSWORD_MISSING_UPPERCASE" // password without uppercase letters.
+	ErrCodePasswordMissingLower  = "ERR_PASSWORD_MISSING_LOWERCASE" // password without lowercase letters.
+	ErrCodePasswordMissingDigit  = "ERR_PASSWORD_MISSING_DIGIT"     // password without digits.
+	ErrCodePasswordMissingSymbol = "ERR_PASSWORD_MISSING_SYMBOL"    // password without symbols.
+	ErrCodePasswordContainsEmail = "ERR_PASSWORD_CONTAINS_EMAIL"    // password containing the email address.
+	ErrCodePasswordBreached      = "ERR_PASSWORD_BREACHED"          // password found in the breached passwords list.
 )
 
 var (
@@ -41,4 +75,202 @@ var (
 		Code:    ErrCodeJWTGenerationFailed,
 		Message: "Não foi possível autenticar o usuário e criar a sessão. Por favor, tente novamente mais tarde.",
 	}
+
+	// ErrAccountLocked is triggered when a login is attempted on an account temporarily locked by repeated failures.
+	ErrAccountLocked = errors.Error{
+		Code:    ErrCodeAccountLocked,
+		Message: "Conta temporariamente bloqueada devido a múltiplas tentativas de login sem sucesso. Tente novamente mais tarde.",
+	}
+
+	// ErrTooManyAttempts is triggered when the client IP exceeds the allowed amount of failed login attempts.
+	ErrTooManyAttempts = errors.Error{
+		Code:    ErrCodeTooManyAttempts,
+		Message: "Muitas tentativas de login sem sucesso a partir deste endereço. Aguarde alguns instantes e tente novamente.",
+	}
+
+	// ErrInvalidUserID is triggered when the user ID informed in the request path is not valid.
+	ErrInvalidUserID = errors.Error{
+		Code:    ErrCodeInvalidUserID,
+		Message: "O identificador de usuário informado é inválido.",
+	}
+
+	// ErrOperationFailed is triggered when the system fails to update the user account.
+	ErrOperationFailed = errors.Error{
+		Code:    ErrCodeOperationFailed,
+		Message: "Não foi possível concluir a operação na conta do usuário. Por favor, tente novamente mais tarde.",
+	}
+
+	// ErrUnauthorizedUser is triggered when no authenticated user is found in the request context.
+	ErrUnauthorizedUser = errors.Error{
+		Code:    ErrCodeUnauthorizedUser,
+		Message: "Usuário não autenticado.",
+	}
+
+	// ErrEmailNotVerified is triggered when an unverified user attempts to log in and verification is required.
+	ErrEmailNotVerified = errors.Error{
+		Code:    ErrCodeEmailNotVerified,
+		Message: "O endereço de e-mail ainda não foi confirmado. Verifique sua caixa de entrada e tente novamente.",
+	}
+
+	// ErrInvalidVerificationToken is triggered when the verification token is malformed, expired or tampered.
+	ErrInvalidVerificationToken = errors.Error{
+		Code:    ErrCodeInvalidVerification,
+		Message: "O link de confirmação é inválido ou expirou.",
+	}
+
+	// ErrInvalidPayload is triggered when the request payload cannot be parsed.
+	ErrInvalidPayload = errors.Error{
+		Code:    ErrCodeInvalidPayload,
+		Message: "Os dados informados são inválidos. Verifique o payload e tente novamente.",
+	}
+
+	// ErrInvalidResetToken is triggered when the password reset token is unknown, already used or expired.
+	ErrInvalidResetToken = errors.Error{
+		Code:    ErrCodeInvalidResetToken,
+		Message: "O token de redefinição de senha é inválido ou expirou. Solicite uma nova redefinição.",
+	}
+
+	// ErrEmailAlreadyInUse is triggered when the new email address belongs to another account.
+	ErrEmailAlreadyInUse = errors.Error{
+		Code:    ErrCodeEmailAlreadyInUse,
+		Message: "O e-mail informado já está em uso por outra conta.",
+	}
+
+	// ErrIncorrectPassword is triggered when the current password doesn't match on a password change.
+	ErrIncorrectPassword = errors.Error{
+		Code:    ErrCodeIncorrectPassword,
+		Message: "A senha atual informada está incorreta.",
+	}
+
+	// ErrMFAAlreadyEnabled is triggered when an enrolment is requested while MFA is already enabled.
+	ErrMFAAlreadyEnabled = errors.Error{
+		Code:    ErrCodeMFAAlreadyEnabled,
+		Message: "A autenticação em dois fatores já está habilitada para esta conta.",
+	}
+
+	// ErrMFANotEnrolled is triggered when the enrolment is confirmed before being started.
+	ErrMFANotEnrolled = errors.Error{
+		Code:    ErrCodeMFANotEnrolled,
+		Message: "Nenhuma configuração de autenticação em dois fatores pendente. Inicie a configuração novamente.",
+	}
+
+	// ErrAccountDisabled is triggered when a disabled account tries to log in.
+	ErrAccountDisabled = errors.Error{
+		Code:    ErrCodeAccountDisabled,
+		Message: "Conta desativada. Entre em contato com o suporte.",
+	}
+
+	// ErrPasswordResetRequired is triggered when an account required to reset its password tries to log in.
+	ErrPasswordResetRequired = errors.Error{
+		Code:    ErrCodePasswordResetRequired,
+		Message: "É necessário redefinir sua senha. Verifique seu e-mail para concluir a redefinição.",
+	}
+
+	// ErrSelfAdministration is triggered when an administrator targets their own account with an administrative action.
+	ErrSelfAdministration = errors.Error{
+		Code:    ErrCodeSelfAdministration,
+		Message: "Esta operação não pode ser realizada na sua própria conta.",
+	}
+
+	// ErrSessionNotFound is triggered when the session to terminate doesn't exist or is no longer active.
+	ErrSessionNotFound = errors.Error{
+		Code:    ErrCodeSessionNotFound,
+		Message: "Sessão não encontrada ou já encerrada.",
+	}
+
+	// ErrInvalidSessionID is triggered when the session ID in the request path is not a valid number.
+	ErrInvalidSessionID = errors.Error{
+		Code:    ErrCodeInvalidSessionID,
+		Message: "O identificador da sessão informado é inválido.",
+	}
+
+	// ErrFederationDisabled is triggered when a login at the identity provider is requested while none is configured.
+	ErrFederationDisabled = errors.Error{
+		Code:    ErrCodeFederationDisabled,
+		Message: "O login por provedor de identidade externo não está habilitado.",
+	}
+
+	// ErrInvalidFederatedLogin is triggered when the login at the identity provider can't be completed.
+	ErrInvalidFederatedLogin = errors.Error{
+		Code:    ErrCodeInvalidFederatedLogin,
+		Message: "Não foi possível concluir o login pelo provedor de identidade. Por favor, tente novamente.",
+	}
+
+	// ErrFederatedEmailUnverified is triggered when the identity provider states an email of an existing account without verifying it.
+	ErrFederatedEmailUnverified = errors.Error{
+		Code:    ErrCodeFederatedEmail,
+		Message: "O e-mail informado pelo provedor de identidade pertence a uma conta existente, mas não foi verificado pelo provedor.",
+	}
+
+	// ErrInvalidMFACode is triggered when the TOTP or recovery code doesn't match.
+	ErrInvalidMFACode = errors.Error{
+		Code:    ErrCodeInvalidMFACode,
+		Message: "O código de verificação informado é inválido.",
+	}
+
+	// ErrInvalidMFAToken is triggered when the MFA challenge token is malformed or expired.
+	ErrInvalidMFAToken = errors.Error{
+		Code:    ErrCodeInvalidMFAToken,
+		Message: "A sessão de login expirou ou é inválida. Por favor, faça login novamente.",
+	}
+
+	// ErrPasswordTooShort is triggered when the password is shorter than the policy minimum.
+	ErrPasswordTooShort = errors.Error{
+		Code:    ErrCodePasswordTooShort,
+		Message: "A senha não atinge o tamanho mínimo exigido.",
+	}
+
+	// ErrPasswordTooLong is triggered when the password is longer than the policy maximum.
+	ErrPasswordTooLong = errors.Error{
+		Code:    ErrCodePasswordTooLong,
+		Message: "A senha excede o tamanho máximo permitido.",
+	}
+
+	// ErrPasswordMissingUpper is triggered when the password has no uppercase letters.
+	ErrPasswordMissingUpper = errors.Error{
+		Code:    ErrCodePasswordMissingUpper,
+		Message: "A senha deve conter ao menos uma letra maiúscula.",
+	}
+
+	// ErrPasswordMissingLower is triggered when the password has no lowercase letters.
+	ErrPasswordMissingLower = errors.Error{
+		Code:    ErrCodePasswordMissingLower,
+		Message: "A senha deve conter ao menos uma letra minúscula.",
+	}
+
+	// ErrPasswordMissingDigit is triggered when the password has no digits.
+	ErrPasswordMissingDigit = errors.Error{
+		Code:    ErrCodePasswordMissingDigit,
+		Message: "A senha deve conter ao menos um número.",
+	}
+
+	// ErrPasswordMissingSymbol is triggered when the password has no symbols.
+	ErrPasswordMissingSymbol = errors.Error{
+		Code:    ErrCodePasswordMissingSymbol,
+		Message: "A senha deve conter ao menos um caractere especial.",
+	}
+
+	// ErrPasswordContainsEmail is triggered when the password contains the email address of the user.
+	ErrPasswordContainsEmail = errors.Error{
+		Code:    ErrCodePasswordContainsEmail,
+		Message: "A senha não pode conter o endereço de e-mail.",
+	}
+
+	// ErrPasswordBreached is triggered when the password is found in the list of known-compromised passwords.
+	ErrPasswordBreached = errors.Error{
+		Code:    ErrCodePasswordBreached,
+		Message: "A senha informada foi exposta em vazamentos de dados conhecidos. Escolha outra senha.",
+	}
 )
+
+// passwordViolations maps each password policy violation to its error.
+var passwordViolations = map[error]*errors.Error{
+	password.ErrTooShort:      &ErrPasswordTooShort,
+	password.ErrTooLong:       &ErrPasswordTooLong,
+	password.ErrMissingUpper:  &ErrPasswordMissingUpper,
+	password.ErrMissingLower:  &ErrPasswordMissingLower,
+	password.ErrMissingDigit:  &ErrPasswordMissingDigit,
+	password.ErrMissingSymbol: &ErrPasswordMissingSymbol,
+	password.ErrContainsEmail: &ErrPasswordContainsEmail,
+	password.ErrBreached:      &ErrPasswordBreached,
+}
@@ -8,6 +8,7 @@ import (
 	auth "luizalabs-technical-test/internal/features/auth"
 	entity "luizalabs-technical-test/internal/pkg/entity"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,6 +36,149 @@ func (m *MockRepositoryImp) EXPECT() *MockRepositoryImpMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockRepositoryImp) ChangePassword(id uint, hashedPassword string, changedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", id, hashedPassword, changedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockRepositoryImpMockRecorder) ChangePassword(id, hashedPassword, changedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockRepositoryImp)(nil).ChangePassword), id, hashedPassword, changedAt)
+}
+
+// CreateFederatedIdentity mocks base method.
+func (m *MockRepositoryImp) CreateFederatedIdentity(identity *entity.FederatedIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFederatedIdentity", identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFederatedIdentity indicates an expected call of CreateFederatedIdentity.
+func (mr *MockRepositoryImpMockRecorder) CreateFederatedIdentity(identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFederatedIdentity", reflect.TypeOf((*MockRepositoryImp)(nil).CreateFederatedIdentity), identity)
+}
+
+// CreatePasswordResetToken mocks base method.
+func (m *MockRepositoryImp) CreatePasswordResetToken(resetToken *entity.PasswordResetToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordResetToken", resetToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePasswordResetToken indicates an expected call of CreatePasswordResetToken.
+func (mr *MockRepositoryImpMockRecorder) CreatePasswordResetToken(resetToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordResetToken", reflect.TypeOf((*MockRepositoryImp)(nil).CreatePasswordResetToken), resetToken)
+}
+
+// CreateSession mocks base method.
+func (m *MockRepositoryImp) CreateSession(session *entity.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockRepositoryImpMockRecorder) CreateSession(session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockRepositoryImp)(nil).CreateSession), session)
+}
+
+// DeleteUser mocks base method.
+func (m *MockRepositoryImp) DeleteUser(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockRepositoryImpMockRecorder) DeleteUser(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockRepositoryImp)(nil).DeleteUser), id)
+}
+
+// EnableMFA mocks base method.
+func (m *MockRepositoryImp) EnableMFA(id uint, enabledAt time.Time, recoveryCodes []entity.MFARecoveryCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableMFA", id, enabledAt, recoveryCodes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableMFA indicates an expected call of EnableMFA.
+func (mr *MockRepositoryImpMockRecorder) EnableMFA(id, enabledAt, recoveryCodes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableMFA", reflect.TypeOf((*MockRepositoryImp)(nil).EnableMFA), id, enabledAt, recoveryCodes)
+}
+
+// ForcePasswordReset mocks base method.
+func (m *MockRepositoryImp) ForcePasswordReset(id uint, requiredAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForcePasswordReset", id, requiredAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForcePasswordReset indicates an expected call of ForcePasswordReset.
+func (mr *MockRepositoryImpMockRecorder) ForcePasswordReset(id, requiredAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForcePasswordReset", reflect.TypeOf((*MockRepositoryImp)(nil).ForcePasswordReset), id, requiredAt)
+}
+
+// GetFederatedIdentity mocks base method.
+func (m *MockRepositoryImp) GetFederatedIdentity(issuer, subject string) (*entity.FederatedIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFederatedIdentity", issuer, subject)
+	ret0, _ := ret[0].(*entity.FederatedIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFederatedIdentity indicates an expected call of GetFederatedIdentity.
+func (mr *MockRepositoryImpMockRecorder) GetFederatedIdentity(issuer, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFederatedIdentity", reflect.TypeOf((*MockRepositoryImp)(nil).GetFederatedIdentity), issuer, subject)
+}
+
+// GetPasswordResetToken mocks base method.
+func (m *MockRepositoryImp) GetPasswordResetToken(hashedToken string) (*entity.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordResetToken", hashedToken)
+	ret0, _ := ret[0].(*entity.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordResetToken indicates an expected call of GetPasswordResetToken.
+func (mr *MockRepositoryImpMockRecorder) GetPasswordResetToken(hashedToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordResetToken", reflect.TypeOf((*MockRepositoryImp)(nil).GetPasswordResetToken), hashedToken)
+}
+
+// GetSession mocks base method.
+func (m *MockRepositoryImp) GetSession(tokenID string) (*entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", tokenID)
+	ret0, _ := ret[0].(*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockRepositoryImpMockRecorder) GetSession(tokenID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockRepositoryImp)(nil).GetSession), tokenID)
+}
+
 // GetUser mocks base method.
 func (m *MockRepositoryImp) GetUser(filter auth.GetUserFilter) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +194,94 @@ func (mr *MockRepositoryImpMockRecorder) GetUser(filter interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockRepositoryImp)(nil).GetUser), filter)
 }
 
+// IncrementLoginAttempts mocks base method.
+func (m *MockRepositoryImp) IncrementLoginAttempts(id uint) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementLoginAttempts", id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementLoginAttempts indicates an expected call of IncrementLoginAttempts.
+func (mr *MockRepositoryImpMockRecorder) IncrementLoginAttempts(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementLoginAttempts", reflect.TypeOf((*MockRepositoryImp)(nil).IncrementLoginAttempts), id)
+}
+
+// ListSessions mocks base method.
+func (m *MockRepositoryImp) ListSessions(userID uint, now time.Time) ([]entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", userID, now)
+	ret0, _ := ret[0].([]entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockRepositoryImpMockRecorder) ListSessions(userID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockRepositoryImp)(nil).ListSessions), userID, now)
+}
+
+// ListUsers mocks base method.
+func (m *MockRepositoryImp) ListUsers(filter auth.ListUsersFilter) ([]entity.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", filter)
+	ret0, _ := ret[0].([]entity.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockRepositoryImpMockRecorder) ListUsers(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockRepositoryImp)(nil).ListUsers), filter)
+}
+
+// LockAccount mocks base method.
+func (m *MockRepositoryImp) LockAccount(id uint, lockedUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockAccount", id, lockedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockAccount indicates an expected call of LockAccount.
+func (mr *MockRepositoryImpMockRecorder) LockAccount(id, lockedUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockAccount", reflect.TypeOf((*MockRepositoryImp)(nil).LockAccount), id, lockedUntil)
+}
+
+// MarkUserVerified mocks base method.
+func (m *MockRepositoryImp) MarkUserVerified(id uint, verifiedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUserVerified", id, verifiedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUserVerified indicates an expected call of MarkUserVerified.
+func (mr *MockRepositoryImpMockRecorder) MarkUserVerified(id, verifiedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUserVerified", reflect.TypeOf((*MockRepositoryImp)(nil).MarkUserVerified), id, verifiedAt)
+}
+
+// RegisterFederatedUser mocks base method.
+func (m *MockRepositoryImp) RegisterFederatedUser(user *entity.User, identity *entity.FederatedIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterFederatedUser", user, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterFederatedUser indicates an expected call of RegisterFederatedUser.
+func (mr *MockRepositoryImpMockRecorder) RegisterFederatedUser(user, identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterFederatedUser", reflect.TypeOf((*MockRepositoryImp)(nil).RegisterFederatedUser), user, identity)
+}
+
 // RegisterUser mocks base method.
 func (m *MockRepositoryImp) RegisterUser(user entity.User) error {
 	m.ctrl.T.Helper()
@@ -63,3 +295,171 @@ func (mr *MockRepositoryImpMockRecorder) RegisterUser(user interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockRepositoryImp)(nil).RegisterUser), user)
 }
+
+// ResetPassword mocks base method.
+func (m *MockRepositoryImp) ResetPassword(resetToken entity.PasswordResetToken, hashedPassword string, changedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", resetToken, hashedPassword, changedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockRepositoryImpMockRecorder) ResetPassword(resetToken, hashedPassword, changedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockRepositoryImp)(nil).ResetPassword), resetToken, hashedPassword, changedAt)
+}
+
+// RestoreUser mocks base method.
+func (m *MockRepositoryImp) RestoreUser(id, organizationID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", id, organizationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockRepositoryImpMockRecorder) RestoreUser(id, organizationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockRepositoryImp)(nil).RestoreUser), id, organizationID)
+}
+
+// RevokeSession mocks base method.
+func (m *MockRepositoryImp) RevokeSession(userID, id uint, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", userID, id, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockRepositoryImpMockRecorder) RevokeSession(userID, id, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockRepositoryImp)(nil).RevokeSession), userID, id, revokedAt)
+}
+
+// SetDisabled mocks base method.
+func (m *MockRepositoryImp) SetDisabled(id uint, disabledAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDisabled", id, disabledAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDisabled indicates an expected call of SetDisabled.
+func (mr *MockRepositoryImpMockRecorder) SetDisabled(id, disabledAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDisabled", reflect.TypeOf((*MockRepositoryImp)(nil).SetDisabled), id, disabledAt)
+}
+
+// TouchSession mocks base method.
+func (m *MockRepositoryImp) TouchSession(id uint, lastSeenAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchSession", id, lastSeenAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchSession indicates an expected call of TouchSession.
+func (mr *MockRepositoryImpMockRecorder) TouchSession(id, lastSeenAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchSession", reflect.TypeOf((*MockRepositoryImp)(nil).TouchSession), id, lastSeenAt)
+}
+
+// UpdateEmail mocks base method.
+func (m *MockRepositoryImp) UpdateEmail(id uint, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEmail", id, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEmail indicates an expected call of UpdateEmail.
+func (mr *MockRepositoryImpMockRecorder) UpdateEmail(id, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmail", reflect.TypeOf((*MockRepositoryImp)(nil).UpdateEmail), id, email)
+}
+
+// UpdateLoginAttempts mocks base method.
+func (m *MockRepositoryImp) UpdateLoginAttempts(id uint, attempts int, lockedUntil *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLoginAttempts", id, attempts, lockedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLoginAttempts indicates an expected call of UpdateLoginAttempts.
+func (mr *MockRepositoryImpMockRecorder) UpdateLoginAttempts(id, attempts, lockedUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoginAttempts", reflect.TypeOf((*MockRepositoryImp)(nil).UpdateLoginAttempts), id, attempts, lockedUntil)
+}
+
+// UpdateMFASecret mocks base method.
+func (m *MockRepositoryImp) UpdateMFASecret(id uint, secret string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMFASecret", id, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMFASecret indicates an expected call of UpdateMFASecret.
+func (mr *MockRepositoryImpMockRecorder) UpdateMFASecret(id, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMFASecret", reflect.TypeOf((*MockRepositoryImp)(nil).UpdateMFASecret), id, secret)
+}
+
+// UpdatePasswordHash mocks base method.
+func (m *MockRepositoryImp) UpdatePasswordHash(id uint, oldHash, newHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePasswordHash", id, oldHash, newHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePasswordHash indicates an expected call of UpdatePasswordHash.
+func (mr *MockRepositoryImpMockRecorder) UpdatePasswordHash(id, oldHash, newHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePasswordHash", reflect.TypeOf((*MockRepositoryImp)(nil).UpdatePasswordHash), id, oldHash, newHash)
+}
+
+// UpdateRole mocks base method.
+func (m *MockRepositoryImp) UpdateRole(id uint, role string, changedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", id, role, changedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockRepositoryImpMockRecorder) UpdateRole(id, role, changedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockRepositoryImp)(nil).UpdateRole), id, role, changedAt)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockRepositoryImp) UseRecoveryCode(userID uint, hashedCode string, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", userID, hashedCode, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockRepositoryImpMockRecorder) UseRecoveryCode(userID, hashedCode, usedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockRepositoryImp)(nil).UseRecoveryCode), userID, hashedCode, usedAt)
+}
+
+// UseTOTPCounter mocks base method.
+func (m *MockRepositoryImp) UseTOTPCounter(userID uint, counter int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPCounter", userID, counter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseTOTPCounter indicates an expected call of UseTOTPCounter.
+func (mr *MockRepositoryImpMockRecorder) UseTOTPCounter(userID, counter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPCounter", reflect.TypeOf((*MockRepositoryImp)(nil).UseTOTPCounter), userID, counter)
+}
@@ -7,6 +7,7 @@ package mock
 import (
 	auth "luizalabs-technical-test/internal/features/auth"
 	entity "luizalabs-technical-test/internal/pkg/entity"
+	token "luizalabs-technical-test/pkg/token"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,11 +36,26 @@ func (m *MockServiceImp) EXPECT() *MockServiceImpMockRecorder {
 	return m.recorder
 }
 
+// AuthenticateMFA mocks base method.
+func (m *MockServiceImp) AuthenticateMFA(input auth.AuthenticateMFAInput) (*auth.AuthenticateUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateMFA", input)
+	ret0, _ := ret[0].(*auth.AuthenticateUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateMFA indicates an expected call of AuthenticateMFA.
+func (mr *MockServiceImpMockRecorder) AuthenticateMFA(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateMFA", reflect.TypeOf((*MockServiceImp)(nil).AuthenticateMFA), input)
+}
+
 // AuthenticateUser mocks base method.
-func (m *MockServiceImp) AuthenticateUser(input auth.AuthenticateUserInput) (string, error) {
+func (m *MockServiceImp) AuthenticateUser(input auth.AuthenticateUserInput) (*auth.AuthenticateUserResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AuthenticateUser", input)
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].(*auth.AuthenticateUserResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -50,6 +66,251 @@ func (mr *MockServiceImpMockRecorder) AuthenticateUser(input interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateUser", reflect.TypeOf((*MockServiceImp)(nil).AuthenticateUser), input)
 }
 
+// BeginFederatedLogin mocks base method.
+func (m *MockServiceImp) BeginFederatedLogin() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginFederatedLogin")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginFederatedLogin indicates an expected call of BeginFederatedLogin.
+func (mr *MockServiceImpMockRecorder) BeginFederatedLogin() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginFederatedLogin", reflect.TypeOf((*MockServiceImp)(nil).BeginFederatedLogin))
+}
+
+// ChangePassword mocks base method.
+func (m *MockServiceImp) ChangePassword(input auth.ChangePasswordInput) (*auth.AuthenticateUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", input)
+	ret0, _ := ret[0].(*auth.AuthenticateUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockServiceImpMockRecorder) ChangePassword(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockServiceImp)(nil).ChangePassword), input)
+}
+
+// ChangeRole mocks base method.
+func (m *MockServiceImp) ChangeRole(admin auth.Administrator, userID uint, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeRole", admin, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeRole indicates an expected call of ChangeRole.
+func (mr *MockServiceImpMockRecorder) ChangeRole(admin, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeRole", reflect.TypeOf((*MockServiceImp)(nil).ChangeRole), admin, userID, role)
+}
+
+// CompleteFederatedLogin mocks base method.
+func (m *MockServiceImp) CompleteFederatedLogin(input auth.CompleteFederatedLoginInput) (*auth.AuthenticateUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteFederatedLogin", input)
+	ret0, _ := ret[0].(*auth.AuthenticateUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteFederatedLogin indicates an expected call of CompleteFederatedLogin.
+func (mr *MockServiceImpMockRecorder) CompleteFederatedLogin(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteFederatedLogin", reflect.TypeOf((*MockServiceImp)(nil).CompleteFederatedLogin), input)
+}
+
+// ConfirmMFA mocks base method.
+func (m *MockServiceImp) ConfirmMFA(userID uint, code string) (*auth.ConfirmMFAResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmMFA", userID, code)
+	ret0, _ := ret[0].(*auth.ConfirmMFAResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmMFA indicates an expected call of ConfirmMFA.
+func (mr *MockServiceImpMockRecorder) ConfirmMFA(userID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmMFA", reflect.TypeOf((*MockServiceImp)(nil).ConfirmMFA), userID, code)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockServiceImp) DeleteAccount(userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockServiceImpMockRecorder) DeleteAccount(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockServiceImp)(nil).DeleteAccount), userID)
+}
+
+// DeleteUser mocks base method.
+func (m *MockServiceImp) DeleteUser(admin auth.Administrator, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", admin, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockServiceImpMockRecorder) DeleteUser(admin, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockServiceImp)(nil).DeleteUser), admin, userID)
+}
+
+// DisableUser mocks base method.
+func (m *MockServiceImp) DisableUser(admin auth.Administrator, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableUser", admin, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableUser indicates an expected call of DisableUser.
+func (mr *MockServiceImpMockRecorder) DisableUser(admin, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableUser", reflect.TypeOf((*MockServiceImp)(nil).DisableUser), admin, userID)
+}
+
+// EnableUser mocks base method.
+func (m *MockServiceImp) EnableUser(admin auth.Administrator, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableUser", admin, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableUser indicates an expected call of EnableUser.
+func (mr *MockServiceImpMockRecorder) EnableUser(admin, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableUser", reflect.TypeOf((*MockServiceImp)(nil).EnableUser), admin, userID)
+}
+
+// EnrollMFA mocks base method.
+func (m *MockServiceImp) EnrollMFA(userID uint) (*auth.EnrollMFAResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollMFA", userID)
+	ret0, _ := ret[0].(*auth.EnrollMFAResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollMFA indicates an expected call of EnrollMFA.
+func (mr *MockServiceImpMockRecorder) EnrollMFA(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollMFA", reflect.TypeOf((*MockServiceImp)(nil).EnrollMFA), userID)
+}
+
+// ForcePasswordReset mocks base method.
+func (m *MockServiceImp) ForcePasswordReset(admin auth.Administrator, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForcePasswordReset", admin, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForcePasswordReset indicates an expected call of ForcePasswordReset.
+func (mr *MockServiceImpMockRecorder) ForcePasswordReset(admin, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForcePasswordReset", reflect.TypeOf((*MockServiceImp)(nil).ForcePasswordReset), admin, userID)
+}
+
+// ForgotPassword mocks base method.
+func (m *MockServiceImp) ForgotPassword(email string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ForgotPassword", email)
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockServiceImpMockRecorder) ForgotPassword(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockServiceImp)(nil).ForgotPassword), email)
+}
+
+// GetProfile mocks base method.
+func (m *MockServiceImp) GetProfile(userID uint) (*auth.UserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", userID)
+	ret0, _ := ret[0].(*auth.UserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockServiceImpMockRecorder) GetProfile(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockServiceImp)(nil).GetProfile), userID)
+}
+
+// IsTokenRevoked mocks base method.
+func (m *MockServiceImp) IsTokenRevoked(claims *token.CustomClaims) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTokenRevoked", claims)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsTokenRevoked indicates an expected call of IsTokenRevoked.
+func (mr *MockServiceImpMockRecorder) IsTokenRevoked(claims interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenRevoked", reflect.TypeOf((*MockServiceImp)(nil).IsTokenRevoked), claims)
+}
+
+// ListSessions mocks base method.
+func (m *MockServiceImp) ListSessions(userID uint, currentTokenID string) ([]auth.SessionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", userID, currentTokenID)
+	ret0, _ := ret[0].([]auth.SessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockServiceImpMockRecorder) ListSessions(userID, currentTokenID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockServiceImp)(nil).ListSessions), userID, currentTokenID)
+}
+
+// ListUserSessions mocks base method.
+func (m *MockServiceImp) ListUserSessions(admin auth.Administrator, userID uint) ([]auth.SessionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserSessions", admin, userID)
+	ret0, _ := ret[0].([]auth.SessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserSessions indicates an expected call of ListUserSessions.
+func (mr *MockServiceImpMockRecorder) ListUserSessions(admin, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserSessions", reflect.TypeOf((*MockServiceImp)(nil).ListUserSessions), admin, userID)
+}
+
+// ListUsers mocks base method.
+func (m *MockServiceImp) ListUsers(filter auth.ListUsersFilter) (*auth.ListUsersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", filter)
+	ret0, _ := ret[0].(*auth.ListUsersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockServiceImpMockRecorder) ListUsers(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockServiceImp)(nil).ListUsers), filter)
+}
+
 // RegisterUser mocks base method.
 func (m *MockServiceImp) RegisterUser(user entity.User) error {
 	m.ctrl.T.Helper()
@@ -63,3 +324,89 @@ func (mr *MockServiceImpMockRecorder) RegisterUser(user interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockServiceImp)(nil).RegisterUser), user)
 }
+
+// ResetPassword mocks base method.
+func (m *MockServiceImp) ResetPassword(input auth.ResetPasswordInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockServiceImpMockRecorder) ResetPassword(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockServiceImp)(nil).ResetPassword), input)
+}
+
+// RestoreUser mocks base method.
+func (m *MockServiceImp) RestoreUser(admin auth.Administrator, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", admin, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockServiceImpMockRecorder) RestoreUser(admin, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockServiceImp)(nil).RestoreUser), admin, userID)
+}
+
+// RevokeSession mocks base method.
+func (m *MockServiceImp) RevokeSession(userID, sessionID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", userID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockServiceImpMockRecorder) RevokeSession(userID, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockServiceImp)(nil).RevokeSession), userID, sessionID)
+}
+
+// UnlockUser mocks base method.
+func (m *MockServiceImp) UnlockUser(admin auth.Administrator, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", admin, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockServiceImpMockRecorder) UnlockUser(admin, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockServiceImp)(nil).UnlockUser), admin, userID)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockServiceImp) UpdateProfile(input auth.UpdateProfileInput) (*auth.UserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", input)
+	ret0, _ := ret[0].(*auth.UserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockServiceImpMockRecorder) UpdateProfile(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockServiceImp)(nil).UpdateProfile), input)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockServiceImp) VerifyEmail(verificationToken string) (*auth.VerifyEmailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", verificationToken)
+	ret0, _ := ret[0].(*auth.VerifyEmailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockServiceImpMockRecorder) VerifyEmail(verificationToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockServiceImp)(nil).VerifyEmail), verificationToken)
+}
@@ -1,6 +1,12 @@
 package auth
 
-import "luizalabs-technical-test/internal/pkg/entity"
+import (
+	"luizalabs-technical-test/internal/pkg/entity"
+	"time"
+)
+
+// defaultPageSize defines how many users are listed per page when no page size is requested.
+const defaultPageSize = 20
 
 // PostRegisterPayload represents the payload for register a user in database.
 type PostRegisterPayload struct {
@@ -14,21 +20,263 @@ type PostLoginPayload struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// PostForgotPasswordPayload represents the payload for requesting a password reset.
+type PostForgotPasswordPayload struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// PostResetPasswordPayload represents the payload for choosing a new password with a reset token.
+type PostResetPasswordPayload struct {
+	Token    string `json:"token"    binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// PostLoginMFAPayload represents the payload completing a login with the second factor.
+// The code is either the current TOTP code or one of the recovery codes.
+type PostLoginMFAPayload struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code"      binding:"required"`
+}
+
+// PostConfirmMFAPayload represents the payload confirming an MFA enrolment with the first TOTP code.
+type PostConfirmMFAPayload struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// PatchProfilePayload represents the payload for updating the profile of the authenticated user.
+type PatchProfilePayload struct {
+	Email string `json:"email" binding:"omitempty,email"`
+}
+
+// PostChangePasswordPayload represents the payload for changing the password of the authenticated user.
+type PostChangePasswordPayload struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password"     binding:"required"`
+}
+
+// PutRolePayload represents the payload for changing the role of a user.
+type PutRolePayload struct {
+	Role string `json:"role" binding:"required,oneof=user admin"`
+}
+
+// ListUsersQuery represents the query parameters for listing users.
+// Dates follow RFC 3339 (e.g. 2024-01-31T00:00:00Z).
+type ListUsersQuery struct {
+	Page           int       `form:"page"            binding:"omitempty,min=1"`
+	PageSize       int       `form:"page_size"       binding:"omitempty,min=1,max=100"`
+	Email          string    `form:"email"`
+	CreatedFrom    time.Time `form:"created_from"    time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo      time.Time `form:"created_to"      time_format:"2006-01-02T15:04:05Z07:00"`
+	IncludeDeleted bool      `form:"include_deleted"`
+	OrganizationID uint      `form:"organization_id"`
+}
+
 // AuthenticateUserInput represents the input structure in
 // service layer for autentication of user login.
 type AuthenticateUserInput struct {
-	Email    string
+	Email     string
+	Password  string
+	IP        string
+	UserAgent string
+}
+
+// AuthenticateMFAInput represents the input structure in service layer for the second step of the login.
+type AuthenticateMFAInput struct {
+	MFAToken  string
+	Code      string
+	IP        string
+	UserAgent string
+}
+
+// UpdateProfileInput represents the input structure in service layer for updating a profile.
+type UpdateProfileInput struct {
+	UserID uint
+	Email  string
+}
+
+// ChangePasswordInput represents the input structure in service layer for changing a password.
+type ChangePasswordInput struct {
+	UserID          uint
+	CurrentPassword string
+	NewPassword     string
+	IP              string
+	UserAgent       string
+}
+
+// ResetPasswordInput represents the input structure in service layer for resetting a password.
+type ResetPasswordInput struct {
+	Token    string
 	Password string
 }
 
+// VerifyEmailQuery represents the query parameters of the email verification link.
+type VerifyEmailQuery struct {
+	Token string `form:"token" binding:"required"`
+}
+
+// FederatedLoginCallbackQuery represents the query parameters sent by the identity provider to the callback.
+// The error is set instead of the code when the user denies the login or the provider fails.
+type FederatedLoginCallbackQuery struct {
+	Code  string `form:"code"`
+	State string `form:"state" binding:"required"`
+	Error string `form:"error"`
+}
+
+// CompleteFederatedLoginInput represents the input structure in service layer for completing a login at the identity provider.
+type CompleteFederatedLoginInput struct {
+	Code      string
+	State     string
+	IP        string
+	UserAgent string
+}
+
+// VerifyEmailResponse represents the response structure containing the verified email address.
+type VerifyEmailResponse struct {
+	Email string `json:"email"`
+}
+
 // AuthenticateUserResponse represents the response structure
 // containing a JWT token upon successful user authentication.
+// When MFA is enabled, the password step only returns the challenge token expected by the second step.
 type AuthenticateUserResponse struct {
-	JWTToken string `json:"token"`
+	JWTToken    string `json:"token,omitempty"`
+	MFARequired bool   `json:"mfa_required,omitempty"`
+	MFAToken    string `json:"mfa_token,omitempty"`
+	UserID      uint   `json:"-"` // account authenticated, used to identify the actor in the audit log.
+	TenantID    uint   `json:"-"` // organization of the account authenticated, used to scope the audit log.
+}
+
+// UserResponse represents the public view of a user account.
+type UserResponse struct {
+	ID             uint      `json:"id"`
+	Email          string    `json:"email"`
+	Role           string    `json:"role"`
+	EmailVerified  bool      `json:"email_verified"`
+	MFAEnabled     bool      `json:"mfa_enabled"`
+	OrganizationID uint      `json:"organization_id,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// SessionResponse represents a login of the user. Current marks the session of the access token in use.
+type SessionResponse struct {
+	ID         uint      `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+// AdminUserResponse represents the view of a user account for administrators, including its status.
+type AdminUserResponse struct {
+	UserResponse
+	Disabled              bool       `json:"disabled"`
+	PasswordResetRequired bool       `json:"password_reset_required"`
+	LockedUntil           *time.Time `json:"locked_until,omitempty"`
+	DeletedAt             *time.Time `json:"deleted_at,omitempty"`
+}
+
+// ListUsersResponse represents a page of users.
+type ListUsersResponse struct {
+	Users    []AdminUserResponse `json:"users"`
+	Page     int                 `json:"page"`
+	PageSize int                 `json:"page_size"`
+	Total    int64               `json:"total"`
+}
+
+// EnrollMFAResponse represents the TOTP secret of a pending enrolment, along with its otpauth URI.
+type EnrollMFAResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+// ConfirmMFAResponse represents the recovery codes issued on enrolment. They are only returned once.
+type ConfirmMFAResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// Policy groups the configurable rules applied by the auth service.
+type Policy struct {
+	Lockout       LockoutPolicy
+	Verification  VerificationPolicy
+	PasswordReset PasswordResetPolicy
+	MFA           MFAPolicy
+	Enumeration   EnumerationPolicy
+}
+
+// VerificationPolicy defines how new accounts confirm ownership of their email address.
+type VerificationPolicy struct {
+	Required        bool          // blocks unverified users from logging in.
+	TokenExpiration time.Duration // lifetime of the token sent by email.
+	URL             string        // endpoint receiving the token, linked in the email.
+}
+
+// PasswordResetPolicy defines how password reset tokens are issued.
+type PasswordResetPolicy struct {
+	TokenExpiration time.Duration // lifetime of the token sent by email.
+	URL             string        // optional page receiving the token, linked in the email.
+}
+
+// MFAPolicy defines how TOTP enrolments and MFA challenges are issued.
+type MFAPolicy struct {
+	Issuer              string        // name displayed by the authenticator apps.
+	ChallengeExpiration time.Duration // lifetime of the token returned by the password step.
+}
+
+// EnumerationPolicy defines whether the responses of logins and registrations may reveal that an email is registered.
+type EnumerationPolicy struct {
+	Hardened bool // answers unknown, locked and taken emails like any other, see AuthenticateUser and RegisterUser.
+}
+
+// LockoutPolicy defines how failed login attempts are throttled per account and per client IP.
+// Once a limit is reached, every further failure doubles the lockout, up to MaxLockoutDuration.
+type LockoutPolicy struct {
+	MaxAttempts        int
+	MaxAttemptsPerIP   int
+	LockoutDuration    time.Duration
+	MaxLockoutDuration time.Duration
+}
+
+// Administrator identifies the administrator performing an administrative action.
+// Administrators of an organization can only administer its members; the others administer every account.
+type Administrator struct {
+	ID       uint
+	TenantID uint
+}
+
+// sessionClient identifies the client a session is created for.
+type sessionClient struct {
+	IP        string
+	UserAgent string
+}
+
+// loginAttempts represents the failed login attempts of a client IP, kept in cache as JSON.
+type loginAttempts struct {
+	Count       int       `json:"count"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+// federatedLoginState represents a login started at the identity provider, kept in cache as JSON until the callback.
+type federatedLoginState struct {
+	CodeVerifier string `json:"code_verifier"`
+	Nonce        string `json:"nonce"`
+}
+
+// ListUsersFilter represents the filter criteria for listing users. Zero values are ignored.
+type ListUsersFilter struct {
+	Page           int
+	PageSize       int
+	Email          string
+	CreatedFrom    time.Time
+	CreatedTo      time.Time
+	IncludeDeleted bool
+	OrganizationID uint
 }
 
 // GetUserFilter represents the filter criteria for querying users.
 type GetUserFilter struct {
+	ID    uint
 	Email string
 }
 
@@ -48,9 +296,124 @@ func (p *PostLoginPayload) ToPostLoginPayloadToInput() AuthenticateUserInput {
 	}
 }
 
+// ToResetPasswordInput maps PostResetPasswordPayload to ResetPasswordInput.
+func (p *PostResetPasswordPayload) ToResetPasswordInput() ResetPasswordInput {
+	return ResetPasswordInput{
+		Token:    p.Token,
+		Password: p.Password,
+	}
+}
+
+// ToAuthenticateMFAInput maps PostLoginMFAPayload to AuthenticateMFAInput.
+func (p *PostLoginMFAPayload) ToAuthenticateMFAInput() AuthenticateMFAInput {
+	return AuthenticateMFAInput{
+		MFAToken: p.MFAToken,
+		Code:     p.Code,
+	}
+}
+
+// ToCompleteFederatedLoginInput maps FederatedLoginCallbackQuery to CompleteFederatedLoginInput.
+func (q *FederatedLoginCallbackQuery) ToCompleteFederatedLoginInput() CompleteFederatedLoginInput {
+	return CompleteFederatedLoginInput{
+		Code:  q.Code,
+		State: q.State,
+	}
+}
+
+// ToUpdateProfileInput maps PatchProfilePayload to UpdateProfileInput.
+func (p *PatchProfilePayload) ToUpdateProfileInput(userID uint) UpdateProfileInput {
+	return UpdateProfileInput{
+		UserID: userID,
+		Email:  p.Email,
+	}
+}
+
+// ToChangePasswordInput maps PostChangePasswordPayload to ChangePasswordInput.
+func (p *PostChangePasswordPayload) ToChangePasswordInput(userID uint) ChangePasswordInput {
+	return ChangePasswordInput{
+		UserID:          userID,
+		CurrentPassword: p.CurrentPassword,
+		NewPassword:     p.NewPassword,
+	}
+}
+
+// ToUserResponse converts a User entity to its public representation.
+func ToUserResponse(user entity.User) UserResponse {
+	return UserResponse{
+		ID:             user.ID,
+		Email:          user.Email,
+		Role:           user.Role,
+		EmailVerified:  user.IsVerified(),
+		MFAEnabled:     user.IsMFAEnabled(),
+		OrganizationID: user.OrganizationID,
+		CreatedAt:      user.CreatedAt,
+	}
+}
+
+// ToListUsersFilter maps ListUsersQuery to ListUsersFilter, applying the default pagination.
+func (q *ListUsersQuery) ToListUsersFilter() ListUsersFilter {
+	filter := ListUsersFilter{
+		Page:           q.Page,
+		PageSize:       q.PageSize,
+		Email:          q.Email,
+		CreatedFrom:    q.CreatedFrom,
+		CreatedTo:      q.CreatedTo,
+		IncludeDeleted: q.IncludeDeleted,
+		OrganizationID: q.OrganizationID,
+	}
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize == 0 {
+		filter.PageSize = defaultPageSize
+	}
+	return filter
+}
+
+// ToSessionResponse converts a Session entity to its public representation, comparing it to the token ID in use.
+func ToSessionResponse(session entity.Session, currentTokenID string) SessionResponse {
+	return SessionResponse{
+		ID:         session.ID,
+		UserAgent:  session.UserAgent,
+		IP:         session.IP,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+		ExpiresAt:  session.ExpiresAt,
+		Current:    currentTokenID != "" && session.TokenID == currentTokenID,
+	}
+}
+
+// ToAdminUserResponse converts a User entity to its representation for administrators.
+func ToAdminUserResponse(user entity.User) AdminUserResponse {
+	response := AdminUserResponse{
+		UserResponse:          ToUserResponse(user),
+		Disabled:              user.IsDisabled(),
+		PasswordResetRequired: user.PasswordResetRequired,
+		LockedUntil:           user.LockedUntil,
+	}
+	if user.DeletedAt.Valid {
+		response.DeletedAt = &user.DeletedAt.Time
+	}
+	return response
+}
+
 // ToPostLoginInputToFilter maps PostLoginInput to GetUserFilter.
 func (i *AuthenticateUserInput) ToPostLoginInputToFilter() GetUserFilter {
 	return GetUserFilter{
 		Email: i.Email,
 	}
 }
+
+// LockoutFor returns how long to lock after the given amount of consecutive failures,
+// or zero while the amount is below the limit.
+func (p LockoutPolicy) LockoutFor(attempts, limit int) time.Duration {
+	if limit <= 0 || attempts < limit {
+		return 0
+	}
+
+	lockout := p.LockoutDuration
+	for i := limit; i < attempts && lockout < p.MaxLockoutDuration; i++ {
+		lockout *= 2
+	}
+	return min(lockout, p.MaxLockoutDuration)
+}
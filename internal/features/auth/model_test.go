@@ -1,7 +1,9 @@
 package auth
 
 import (
+	"luizalabs-technical-test/internal/pkg/entity"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -30,3 +32,114 @@ func TestToPostLoginPayloadToInput(t *testing.T) {
 	assert.Equal(t, payload.Email, loginInput.Email, "Expected email to match")
 	assert.Equal(t, payload.Password, loginInput.Password, "Expected password to match")
 }
+
+// TestLockoutFor tests the exponential back-off of the LockoutPolicy.
+func TestLockoutFor(t *testing.T) {
+	policy := LockoutPolicy{LockoutDuration: time.Minute, MaxLockoutDuration: 5 * time.Minute}
+
+	assert.Zero(t, policy.LockoutFor(2, 3), "Expected no lockout below the limit")
+	assert.Equal(t, time.Minute, policy.LockoutFor(3, 3))
+	assert.Equal(t, 2*time.Minute, policy.LockoutFor(4, 3))
+	assert.Equal(t, 4*time.Minute, policy.LockoutFor(5, 3))
+	assert.Equal(t, 5*time.Minute, policy.LockoutFor(20, 3), "Expected lockout to be capped")
+	assert.Zero(t, policy.LockoutFor(20, 0), "Expected no lockout when the limit is disabled")
+}
+
+// TestToResetPasswordInput tests the ToResetPasswordInput method of PostResetPasswordPayload.
+func TestToResetPasswordInput(t *testing.T) {
+	payload := &PostResetPasswordPayload{
+		Token:    "reset-token",
+		Password: "securepassword",
+	}
+
+	input := payload.ToResetPasswordInput()
+
+	assert.Equal(t, payload.Token, input.Token, "Expected token to match")
+	assert.Equal(t, payload.Password, input.Password, "Expected password to match")
+}
+
+// TestToAuthenticateMFAInput tests the ToAuthenticateMFAInput method of PostLoginMFAPayload.
+func TestToAuthenticateMFAInput(t *testing.T) {
+	payload := &PostLoginMFAPayload{
+		MFAToken: "mfa-token",
+		Code:     "123456",
+	}
+
+	input := payload.ToAuthenticateMFAInput()
+
+	assert.Equal(t, payload.MFAToken, input.MFAToken, "Expected MFA token to match")
+	assert.Equal(t, payload.Code, input.Code, "Expected code to match")
+	assert.Empty(t, input.IP, "Expected IP to be set by the handler")
+}
+
+// TestToCompleteFederatedLoginInput tests the ToCompleteFederatedLoginInput method of FederatedLoginCallbackQuery.
+func TestToCompleteFederatedLoginInput(t *testing.T) {
+	query := &FederatedLoginCallbackQuery{
+		Code:  "code",
+		State: "state",
+	}
+
+	input := query.ToCompleteFederatedLoginInput()
+
+	assert.Equal(t, query.Code, input.Code, "Expected code to match")
+	assert.Equal(t, query.State, input.State, "Expected state to match")
+	assert.Empty(t, input.IP, "Expected IP to be set by the handler")
+}
+
+// TestToUserResponse tests the conversion of a User entity to its public representation.
+func TestToUserResponse(t *testing.T) {
+	verifiedAt := time.Now()
+	user := entity.User{Email: "test@example.com", Password: "hashedPassword", Role: entity.RoleUser, VerifiedAt: &verifiedAt}
+	user.ID = 7
+
+	response := ToUserResponse(user)
+
+	assert.Equal(t, UserResponse{
+		ID:            7,
+		Email:         "test@example.com",
+		Role:          entity.RoleUser,
+		EmailVerified: true,
+		MFAEnabled:    false,
+		CreatedAt:     user.CreatedAt,
+	}, response)
+}
+
+// TestToListUsersFilter tests the default pagination of the user listing.
+func TestToListUsersFilter(t *testing.T) {
+	query := ListUsersQuery{Email: "example.com", IncludeDeleted: true}
+
+	filter := query.ToListUsersFilter()
+
+	assert.Equal(t, ListUsersFilter{Page: 1, PageSize: defaultPageSize, Email: "example.com", IncludeDeleted: true}, filter)
+}
+
+// TestToAdminUserResponse tests the conversion of a User entity to its representation for administrators.
+func TestToAdminUserResponse(t *testing.T) {
+	disabledAt := time.Now()
+	user := entity.User{Email: "test@example.com", Role: entity.RoleUser, DisabledAt: &disabledAt, PasswordResetRequired: true}
+	user.ID = 7
+	user.DeletedAt.Time = disabledAt
+	user.DeletedAt.Valid = true
+
+	response := ToAdminUserResponse(user)
+
+	assert.Equal(t, ToUserResponse(user), response.UserResponse)
+	assert.True(t, response.Disabled)
+	assert.True(t, response.PasswordResetRequired)
+	assert.Nil(t, response.LockedUntil)
+	assert.Equal(t, &disabledAt, response.DeletedAt)
+}
+
+// TestToSessionResponse tests the conversion of a Session entity, marking the one of the token in use.
+func TestToSessionResponse(t *testing.T) {
+	session := entity.Session{TokenID: "token-id", UserAgent: "Mozilla/5.0", IP: "203.0.113.10", ExpiresAt: time.Now()}
+	session.ID = 3
+
+	response := ToSessionResponse(session, "token-id")
+
+	assert.Equal(t, uint(3), response.ID)
+	assert.Equal(t, session.UserAgent, response.UserAgent)
+	assert.Equal(t, session.IP, response.IP)
+	assert.True(t, response.Current)
+	assert.False(t, ToSessionResponse(session, "").Current)
+}
@@ -1,9 +1,13 @@
 package auth
 
 import (
+	"errors"
 	"luizalabs-technical-test/internal/pkg/entity"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // RepositoryImp defines the interface for the repository layer,
@@ -11,8 +15,53 @@ import (
 type RepositoryImp interface {
 	RegisterUser(user entity.User) error
 	GetUser(filter GetUserFilter) (*entity.User, error)
+	ListUsers(filter ListUsersFilter) ([]entity.User, int64, error)
+	UpdateLoginAttempts(id uint, attempts int, lockedUntil *time.Time) error
+	IncrementLoginAttempts(id uint) (int, error)
+	LockAccount(id uint, lockedUntil time.Time) error
+	MarkUserVerified(id uint, verifiedAt time.Time) error
+	UpdatePasswordHash(id uint, oldHash, newHash string) error
+	CreatePasswordResetToken(resetToken *entity.PasswordResetToken) error
+	GetPasswordResetToken(hashedToken string) (*entity.PasswordResetToken, error)
+	ResetPassword(resetToken entity.PasswordResetToken, hashedPassword string, changedAt time.Time) error
+	UpdateEmail(id uint, email string) error
+	ChangePassword(id uint, hashedPassword string, changedAt time.Time) error
+	DeleteUser(id uint) error
+	RestoreUser(id, organizationID uint) error
+	SetDisabled(id uint, disabledAt *time.Time) error
+	UpdateRole(id uint, role string, changedAt time.Time) error
+	ForcePasswordReset(id uint, requiredAt time.Time) error
+	UpdateMFASecret(id uint, secret string) error
+	EnableMFA(id uint, enabledAt time.Time, recoveryCodes []entity.MFARecoveryCode) error
+	UseRecoveryCode(userID uint, hashedCode string, usedAt time.Time) error
+	UseTOTPCounter(userID uint, counter int64) error
+	CreateSession(session *entity.Session) error
+	GetSession(tokenID string) (*entity.Session, error)
+	ListSessions(userID uint, now time.Time) ([]entity.Session, error)
+	TouchSession(id uint, lastSeenAt time.Time) error
+	RevokeSession(userID, id uint, revokedAt time.Time) error
+	GetFederatedIdentity(issuer, subject string) (*entity.FederatedIdentity, error)
+	CreateFederatedIdentity(identity *entity.FederatedIdentity) error
+	RegisterFederatedUser(user *entity.User, identity *entity.FederatedIdentity) error
 }
 
+var (
+	// errResetTokenUsed is returned when a password reset token is consumed concurrently.
+	errResetTokenUsed = errors.New("password reset token already used")
+
+	// errRecoveryCodeNotFound is returned when no unused recovery code of the user matches the hash.
+	errRecoveryCodeNotFound = errors.New("recovery code not found or already used")
+
+	// errTOTPCounterUsed is returned when a TOTP code of the same or an earlier period was already accepted.
+	errTOTPCounterUsed = errors.New("TOTP code already used")
+
+	// errUserNotDeleted is returned when restoring a user that doesn't exist or isn't deleted.
+	errUserNotDeleted = errors.New("user not found or not deleted")
+
+	// errSessionNotFound is returned when no active session of the user matches the ID.
+	errSessionNotFound = errors.New("session not found or already revoked")
+)
+
 // repository struct implements the repositoryImp interface,
 // that interacts with external entities such as databases or external APIs.
 type repository struct {
@@ -33,14 +82,380 @@ func (r *repository) RegisterUser(user entity.User) error {
 	return nil
 }
 
-// GetUser retrieves a user by email from the database.
+// GetUser retrieves a user by ID or email from the database.
 func (r *repository) GetUser(filter GetUserFilter) (*entity.User, error) {
 	fetchedUser := new(entity.User)
 
-	tx := r.db.Where("Email = ?", filter.Email).First(&fetchedUser)
+	tx := r.db.Where(&entity.User{
+		Model: gorm.Model{ID: filter.ID},
+		Email: filter.Email,
+	}).First(&fetchedUser)
 	if err := tx.Error; err != nil {
 		return nil, err
 	}
 
 	return fetchedUser, nil
 }
+
+// ListUsers retrieves a page of users matching the filter, oldest first, along with the total of matching users.
+// Note: soft deleted users are only listed when requested.
+func (r *repository) ListUsers(filter ListUsersFilter) ([]entity.User, int64, error) {
+	query := r.db.Model(&entity.User{})
+	if filter.IncludeDeleted {
+		query = query.Unscoped()
+	}
+	if filter.OrganizationID != 0 {
+		query = query.Where("organization_id = ?", filter.OrganizationID)
+	}
+	if filter.Email != "" {
+		query = query.Where("LOWER(email) LIKE ?", "%"+strings.ToLower(filter.Email)+"%")
+	}
+	if !filter.CreatedFrom.IsZero() {
+		query = query.Where("created_at >= ?", filter.CreatedFrom)
+	}
+	if !filter.CreatedTo.IsZero() {
+		query = query.Where("created_at <= ?", filter.CreatedTo)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []entity.User
+	tx := query.Order("id").Offset((filter.Page - 1) * filter.PageSize).Limit(filter.PageSize).Find(&users)
+	if err := tx.Error; err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// UpdateLoginAttempts stores the failed login counter and lockout of the user.
+func (r *repository) UpdateLoginAttempts(id uint, attempts int, lockedUntil *time.Time) error {
+	tx := r.db.Model(&entity.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"failed_login_attempts": attempts,
+		"locked_until":          lockedUntil,
+	})
+	if err := tx.Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// IncrementLoginAttempts increments the failed login counter of the user in the database, returning its new value.
+// Note: the increment is a single statement, so concurrent failures are all counted.
+func (r *repository) IncrementLoginAttempts(id uint) (int, error) {
+	var user entity.User
+	tx := r.db.Model(&user).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "failed_login_attempts"}}}).
+		Where("id = ?", id).
+		Update("failed_login_attempts", gorm.Expr("failed_login_attempts + 1"))
+	if err := tx.Error; err != nil {
+		return 0, err
+	}
+	if tx.RowsAffected == 0 {
+		return 0, gorm.ErrRecordNotFound
+	}
+	return user.FailedLoginAttempts, nil
+}
+
+// LockAccount locks the account until the given time, keeping a later lockout set by a concurrent failure.
+func (r *repository) LockAccount(id uint, lockedUntil time.Time) error {
+	tx := r.db.Model(&entity.User{}).
+		Where("id = ? AND (locked_until IS NULL OR locked_until < ?)", id, lockedUntil).
+		Update("locked_until", lockedUntil)
+	if err := tx.Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarkUserVerified records when the user confirmed ownership of the email address.
+func (r *repository) MarkUserVerified(id uint, verifiedAt time.Time) error {
+	tx := r.db.Model(&entity.User{}).Where("id = ?", id).Update("verified_at", verifiedAt)
+	if err := tx.Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdatePasswordHash replaces the stored hash of an unchanged password, e.g. when upgrading its algorithm.
+// Note: the update is skipped when the hash no longer matches, so a concurrent password change always wins.
+func (r *repository) UpdatePasswordHash(id uint, oldHash, newHash string) error {
+	tx := r.db.Model(&entity.User{}).Where("id = ? AND password = ?", id, oldHash).Update("password", newHash)
+	if err := tx.Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// CreatePasswordResetToken stores a new password reset token, discarding the unused ones previously issued to the user.
+func (r *repository) CreatePasswordResetToken(resetToken *entity.PasswordResetToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		discarded := tx.Model(&entity.PasswordResetToken{}).
+			Where("user_id = ? AND used_at IS NULL", resetToken.UserID).
+			Update("used_at", time.Now())
+		if err := discarded.Error; err != nil {
+			return err
+		}
+
+		return tx.Table(entity.TbPasswordResetToken).Create(resetToken).Error
+	})
+}
+
+// GetPasswordResetToken retrieves a password reset token by its hash, along with its user.
+func (r *repository) GetPasswordResetToken(hashedToken string) (*entity.PasswordResetToken, error) {
+	fetchedToken := new(entity.PasswordResetToken)
+
+	tx := r.db.Preload("User").Where(&entity.PasswordResetToken{HashedToken: hashedToken}).First(fetchedToken)
+	if err := tx.Error; err != nil {
+		return nil, err
+	}
+
+	return fetchedToken, nil
+}
+
+// ResetPassword consumes the reset token and stores the new password, clearing any lockout of the account.
+// Note: the token is only consumed while unused, so concurrent requests can't reuse it.
+func (r *repository) ResetPassword(resetToken entity.PasswordResetToken, hashedPassword string, changedAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		consumed := tx.Model(&entity.PasswordResetToken{}).
+			Where("id = ? AND used_at IS NULL", resetToken.ID).
+			Update("used_at", changedAt)
+		if err := consumed.Error; err != nil {
+			return err
+		}
+		if consumed.RowsAffected == 0 {
+			return errResetTokenUsed
+		}
+
+		return tx.Model(&entity.User{}).Where("id = ?", resetToken.UserID).Updates(map[string]interface{}{
+			"password":                hashedPassword,
+			"credentials_changed_at":  changedAt,
+			"failed_login_attempts":   0,
+			"locked_until":            nil,
+			"password_reset_required": false,
+		}).Error
+	})
+}
+
+// UpdateEmail replaces the email address of the user, which must be verified again.
+func (r *repository) UpdateEmail(id uint, email string) error {
+	tx := r.db.Model(&entity.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"email":       email,
+		"verified_at": nil,
+	})
+	if err := tx.Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// ChangePassword stores the new password of the user, revoking the access tokens issued before the change.
+func (r *repository) ChangePassword(id uint, hashedPassword string, changedAt time.Time) error {
+	tx := r.db.Model(&entity.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"password":               hashedPassword,
+		"credentials_changed_at": changedAt,
+	})
+	if err := tx.Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteUser soft deletes the user, so it can no longer log in or use the access tokens previously issued.
+func (r *repository) DeleteUser(id uint) error {
+	tx := r.db.Delete(&entity.User{}, id)
+	if err := tx.Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// RestoreUser reverts the soft deletion of the user, restricted to the members of the organization when one is given.
+func (r *repository) RestoreUser(id, organizationID uint) error {
+	query := r.db.Unscoped().Model(&entity.User{}).Where("id = ? AND deleted_at IS NOT NULL", id)
+	if organizationID != 0 {
+		query = query.Where("organization_id = ?", organizationID)
+	}
+
+	tx := query.Update("deleted_at", nil)
+	if err := tx.Error; err != nil {
+		return err
+	}
+	if tx.RowsAffected == 0 {
+		return errUserNotDeleted
+	}
+	return nil
+}
+
+// SetDisabled disables the user at the given time, or enables it back when the time is nil.
+func (r *repository) SetDisabled(id uint, disabledAt *time.Time) error {
+	tx := r.db.Model(&entity.User{}).Where("id = ?", id).Update("disabled_at", disabledAt)
+	if err := tx.Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateRole changes the role of the user, revoking the access tokens carrying the previous one.
+func (r *repository) UpdateRole(id uint, role string, changedAt time.Time) error {
+	tx := r.db.Model(&entity.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"role":                   role,
+		"credentials_changed_at": changedAt,
+	})
+	if err := tx.Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// ForcePasswordReset blocks the logins of the user until the password is reset, revoking its access tokens.
+func (r *repository) ForcePasswordReset(id uint, requiredAt time.Time) error {
+	tx := r.db.Model(&entity.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"password_reset_required": true,
+		"credentials_changed_at":  requiredAt,
+	})
+	if err := tx.Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateMFASecret stores the TOTP secret of a pending enrolment, replacing any previous one.
+func (r *repository) UpdateMFASecret(id uint, secret string) error {
+	tx := r.db.Model(&entity.User{}).Where("id = ?", id).Update("mfa_secret", secret)
+	if err := tx.Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// EnableMFA confirms the enrolment of the user, replacing the recovery codes previously issued.
+func (r *repository) EnableMFA(id uint, enabledAt time.Time, recoveryCodes []entity.MFARecoveryCode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", id).Delete(&entity.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Table(entity.TbMFARecoveryCode).Create(&recoveryCodes).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&entity.User{}).Where("id = ?", id).Update("mfa_enabled_at", enabledAt).Error
+	})
+}
+
+// UseRecoveryCode consumes an unused recovery code of the user.
+// Note: the code is only consumed while unused, so concurrent requests can't reuse it.
+func (r *repository) UseRecoveryCode(userID uint, hashedCode string, usedAt time.Time) error {
+	tx := r.db.Model(&entity.MFARecoveryCode{}).
+		Where("user_id = ? AND hashed_code = ? AND used_at IS NULL", userID, hashedCode).
+		Update("used_at", usedAt)
+	if err := tx.Error; err != nil {
+		return err
+	}
+	if tx.RowsAffected == 0 {
+		return errRecoveryCodeNotFound
+	}
+	return nil
+}
+
+// UseTOTPCounter records the counter of the TOTP code accepted for the user.
+// Note: the counter only moves forward, so a code can't be replayed, not even by concurrent requests.
+func (r *repository) UseTOTPCounter(userID uint, counter int64) error {
+	tx := r.db.Model(&entity.User{}).
+		Where("id = ? AND mfa_last_counter < ?", userID, counter).
+		Update("mfa_last_counter", counter)
+	if err := tx.Error; err != nil {
+		return err
+	}
+	if tx.RowsAffected == 0 {
+		return errTOTPCounterUsed
+	}
+	return nil
+}
+
+// CreateSession stores the session of a new login.
+func (r *repository) CreateSession(session *entity.Session) error {
+	return r.db.Table(entity.TbSession).Create(session).Error
+}
+
+// GetSession retrieves a session by the ID of the access token bound to it.
+func (r *repository) GetSession(tokenID string) (*entity.Session, error) {
+	fetchedSession := new(entity.Session)
+
+	tx := r.db.Where(&entity.Session{TokenID: tokenID}).First(fetchedSession)
+	if err := tx.Error; err != nil {
+		return nil, err
+	}
+
+	return fetchedSession, nil
+}
+
+// ListSessions retrieves the sessions of the user neither revoked nor expired, most recently seen first.
+func (r *repository) ListSessions(userID uint, now time.Time) ([]entity.Session, error) {
+	var sessions []entity.Session
+
+	tx := r.db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_seen_at DESC").
+		Find(&sessions)
+	if err := tx.Error; err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// TouchSession records the last time the session was used.
+func (r *repository) TouchSession(id uint, lastSeenAt time.Time) error {
+	tx := r.db.Model(&entity.Session{}).Where("id = ?", id).Update("last_seen_at", lastSeenAt)
+	if err := tx.Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// RevokeSession terminates an active session of the user.
+func (r *repository) RevokeSession(userID, id uint, revokedAt time.Time) error {
+	tx := r.db.Model(&entity.Session{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", revokedAt)
+	if err := tx.Error; err != nil {
+		return err
+	}
+	if tx.RowsAffected == 0 {
+		return errSessionNotFound
+	}
+	return nil
+}
+
+// GetFederatedIdentity retrieves the identity of an external provider by its issuer and subject.
+func (r *repository) GetFederatedIdentity(issuer, subject string) (*entity.FederatedIdentity, error) {
+	fetchedIdentity := new(entity.FederatedIdentity)
+
+	tx := r.db.Where(&entity.FederatedIdentity{Issuer: issuer, Subject: subject}).First(fetchedIdentity)
+	if err := tx.Error; err != nil {
+		return nil, err
+	}
+
+	return fetchedIdentity, nil
+}
+
+// CreateFederatedIdentity links the identity of an external provider to an existing user.
+func (r *repository) CreateFederatedIdentity(identity *entity.FederatedIdentity) error {
+	return r.db.Table(entity.TbFederatedIdentity).Create(identity).Error
+}
+
+// RegisterFederatedUser adds a new user along with the identity of the external provider it was created from.
+func (r *repository) RegisterFederatedUser(user *entity.User, identity *entity.FederatedIdentity) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table(entity.TbUser).Create(user).Error; err != nil {
+			return err
+		}
+
+		identity.UserID = user.ID
+		return tx.Table(entity.TbFederatedIdentity).Create(identity).Error
+	})
+}
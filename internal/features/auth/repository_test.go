@@ -2,7 +2,9 @@ package auth
 
 import (
 	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"luizalabs-technical-test/internal/pkg/entity"
 
@@ -49,8 +51,8 @@ func (s *AuthRepositoryTestSuite) SetupSuite() {
 	s.db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	s.Require().NoError(err)
 
-	// Auto-migrate the User table
-	s.Require().NoError(s.db.AutoMigrate(&entity.User{}))
+	// Auto-migrate the User and PasswordResetToken tables
+	s.Require().NoError(s.db.AutoMigrate(&entity.User{}, &entity.PasswordResetToken{}, &entity.MFARecoveryCode{}, &entity.Session{}, &entity.FederatedIdentity{}))
 }
 
 func (s *AuthRepositoryTestSuite) TearDownSuite() {
@@ -91,6 +93,343 @@ func (s *AuthRepositoryTestSuite) TestGetUserNotFound() {
 	s.Nil(user)
 }
 
+func (s *AuthRepositoryTestSuite) TestUpdateLoginAttempts() {
+	repo := NewRepository(s.db)
+
+	user := entity.User{Email: "locked@example.com"}
+	s.Require().NoError(repo.RegisterUser(user))
+
+	fetchedUser, err := repo.GetUser(GetUserFilter{Email: user.Email})
+	s.Require().NoError(err)
+
+	// Lock the account and verify it through the ID filter
+	lockedUntil := time.Now().Add(time.Minute)
+	s.NoError(repo.UpdateLoginAttempts(fetchedUser.ID, 3, &lockedUntil))
+
+	lockedUser, err := repo.GetUser(GetUserFilter{ID: fetchedUser.ID})
+	s.NoError(err)
+	s.Equal(3, lockedUser.FailedLoginAttempts)
+	s.NotNil(lockedUser.LockedUntil)
+
+	// Unlock the account, clearing both the counter and the lockout
+	s.NoError(repo.UpdateLoginAttempts(fetchedUser.ID, 0, nil))
+
+	unlockedUser, err := repo.GetUser(GetUserFilter{ID: fetchedUser.ID})
+	s.NoError(err)
+	s.Zero(unlockedUser.FailedLoginAttempts)
+	s.Nil(unlockedUser.LockedUntil)
+}
+
+func (s *AuthRepositoryTestSuite) TestIncrementLoginAttempts() {
+	repo := NewRepository(s.db)
+
+	user := entity.User{Email: "concurrent-failures@example.com"}
+	s.Require().NoError(repo.RegisterUser(user))
+
+	fetchedUser, err := repo.GetUser(GetUserFilter{Email: user.Email})
+	s.Require().NoError(err)
+
+	// Concurrent failures are all counted, each one seeing its own value of the counter
+	const failures = 10
+	var wg sync.WaitGroup
+	seen := make(chan int, failures)
+	for range failures {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			attempts, err := repo.IncrementLoginAttempts(fetchedUser.ID)
+			s.NoError(err)
+			seen <- attempts
+		}()
+	}
+	wg.Wait()
+	close(seen)
+
+	distinct := make(map[int]bool)
+	for attempts := range seen {
+		distinct[attempts] = true
+	}
+	s.Len(distinct, failures)
+
+	failedUser, err := repo.GetUser(GetUserFilter{ID: fetchedUser.ID})
+	s.NoError(err)
+	s.Equal(failures, failedUser.FailedLoginAttempts)
+
+	// An earlier lockout doesn't shorten a later one
+	lockedUntil := time.Now().Add(time.Hour)
+	s.NoError(repo.LockAccount(fetchedUser.ID, lockedUntil))
+	s.NoError(repo.LockAccount(fetchedUser.ID, lockedUntil.Add(-time.Minute)))
+
+	lockedUser, err := repo.GetUser(GetUserFilter{ID: fetchedUser.ID})
+	s.NoError(err)
+	s.WithinDuration(lockedUntil, *lockedUser.LockedUntil, time.Second)
+
+	_, err = repo.IncrementLoginAttempts(0)
+	s.Error(err)
+}
+
+func (s *AuthRepositoryTestSuite) TestMarkUserVerified() {
+	repo := NewRepository(s.db)
+
+	user := entity.User{Email: "unverified@example.com"}
+	s.Require().NoError(repo.RegisterUser(user))
+
+	fetchedUser, err := repo.GetUser(GetUserFilter{Email: user.Email})
+	s.Require().NoError(err)
+	s.False(fetchedUser.IsVerified())
+
+	s.NoError(repo.MarkUserVerified(fetchedUser.ID, time.Now()))
+
+	verifiedUser, err := repo.GetUser(GetUserFilter{ID: fetchedUser.ID})
+	s.NoError(err)
+	s.True(verifiedUser.IsVerified())
+}
+
+func (s *AuthRepositoryTestSuite) TestUpdatePasswordHash() {
+	repo := NewRepository(s.db)
+
+	user := entity.User{Email: "rehash@example.com", Password: "outdated-hash"}
+	s.Require().NoError(repo.RegisterUser(user))
+
+	fetchedUser, err := repo.GetUser(GetUserFilter{Email: user.Email})
+	s.Require().NoError(err)
+
+	// A stale hash means the password changed meanwhile, so nothing is updated
+	s.NoError(repo.UpdatePasswordHash(fetchedUser.ID, "stale-hash", "upgraded-hash"))
+	unchangedUser, err := repo.GetUser(GetUserFilter{ID: fetchedUser.ID})
+	s.NoError(err)
+	s.Equal("outdated-hash", unchangedUser.Password)
+
+	s.NoError(repo.UpdatePasswordHash(fetchedUser.ID, "outdated-hash", "upgraded-hash"))
+	upgradedUser, err := repo.GetUser(GetUserFilter{ID: fetchedUser.ID})
+	s.NoError(err)
+	s.Equal("upgraded-hash", upgradedUser.Password)
+	s.Nil(upgradedUser.CredentialsChangedAt, "Expected rehash not to revoke issued tokens")
+}
+
+func (s *AuthRepositoryTestSuite) TestResetPassword() {
+	repo := NewRepository(s.db)
+
+	user := entity.User{Email: "reset@example.com", Password: "old-hash"}
+	s.Require().NoError(repo.RegisterUser(user))
+
+	fetchedUser, err := repo.GetUser(GetUserFilter{Email: user.Email})
+	s.Require().NoError(err)
+
+	// Issuing a new token discards the unused ones previously issued
+	firstToken := entity.PasswordResetToken{UserID: fetchedUser.ID, HashedToken: "first", ExpiresAt: time.Now().Add(time.Hour)}
+	s.NoError(repo.CreatePasswordResetToken(&firstToken))
+
+	secondToken := entity.PasswordResetToken{UserID: fetchedUser.ID, HashedToken: "second", ExpiresAt: time.Now().Add(time.Hour)}
+	s.NoError(repo.CreatePasswordResetToken(&secondToken))
+
+	discardedToken, err := repo.GetPasswordResetToken("first")
+	s.NoError(err)
+	s.NotNil(discardedToken.UsedAt)
+
+	// The token is consumed only once
+	s.NoError(repo.ResetPassword(secondToken, "new-hash", time.Now()))
+	s.ErrorIs(repo.ResetPassword(secondToken, "other-hash", time.Now()), errResetTokenUsed)
+
+	updatedUser, err := repo.GetUser(GetUserFilter{ID: fetchedUser.ID})
+	s.NoError(err)
+	s.Equal("new-hash", updatedUser.Password)
+	s.NotNil(updatedUser.CredentialsChangedAt)
+}
+
+func (s *AuthRepositoryTestSuite) TestSelfService() {
+	repo := NewRepository(s.db)
+
+	user := entity.User{Email: "self-service@example.com", Password: "old-hash"}
+	s.Require().NoError(repo.RegisterUser(user))
+
+	fetchedUser, err := repo.GetUser(GetUserFilter{Email: user.Email})
+	s.Require().NoError(err)
+	s.Require().NoError(repo.MarkUserVerified(fetchedUser.ID, time.Now()))
+
+	s.NoError(repo.UpdateEmail(fetchedUser.ID, "changed@example.com"))
+	s.NoError(repo.ChangePassword(fetchedUser.ID, "new-hash", time.Now()))
+
+	updatedUser, err := repo.GetUser(GetUserFilter{ID: fetchedUser.ID})
+	s.NoError(err)
+	s.Equal("changed@example.com", updatedUser.Email)
+	s.False(updatedUser.IsVerified())
+	s.Equal("new-hash", updatedUser.Password)
+	s.NotNil(updatedUser.CredentialsChangedAt)
+
+	s.NoError(repo.DeleteUser(fetchedUser.ID))
+	_, err = repo.GetUser(GetUserFilter{ID: fetchedUser.ID})
+	s.Error(err)
+}
+
+func (s *AuthRepositoryTestSuite) TestAdminUserManagement() {
+	repo := NewRepository(s.db)
+
+	for _, email := range []string{"managed-1@admin.example.com", "Managed-2@admin.example.com", "managed-3@admin.example.com"} {
+		s.Require().NoError(repo.RegisterUser(entity.User{Email: email, Password: "hash", Role: entity.RoleUser}))
+	}
+
+	users, total, err := repo.ListUsers(ListUsersFilter{Page: 1, PageSize: 2, Email: "MANAGED-"})
+	s.Require().NoError(err)
+	s.Equal(int64(3), total)
+	s.Require().Len(users, 2)
+	s.Equal("managed-1@admin.example.com", users[0].Email)
+
+	users, _, err = repo.ListUsers(ListUsersFilter{Page: 2, PageSize: 2, Email: "managed-"})
+	s.Require().NoError(err)
+	s.Require().Len(users, 1)
+	managedUser := users[0]
+
+	_, total, err = repo.ListUsers(ListUsersFilter{Page: 1, PageSize: 2, Email: "managed-", CreatedFrom: time.Now().Add(time.Hour)})
+	s.NoError(err)
+	s.Zero(total)
+
+	// Soft deleted users are only listed when requested
+	s.Require().NoError(repo.DeleteUser(managedUser.ID))
+	_, total, err = repo.ListUsers(ListUsersFilter{Page: 1, PageSize: 10, Email: "managed-"})
+	s.NoError(err)
+	s.Equal(int64(2), total)
+	users, total, err = repo.ListUsers(ListUsersFilter{Page: 1, PageSize: 10, Email: "managed-", IncludeDeleted: true})
+	s.NoError(err)
+	s.Equal(int64(3), total)
+	s.True(users[2].DeletedAt.Valid)
+
+	// Administrators of an organization only restore its members
+	s.ErrorIs(repo.RestoreUser(managedUser.ID, 7), errUserNotDeleted)
+	s.NoError(repo.RestoreUser(managedUser.ID, 0))
+	s.ErrorIs(repo.RestoreUser(managedUser.ID, 0), errUserNotDeleted)
+	_, err = repo.GetUser(GetUserFilter{ID: managedUser.ID})
+	s.NoError(err)
+
+	now := time.Now()
+	s.NoError(repo.SetDisabled(managedUser.ID, &now))
+	s.NoError(repo.UpdateRole(managedUser.ID, entity.RoleAdmin, now))
+	s.NoError(repo.ForcePasswordReset(managedUser.ID, now))
+
+	updatedUser, err := repo.GetUser(GetUserFilter{ID: managedUser.ID})
+	s.Require().NoError(err)
+	s.True(updatedUser.IsDisabled())
+	s.Equal(entity.RoleAdmin, updatedUser.Role)
+	s.True(updatedUser.PasswordResetRequired)
+	s.NotNil(updatedUser.CredentialsChangedAt)
+
+	s.NoError(repo.SetDisabled(managedUser.ID, nil))
+	updatedUser, err = repo.GetUser(GetUserFilter{ID: managedUser.ID})
+	s.Require().NoError(err)
+	s.False(updatedUser.IsDisabled())
+}
+
+func (s *AuthRepositoryTestSuite) TestSessions() {
+	repo := NewRepository(s.db)
+	now := time.Now()
+
+	active := entity.Session{UserID: 77, TokenID: "active-token", LastSeenAt: now.Add(-time.Hour), ExpiresAt: now.Add(time.Hour)}
+	recent := entity.Session{UserID: 77, TokenID: "recent-token", LastSeenAt: now, ExpiresAt: now.Add(time.Hour)}
+	expired := entity.Session{UserID: 77, TokenID: "expired-token", LastSeenAt: now, ExpiresAt: now.Add(-time.Minute)}
+	for _, session := range []*entity.Session{&active, &recent, &expired} {
+		s.Require().NoError(repo.CreateSession(session))
+	}
+
+	fetchedSession, err := repo.GetSession("active-token")
+	s.Require().NoError(err)
+	s.Equal(active.ID, fetchedSession.ID)
+	_, err = repo.GetSession("unknown-token")
+	s.Error(err)
+
+	sessions, err := repo.ListSessions(77, now)
+	s.Require().NoError(err)
+	s.Require().Len(sessions, 2)
+	s.Equal(recent.ID, sessions[0].ID)
+
+	s.NoError(repo.TouchSession(active.ID, now.Add(time.Minute)))
+	sessions, err = repo.ListSessions(77, now)
+	s.Require().NoError(err)
+	s.Equal(active.ID, sessions[0].ID)
+
+	s.ErrorIs(repo.RevokeSession(78, active.ID, now), errSessionNotFound)
+	s.NoError(repo.RevokeSession(77, active.ID, now))
+	s.ErrorIs(repo.RevokeSession(77, active.ID, now), errSessionNotFound)
+
+	sessions, err = repo.ListSessions(77, now)
+	s.Require().NoError(err)
+	s.Require().Len(sessions, 1)
+	s.Equal(recent.ID, sessions[0].ID)
+}
+
+func (s *AuthRepositoryTestSuite) TestEnableMFA() {
+	repo := NewRepository(s.db)
+
+	user := entity.User{Email: "mfa@example.com"}
+	s.Require().NoError(repo.RegisterUser(user))
+
+	fetchedUser, err := repo.GetUser(GetUserFilter{Email: user.Email})
+	s.Require().NoError(err)
+
+	s.NoError(repo.UpdateMFASecret(fetchedUser.ID, "JBSWY3DPEHPK3PXP"))
+	s.NoError(repo.EnableMFA(fetchedUser.ID, time.Now(), []entity.MFARecoveryCode{
+		{UserID: fetchedUser.ID, HashedCode: "first-hash"},
+	}))
+
+	// Enabling it again replaces the previous recovery codes
+	s.NoError(repo.EnableMFA(fetchedUser.ID, time.Now(), []entity.MFARecoveryCode{
+		{UserID: fetchedUser.ID, HashedCode: "second-hash"},
+	}))
+
+	enabledUser, err := repo.GetUser(GetUserFilter{ID: fetchedUser.ID})
+	s.NoError(err)
+	s.Equal("JBSWY3DPEHPK3PXP", enabledUser.MFASecret)
+	s.True(enabledUser.IsMFAEnabled())
+
+	s.ErrorIs(repo.UseRecoveryCode(fetchedUser.ID, "first-hash", time.Now()), errRecoveryCodeNotFound)
+	s.NoError(repo.UseRecoveryCode(fetchedUser.ID, "second-hash", time.Now()))
+	s.ErrorIs(repo.UseRecoveryCode(fetchedUser.ID, "second-hash", time.Now()), errRecoveryCodeNotFound)
+}
+
+func (s *AuthRepositoryTestSuite) TestUseTOTPCounter() {
+	repo := NewRepository(s.db)
+
+	user := entity.User{Email: "totp@example.com"}
+	s.Require().NoError(repo.RegisterUser(user))
+
+	fetchedUser, err := repo.GetUser(GetUserFilter{Email: user.Email})
+	s.Require().NoError(err)
+
+	s.NoError(repo.UseTOTPCounter(fetchedUser.ID, 100))
+	s.ErrorIs(repo.UseTOTPCounter(fetchedUser.ID, 100), errTOTPCounterUsed)
+	s.ErrorIs(repo.UseTOTPCounter(fetchedUser.ID, 99), errTOTPCounterUsed)
+	s.NoError(repo.UseTOTPCounter(fetchedUser.ID, 101))
+
+	updatedUser, err := repo.GetUser(GetUserFilter{ID: fetchedUser.ID})
+	s.NoError(err)
+	s.Equal(int64(101), updatedUser.MFALastCounter)
+}
+
+func (s *AuthRepositoryTestSuite) TestFederatedIdentities() {
+	repo := NewRepository(s.db)
+
+	user := entity.User{Email: "federated@example.com"}
+	identity := entity.FederatedIdentity{Issuer: "https://issuer.example.com", Subject: "42", Email: user.Email}
+	s.Require().NoError(repo.RegisterFederatedUser(&user, &identity))
+	s.NotZero(user.ID)
+	s.Equal(user.ID, identity.UserID)
+
+	fetchedIdentity, err := repo.GetFederatedIdentity("https://issuer.example.com", "42")
+	s.Require().NoError(err)
+	s.Equal(user.ID, fetchedIdentity.UserID)
+	_, err = repo.GetFederatedIdentity("https://other.example.com", "42")
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	s.NoError(repo.CreateFederatedIdentity(&entity.FederatedIdentity{UserID: user.ID, Issuer: "https://other.example.com", Subject: "42"}))
+	s.Error(repo.CreateFederatedIdentity(&entity.FederatedIdentity{UserID: 99, Issuer: "https://issuer.example.com", Subject: "42"}),
+		"Expected identities to be linked to a single user")
+
+	// A failed link rolls back the creation of the user
+	duplicated := entity.User{Email: "duplicated@example.com"}
+	s.Error(repo.RegisterFederatedUser(&duplicated, &entity.FederatedIdentity{Issuer: "https://issuer.example.com", Subject: "42"}))
+	_, err = repo.GetUser(GetUserFilter{Email: duplicated.Email})
+	s.Error(err)
+}
+
 func TestAuthRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(AuthRepositoryTestSuite))
 }
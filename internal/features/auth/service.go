@@ -1,76 +1,1247 @@
 package auth
 
 import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
 	"luizalabs-technical-test/internal/config"
 	"luizalabs-technical-test/internal/pkg/entity"
+	"luizalabs-technical-test/pkg/cache"
 	"luizalabs-technical-test/pkg/constants/str"
 	"luizalabs-technical-test/pkg/crypt"
+	"luizalabs-technical-test/pkg/logger"
+	"luizalabs-technical-test/pkg/mail"
+	"luizalabs-technical-test/pkg/oidc"
+	"luizalabs-technical-test/pkg/password"
 	"luizalabs-technical-test/pkg/token"
+	"luizalabs-technical-test/pkg/totp"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
 )
 
+const (
+	// loginAttemptsCachePrefix namespaces the failed login counters of client IPs in the cache.
+	loginAttemptsCachePrefix = "auth:login-attempts:"
+
+	// verificationTokenPurpose marks the tokens sent by email to confirm the address.
+	verificationTokenPurpose = "email_verification"
+
+	// mfaChallengePurpose marks the tokens returned by the password step of a login requiring MFA.
+	mfaChallengePurpose = "mfa_challenge"
+
+	// recoveryCodeCount defines how many recovery codes are issued on MFA enrolment.
+	recoveryCodeCount = 10
+
+	// recoveryCodeRandomBytes defines the amount of random bytes used to build each recovery code.
+	recoveryCodeRandomBytes = 10
+
+	// resetTokenRandomBytes defines the amount of random bytes used to build password reset tokens.
+	resetTokenRandomBytes = 32
+
+	// accessTokenExpiration defines the lifetime of the access tokens, and so of their sessions.
+	accessTokenExpiration = time.Hour
+
+	// sessionTokenIDRandomBytes defines the amount of random bytes used to build the token ID of each session.
+	sessionTokenIDRandomBytes = 16
+
+	// sessionLastSeenInterval defines how often the last time a session was seen is stored, sparing a write per request.
+	sessionLastSeenInterval = time.Minute
+
+	// sessionUserAgentMaxLength defines how many characters of the User-Agent are stored with each session.
+	sessionUserAgentMaxLength = 512
+
+	// dummyPasswordRandomBytes defines the amount of random bytes used to build the password behind the dummy hash.
+	dummyPasswordRandomBytes = 16
+
+	// federatedStateCachePrefix namespaces the logins started at the identity provider in the cache.
+	federatedStateCachePrefix = "auth:oidc-state:"
+
+	// federatedStateExpiration defines how long the user has to complete a login started at the identity provider.
+	federatedStateExpiration = 10 * time.Minute
+
+	// federatedStateRandomBytes defines the amount of random bytes used to build the state and the nonce of each login.
+	federatedStateRandomBytes = 32
+
+	// tokenIssuer identifies this service as the issuer of the tokens.
+	tokenIssuer = "luizalabs-technical-test"
+)
+
 // ServiceImp defines the interface for the service layer, with a method to retrieve a CEP.
 type ServiceImp interface {
 	RegisterUser(user entity.User) error
-	AuthenticateUser(input AuthenticateUserInput) (string, error)
+	AuthenticateUser(input AuthenticateUserInput) (*AuthenticateUserResponse, error)
+	AuthenticateMFA(input AuthenticateMFAInput) (*AuthenticateUserResponse, error)
+	BeginFederatedLogin() (string, error)
+	CompleteFederatedLogin(input CompleteFederatedLoginInput) (*AuthenticateUserResponse, error)
+	EnrollMFA(userID uint) (*EnrollMFAResponse, error)
+	ConfirmMFA(userID uint, code string) (*ConfirmMFAResponse, error)
+	VerifyEmail(verificationToken string) (*VerifyEmailResponse, error)
+	ForgotPassword(email string)
+	ResetPassword(input ResetPasswordInput) error
+	GetProfile(userID uint) (*UserResponse, error)
+	UpdateProfile(input UpdateProfileInput) (*UserResponse, error)
+	ChangePassword(input ChangePasswordInput) (*AuthenticateUserResponse, error)
+	DeleteAccount(userID uint) error
+	IsTokenRevoked(claims *token.CustomClaims) bool
+	UnlockUser(admin Administrator, userID uint) error
+	ListUsers(filter ListUsersFilter) (*ListUsersResponse, error)
+	DisableUser(admin Administrator, userID uint) error
+	EnableUser(admin Administrator, userID uint) error
+	DeleteUser(admin Administrator, userID uint) error
+	RestoreUser(admin Administrator, userID uint) error
+	ForcePasswordReset(admin Administrator, userID uint) error
+	ChangeRole(admin Administrator, userID uint, role string) error
+	ListSessions(userID uint, currentTokenID string) ([]SessionResponse, error)
+	ListUserSessions(admin Administrator, userID uint) ([]SessionResponse, error)
+	RevokeSession(userID, sessionID uint) error
 }
 
 // service struct implements the serviceImp interface and holds a reference to the repository.
 type service struct {
-	repository     RepositoryImp
-	passwordHasher crypt.PasswordHasher
+	repository          RepositoryImp
+	passwordHasher      crypt.PasswordHasher
+	passwordValidator   password.Validator
+	loginAttemptsCache  *cache.Typed[loginAttempts]
+	federatedStateCache *cache.Typed[federatedLoginState]
+	mailer              mail.Mailer
+	identityProvider    oidc.Provider
+	policy              Policy
+	mutex               *sync.Mutex
+	dummyPasswordHash   string
 }
 
 // NewService creates and returns a new service instance, injecting the repository dependency.
-func NewService(repository RepositoryImp, passwordHasher crypt.PasswordHasher) ServiceImp {
-	return &service{repository, passwordHasher}
+// The identity provider is optional, logins through it are disabled when nil.
+func NewService(
+	repository RepositoryImp,
+	passwordHasher crypt.PasswordHasher,
+	passwordValidator password.Validator,
+	cacheManager cache.Manager,
+	mailer mail.Mailer,
+	identityProvider oidc.Provider,
+	policy Policy,
+) ServiceImp {
+	s := &service{
+		repository, passwordHasher, passwordValidator,
+		cache.NewTyped[loginAttempts](cacheManager), cache.NewTyped[federatedLoginState](cacheManager),
+		mailer, identityProvider, policy, &sync.Mutex{}, str.EmptyString,
+	}
+	if policy.Enumeration.Hardened {
+		s.dummyPasswordHash = s.newDummyPasswordHash()
+	}
+	return s
 }
 
 // RegisterUser registers a new user by hashing their password and saving the user in the repository.
+// The account starts unverified, and a verification link is sent to the informed email.
+// In hardened mode, a taken email is answered like a new one and its owner is warned by email instead.
 func (s *service) RegisterUser(user entity.User) error {
+	if err := s.validatePassword(user.Password, user.Email); err != nil {
+		return err
+	}
+
 	hashedPassword, err := s.passwordHasher.HashPassword(user.Password)
 	if err != nil {
 		return ErrInvalidCredentials.WithErr(err)
 	}
 	user.Password = hashedPassword
+	user.VerifiedAt = nil
 
 	if err = s.repository.RegisterUser(user); err != nil {
+		if s.policy.Enumeration.Hardened {
+			return s.notifyExistingAccount(user.Email, err)
+		}
 		return ErrUserAlreadyExists.WithErr(err)
 	}
+
+	// Note: delivery failures must not undo the registration, the user may ask for a new link later.
+	if err := s.sendVerificationEmail(user.Email); err != nil {
+		logger.Error(err)
+	}
 	return nil
 }
 
+// VerifyEmail validates the token sent by email and marks the account as verified.
+func (s *service) VerifyEmail(verificationToken string) (*VerifyEmailResponse, error) {
+	claims, err := s.validatePurposeToken(verificationToken, verificationTokenPurpose)
+	if err != nil {
+		return nil, ErrInvalidVerificationToken.WithErr(err)
+	}
+
+	user, err := s.repository.GetUser(GetUserFilter{Email: claims.Subject})
+	if err != nil {
+		return nil, ErrInvalidVerificationToken.WithErr(err)
+	}
+
+	if !user.IsVerified() {
+		if err := s.repository.MarkUserVerified(user.ID, time.Now()); err != nil {
+			return nil, ErrOperationFailed.WithErr(err)
+		}
+	}
+
+	return &VerifyEmailResponse{Email: user.Email}, nil
+}
+
 // AuthenticateUser attempts to authenticate a user with the provided credentials.
-func (s *service) AuthenticateUser(input AuthenticateUserInput) (string, error) {
+// Failed attempts are counted per account and per client IP, locking both out with exponential back-off.
+// When MFA is enabled, a challenge token is returned instead of the access token, see AuthenticateMFA.
+// In hardened mode, unknown emails and locked accounts fail with invalid credentials, after the same hash comparison as wrong passwords.
+func (s *service) AuthenticateUser(input AuthenticateUserInput) (*AuthenticateUserResponse, error) {
+	now := time.Now()
+	if err := s.checkIPThrottle(input.IP, now); err != nil {
+		return nil, err
+	}
+
 	user, err := s.repository.GetUser(input.ToPostLoginInputToFilter())
 	if err != nil {
-		return str.EmptyString, ErrUserNotFound.WithErr(err)
+		s.registerIPFailure(input.IP, now)
+		if s.policy.Enumeration.Hardened {
+			s.passwordHasher.CheckPasswordHash(input.Password, s.dummyPasswordHash)
+			return nil, ErrInvalidCredentials.WithStrErr("unknown email: %v", err)
+		}
+		return nil, ErrUserNotFound.WithErr(err)
+	}
+
+	if user.IsLocked(now) {
+		if s.policy.Enumeration.Hardened {
+			s.passwordHasher.CheckPasswordHash(input.Password, user.Password)
+			s.registerIPFailure(input.IP, now)
+			return nil, ErrInvalidCredentials.WithStrErr(
+				"account %d locked until %s", user.ID, user.LockedUntil.Format(time.RFC3339),
+			)
+		}
+		return nil, ErrAccountLocked.WithStrErr(
+			"account %d locked until %s", user.ID, user.LockedUntil.Format(time.RFC3339),
+		)
 	}
 
 	isAutheticated := s.passwordHasher.CheckPasswordHash(input.Password, user.Password)
 	if !isAutheticated {
-		return str.EmptyString, ErrInvalidCredentials.WithErr(err)
+		s.registerIPFailure(input.IP, now)
+		if err := s.registerAccountFailure(*user, now); err != &ErrAccountLocked || !s.policy.Enumeration.Hardened {
+			return nil, err
+		}
+		return nil, ErrInvalidCredentials.WithStrErr("invalid password for account %d, now locked", user.ID)
+	}
+
+	if s.policy.Verification.Required && !user.IsVerified() {
+		return nil, ErrEmailNotVerified.WithStrErr("account %d not verified", user.ID)
+	}
+
+	if err := s.checkAccountStatus(*user); err != nil {
+		return nil, err
+	}
+
+	if s.passwordHasher.NeedsRehash(user.Password) {
+		s.rehashPassword(*user, input.Password)
+	}
+
+	if user.IsMFAEnabled() {
+		// Note: failed attempts are only cleared after the second step, so the counter also covers the codes.
+		return s.challengeMFA(*user)
+	}
+
+	return s.completeLogin(*user, sessionClient{IP: input.IP, UserAgent: input.UserAgent})
+}
+
+// AuthenticateMFA completes a login requiring MFA, checking the TOTP or recovery code against the challenge token.
+// Wrong codes count as failed login attempts, locking the account like wrong passwords.
+func (s *service) AuthenticateMFA(input AuthenticateMFAInput) (*AuthenticateUserResponse, error) {
+	now := time.Now()
+	if err := s.checkIPThrottle(input.IP, now); err != nil {
+		return nil, err
+	}
+
+	claims, err := s.validatePurposeToken(input.MFAToken, mfaChallengePurpose)
+	if err != nil {
+		return nil, ErrInvalidMFAToken.WithErr(err)
+	}
+
+	user, err := s.repository.GetUser(GetUserFilter{Email: claims.Subject})
+	if err != nil {
+		return nil, ErrInvalidMFAToken.WithErr(err)
+	}
+
+	if !user.IsMFAEnabled() {
+		return nil, ErrInvalidMFAToken.WithStrErr("MFA not enabled for account %d", user.ID)
+	}
+
+	if user.IsLocked(now) {
+		return nil, ErrAccountLocked.WithStrErr(
+			"account %d locked until %s", user.ID, user.LockedUntil.Format(time.RFC3339),
+		)
+	}
+
+	if !s.checkMFACode(*user, input.Code, now) {
+		s.registerIPFailure(input.IP, now)
+		if err := s.registerAccountFailure(*user, now); err == &ErrAccountLocked {
+			return nil, err
+		}
+		return nil, ErrInvalidMFACode.WithStrErr("invalid MFA code for account %d", user.ID)
+	}
+
+	if err := s.checkAccountStatus(*user); err != nil {
+		return nil, err
+	}
+
+	return s.completeLogin(*user, sessionClient{IP: input.IP, UserAgent: input.UserAgent})
+}
+
+// BeginFederatedLogin starts a login at the identity provider, returning the URL the user must be redirected to.
+// The PKCE code verifier and the nonce are kept in cache under the random state until the provider calls back.
+func (s *service) BeginFederatedLogin() (string, error) {
+	if s.identityProvider == nil {
+		return "", ErrFederationDisabled.WithStrErr("no identity provider configured")
+	}
+
+	state, err := crypt.GenerateRandomToken(federatedStateRandomBytes)
+	if err != nil {
+		return "", ErrOperationFailed.WithErr(err)
+	}
+	nonce, err := crypt.GenerateRandomToken(federatedStateRandomBytes)
+	if err != nil {
+		return "", ErrOperationFailed.WithErr(err)
+	}
+	codeVerifier, err := oidc.NewCodeVerifier()
+	if err != nil {
+		return "", ErrOperationFailed.WithErr(err)
+	}
+
+	authURL, err := s.identityProvider.AuthCodeURL(state, nonce, codeVerifier)
+	if err != nil {
+		return "", ErrOperationFailed.WithErr(err)
+	}
+
+	s.federatedStateCache.Set(federatedStateCachePrefix+state, federatedLoginState{codeVerifier, nonce}, federatedStateExpiration)
+	return authURL, nil
+}
+
+// CompleteFederatedLogin exchanges the authorization code returned by the identity provider, logging in the linked user.
+// Unknown identities are linked to the account with the same email when the provider verified it, or to a new account.
+// Like password logins, users with MFA enabled get a challenge token, and failures count towards the client IP throttling.
+func (s *service) CompleteFederatedLogin(input CompleteFederatedLoginInput) (*AuthenticateUserResponse, error) {
+	if s.identityProvider == nil {
+		return nil, ErrFederationDisabled.WithStrErr("no identity provider configured")
+	}
+
+	now := time.Now()
+	if err := s.checkIPThrottle(input.IP, now); err != nil {
+		return nil, err
+	}
+
+	state, found := s.consumeFederatedState(input.State)
+	if !found {
+		s.registerIPFailure(input.IP, now)
+		return nil, ErrInvalidFederatedLogin.WithStrErr("unknown or expired state")
+	}
+
+	identity, err := s.identityProvider.Authenticate(input.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		s.registerIPFailure(input.IP, now)
+		return nil, ErrInvalidFederatedLogin.WithErr(err)
+	}
+
+	user, err := s.federatedUser(*identity, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.policy.Verification.Required && !user.IsVerified() {
+		return nil, ErrEmailNotVerified.WithStrErr("account %d not verified", user.ID)
+	}
+
+	if err := s.checkAccountStatus(*user); err != nil {
+		return nil, err
+	}
+
+	if user.IsMFAEnabled() {
+		return s.challengeMFA(*user)
+	}
+
+	return s.completeLogin(*user, sessionClient{IP: input.IP, UserAgent: input.UserAgent})
+}
+
+// EnrollMFA starts the MFA enrolment, generating the TOTP secret to be added to an authenticator app.
+// MFA is only enabled once the enrolment is confirmed with a valid code, see ConfirmMFA.
+func (s *service) EnrollMFA(userID uint) (*EnrollMFAResponse, error) {
+	user, err := s.repository.GetUser(GetUserFilter{ID: userID})
+	if err != nil {
+		return nil, ErrUserNotFound.WithErr(err)
+	}
+
+	if user.IsMFAEnabled() {
+		return nil, ErrMFAAlreadyEnabled.WithStrErr("MFA already enabled for account %d", user.ID)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, ErrOperationFailed.WithErr(err)
+	}
+
+	if err := s.repository.UpdateMFASecret(user.ID, secret); err != nil {
+		return nil, ErrOperationFailed.WithErr(err)
+	}
+
+	return &EnrollMFAResponse{
+		Secret: secret,
+		URI:    totp.URI(s.policy.MFA.Issuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmMFA enables MFA once the user proves the secret was enrolled, issuing the recovery codes.
+func (s *service) ConfirmMFA(userID uint, code string) (*ConfirmMFAResponse, error) {
+	user, err := s.repository.GetUser(GetUserFilter{ID: userID})
+	if err != nil {
+		return nil, ErrUserNotFound.WithErr(err)
+	}
+
+	if user.IsMFAEnabled() {
+		return nil, ErrMFAAlreadyEnabled.WithStrErr("MFA already enabled for account %d", user.ID)
+	}
+
+	if user.MFASecret == str.EmptyString {
+		return nil, ErrMFANotEnrolled.WithStrErr("no pending MFA enrolment for account %d", user.ID)
+	}
+
+	now := time.Now()
+	counter, ok := totp.Match(user.MFASecret, code, now)
+	if !ok {
+		return nil, ErrInvalidMFACode.WithStrErr("invalid MFA code for account %d", user.ID)
+	}
+
+	if err := s.repository.UseTOTPCounter(user.ID, counter); err != nil {
+		return nil, ErrInvalidMFACode.WithErr(err)
+	}
+
+	recoveryCodes, hashedCodes, err := s.generateRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, ErrOperationFailed.WithErr(err)
+	}
+
+	if err := s.repository.EnableMFA(user.ID, now, hashedCodes); err != nil {
+		return nil, ErrOperationFailed.WithErr(err)
+	}
+
+	logger.Warn(fmt.Sprintf("MFA enabled for account %d", user.ID))
+	return &ConfirmMFAResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// ForgotPassword issues a single-use password reset token and sends it by email.
+// Note: the outcome is never reported, and the token is issued in the background so the response takes the same time
+// either way, so the caller can't tell whether the email is registered.
+func (s *service) ForgotPassword(email string) {
+	go s.requestPasswordReset(email)
+}
+
+// requestPasswordReset issues the password reset token of the account registered with the email, if any.
+func (s *service) requestPasswordReset(email string) {
+	user, err := s.repository.GetUser(GetUserFilter{Email: email})
+	if err != nil {
+		logger.Debug("password reset requested for an unknown email")
+		return
+	}
+
+	if err := s.issuePasswordReset(*user); err != nil {
+		logger.Error(err)
+	}
+}
+
+// ResetPassword consumes the reset token and stores the new password.
+// Every access token issued before the change is revoked.
+func (s *service) ResetPassword(input ResetPasswordInput) error {
+	now := time.Now()
+
+	resetToken, err := s.repository.GetPasswordResetToken(crypt.HashToken(input.Token))
+	if err != nil {
+		return ErrInvalidResetToken.WithErr(err)
+	}
+
+	if !resetToken.IsUsable(now) {
+		return ErrInvalidResetToken.WithStrErr("password reset token %d already used or expired", resetToken.ID)
+	}
+
+	if err := s.validatePassword(input.Password, resetToken.User.Email); err != nil {
+		return err
+	}
+
+	hashedPassword, err := s.passwordHasher.HashPassword(input.Password)
+	if err != nil {
+		return ErrOperationFailed.WithErr(err)
+	}
+
+	if err := s.repository.ResetPassword(*resetToken, hashedPassword, now); err != nil {
+		if errors.Is(err, errResetTokenUsed) {
+			return ErrInvalidResetToken.WithErr(err)
+		}
+		return ErrOperationFailed.WithErr(err)
+	}
+
+	logger.Warn(fmt.Sprintf("password reset for account %d, previous sessions revoked", resetToken.UserID))
+	return nil
+}
+
+// GetProfile returns the account of the user.
+func (s *service) GetProfile(userID uint) (*UserResponse, error) {
+	user, err := s.repository.GetUser(GetUserFilter{ID: userID})
+	if err != nil {
+		return nil, ErrUserNotFound.WithErr(err)
+	}
+
+	response := ToUserResponse(*user)
+	return &response, nil
+}
+
+// UpdateProfile updates the account of the user.
+// Changing the email address marks the account as unverified and sends a verification link to the new address.
+func (s *service) UpdateProfile(input UpdateProfileInput) (*UserResponse, error) {
+	user, err := s.repository.GetUser(GetUserFilter{ID: input.UserID})
+	if err != nil {
+		return nil, ErrUserNotFound.WithErr(err)
+	}
+
+	if input.Email == str.EmptyString || strings.EqualFold(input.Email, user.Email) {
+		response := ToUserResponse(*user)
+		return &response, nil
+	}
+
+	if _, err := s.repository.GetUser(GetUserFilter{Email: input.Email}); err == nil {
+		return nil, ErrEmailAlreadyInUse.WithStrErr("email already used by another account")
+	}
+
+	if err := s.repository.UpdateEmail(user.ID, input.Email); err != nil {
+		return nil, ErrOperationFailed.WithErr(err)
+	}
+	user.Email = input.Email
+	user.VerifiedAt = nil
+
+	if err := s.sendVerificationEmail(user.Email); err != nil {
+		logger.Error(err)
+	}
+
+	response := ToUserResponse(*user)
+	return &response, nil
+}
+
+// ChangePassword replaces the password of the user once the current one is confirmed.
+// Every access token issued before the change is revoked, so a new one is returned.
+func (s *service) ChangePassword(input ChangePasswordInput) (*AuthenticateUserResponse, error) {
+	user, err := s.repository.GetUser(GetUserFilter{ID: input.UserID})
+	if err != nil {
+		return nil, ErrUserNotFound.WithErr(err)
+	}
+
+	if !s.passwordHasher.CheckPasswordHash(input.CurrentPassword, user.Password) {
+		return nil, ErrIncorrectPassword.WithStrErr("incorrect current password for account %d", user.ID)
+	}
+
+	if err := s.validatePassword(input.NewPassword, user.Email); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := s.passwordHasher.HashPassword(input.NewPassword)
+	if err != nil {
+		return nil, ErrOperationFailed.WithErr(err)
+	}
+
+	if err := s.repository.ChangePassword(user.ID, hashedPassword, time.Now()); err != nil {
+		return nil, ErrOperationFailed.WithErr(err)
+	}
+
+	logger.Warn(fmt.Sprintf("password changed for account %d, previous sessions revoked", user.ID))
+
+	jwt, err := s.createJWTToken(*user, sessionClient{IP: input.IP, UserAgent: input.UserAgent})
+	if err != nil {
+		return nil, ErrFailedJWTGeneration.WithErr(err)
+	}
+	return &AuthenticateUserResponse{JWTToken: jwt, UserID: user.ID, TenantID: user.OrganizationID}, nil
+}
+
+// DeleteAccount deletes the account of the user, revoking every access token previously issued.
+func (s *service) DeleteAccount(userID uint) error {
+	user, err := s.repository.GetUser(GetUserFilter{ID: userID})
+	if err != nil {
+		return ErrUserNotFound.WithErr(err)
+	}
+
+	if err := s.repository.DeleteUser(user.ID); err != nil {
+		return ErrOperationFailed.WithErr(err)
+	}
+
+	logger.Warn(fmt.Sprintf("account %d deleted by its owner", user.ID))
+	return nil
+}
+
+// IsTokenRevoked reports whether the access token was issued before the last credentials change of its user,
+// its user was disabled or its session was terminated.
+func (s *service) IsTokenRevoked(claims *token.CustomClaims) bool {
+	userID := claims.UintKey("ID")
+	if userID == 0 {
+		// Note: tokens not issued to users (e.g. OAuth2 clients) are not affected by credential changes.
+		return false
+	}
+
+	user, err := s.repository.GetUser(GetUserFilter{ID: userID})
+	if err != nil {
+		return true
+	}
+	if user.IsDisabled() || user.IsTokenRevoked(claims.IssuedAt) {
+		return true
+	}
+
+	if claims.Id == str.EmptyString {
+		// Note: tokens issued before sessions were recorded are only bound to the credentials, until they expire.
+		return false
+	}
+	return s.isSessionRevoked(user.ID, claims.Id)
+}
+
+// ListSessions returns the active sessions of the user, marking the one of the access token in use.
+func (s *service) ListSessions(userID uint, currentTokenID string) ([]SessionResponse, error) {
+	user, err := s.repository.GetUser(GetUserFilter{ID: userID})
+	if err != nil {
+		return nil, ErrUserNotFound.WithErr(err)
+	}
+	return s.activeSessions(*user, currentTokenID)
+}
+
+// ListUserSessions lists the active sessions of an account on behalf of an administrator.
+func (s *service) ListUserSessions(admin Administrator, userID uint) ([]SessionResponse, error) {
+	user, err := s.tenantUser(admin, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.activeSessions(*user, str.EmptyString)
+}
+
+// activeSessions lists the sessions of the user whose access tokens are still valid.
+func (s *service) activeSessions(user entity.User, currentTokenID string) ([]SessionResponse, error) {
+	sessions, err := s.repository.ListSessions(user.ID, time.Now())
+	if err != nil {
+		return nil, ErrOperationFailed.WithErr(err)
+	}
+
+	response := make([]SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		// Note: sessions started before the last credentials change have their tokens revoked already.
+		if user.IsTokenRevoked(session.CreatedAt.Unix()) {
+			continue
+		}
+		response = append(response, ToSessionResponse(session, currentTokenID))
+	}
+	return response, nil
+}
+
+// RevokeSession terminates a session of the user, revoking the access token bound to it.
+func (s *service) RevokeSession(userID, sessionID uint) error {
+	if err := s.repository.RevokeSession(userID, sessionID, time.Now()); err != nil {
+		if errors.Is(err, errSessionNotFound) {
+			return ErrSessionNotFound.WithErr(err)
+		}
+		return ErrOperationFailed.WithErr(err)
 	}
 
-	jwt, err := s.createJWTToken(*user)
+	logger.Warn(fmt.Sprintf("session %d of account %d terminated", sessionID, userID))
+	return nil
+}
+
+// UnlockUser clears the failed login counter and lockout of an account on behalf of an administrator.
+func (s *service) UnlockUser(admin Administrator, userID uint) error {
+	user, err := s.tenantUser(admin, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repository.UpdateLoginAttempts(user.ID, 0, nil); err != nil {
+		return ErrOperationFailed.WithErr(err)
+	}
+
+	logger.Warn(fmt.Sprintf("account %d unlocked by admin %d", user.ID, admin.ID))
+	return nil
+}
+
+// ListUsers returns a page of the registered users on behalf of an administrator.
+func (s *service) ListUsers(filter ListUsersFilter) (*ListUsersResponse, error) {
+	users, total, err := s.repository.ListUsers(filter)
+	if err != nil {
+		return nil, ErrOperationFailed.WithErr(err)
+	}
+
+	response := ListUsersResponse{
+		Users:    make([]AdminUserResponse, 0, len(users)),
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+		Total:    total,
+	}
+	for _, user := range users {
+		response.Users = append(response.Users, ToAdminUserResponse(user))
+	}
+	return &response, nil
+}
+
+// DisableUser blocks the logins of an account on behalf of an administrator, revoking its access tokens.
+func (s *service) DisableUser(admin Administrator, userID uint) error {
+	user, err := s.administeredUser(admin, userID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := s.repository.SetDisabled(user.ID, &now); err != nil {
+		return ErrOperationFailed.WithErr(err)
+	}
+
+	logger.Warn(fmt.Sprintf("account %d disabled by admin %d", user.ID, admin.ID))
+	return nil
+}
+
+// EnableUser allows the logins of a disabled account again on behalf of an administrator.
+func (s *service) EnableUser(admin Administrator, userID uint) error {
+	user, err := s.administeredUser(admin, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repository.SetDisabled(user.ID, nil); err != nil {
+		return ErrOperationFailed.WithErr(err)
+	}
+
+	logger.Warn(fmt.Sprintf("account %d enabled by admin %d", user.ID, admin.ID))
+	return nil
+}
+
+// DeleteUser soft deletes an account on behalf of an administrator. The account can be restored, see RestoreUser.
+func (s *service) DeleteUser(admin Administrator, userID uint) error {
+	user, err := s.administeredUser(admin, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repository.DeleteUser(user.ID); err != nil {
+		return ErrOperationFailed.WithErr(err)
+	}
+
+	logger.Warn(fmt.Sprintf("account %d deleted by admin %d", user.ID, admin.ID))
+	return nil
+}
+
+// RestoreUser reverts the deletion of an account on behalf of an administrator.
+func (s *service) RestoreUser(admin Administrator, userID uint) error {
+	if admin.ID == userID {
+		return ErrSelfAdministration.WithStrErr("admin %d tried to restore their own account", admin.ID)
+	}
+
+	if err := s.repository.RestoreUser(userID, admin.TenantID); err != nil {
+		if errors.Is(err, errUserNotDeleted) {
+			return ErrUserNotFound.WithErr(err)
+		}
+		return ErrOperationFailed.WithErr(err)
+	}
+
+	logger.Warn(fmt.Sprintf("account %d restored by admin %d", userID, admin.ID))
+	return nil
+}
+
+// ForcePasswordReset blocks the logins of an account until its password is reset, on behalf of an administrator.
+// Every access token previously issued is revoked and a password reset email is sent to the user.
+func (s *service) ForcePasswordReset(admin Administrator, userID uint) error {
+	user, err := s.administeredUser(admin, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repository.ForcePasswordReset(user.ID, time.Now()); err != nil {
+		return ErrOperationFailed.WithErr(err)
+	}
+
+	logger.Warn(fmt.Sprintf("password reset of account %d required by admin %d", user.ID, admin.ID))
+	if err := s.issuePasswordReset(*user); err != nil {
+		logger.Error(err)
+	}
+	return nil
+}
+
+// ChangeRole changes the role of an account on behalf of an administrator.
+// Every access token previously issued is revoked, as it carries the previous role.
+func (s *service) ChangeRole(admin Administrator, userID uint, role string) error {
+	user, err := s.administeredUser(admin, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.Role == role {
+		return nil
+	}
+
+	if err := s.repository.UpdateRole(user.ID, role, time.Now()); err != nil {
+		return ErrOperationFailed.WithErr(err)
+	}
+
+	logger.Warn(fmt.Sprintf("role of account %d changed from %s to %s by admin %d", user.ID, user.Role, role, admin.ID))
+	return nil
+}
+
+// isSessionRevoked reports whether the session bound to the token ID is no longer active,
+// recording the last time it was seen otherwise.
+func (s *service) isSessionRevoked(userID uint, tokenID string) bool {
+	session, err := s.repository.GetSession(tokenID)
+	if err != nil {
+		return true
+	}
+
+	now := time.Now()
+	if session.UserID != userID || !session.IsActive(now) {
+		return true
+	}
+
+	if now.Sub(session.LastSeenAt) >= sessionLastSeenInterval {
+		if err := s.repository.TouchSession(session.ID, now); err != nil {
+			logger.Error(err)
+		}
+	}
+	return false
+}
+
+// administeredUser fetches the account targeted by an administrative action.
+// Note: administrators can't target their own account, so they can't lock themselves out.
+func (s *service) administeredUser(admin Administrator, userID uint) (*entity.User, error) {
+	if admin.ID == userID {
+		return nil, ErrSelfAdministration.WithStrErr("admin %d tried to administer their own account", admin.ID)
+	}
+	return s.tenantUser(admin, userID)
+}
+
+// tenantUser fetches an account visible to the administrator.
+// Note: accounts of other organizations are reported as not found, so their existence isn't disclosed.
+func (s *service) tenantUser(admin Administrator, userID uint) (*entity.User, error) {
+	user, err := s.repository.GetUser(GetUserFilter{ID: userID})
+	if err != nil {
+		return nil, ErrUserNotFound.WithErr(err)
+	}
+
+	if admin.TenantID != 0 && user.OrganizationID != admin.TenantID {
+		return nil, ErrUserNotFound.WithStrErr("admin %d of organization %d tried to administer account %d", admin.ID, admin.TenantID, user.ID)
+	}
+	return user, nil
+}
+
+// consumeFederatedState retrieves the login started with the state, forgetting it so the callback can't be replayed.
+func (s *service) consumeFederatedState(state string) (federatedLoginState, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	loginState, found := s.federatedStateCache.Get(federatedStateCachePrefix + state)
+	if !found {
+		return federatedLoginState{}, false
+	}
+
+	// Note: the entry is removed, so the callback can only be completed once.
+	s.federatedStateCache.Delete(federatedStateCachePrefix + state)
+	return loginState, true
+}
+
+// federatedUser returns the user linked to the identity, linking it on its first login.
+// Note: an identity is only linked to an existing account when the provider verified the email,
+// otherwise anyone registering the address at the provider could take the account over.
+func (s *service) federatedUser(identity oidc.Identity, now time.Time) (*entity.User, error) {
+	linked, err := s.repository.GetFederatedIdentity(identity.Issuer, identity.Subject)
+	if err == nil {
+		user, err := s.repository.GetUser(GetUserFilter{ID: linked.UserID})
+		if err != nil {
+			return nil, ErrInvalidFederatedLogin.WithErr(err)
+		}
+		return user, nil
+	}
+
+	if identity.Email == str.EmptyString {
+		return nil, ErrInvalidFederatedLogin.WithStrErr("identity %s of %s without email", identity.Subject, identity.Issuer)
+	}
+
+	federatedIdentity := entity.FederatedIdentity{Issuer: identity.Issuer, Subject: identity.Subject, Email: identity.Email}
+
+	user, err := s.repository.GetUser(GetUserFilter{Email: identity.Email})
+	if err != nil {
+		// Note: the account has no password, so it can only log in through the provider until one is reset.
+		user = &entity.User{Email: identity.Email, Role: entity.RoleUser}
+		if identity.EmailVerified {
+			user.VerifiedAt = &now
+		}
+		if err := s.repository.RegisterFederatedUser(user, &federatedIdentity); err != nil {
+			return nil, ErrOperationFailed.WithErr(err)
+		}
+		logger.Warn(fmt.Sprintf("account %d registered through %s", user.ID, identity.Issuer))
+
+		if !user.IsVerified() {
+			if err := s.sendVerificationEmail(user.Email); err != nil {
+				logger.Error(err)
+			}
+		}
+		return user, nil
+	}
+
+	if !identity.EmailVerified {
+		return nil, ErrFederatedEmailUnverified.WithStrErr("identity %s of %s not verified for account %d", identity.Subject, identity.Issuer, user.ID)
+	}
+
+	federatedIdentity.UserID = user.ID
+	if err := s.repository.CreateFederatedIdentity(&federatedIdentity); err != nil {
+		return nil, ErrOperationFailed.WithErr(err)
+	}
+	if !user.IsVerified() {
+		if err := s.repository.MarkUserVerified(user.ID, now); err != nil {
+			logger.Error(err)
+		}
+		user.VerifiedAt = &now
+	}
+
+	logger.Warn(fmt.Sprintf("identity of %s linked to account %d", identity.Issuer, user.ID))
+	return user, nil
+}
+
+// checkAccountStatus blocks the logins of accounts disabled or required to reset their password.
+// Note: it must only run after the credentials are checked, so the status isn't disclosed to anyone else.
+func (s *service) checkAccountStatus(user entity.User) error {
+	if user.IsDisabled() {
+		return ErrAccountDisabled.WithStrErr("account %d disabled", user.ID)
+	}
+	if user.PasswordResetRequired {
+		return ErrPasswordResetRequired.WithStrErr("account %d required to reset its password", user.ID)
+	}
+	return nil
+}
+
+// issuePasswordReset stores a single-use password reset token for the user and sends it by email.
+func (s *service) issuePasswordReset(user entity.User) error {
+	resetToken, err := crypt.GenerateRandomToken(resetTokenRandomBytes)
+	if err != nil {
+		return err
+	}
+
+	err = s.repository.CreatePasswordResetToken(&entity.PasswordResetToken{
+		UserID:      user.ID,
+		HashedToken: crypt.HashToken(resetToken),
+		ExpiresAt:   time.Now().Add(s.policy.PasswordReset.TokenExpiration),
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.sendPasswordResetEmail(user.Email, resetToken)
+}
+
+// completeLogin clears the failed login attempts of the account and issues its access token.
+func (s *service) completeLogin(user entity.User, client sessionClient) (*AuthenticateUserResponse, error) {
+	if user.FailedLoginAttempts > 0 {
+		if err := s.repository.UpdateLoginAttempts(user.ID, 0, nil); err != nil {
+			logger.Error(err)
+		}
+	}
+
+	jwt, err := s.createJWTToken(user, client)
+	if err != nil {
+		return nil, ErrFailedJWTGeneration.WithErr(err)
+	}
+
+	return &AuthenticateUserResponse{JWTToken: jwt, UserID: user.ID, TenantID: user.OrganizationID}, nil
+}
+
+// challengeMFA issues the token exchanged, along with a TOTP or recovery code, for the access token of the user.
+func (s *service) challengeMFA(user entity.User) (*AuthenticateUserResponse, error) {
+	mfaToken, err := s.createPurposeToken(user.Email, mfaChallengePurpose, s.policy.MFA.ChallengeExpiration)
+	if err != nil {
+		return nil, ErrFailedJWTGeneration.WithErr(err)
+	}
+	return &AuthenticateUserResponse{MFARequired: true, MFAToken: mfaToken, UserID: user.ID, TenantID: user.OrganizationID}, nil
+}
+
+// checkMFACode checks the code against the TOTP secret, falling back to the unused recovery codes of the user.
+// Note: a TOTP code is refused once a code of the same or a later period was accepted, so it can't be replayed.
+func (s *service) checkMFACode(user entity.User, code string, now time.Time) bool {
+	if counter, ok := totp.Match(user.MFASecret, code, now); ok {
+		if err := s.repository.UseTOTPCounter(user.ID, counter); err != nil {
+			logger.Warn(fmt.Sprintf("replayed MFA code refused for account %d", user.ID))
+			return false
+		}
+		return true
+	}
+
+	err := s.repository.UseRecoveryCode(user.ID, crypt.HashToken(normalizeRecoveryCode(code)), now)
+	if err != nil {
+		return false
+	}
+
+	logger.Warn(fmt.Sprintf("recovery code used by account %d", user.ID))
+	return true
+}
+
+// generateRecoveryCodes returns the plain recovery codes, formatted for display, along with their hashed entities.
+func (*service) generateRecoveryCodes(userID uint) ([]string, []entity.MFARecoveryCode, error) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+
+	recoveryCodes := make([]string, 0, recoveryCodeCount)
+	hashedCodes := make([]entity.MFARecoveryCode, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		buffer := make([]byte, recoveryCodeRandomBytes)
+		if _, err := rand.Read(buffer); err != nil {
+			return nil, nil, err
+		}
+
+		// Formats 16 characters as "xxxx-xxxx-xxxx-xxxx", easier to copy by hand
+		code := strings.ToLower(encoding.EncodeToString(buffer))
+		recoveryCodes = append(recoveryCodes, code[0:4]+"-"+code[4:8]+"-"+code[8:12]+"-"+code[12:16])
+		hashedCodes = append(hashedCodes, entity.MFARecoveryCode{UserID: userID, HashedCode: crypt.HashToken(code)})
+	}
+	return recoveryCodes, hashedCodes, nil
+}
+
+// normalizeRecoveryCode reverts the display formatting of a recovery code.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+// rehashPassword upgrades a hash using an outdated algorithm or cost, while the plain password is at hand.
+// Note: failures are only logged, as the current hash remains valid.
+func (s *service) rehashPassword(user entity.User, plainPassword string) {
+	hashedPassword, err := s.passwordHasher.HashPassword(plainPassword)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	if err := s.repository.UpdatePasswordHash(user.ID, user.Password, hashedPassword); err != nil {
+		logger.Error(err)
+	}
+}
+
+// validatePassword checks the password against the password policy, mapping each violation to its error.
+func (s *service) validatePassword(plainPassword, email string) error {
+	err := s.passwordValidator.Validate(plainPassword, email)
+	if err == nil {
+		return nil
+	}
+
+	if violation, found := passwordViolations[err]; found {
+		return violation.WithErr(err)
+	}
+	return ErrOperationFailed.WithErr(err)
+}
+
+// registerAccountFailure increments the failed login counter of the user, locking the account once the limit is reached.
+// Note: the counter is incremented by the database, as concurrent failures would overwrite each other's count.
+func (s *service) registerAccountFailure(user entity.User, now time.Time) error {
+	attempts, err := s.repository.IncrementLoginAttempts(user.ID)
+	if err != nil {
+		logger.Error(err)
+		attempts = user.FailedLoginAttempts + 1
+	}
+
+	lockout := s.policy.Lockout.LockoutFor(attempts, s.policy.Lockout.MaxAttempts)
+	if lockout <= 0 {
+		return ErrInvalidCredentials.WithStrErr("invalid password for account %d", user.ID)
+	}
+
+	lockedUntil := now.Add(lockout)
+	if err := s.repository.LockAccount(user.ID, lockedUntil); err != nil {
+		logger.Error(err)
+	}
+
+	logger.Warn(fmt.Sprintf(
+		"account %d locked until %s after %d failed login attempts",
+		user.ID, lockedUntil.Format(time.RFC3339), attempts,
+	))
+	return ErrAccountLocked.WithStrErr("account %d locked until %s", user.ID, lockedUntil.Format(time.RFC3339))
+}
+
+// checkIPThrottle rejects the login attempts of a client IP throttled after repeated failures.
+func (s *service) checkIPThrottle(ip string, now time.Time) error {
+	if attempts := s.ipLoginAttempts(ip); now.Before(attempts.LockedUntil) {
+		return ErrTooManyAttempts.WithStrErr("client %s throttled until %s", ip, attempts.LockedUntil.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// registerIPFailure increments the failed login counter of the client IP, throttling it once the limit is reached.
+func (s *service) registerIPFailure(ip string, now time.Time) {
+	if ip == str.EmptyString {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	attempts := s.ipLoginAttempts(ip)
+	attempts.Count++
+
+	if lockout := s.policy.Lockout.LockoutFor(attempts.Count, s.policy.Lockout.MaxAttemptsPerIP); lockout > 0 {
+		attempts.LockedUntil = now.Add(lockout)
+		logger.Warn(fmt.Sprintf(
+			"client %s throttled until %s after %d failed login attempts",
+			ip, attempts.LockedUntil.Format(time.RFC3339), attempts.Count,
+		))
+	}
+
+	// Note: the counter is forgotten once the client stays quiet for the longest lockout.
+	s.loginAttemptsCache.Set(loginAttemptsCachePrefix+ip, attempts, s.policy.Lockout.MaxLockoutDuration)
+}
+
+// ipLoginAttempts retrieves the failed login attempts of the client IP from the cache.
+func (s *service) ipLoginAttempts(ip string) loginAttempts {
+	attempts, _ := s.loginAttemptsCache.Get(loginAttemptsCachePrefix + ip)
+	return attempts
+}
+
+// sendVerificationEmail sends the link confirming the ownership of the email address.
+func (s *service) sendVerificationEmail(email string) error {
+	verificationToken, err := s.createPurposeToken(email, verificationTokenPurpose, s.policy.Verification.TokenExpiration)
+	if err != nil {
+		return err
+	}
+
+	link := s.policy.Verification.URL + "?token=" + url.QueryEscape(verificationToken)
+	return s.mailer.Send(mail.Message{
+		To:      email,
+		Subject: "Confirme seu endereço de e-mail",
+		Body: fmt.Sprintf(
+			"Olá!\n\nPara confirmar seu endereço de e-mail, acesse o link abaixo:\n\n%s\n\n"+
+				"O link expira em %s. Se você não criou uma conta, ignore esta mensagem.",
+			link, s.policy.Verification.TokenExpiration,
+		),
+	})
+}
+
+// notifyExistingAccount answers, in hardened mode, a registration with a taken email like a successful one,
+// warning the owner of the account by email instead.
+func (s *service) notifyExistingAccount(email string, registerErr error) error {
+	if _, err := s.repository.GetUser(GetUserFilter{Email: email}); err != nil {
+		return ErrOperationFailed.WithErr(registerErr)
+	}
+
+	logger.Debug("registration attempted with a registered email")
+	if err := s.sendAccountExistsEmail(email); err != nil {
+		logger.Error(err)
+	}
+	return nil
+}
+
+// newDummyPasswordHash hashes a random password, checked against the passwords of unknown emails in hardened mode.
+// Note: on failure the comparisons become instant, weakening only the timing protection.
+func (s *service) newDummyPasswordHash() string {
+	dummyPassword, err := crypt.GenerateRandomToken(dummyPasswordRandomBytes)
 	if err != nil {
-		return str.EmptyString, ErrFailedJWTGeneration.WithErr(err)
+		logger.Error(err)
+		return str.EmptyString
 	}
 
-	return jwt, nil
+	hash, err := s.passwordHasher.HashPassword(dummyPassword)
+	if err != nil {
+		logger.Error(err)
+		return str.EmptyString
+	}
+	return hash
+}
+
+// sendAccountExistsEmail warns the owner of an account that someone tried to register with their email.
+func (s *service) sendAccountExistsEmail(email string) error {
+	return s.mailer.Send(mail.Message{
+		To:      email,
+		Subject: "Tentativa de cadastro com seu e-mail",
+		Body: "Olá!\n\nRecebemos uma solicitação de cadastro com este endereço de e-mail, mas ele já possui uma conta. " +
+			"Se foi você, faça login ou redefina sua senha caso não se lembre dela.\n\n" +
+			"Se você não fez essa solicitação, ignore esta mensagem.",
+	})
 }
 
-// createJWTToken generates a JWT token for the provided user.
-func (*service) createJWTToken(user entity.User) (string, error) {
+// sendPasswordResetEmail sends the token allowing the user to choose a new password.
+func (s *service) sendPasswordResetEmail(email, resetToken string) error {
+	instructions := "Para redefinir sua senha, utilize o código abaixo:\n\n" + resetToken
+	if s.policy.PasswordReset.URL != str.EmptyString {
+		instructions = "Para redefinir sua senha, acesse o link abaixo:\n\n" +
+			s.policy.PasswordReset.URL + "?token=" + url.QueryEscape(resetToken)
+	}
+
+	return s.mailer.Send(mail.Message{
+		To:      email,
+		Subject: "Redefinição de senha",
+		Body: fmt.Sprintf(
+			"Olá!\n\n%s\n\nO código expira em %s e só pode ser utilizado uma vez. "+
+				"Se você não solicitou a redefinição, ignore esta mensagem.",
+			instructions, s.policy.PasswordReset.TokenExpiration,
+		),
+	})
+}
+
+// createJWTToken records a new session of the user and generates the access token bound to it.
+func (s *service) createJWTToken(user entity.User, client sessionClient) (string, error) {
+	tokenID, err := crypt.GenerateRandomToken(sessionTokenIDRandomBytes)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	session := entity.Session{
+		UserID:     user.ID,
+		TokenID:    tokenID,
+		UserAgent:  truncate(client.UserAgent, sessionUserAgentMaxLength),
+		IP:         client.IP,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(accessTokenExpiration),
+	}
+	// Note: the session shares the creation time of the token, so both are revoked by the same credentials change.
+	session.CreatedAt = now
+	if err := s.repository.CreateSession(&session); err != nil {
+		return "", err
+	}
+
 	claims := token.CustomClaims{
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(time.Hour).Unix(),
-			Issuer:    "luizalabs-technical-test",
+			Id:        tokenID,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: session.ExpiresAt.Unix(),
+			Issuer:    tokenIssuer,
 		},
 		CustomKeys: user.ToJSONClaims(),
 	}
 
 	return token.CreateToken(config.GeneralConfig.SecretAuthTokenKey, claims)
 }
+
+// truncate limits the string to the given amount of characters, keeping multibyte characters intact.
+func truncate(value string, maxLength int) string {
+	runes := []rune(value)
+	if len(runes) <= maxLength {
+		return value
+	}
+	return string(runes[:maxLength])
+}
+
+// createPurposeToken generates a short-lived JWT token that is only valid for the given purpose.
+// Note: the token middleware rejects every token carrying a purpose, so they can't be used as access tokens.
+func (*service) createPurposeToken(subject, purpose string, expiration time.Duration) (string, error) {
+	claims := token.CustomClaims{
+		StandardClaims: jwt.StandardClaims{
+			Subject:   subject,
+			ExpiresAt: time.Now().Add(expiration).Unix(),
+			Issuer:    tokenIssuer,
+		},
+		CustomKeys: map[string]any{token.PurposeClaimName: purpose},
+	}
+
+	return token.CreateToken(config.GeneralConfig.SecretAuthTokenKey, claims)
+}
+
+// validatePurposeToken validates the token and checks that it was issued for the given purpose.
+func (*service) validatePurposeToken(tokenString, purpose string) (*token.CustomClaims, error) {
+	claims, err := token.ValidateToken(config.GeneralConfig.SecretAuthTokenKey, tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.StringKey(token.PurposeClaimName) != purpose {
+		return nil, fmt.Errorf("token issued for another purpose: %s", claims.StringKey(token.PurposeClaimName))
+	}
+	return claims, nil
+}
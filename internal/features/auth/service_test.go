@@ -2,12 +2,27 @@ package auth_test
 
 import (
 	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"luizalabs-technical-test/internal/features/auth"
 	authMock "luizalabs-technical-test/internal/features/auth/mock"
 	"luizalabs-technical-test/internal/pkg/entity"
+	"luizalabs-technical-test/pkg/cache"
+	"luizalabs-technical-test/pkg/crypt"
 	cryptMock "luizalabs-technical-test/pkg/crypt/mock"
+	"luizalabs-technical-test/pkg/mail"
+	mailMock "luizalabs-technical-test/pkg/mail/mock"
+	"luizalabs-technical-test/pkg/oidc"
+	"luizalabs-technical-test/pkg/oidc/oidctest"
+	"luizalabs-technical-test/pkg/password"
+	"luizalabs-technical-test/pkg/token"
+	"luizalabs-technical-test/pkg/totp"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -20,15 +35,76 @@ type AuthServiceTestSuite struct {
 	ctrl        *gomock.Controller
 	repoMock    *authMock.MockRepositoryImp
 	cryptMock   *cryptMock.MockPasswordHasher
+	mailMock    *mailMock.MockMailer
+	oidcServer  *oidctest.Server
 	authService auth.ServiceImp
 }
 
+// SetupSuite starts the stub identity provider shared by the federated login tests.
+func (suite *AuthServiceTestSuite) SetupSuite() {
+	suite.oidcServer = oidctest.NewServer("client", "secret")
+}
+
+// TearDownSuite stops the stub identity provider.
+func (suite *AuthServiceTestSuite) TearDownSuite() {
+	suite.oidcServer.Close()
+}
+
 // SetupTest initializes the test suite, creating a new mock controller and instances of mocks.
 func (suite *AuthServiceTestSuite) SetupTest() {
 	suite.ctrl = gomock.NewController(suite.T())
 	suite.repoMock = authMock.NewMockRepositoryImp(suite.ctrl)
 	suite.cryptMock = cryptMock.NewMockPasswordHasher(suite.ctrl)
-	suite.authService = auth.NewService(suite.repoMock, suite.cryptMock)
+	suite.mailMock = mailMock.NewMockMailer(suite.ctrl)
+	suite.authService = suite.newService(auth.Policy{
+		Lockout:       lockoutPolicy,
+		Verification:  verificationPolicy,
+		PasswordReset: auth.PasswordResetPolicy{TokenExpiration: 30 * time.Minute},
+		MFA:           auth.MFAPolicy{Issuer: "luizalabs", ChallengeExpiration: 5 * time.Minute},
+	})
+}
+
+// newService creates the service under test with the given policy.
+func (suite *AuthServiceTestSuite) newService(policy auth.Policy) auth.ServiceImp {
+	breachedList, err := password.NewBreachedList(strings.NewReader(breachedPasswordHash))
+	suite.Require().NoError(err)
+
+	passwordValidator := password.NewValidator(passwordPolicy, breachedList)
+	identityProvider := oidc.NewProvider(oidc.Config{
+		Issuer:       suite.oidcServer.URL,
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost:8080/v1/auth/oidc/callback",
+		Scopes:       []string{"openid", "email"},
+	}, http.DefaultClient)
+
+	return auth.NewService(
+		suite.repoMock, suite.cryptMock, passwordValidator, cache.NewManager(time.Minute), suite.mailMock, identityProvider, policy,
+	)
+}
+
+// passwordPolicy is the password policy used across the tests.
+var passwordPolicy = password.Policy{
+	MinLength:   8,
+	MaxLength:   64,
+	ForbidEmail: true,
+}
+
+// breachedPasswordHash is the SHA-1 hash of "letmein2024", the only breached password known by the tests.
+const breachedPasswordHash = "936FA92E3681CD1979871D76998D392BB9C1699A:42"
+
+// lockoutPolicy is the login throttling policy used across the tests.
+var lockoutPolicy = auth.LockoutPolicy{
+	MaxAttempts:        3,
+	MaxAttemptsPerIP:   2,
+	LockoutDuration:    time.Minute,
+	MaxLockoutDuration: time.Hour,
+}
+
+// verificationPolicy is the email verification policy used across the tests.
+var verificationPolicy = auth.VerificationPolicy{
+	TokenExpiration: time.Hour,
+	URL:             "http://localhost:8080/v1/auth/verify",
 }
 
 // TearDownTest cleans up the mock controller after each test.
@@ -87,8 +163,203 @@ func (suite *AuthServiceTestSuite) TestRegisterUser_Success() {
 		RegisterUser(gomock.Any()).
 		Return(nil)
 
+	var sentMessage mail.Message
+	suite.mailMock.EXPECT().
+		Send(gomock.Any()).
+		DoAndReturn(func(message mail.Message) error {
+			sentMessage = message
+			return nil
+		})
+
 	err := suite.authService.RegisterUser(user)
 	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), user.Email, sentMessage.To)
+	assert.Contains(suite.T(), sentMessage.Body, verificationPolicy.URL+"?token=")
+}
+
+// TestRegisterUser_FailedToSendEmail tests that a mail delivery failure does not undo the registration.
+func (suite *AuthServiceTestSuite) TestRegisterUser_FailedToSendEmail() {
+	suite.cryptMock.EXPECT().
+		HashPassword(gomock.Any()).
+		Return("hashedPassword", nil)
+
+	suite.repoMock.EXPECT().
+		RegisterUser(gomock.Any()).
+		Return(nil)
+
+	suite.mailMock.EXPECT().
+		Send(gomock.Any()).
+		Return(errors.New("connection refused"))
+
+	err := suite.authService.RegisterUser(entity.User{Email: "user@example.com", Password: "password123"})
+	assert.NoError(suite.T(), err)
+}
+
+// TestRegisterUser_HardenedEmailTaken tests that a taken email is answered like a new one in hardened mode,
+// warning the owner of the account by email.
+func (suite *AuthServiceTestSuite) TestRegisterUser_HardenedEmailTaken() {
+	authService := suite.newHardenedService()
+
+	suite.cryptMock.EXPECT().
+		HashPassword(gomock.Any()).
+		Return("hashedPassword", nil)
+
+	suite.repoMock.EXPECT().
+		RegisterUser(gomock.Any()).
+		Return(errors.New("duplicated key"))
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{Email: "user@example.com"}).
+		Return(&entity.User{Email: "user@example.com"}, nil)
+
+	var sentMessage mail.Message
+	suite.mailMock.EXPECT().
+		Send(gomock.Any()).
+		DoAndReturn(func(message mail.Message) error {
+			sentMessage = message
+			return nil
+		})
+
+	err := authService.RegisterUser(entity.User{Email: "user@example.com", Password: "password123"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "user@example.com", sentMessage.To)
+	assert.Contains(suite.T(), sentMessage.Body, "já possui uma conta")
+	assert.NotContains(suite.T(), sentMessage.Body, "?token=")
+}
+
+// TestRegisterUser_HardenedFailedToRegister tests that, in hardened mode, failures unrelated to a taken email are still reported.
+func (suite *AuthServiceTestSuite) TestRegisterUser_HardenedFailedToRegister() {
+	authService := suite.newHardenedService()
+
+	suite.cryptMock.EXPECT().
+		HashPassword(gomock.Any()).
+		Return("hashedPassword", nil)
+
+	suite.repoMock.EXPECT().
+		RegisterUser(gomock.Any()).
+		Return(errors.New("connection refused"))
+
+	suite.repoMock.EXPECT().
+		GetUser(gomock.Any()).
+		Return(nil, errors.New("connection refused"))
+
+	err := authService.RegisterUser(entity.User{Email: "user@example.com", Password: "password123"})
+	assert.Equal(suite.T(), &auth.ErrOperationFailed, err)
+}
+
+// TestRegisterUser_PasswordPolicyViolations tests that weak passwords are rejected before being hashed.
+func (suite *AuthServiceTestSuite) TestRegisterUser_PasswordPolicyViolations() {
+	tests := []struct {
+		name     string
+		password string
+		expected error
+	}{
+		{"too short", "short", &auth.ErrPasswordTooShort},
+		{"too long", strings.Repeat("a", 65), &auth.ErrPasswordTooLong},
+		{"contains email", "my-johnny-password", &auth.ErrPasswordContainsEmail},
+		{"breached", "letmein2024", &auth.ErrPasswordBreached},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			err := suite.authService.RegisterUser(entity.User{Email: "johnny@example.com", Password: tt.password})
+			assert.Equal(suite.T(), tt.expected, err)
+		})
+	}
+}
+
+// TestVerifyEmail_InvalidToken tests the verification with a malformed token.
+func (suite *AuthServiceTestSuite) TestVerifyEmail_InvalidToken() {
+	_, err := suite.authService.VerifyEmail("invalid-token")
+	assert.Equal(suite.T(), &auth.ErrInvalidVerificationToken, err)
+}
+
+// TestVerifyEmail_Success tests the verification with the token sent on registration.
+func (suite *AuthServiceTestSuite) TestVerifyEmail_Success() {
+	user := &entity.User{Email: "user@example.com"}
+	user.ID = 7
+
+	suite.cryptMock.EXPECT().
+		HashPassword(gomock.Any()).
+		Return("hashedPassword", nil)
+
+	suite.repoMock.EXPECT().
+		RegisterUser(gomock.Any()).
+		Return(nil)
+
+	var sentMessage mail.Message
+	suite.mailMock.EXPECT().
+		Send(gomock.Any()).
+		DoAndReturn(func(message mail.Message) error {
+			sentMessage = message
+			return nil
+		})
+
+	suite.Require().NoError(suite.authService.RegisterUser(entity.User{Email: user.Email, Password: "password123"}))
+
+	// Extract the token from the link sent by email
+	_, query, _ := strings.Cut(sentMessage.Body, "?token=")
+	verificationToken, _, _ := strings.Cut(query, "\n")
+	verificationToken, _ = url.QueryUnescape(verificationToken)
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{Email: user.Email}).
+		Return(user, nil)
+
+	suite.repoMock.EXPECT().
+		MarkUserVerified(user.ID, gomock.Any()).
+		Return(nil)
+
+	response, err := suite.authService.VerifyEmail(verificationToken)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), user.Email, response.Email)
+}
+
+// TestAuthenticateUser_EmailNotVerified tests that unverified users are blocked when verification is required.
+func (suite *AuthServiceTestSuite) TestAuthenticateUser_EmailNotVerified() {
+	service := suite.newService(auth.Policy{
+		Lockout:      lockoutPolicy,
+		Verification: auth.VerificationPolicy{Required: true},
+	})
+
+	suite.repoMock.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&entity.User{Email: "testuser", Password: "hashedPassword"}, nil)
+
+	suite.cryptMock.EXPECT().
+		CheckPasswordHash(gomock.Any(), gomock.Any()).
+		Return(true)
+
+	_, err := service.AuthenticateUser(auth.AuthenticateUserInput{Email: "testuser", Password: "password"})
+	assert.Equal(suite.T(), &auth.ErrEmailNotVerified, err)
+}
+
+// TestAuthenticateUser_AccountStatus tests that disabled accounts and accounts required to reset their password can't log in.
+func (suite *AuthServiceTestSuite) TestAuthenticateUser_AccountStatus() {
+	disabledAt := time.Now()
+	tests := []struct {
+		name     string
+		user     entity.User
+		expected error
+	}{
+		{"disabled", entity.User{Email: "testuser", Password: "hashedPassword", DisabledAt: &disabledAt}, &auth.ErrAccountDisabled},
+		{"password reset required", entity.User{Email: "testuser", Password: "hashedPassword", PasswordResetRequired: true}, &auth.ErrPasswordResetRequired},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.repoMock.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&tt.user, nil)
+
+			suite.cryptMock.EXPECT().
+				CheckPasswordHash(gomock.Any(), gomock.Any()).
+				Return(true)
+
+			_, err := suite.authService.AuthenticateUser(auth.AuthenticateUserInput{Email: "testuser", Password: "password"})
+			assert.Equal(suite.T(), tt.expected, err)
+		})
+	}
 }
 
 // TestAuthenticateUser_UserNotFound tests the scenario where the user is not found during authentication.
@@ -127,11 +398,202 @@ func (suite *AuthServiceTestSuite) TestAuthenticateUser_InvalidCredentials() {
 		CheckPasswordHash(gomock.Any(), gomock.Any()).
 		Return(false)
 
+	suite.repoMock.EXPECT().
+		IncrementLoginAttempts(user.ID).
+		Return(1, nil)
+
 	_, err := suite.authService.AuthenticateUser(input)
 	assert.Error(suite.T(), err)
 	assert.Equal(suite.T(), &auth.ErrInvalidCredentials, err)
 }
 
+// TestAuthenticateUser_LocksAccount tests that the account is locked once the failed attempts limit is reached.
+func (suite *AuthServiceTestSuite) TestAuthenticateUser_LocksAccount() {
+	input := auth.AuthenticateUserInput{
+		Email:    "testuser",
+		Password: "wrongpassword",
+	}
+
+	user := &entity.User{
+		Email:               input.Email,
+		Password:            "hashedPassword",
+		FailedLoginAttempts: lockoutPolicy.MaxAttempts - 1,
+	}
+
+	suite.repoMock.EXPECT().
+		GetUser(gomock.Any()).
+		Return(user, nil)
+
+	suite.cryptMock.EXPECT().
+		CheckPasswordHash(gomock.Any(), gomock.Any()).
+		Return(false)
+
+	suite.repoMock.EXPECT().
+		IncrementLoginAttempts(user.ID).
+		Return(lockoutPolicy.MaxAttempts, nil)
+	suite.repoMock.EXPECT().
+		LockAccount(user.ID, gomock.Any()).
+		Return(nil)
+
+	_, err := suite.authService.AuthenticateUser(input)
+	assert.Equal(suite.T(), &auth.ErrAccountLocked, err)
+}
+
+// TestAuthenticateUser_ConcurrentFailures tests that concurrent failures read from the same stale user are all counted,
+// locking the account from the failure reaching the limit on.
+func (suite *AuthServiceTestSuite) TestAuthenticateUser_ConcurrentFailures() {
+	const failures = 4
+	user := &entity.User{Email: "testuser", Password: "hashedPassword"}
+
+	var counter atomic.Int32
+	suite.repoMock.EXPECT().GetUser(gomock.Any()).Return(user, nil).Times(failures)
+	suite.cryptMock.EXPECT().CheckPasswordHash(gomock.Any(), gomock.Any()).Return(false).Times(failures)
+	suite.repoMock.EXPECT().
+		IncrementLoginAttempts(user.ID).
+		DoAndReturn(func(uint) (int, error) { return int(counter.Add(1)), nil }).
+		Times(failures)
+	suite.repoMock.EXPECT().
+		LockAccount(user.ID, gomock.Any()).
+		Return(nil).
+		Times(failures - lockoutPolicy.MaxAttempts + 1)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, failures)
+	for range failures {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := suite.authService.AuthenticateUser(auth.AuthenticateUserInput{Email: user.Email, Password: "wrongpassword"})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	locked := 0
+	for err := range errs {
+		if err == &auth.ErrAccountLocked {
+			locked++
+		}
+	}
+	assert.Equal(suite.T(), failures-lockoutPolicy.MaxAttempts+1, locked)
+}
+
+// TestAuthenticateUser_LockedAccount tests that a locked account is rejected without checking the password.
+func (suite *AuthServiceTestSuite) TestAuthenticateUser_LockedAccount() {
+	lockedUntil := time.Now().Add(time.Minute)
+	user := &entity.User{
+		Email:       "testuser",
+		Password:    "hashedPassword",
+		LockedUntil: &lockedUntil,
+	}
+
+	suite.repoMock.EXPECT().
+		GetUser(gomock.Any()).
+		Return(user, nil)
+
+	_, err := suite.authService.AuthenticateUser(auth.AuthenticateUserInput{Email: user.Email, Password: "password"})
+	assert.Equal(suite.T(), &auth.ErrAccountLocked, err)
+}
+
+// newHardenedService creates the service under test in hardened mode, computing its dummy hash upfront.
+func (suite *AuthServiceTestSuite) newHardenedService() auth.ServiceImp {
+	suite.cryptMock.EXPECT().
+		HashPassword(gomock.Any()).
+		Return("dummyHash", nil)
+
+	return suite.newService(auth.Policy{
+		Lockout:      lockoutPolicy,
+		Verification: verificationPolicy,
+		Enumeration:  auth.EnumerationPolicy{Hardened: true},
+	})
+}
+
+// TestAuthenticateUser_HardenedUserNotFound tests that unknown emails are checked against the dummy hash
+// and rejected like wrong passwords in hardened mode.
+func (suite *AuthServiceTestSuite) TestAuthenticateUser_HardenedUserNotFound() {
+	authService := suite.newHardenedService()
+
+	suite.repoMock.EXPECT().
+		GetUser(gomock.Any()).
+		Return(nil, errors.New("record not found"))
+
+	suite.cryptMock.EXPECT().
+		CheckPasswordHash("password123", "dummyHash").
+		Return(false)
+
+	_, err := authService.AuthenticateUser(auth.AuthenticateUserInput{Email: "unknown@example.com", Password: "password123"})
+	assert.Equal(suite.T(), &auth.ErrInvalidCredentials, err)
+}
+
+// TestAuthenticateUser_HardenedLockedAccount tests that locked accounts check the password
+// and are rejected like wrong passwords in hardened mode.
+func (suite *AuthServiceTestSuite) TestAuthenticateUser_HardenedLockedAccount() {
+	authService := suite.newHardenedService()
+
+	lockedUntil := time.Now().Add(time.Minute)
+	user := &entity.User{Email: "testuser", Password: "hashedPassword", LockedUntil: &lockedUntil}
+
+	suite.repoMock.EXPECT().
+		GetUser(gomock.Any()).
+		Return(user, nil)
+
+	suite.cryptMock.EXPECT().
+		CheckPasswordHash("password", user.Password).
+		Return(true)
+
+	_, err := authService.AuthenticateUser(auth.AuthenticateUserInput{Email: user.Email, Password: "password"})
+	assert.Equal(suite.T(), &auth.ErrInvalidCredentials, err)
+}
+
+// TestAuthenticateUser_HardenedLocksAccount tests that the attempt locking the account is rejected
+// like any wrong password in hardened mode.
+func (suite *AuthServiceTestSuite) TestAuthenticateUser_HardenedLocksAccount() {
+	authService := suite.newHardenedService()
+
+	user := &entity.User{Email: "testuser", Password: "hashedPassword", FailedLoginAttempts: lockoutPolicy.MaxAttempts - 1}
+
+	suite.repoMock.EXPECT().
+		GetUser(gomock.Any()).
+		Return(user, nil)
+
+	suite.cryptMock.EXPECT().
+		CheckPasswordHash(gomock.Any(), gomock.Any()).
+		Return(false)
+
+	suite.repoMock.EXPECT().
+		IncrementLoginAttempts(user.ID).
+		Return(lockoutPolicy.MaxAttempts, nil)
+	suite.repoMock.EXPECT().
+		LockAccount(user.ID, gomock.Any()).
+		Return(nil)
+
+	_, err := authService.AuthenticateUser(auth.AuthenticateUserInput{Email: user.Email, Password: "wrongpassword"})
+	assert.Equal(suite.T(), &auth.ErrInvalidCredentials, err)
+}
+
+// TestAuthenticateUser_ThrottlesClientIP tests that a client IP is throttled once the failed attempts limit is reached.
+func (suite *AuthServiceTestSuite) TestAuthenticateUser_ThrottlesClientIP() {
+	input := auth.AuthenticateUserInput{
+		Email:    "nonexistentuser",
+		Password: "password123",
+		IP:       "203.0.113.10",
+	}
+
+	suite.repoMock.EXPECT().
+		GetUser(gomock.Any()).
+		Return(nil, errors.New("faild to retrieve user")).
+		Times(lockoutPolicy.MaxAttemptsPerIP)
+
+	for range lockoutPolicy.MaxAttemptsPerIP {
+		_, err := suite.authService.AuthenticateUser(input)
+		assert.Equal(suite.T(), &auth.ErrUserNotFound, err)
+	}
+
+	_, err := suite.authService.AuthenticateUser(input)
+	assert.Equal(suite.T(), &auth.ErrTooManyAttempts, err)
+}
+
 // TestAuthenticateUser_ValidCredentials tests the scenario where valid credentials are provided.
 func (suite *AuthServiceTestSuite) TestAuthenticateUser_ValidCredentials() {
 	input := auth.AuthenticateUserInput{
@@ -152,9 +614,1282 @@ func (suite *AuthServiceTestSuite) TestAuthenticateUser_ValidCredentials() {
 		CheckPasswordHash(gomock.Any(), gomock.Any()).
 		Return(true)
 
-	jwt, err := suite.authService.AuthenticateUser(input)
+	suite.cryptMock.EXPECT().
+		NeedsRehash(user.Password).
+		Return(false)
+
+	var storedSession *entity.Session
+	suite.repoMock.EXPECT().
+		CreateSession(gomock.Any()).
+		DoAndReturn(func(session *entity.Session) error {
+			storedSession = session
+			return nil
+		})
+
+	input.IP = "203.0.113.10"
+	input.UserAgent = "Mozilla/5.0"
+	response, err := suite.authService.AuthenticateUser(input)
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), response.JWTToken)
+
+	// The access token is bound to the session recorded for the client
+	suite.Require().NotNil(storedSession)
+	assert.Equal(suite.T(), input.IP, storedSession.IP)
+	assert.Equal(suite.T(), input.UserAgent, storedSession.UserAgent)
+	assert.True(suite.T(), storedSession.IsActive(time.Now()))
+
+	claims, err := token.ValidateToken("", response.JWTToken)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), storedSession.TokenID, claims.Id)
+	assert.Equal(suite.T(), storedSession.ExpiresAt.Unix(), claims.ExpiresAt)
+}
+
+// TestAuthenticateUser_RehashesOutdatedPassword tests that a hash using an outdated algorithm is upgraded on login.
+func (suite *AuthServiceTestSuite) TestAuthenticateUser_RehashesOutdatedPassword() {
+	user := &entity.User{
+		Email:    "testuser",
+		Password: "outdatedHash",
+	}
+	user.ID = 7
+
+	suite.repoMock.EXPECT().
+		GetUser(gomock.Any()).
+		Return(user, nil)
+
+	suite.cryptMock.EXPECT().
+		CheckPasswordHash("password", user.Password).
+		Return(true)
+
+	suite.cryptMock.EXPECT().
+		NeedsRehash(user.Password).
+		Return(true)
+
+	suite.cryptMock.EXPECT().
+		HashPassword("password").
+		Return("upgradedHash", nil)
+
+	suite.repoMock.EXPECT().
+		UpdatePasswordHash(user.ID, "outdatedHash", "upgradedHash").
+		Return(nil)
+
+	suite.repoMock.EXPECT().
+		CreateSession(gomock.Any()).
+		Return(nil)
+
+	response, err := suite.authService.AuthenticateUser(auth.AuthenticateUserInput{Email: user.Email, Password: "password"})
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), response.JWTToken)
+}
+
+// TestAuthenticateUser_RehashFailure tests that a failed hash upgrade does not block the login.
+func (suite *AuthServiceTestSuite) TestAuthenticateUser_RehashFailure() {
+	user := &entity.User{
+		Email:    "testuser",
+		Password: "outdatedHash",
+	}
+
+	suite.repoMock.EXPECT().
+		GetUser(gomock.Any()).
+		Return(user, nil)
+
+	suite.cryptMock.EXPECT().
+		CheckPasswordHash(gomock.Any(), gomock.Any()).
+		Return(true)
+
+	suite.cryptMock.EXPECT().
+		NeedsRehash(user.Password).
+		Return(true)
+
+	suite.cryptMock.EXPECT().
+		HashPassword(gomock.Any()).
+		Return("", errors.New("hashing failed"))
+
+	suite.repoMock.EXPECT().
+		CreateSession(gomock.Any()).
+		Return(nil)
+
+	response, err := suite.authService.AuthenticateUser(auth.AuthenticateUserInput{Email: user.Email, Password: "password"})
 	assert.NoError(suite.T(), err)
-	assert.NotEmpty(suite.T(), jwt)
+	assert.NotEmpty(suite.T(), response.JWTToken)
+}
+
+// TestAuthenticateUser_ResetsFailedAttempts tests that a successful login clears previous failed attempts.
+func (suite *AuthServiceTestSuite) TestAuthenticateUser_ResetsFailedAttempts() {
+	user := &entity.User{
+		Email:               "testuser",
+		Password:            "hashedPassword",
+		FailedLoginAttempts: 2,
+	}
+
+	suite.repoMock.EXPECT().
+		GetUser(gomock.Any()).
+		Return(user, nil)
+
+	suite.cryptMock.EXPECT().
+		CheckPasswordHash(gomock.Any(), gomock.Any()).
+		Return(true)
+
+	suite.repoMock.EXPECT().
+		UpdateLoginAttempts(user.ID, 0, nil).
+		Return(nil)
+
+	suite.cryptMock.EXPECT().
+		NeedsRehash(gomock.Any()).
+		Return(false)
+
+	suite.repoMock.EXPECT().
+		CreateSession(gomock.Any()).
+		Return(nil)
+
+	_, err := suite.authService.AuthenticateUser(auth.AuthenticateUserInput{Email: user.Email, Password: "password"})
+	assert.NoError(suite.T(), err)
+}
+
+// mfaSecret is the TOTP secret of the users with MFA enabled across the tests.
+const mfaSecret = "JBSWY3DPEHPK3PXP"
+
+// mfaUser returns a user with MFA enabled.
+func mfaUser() *entity.User {
+	enabledAt := time.Now()
+	user := &entity.User{Email: "mfa@example.com", Password: "hashedPassword", MFASecret: mfaSecret, MFAEnabledAt: &enabledAt}
+	user.ID = 9
+	return user
+}
+
+// mfaChallenge runs the password step of the login of the user, returning the MFA challenge token.
+func (suite *AuthServiceTestSuite) mfaChallenge(user *entity.User) string {
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{Email: user.Email}).
+		Return(user, nil)
+
+	suite.cryptMock.EXPECT().
+		CheckPasswordHash("password", user.Password).
+		Return(true)
+
+	suite.cryptMock.EXPECT().
+		NeedsRehash(user.Password).
+		Return(false)
+
+	response, err := suite.authService.AuthenticateUser(auth.AuthenticateUserInput{Email: user.Email, Password: "password"})
+	suite.Require().NoError(err)
+	suite.Require().True(response.MFARequired)
+	return response.MFAToken
+}
+
+// TestAuthenticateUser_MFARequired tests that the password step only returns the challenge token,
+// keeping the failed attempts so they also cover the codes.
+func (suite *AuthServiceTestSuite) TestAuthenticateUser_MFARequired() {
+	user := mfaUser()
+	user.FailedLoginAttempts = 2
+
+	mfaToken := suite.mfaChallenge(user)
+	assert.NotEmpty(suite.T(), mfaToken)
+
+	// The challenge token can't be used as an access token
+	claims, err := token.ValidateToken("", mfaToken)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "mfa_challenge", claims.StringKey(token.PurposeClaimName))
+	assert.Zero(suite.T(), claims.UintKey("ID"))
+}
+
+// TestAuthenticateMFA_InvalidToken tests the second step with a token not issued for MFA.
+func (suite *AuthServiceTestSuite) TestAuthenticateMFA_InvalidToken() {
+	_, err := suite.authService.AuthenticateMFA(auth.AuthenticateMFAInput{MFAToken: "invalid-token", Code: "123456"})
+	assert.Equal(suite.T(), &auth.ErrInvalidMFAToken, err)
+}
+
+// TestAuthenticateMFA_ValidCode tests the second step with the current TOTP code.
+func (suite *AuthServiceTestSuite) TestAuthenticateMFA_ValidCode() {
+	user := mfaUser()
+	user.FailedLoginAttempts = 1
+	mfaToken := suite.mfaChallenge(user)
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{Email: user.Email}).
+		Return(user, nil)
+
+	suite.repoMock.EXPECT().
+		UpdateLoginAttempts(user.ID, 0, nil).
+		Return(nil)
+
+	now := time.Now()
+	code, err := totp.Code(mfaSecret, now)
+	suite.Require().NoError(err)
+
+	suite.repoMock.EXPECT().
+		UseTOTPCounter(user.ID, now.Unix()/int64(totp.Period.Seconds())).
+		Return(nil)
+
+	suite.repoMock.EXPECT().
+		CreateSession(gomock.Any()).
+		Return(nil)
+
+	response, err := suite.authService.AuthenticateMFA(auth.AuthenticateMFAInput{MFAToken: mfaToken, Code: code})
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), response.JWTToken)
+	assert.False(suite.T(), response.MFARequired)
+}
+
+// TestAuthenticateMFA_ReplayedCode tests that a TOTP code is refused once accepted, counting as a failed attempt.
+func (suite *AuthServiceTestSuite) TestAuthenticateMFA_ReplayedCode() {
+	user := mfaUser()
+
+	// The repository only moves the last accepted counter forward
+	var lastCounter int64
+	suite.repoMock.EXPECT().
+		UseTOTPCounter(user.ID, gomock.Any()).
+		DoAndReturn(func(_ uint, counter int64) error {
+			if counter <= lastCounter {
+				return errors.New("TOTP code already used")
+			}
+			lastCounter = counter
+			return nil
+		}).
+		Times(2)
+
+	code, err := totp.Code(mfaSecret, time.Now())
+	suite.Require().NoError(err)
+
+	mfaToken := suite.mfaChallenge(user)
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{Email: user.Email}).
+		Return(user, nil)
+
+	suite.repoMock.EXPECT().
+		CreateSession(gomock.Any()).
+		Return(nil)
+
+	_, err = suite.authService.AuthenticateMFA(auth.AuthenticateMFAInput{MFAToken: mfaToken, Code: code})
+	suite.Require().NoError(err)
+
+	mfaToken = suite.mfaChallenge(user)
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{Email: user.Email}).
+		Return(user, nil)
+
+	suite.repoMock.EXPECT().
+		IncrementLoginAttempts(user.ID).
+		Return(1, nil)
+
+	_, err = suite.authService.AuthenticateMFA(auth.AuthenticateMFAInput{MFAToken: mfaToken, Code: code})
+	assert.Equal(suite.T(), &auth.ErrInvalidMFACode, err)
+}
+
+// TestAuthenticateMFA_AccountDisabled tests that the second step is rejected when the account was disabled meanwhile.
+func (suite *AuthServiceTestSuite) TestAuthenticateMFA_AccountDisabled() {
+	user := mfaUser()
+	mfaToken := suite.mfaChallenge(user)
+	disabledAt := time.Now()
+	user.DisabledAt = &disabledAt
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{Email: user.Email}).
+		Return(user, nil)
+
+	suite.repoMock.EXPECT().
+		UseTOTPCounter(user.ID, gomock.Any()).
+		Return(nil)
+
+	code, err := totp.Code(mfaSecret, time.Now())
+	suite.Require().NoError(err)
+
+	_, err = suite.authService.AuthenticateMFA(auth.AuthenticateMFAInput{MFAToken: mfaToken, Code: code})
+	assert.Equal(suite.T(), &auth.ErrAccountDisabled, err)
+}
+
+// TestAuthenticateMFA_RecoveryCode tests the second step with a recovery code, typed without its formatting.
+func (suite *AuthServiceTestSuite) TestAuthenticateMFA_RecoveryCode() {
+	user := mfaUser()
+	mfaToken := suite.mfaChallenge(user)
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{Email: user.Email}).
+		Return(user, nil)
+
+	suite.repoMock.EXPECT().
+		UseRecoveryCode(user.ID, crypt.HashToken("abcdefghijklmnop"), gomock.Any()).
+		Return(nil)
+
+	suite.repoMock.EXPECT().
+		CreateSession(gomock.Any()).
+		Return(nil)
+
+	response, err := suite.authService.AuthenticateMFA(auth.AuthenticateMFAInput{MFAToken: mfaToken, Code: "ABCD-EFGH ijkl-mnop"})
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), response.JWTToken)
+}
+
+// TestAuthenticateMFA_InvalidCode tests that wrong codes count as failed login attempts.
+func (suite *AuthServiceTestSuite) TestAuthenticateMFA_InvalidCode() {
+	user := mfaUser()
+	mfaToken := suite.mfaChallenge(user)
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{Email: user.Email}).
+		Return(user, nil)
+
+	suite.repoMock.EXPECT().
+		UseRecoveryCode(user.ID, gomock.Any(), gomock.Any()).
+		Return(errors.New("recovery code not found or already used"))
+
+	suite.repoMock.EXPECT().
+		IncrementLoginAttempts(user.ID).
+		Return(1, nil)
+
+	_, err := suite.authService.AuthenticateMFA(auth.AuthenticateMFAInput{MFAToken: mfaToken, Code: "000000"})
+	assert.Equal(suite.T(), &auth.ErrInvalidMFACode, err)
+}
+
+// TestAuthenticateMFA_LocksAccount tests that the account is locked once the limit of wrong codes is reached.
+func (suite *AuthServiceTestSuite) TestAuthenticateMFA_LocksAccount() {
+	user := mfaUser()
+	user.FailedLoginAttempts = lockoutPolicy.MaxAttempts - 1
+	mfaToken := suite.mfaChallenge(user)
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{Email: user.Email}).
+		Return(user, nil)
+
+	suite.repoMock.EXPECT().
+		UseRecoveryCode(user.ID, gomock.Any(), gomock.Any()).
+		Return(errors.New("recovery code not found or already used"))
+
+	suite.repoMock.EXPECT().
+		IncrementLoginAttempts(user.ID).
+		Return(lockoutPolicy.MaxAttempts, nil)
+	suite.repoMock.EXPECT().
+		LockAccount(user.ID, gomock.Any()).
+		Return(nil)
+
+	_, err := suite.authService.AuthenticateMFA(auth.AuthenticateMFAInput{MFAToken: mfaToken, Code: "000000"})
+	assert.Equal(suite.T(), &auth.ErrAccountLocked, err)
+}
+
+// TestEnrollMFA_AlreadyEnabled tests the enrolment of a user with MFA enabled.
+func (suite *AuthServiceTestSuite) TestEnrollMFA_AlreadyEnabled() {
+	user := mfaUser()
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: user.ID}).
+		Return(user, nil)
+
+	_, err := suite.authService.EnrollMFA(user.ID)
+	assert.Equal(suite.T(), &auth.ErrMFAAlreadyEnabled, err)
+}
+
+// TestEnrollMFA_Success tests that a new secret is stored and returned along with its otpauth URI.
+func (suite *AuthServiceTestSuite) TestEnrollMFA_Success() {
+	user := &entity.User{Email: "user@example.com"}
+	user.ID = 7
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: user.ID}).
+		Return(user, nil)
+
+	var storedSecret string
+	suite.repoMock.EXPECT().
+		UpdateMFASecret(user.ID, gomock.Any()).
+		DoAndReturn(func(_ uint, secret string) error {
+			storedSecret = secret
+			return nil
+		})
+
+	response, err := suite.authService.EnrollMFA(user.ID)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), storedSecret, response.Secret)
+	assert.Equal(suite.T(), totp.URI("luizalabs", user.Email, storedSecret), response.URI)
+}
+
+// TestConfirmMFA_NotEnrolled tests the confirmation without a pending enrolment.
+func (suite *AuthServiceTestSuite) TestConfirmMFA_NotEnrolled() {
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: 7}).
+		Return(&entity.User{}, nil)
+
+	_, err := suite.authService.ConfirmMFA(7, "123456")
+	assert.Equal(suite.T(), &auth.ErrMFANotEnrolled, err)
+}
+
+// TestConfirmMFA_InvalidCode tests the confirmation with a wrong code.
+func (suite *AuthServiceTestSuite) TestConfirmMFA_InvalidCode() {
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: 7}).
+		Return(&entity.User{MFASecret: mfaSecret}, nil)
+
+	_, err := suite.authService.ConfirmMFA(7, "000000")
+	assert.Equal(suite.T(), &auth.ErrInvalidMFACode, err)
+}
+
+// TestConfirmMFA_Success tests that MFA is enabled and the recovery codes are only stored hashed.
+func (suite *AuthServiceTestSuite) TestConfirmMFA_Success() {
+	user := &entity.User{MFASecret: mfaSecret}
+	user.ID = 7
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: user.ID}).
+		Return(user, nil)
+
+	var storedCodes []entity.MFARecoveryCode
+	suite.repoMock.EXPECT().
+		EnableMFA(user.ID, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ uint, _ time.Time, recoveryCodes []entity.MFARecoveryCode) error {
+			storedCodes = recoveryCodes
+			return nil
+		})
+
+	suite.repoMock.EXPECT().
+		UseTOTPCounter(user.ID, gomock.Any()).
+		Return(nil)
+
+	code, err := totp.Code(mfaSecret, time.Now())
+	suite.Require().NoError(err)
+
+	response, err := suite.authService.ConfirmMFA(user.ID, code)
+	suite.Require().NoError(err)
+	suite.Require().Len(response.RecoveryCodes, 10)
+	suite.Require().Len(storedCodes, 10)
+
+	for i, recoveryCode := range response.RecoveryCodes {
+		assert.Regexp(suite.T(), `^[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}$`, recoveryCode)
+		assert.Equal(suite.T(), crypt.HashToken(strings.ReplaceAll(recoveryCode, "-", "")), storedCodes[i].HashedCode)
+		assert.Equal(suite.T(), user.ID, storedCodes[i].UserID)
+	}
+}
+
+// TestUnlockUser_UserNotFound tests the unlock of an unknown user.
+func (suite *AuthServiceTestSuite) TestUnlockUser_UserNotFound() {
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: 42}).
+		Return(nil, errors.New("record not found"))
+
+	err := suite.authService.UnlockUser(auth.Administrator{ID: 1}, 42)
+	assert.Equal(suite.T(), &auth.ErrUserNotFound, err)
+}
+
+// TestUnlockUser_Success tests the successful unlock of a user.
+func (suite *AuthServiceTestSuite) TestUnlockUser_Success() {
+	user := &entity.User{Email: "testuser"}
+	user.ID = 42
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: 42}).
+		Return(user, nil)
+
+	suite.repoMock.EXPECT().
+		UpdateLoginAttempts(user.ID, 0, nil).
+		Return(nil)
+
+	err := suite.authService.UnlockUser(auth.Administrator{ID: 1}, 42)
+	assert.NoError(suite.T(), err)
+}
+
+// TestListUsers tests the listing of a page of users.
+func (suite *AuthServiceTestSuite) TestListUsers() {
+	filter := auth.ListUsersFilter{Page: 2, PageSize: 1, Email: "example.com"}
+	user := entity.User{Email: "user@example.com"}
+	user.ID = 7
+
+	suite.repoMock.EXPECT().
+		ListUsers(filter).
+		Return([]entity.User{user}, int64(2), nil)
+
+	response, err := suite.authService.ListUsers(filter)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), &auth.ListUsersResponse{
+		Users:    []auth.AdminUserResponse{auth.ToAdminUserResponse(user)},
+		Page:     2,
+		PageSize: 1,
+		Total:    2,
+	}, response)
+}
+
+// TestListUsers_Failure tests the listing when the repository fails.
+func (suite *AuthServiceTestSuite) TestListUsers_Failure() {
+	suite.repoMock.EXPECT().
+		ListUsers(gomock.Any()).
+		Return(nil, int64(0), errors.New("connection refused"))
+
+	_, err := suite.authService.ListUsers(auth.ListUsersFilter{Page: 1, PageSize: 20})
+	assert.Equal(suite.T(), &auth.ErrOperationFailed, err)
+}
+
+// TestAdministerUser_SelfAdministration tests that administrators can't target their own account.
+func (suite *AuthServiceTestSuite) TestAdministerUser_SelfAdministration() {
+	actions := map[string]func(admin auth.Administrator, userID uint) error{
+		"disable":        suite.authService.DisableUser,
+		"enable":         suite.authService.EnableUser,
+		"delete":         suite.authService.DeleteUser,
+		"restore":        suite.authService.RestoreUser,
+		"password reset": suite.authService.ForcePasswordReset,
+		"change role": func(admin auth.Administrator, userID uint) error {
+			return suite.authService.ChangeRole(admin, userID, entity.RoleUser)
+		},
+	}
+
+	for name, action := range actions {
+		suite.Run(name, func() {
+			assert.Equal(suite.T(), &auth.ErrSelfAdministration, action(auth.Administrator{ID: 1}, 1))
+		})
+	}
+}
+
+// TestDisableUser_UserNotFound tests disabling an unknown user.
+func (suite *AuthServiceTestSuite) TestDisableUser_UserNotFound() {
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: 42}).
+		Return(nil, errors.New("record not found"))
+
+	err := suite.authService.DisableUser(auth.Administrator{ID: 1}, 42)
+	assert.Equal(suite.T(), &auth.ErrUserNotFound, err)
+}
+
+// TestDisableUser_OtherOrganization tests that administrators of an organization can't administer other accounts.
+func (suite *AuthServiceTestSuite) TestDisableUser_OtherOrganization() {
+	user := &entity.User{Email: "testuser", OrganizationID: 5}
+	user.ID = 42
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: 42}).
+		Return(user, nil)
+
+	err := suite.authService.DisableUser(auth.Administrator{ID: 1, TenantID: 3}, 42)
+	assert.Equal(suite.T(), &auth.ErrUserNotFound, err)
+}
+
+// TestDisableUser_Success tests disabling a user.
+func (suite *AuthServiceTestSuite) TestDisableUser_Success() {
+	user := &entity.User{Email: "testuser"}
+	user.ID = 42
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: 42}).
+		Return(user, nil)
+
+	suite.repoMock.EXPECT().
+		SetDisabled(user.ID, gomock.Not(gomock.Nil())).
+		Return(nil)
+
+	assert.NoError(suite.T(), suite.authService.DisableUser(auth.Administrator{ID: 1}, 42))
+}
+
+// TestEnableUser_Success tests enabling a disabled user.
+func (suite *AuthServiceTestSuite) TestEnableUser_Success() {
+	disabledAt := time.Now()
+	user := &entity.User{Email: "testuser", DisabledAt: &disabledAt}
+	user.ID = 42
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: 42}).
+		Return(user, nil)
+
+	suite.repoMock.EXPECT().
+		SetDisabled(user.ID, nil).
+		Return(nil)
+
+	assert.NoError(suite.T(), suite.authService.EnableUser(auth.Administrator{ID: 1}, 42))
+}
+
+// TestDeleteUser_Success tests deleting a user on behalf of an administrator.
+func (suite *AuthServiceTestSuite) TestDeleteUser_Success() {
+	user := &entity.User{Email: "testuser"}
+	user.ID = 42
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: 42}).
+		Return(user, nil)
+
+	suite.repoMock.EXPECT().
+		DeleteUser(user.ID).
+		Return(nil)
+
+	assert.NoError(suite.T(), suite.authService.DeleteUser(auth.Administrator{ID: 1}, 42))
+}
+
+// TestRestoreUser tests restoring a deleted user.
+func (suite *AuthServiceTestSuite) TestRestoreUser() {
+	tests := []struct {
+		name     string
+		repoErr  error
+		expected error
+	}{
+		{"restored", nil, nil},
+		{"failure", errors.New("connection refused"), &auth.ErrOperationFailed},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.repoMock.EXPECT().
+				RestoreUser(uint(42), uint(0)).
+				Return(tt.repoErr)
+
+			err := suite.authService.RestoreUser(auth.Administrator{ID: 1}, 42)
+			assert.Equal(suite.T(), tt.expected, err)
+		})
+	}
+}
+
+// TestRestoreUser_Organization tests that the restore is restricted to the organization of the administrator.
+func (suite *AuthServiceTestSuite) TestRestoreUser_Organization() {
+	suite.repoMock.EXPECT().
+		RestoreUser(uint(42), uint(3)).
+		Return(nil)
+
+	assert.NoError(suite.T(), suite.authService.RestoreUser(auth.Administrator{ID: 1, TenantID: 3}, 42))
+}
+
+// TestForcePasswordReset_Success tests that the reset is required and a reset email is sent.
+func (suite *AuthServiceTestSuite) TestForcePasswordReset_Success() {
+	user := &entity.User{Email: "user@example.com"}
+	user.ID = 42
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: 42}).
+		Return(user, nil)
+
+	suite.repoMock.EXPECT().
+		ForcePasswordReset(user.ID, gomock.Any()).
+		Return(nil)
+
+	suite.repoMock.EXPECT().
+		CreatePasswordResetToken(gomock.Any()).
+		Return(nil)
+
+	suite.mailMock.EXPECT().
+		Send(gomock.Any()).
+		Return(nil)
+
+	assert.NoError(suite.T(), suite.authService.ForcePasswordReset(auth.Administrator{ID: 1}, 42))
+}
+
+// TestChangeRole tests the change of role, skipped when the role doesn't change.
+func (suite *AuthServiceTestSuite) TestChangeRole() {
+	user := &entity.User{Email: "testuser", Role: entity.RoleUser}
+	user.ID = 42
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: 42}).
+		Return(user, nil).
+		Times(2)
+
+	suite.repoMock.EXPECT().
+		UpdateRole(user.ID, entity.RoleAdmin, gomock.Any()).
+		Return(nil)
+
+	assert.NoError(suite.T(), suite.authService.ChangeRole(auth.Administrator{ID: 1}, 42, entity.RoleAdmin))
+	assert.NoError(suite.T(), suite.authService.ChangeRole(auth.Administrator{ID: 1}, 42, entity.RoleUser))
+}
+
+// TestForgotPassword_UnknownEmail tests that nothing is sent for unknown emails.
+func (suite *AuthServiceTestSuite) TestForgotPassword_UnknownEmail() {
+	looked := make(chan struct{})
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{Email: "unknown@example.com"}).
+		DoAndReturn(func(auth.GetUserFilter) (*entity.User, error) {
+			close(looked)
+			return nil, errors.New("record not found")
+		})
+
+	suite.authService.ForgotPassword("unknown@example.com")
+	suite.waitFor(looked)
+}
+
+// TestForgotPassword_DoesNotWaitOnMailer tests that the request returns while the email is still being sent, so
+// registered and unknown emails take the same time.
+func (suite *AuthServiceTestSuite) TestForgotPassword_DoesNotWaitOnMailer() {
+	user := &entity.User{Email: "user@example.com"}
+
+	suite.repoMock.EXPECT().GetUser(gomock.Any()).Return(user, nil)
+	suite.repoMock.EXPECT().CreatePasswordResetToken(gomock.Any()).Return(nil)
+
+	release := make(chan struct{})
+	sent := make(chan struct{})
+	suite.mailMock.EXPECT().
+		Send(gomock.Any()).
+		DoAndReturn(func(mail.Message) error {
+			<-release
+			close(sent)
+			return nil
+		})
+
+	returned := make(chan struct{})
+	go func() {
+		suite.authService.ForgotPassword(user.Email)
+		close(returned)
+	}()
+	suite.waitFor(returned)
+
+	close(release)
+	suite.waitFor(sent)
+}
+
+// TestForgotPassword_Success tests that a hashed reset token is stored and the plain one is sent by email.
+func (suite *AuthServiceTestSuite) TestForgotPassword_Success() {
+	user := &entity.User{Email: "user@example.com"}
+	user.ID = 7
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{Email: user.Email}).
+		Return(user, nil)
+
+	var storedToken *entity.PasswordResetToken
+	suite.repoMock.EXPECT().
+		CreatePasswordResetToken(gomock.Any()).
+		DoAndReturn(func(resetToken *entity.PasswordResetToken) error {
+			storedToken = resetToken
+			return nil
+		})
+
+	var sentMessage mail.Message
+	sent := make(chan struct{})
+	suite.mailMock.EXPECT().
+		Send(gomock.Any()).
+		DoAndReturn(func(message mail.Message) error {
+			sentMessage = message
+			close(sent)
+			return nil
+		})
+
+	suite.authService.ForgotPassword(user.Email)
+	suite.waitFor(sent)
+
+	suite.Require().NotNil(storedToken)
+	assert.Equal(suite.T(), user.ID, storedToken.UserID)
+	assert.True(suite.T(), storedToken.IsUsable(time.Now()))
+
+	// Note: only the hash is stored, the plain token is the last line before the expiration notice.
+	_, plainToken, _ := strings.Cut(sentMessage.Body, "abaixo:\n\n")
+	plainToken, _, _ = strings.Cut(plainToken, "\n")
+	assert.Equal(suite.T(), crypt.HashToken(plainToken), storedToken.HashedToken)
+	assert.Equal(suite.T(), user.Email, sentMessage.To)
+}
+
+// waitFor waits for the channel to be closed by the work done in the background, failing after a second.
+func (suite *AuthServiceTestSuite) waitFor(done <-chan struct{}) {
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		suite.FailNow("timed out waiting for the background work")
+	}
+}
+
+// TestResetPassword_InvalidToken tests the reset with an unknown token.
+func (suite *AuthServiceTestSuite) TestResetPassword_InvalidToken() {
+	suite.repoMock.EXPECT().
+		GetPasswordResetToken(crypt.HashToken("unknown")).
+		Return(nil, errors.New("record not found"))
+
+	err := suite.authService.ResetPassword(auth.ResetPasswordInput{Token: "unknown", Password: "new-password"})
+	assert.Equal(suite.T(), &auth.ErrInvalidResetToken, err)
+}
+
+// TestResetPassword_ExpiredToken tests the reset with an expired token.
+func (suite *AuthServiceTestSuite) TestResetPassword_ExpiredToken() {
+	suite.repoMock.EXPECT().
+		GetPasswordResetToken(gomock.Any()).
+		Return(&entity.PasswordResetToken{ExpiresAt: time.Now().Add(-time.Minute)}, nil)
+
+	err := suite.authService.ResetPassword(auth.ResetPasswordInput{Token: "expired", Password: "new-password"})
+	assert.Equal(suite.T(), &auth.ErrInvalidResetToken, err)
+}
+
+// TestResetPassword_PasswordPolicyViolation tests that the new password is checked against the user email.
+func (suite *AuthServiceTestSuite) TestResetPassword_PasswordPolicyViolation() {
+	resetToken := &entity.PasswordResetToken{
+		UserID:    7,
+		User:      entity.User{Email: "johnny@example.com"},
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+
+	suite.repoMock.EXPECT().
+		GetPasswordResetToken(crypt.HashToken("valid")).
+		Return(resetToken, nil)
+
+	err := suite.authService.ResetPassword(auth.ResetPasswordInput{Token: "valid", Password: "johnny-2024"})
+	assert.Equal(suite.T(), &auth.ErrPasswordContainsEmail, err)
+}
+
+// TestResetPassword_Success tests that the new password is hashed and stored.
+func (suite *AuthServiceTestSuite) TestResetPassword_Success() {
+	resetToken := &entity.PasswordResetToken{UserID: 7, ExpiresAt: time.Now().Add(time.Minute)}
+
+	suite.repoMock.EXPECT().
+		GetPasswordResetToken(crypt.HashToken("valid")).
+		Return(resetToken, nil)
+
+	suite.cryptMock.EXPECT().
+		HashPassword("new-password").
+		Return("hashedPassword", nil)
+
+	suite.repoMock.EXPECT().
+		ResetPassword(*resetToken, "hashedPassword", gomock.Any()).
+		Return(nil)
+
+	err := suite.authService.ResetPassword(auth.ResetPasswordInput{Token: "valid", Password: "new-password"})
+	assert.NoError(suite.T(), err)
+}
+
+// TestGetProfile_UserNotFound tests the profile of an unknown user.
+func (suite *AuthServiceTestSuite) TestGetProfile_UserNotFound() {
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: 7}).
+		Return(nil, errors.New("record not found"))
+
+	_, err := suite.authService.GetProfile(7)
+	assert.Equal(suite.T(), &auth.ErrUserNotFound, err)
+}
+
+// TestGetProfile_Success tests the profile of the user.
+func (suite *AuthServiceTestSuite) TestGetProfile_Success() {
+	user := mfaUser()
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: user.ID}).
+		Return(user, nil)
+
+	response, err := suite.authService.GetProfile(user.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), auth.ToUserResponse(*user), *response)
+}
+
+// TestUpdateProfile_SameEmail tests that keeping the email address doesn't require a new verification.
+func (suite *AuthServiceTestSuite) TestUpdateProfile_SameEmail() {
+	verifiedAt := time.Now()
+	user := &entity.User{Email: "user@example.com", VerifiedAt: &verifiedAt}
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: 7}).
+		Return(user, nil)
+
+	response, err := suite.authService.UpdateProfile(auth.UpdateProfileInput{UserID: 7, Email: "USER@example.com"})
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), response.EmailVerified)
+}
+
+// TestUpdateProfile_EmailAlreadyInUse tests the change to the email address of another account.
+func (suite *AuthServiceTestSuite) TestUpdateProfile_EmailAlreadyInUse() {
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: 7}).
+		Return(&entity.User{Email: "user@example.com"}, nil)
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{Email: "taken@example.com"}).
+		Return(&entity.User{Email: "taken@example.com"}, nil)
+
+	_, err := suite.authService.UpdateProfile(auth.UpdateProfileInput{UserID: 7, Email: "taken@example.com"})
+	assert.Equal(suite.T(), &auth.ErrEmailAlreadyInUse, err)
+}
+
+// TestUpdateProfile_Success tests that the new email address must be verified again.
+func (suite *AuthServiceTestSuite) TestUpdateProfile_Success() {
+	verifiedAt := time.Now()
+	user := &entity.User{Email: "user@example.com", VerifiedAt: &verifiedAt}
+	user.ID = 7
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: user.ID}).
+		Return(user, nil)
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{Email: "new@example.com"}).
+		Return(nil, errors.New("record not found"))
+
+	suite.repoMock.EXPECT().
+		UpdateEmail(user.ID, "new@example.com").
+		Return(nil)
+
+	var sentMessage mail.Message
+	suite.mailMock.EXPECT().
+		Send(gomock.Any()).
+		DoAndReturn(func(message mail.Message) error {
+			sentMessage = message
+			return nil
+		})
+
+	response, err := suite.authService.UpdateProfile(auth.UpdateProfileInput{UserID: user.ID, Email: "new@example.com"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "new@example.com", response.Email)
+	assert.False(suite.T(), response.EmailVerified)
+	assert.Equal(suite.T(), "new@example.com", sentMessage.To)
+	assert.Contains(suite.T(), sentMessage.Body, verificationPolicy.URL+"?token=")
+}
+
+// TestChangePassword_IncorrectPassword tests the change with a wrong current password.
+func (suite *AuthServiceTestSuite) TestChangePassword_IncorrectPassword() {
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: 7}).
+		Return(&entity.User{Password: "hashedPassword"}, nil)
+
+	suite.cryptMock.EXPECT().
+		CheckPasswordHash("wrong-password", "hashedPassword").
+		Return(false)
+
+	_, err := suite.authService.ChangePassword(auth.ChangePasswordInput{UserID: 7, CurrentPassword: "wrong-password", NewPassword: "new-password"})
+	assert.Equal(suite.T(), &auth.ErrIncorrectPassword, err)
+}
+
+// TestChangePassword_PasswordPolicyViolation tests that the new password is checked against the policy.
+func (suite *AuthServiceTestSuite) TestChangePassword_PasswordPolicyViolation() {
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: 7}).
+		Return(&entity.User{Email: "user@example.com", Password: "hashedPassword"}, nil)
+
+	suite.cryptMock.EXPECT().
+		CheckPasswordHash("password123", "hashedPassword").
+		Return(true)
+
+	_, err := suite.authService.ChangePassword(auth.ChangePasswordInput{UserID: 7, CurrentPassword: "password123", NewPassword: "short"})
+	assert.Equal(suite.T(), &auth.ErrPasswordTooShort, err)
+}
+
+// TestChangePassword_Success tests that the new password is stored and a new access token is issued.
+func (suite *AuthServiceTestSuite) TestChangePassword_Success() {
+	user := &entity.User{Email: "user@example.com", Password: "hashedPassword"}
+	user.ID = 7
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: user.ID}).
+		Return(user, nil)
+
+	suite.cryptMock.EXPECT().
+		CheckPasswordHash("password123", "hashedPassword").
+		Return(true)
+
+	suite.cryptMock.EXPECT().
+		HashPassword("new-password").
+		Return("newHashedPassword", nil)
+
+	var changedAt time.Time
+	suite.repoMock.EXPECT().
+		ChangePassword(user.ID, "newHashedPassword", gomock.Any()).
+		DoAndReturn(func(_ uint, _ string, at time.Time) error {
+			changedAt = at
+			return nil
+		})
+
+	suite.repoMock.EXPECT().
+		CreateSession(gomock.Any()).
+		Return(nil)
+
+	response, err := suite.authService.ChangePassword(auth.ChangePasswordInput{UserID: user.ID, CurrentPassword: "password123", NewPassword: "new-password"})
+	suite.Require().NoError(err)
+
+	// The new access token must survive the revocation of the previous ones
+	claims, err := token.ValidateToken("", response.JWTToken)
+	suite.Require().NoError(err)
+	user.CredentialsChangedAt = &changedAt
+	assert.False(suite.T(), user.IsTokenRevoked(claims.IssuedAt))
+}
+
+// TestDeleteAccount_Success tests the deletion of the account.
+func (suite *AuthServiceTestSuite) TestDeleteAccount_Success() {
+	user := &entity.User{}
+	user.ID = 7
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: user.ID}).
+		Return(user, nil)
+
+	suite.repoMock.EXPECT().
+		DeleteUser(user.ID).
+		Return(nil)
+
+	assert.NoError(suite.T(), suite.authService.DeleteAccount(user.ID))
+}
+
+// TestIsTokenRevoked tests the revocation of access tokens issued before the last credentials change.
+func (suite *AuthServiceTestSuite) TestIsTokenRevoked() {
+	changedAt := time.Now()
+	user := &entity.User{CredentialsChangedAt: &changedAt}
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: 7}).
+		Return(user, nil).
+		Times(2)
+
+	newClaims := func(issuedAt time.Time) *token.CustomClaims {
+		claims := &token.CustomClaims{CustomKeys: map[string]any{"ID": float64(7)}}
+		claims.IssuedAt = issuedAt.Unix()
+		return claims
+	}
+
+	assert.True(suite.T(), suite.authService.IsTokenRevoked(newClaims(changedAt.Add(-time.Hour))))
+	assert.False(suite.T(), suite.authService.IsTokenRevoked(newClaims(changedAt.Add(time.Second))))
+
+	// Tokens not issued to users are never revoked
+	assert.False(suite.T(), suite.authService.IsTokenRevoked(&token.CustomClaims{}))
+
+	// Every token of a disabled user is revoked
+	disabledAt := time.Now()
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: 7}).
+		Return(&entity.User{DisabledAt: &disabledAt}, nil)
+	assert.True(suite.T(), suite.authService.IsTokenRevoked(newClaims(time.Now())))
+}
+
+// TestIsTokenRevoked_Session tests the revocation of access tokens whose session is no longer active.
+func (suite *AuthServiceTestSuite) TestIsTokenRevoked_Session() {
+	now := time.Now()
+	revokedAt := now.Add(-time.Minute)
+	user := &entity.User{}
+	user.ID = 7
+
+	tests := []struct {
+		name     string
+		session  *entity.Session
+		err      error
+		expected bool
+	}{
+		{"unknown session", nil, errors.New("record not found"), true},
+		{"revoked session", &entity.Session{UserID: 7, ExpiresAt: now.Add(time.Hour), RevokedAt: &revokedAt}, nil, true},
+		{"expired session", &entity.Session{UserID: 7, ExpiresAt: now.Add(-time.Minute)}, nil, true},
+		{"session of another user", &entity.Session{UserID: 8, ExpiresAt: now.Add(time.Hour)}, nil, true},
+		{"active session", &entity.Session{UserID: 7, ExpiresAt: now.Add(time.Hour), LastSeenAt: now}, nil, false},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.repoMock.EXPECT().
+				GetUser(auth.GetUserFilter{ID: 7}).
+				Return(user, nil)
+
+			suite.repoMock.EXPECT().
+				GetSession("token-id").
+				Return(tt.session, tt.err)
+
+			claims := &token.CustomClaims{CustomKeys: map[string]any{"ID": float64(7)}}
+			claims.Id = "token-id"
+			claims.IssuedAt = now.Unix()
+			assert.Equal(suite.T(), tt.expected, suite.authService.IsTokenRevoked(claims))
+		})
+	}
+}
+
+// TestIsTokenRevoked_TouchesSession tests that the last time a session was seen is stored at most once per interval.
+func (suite *AuthServiceTestSuite) TestIsTokenRevoked_TouchesSession() {
+	now := time.Now()
+	user := &entity.User{}
+	user.ID = 7
+	session := &entity.Session{UserID: 7, ExpiresAt: now.Add(time.Hour), LastSeenAt: now.Add(-10 * time.Minute)}
+	session.ID = 3
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: 7}).
+		Return(user, nil)
+
+	suite.repoMock.EXPECT().
+		GetSession("token-id").
+		Return(session, nil)
+
+	suite.repoMock.EXPECT().
+		TouchSession(session.ID, gomock.Any()).
+		Return(nil)
+
+	claims := &token.CustomClaims{CustomKeys: map[string]any{"ID": float64(7)}}
+	claims.Id = "token-id"
+	claims.IssuedAt = now.Unix()
+	assert.False(suite.T(), suite.authService.IsTokenRevoked(claims))
+}
+
+// TestListSessions tests the listing of sessions, skipping the ones revoked by a credentials change.
+func (suite *AuthServiceTestSuite) TestListSessions() {
+	changedAt := time.Now().Add(-time.Hour)
+	user := &entity.User{CredentialsChangedAt: &changedAt}
+	user.ID = 7
+
+	current := entity.Session{UserID: 7, TokenID: "current"}
+	current.ID = 1
+	current.CreatedAt = time.Now()
+	other := entity.Session{UserID: 7, TokenID: "other"}
+	other.ID = 2
+	other.CreatedAt = time.Now().Add(-time.Minute)
+	stale := entity.Session{UserID: 7, TokenID: "stale"}
+	stale.ID = 3
+	stale.CreatedAt = changedAt.Add(-time.Minute)
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: 7}).
+		Return(user, nil)
+
+	suite.repoMock.EXPECT().
+		ListSessions(user.ID, gomock.Any()).
+		Return([]entity.Session{current, other, stale}, nil)
+
+	response, err := suite.authService.ListSessions(7, "current")
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), []auth.SessionResponse{
+		auth.ToSessionResponse(current, "current"),
+		auth.ToSessionResponse(other, "current"),
+	}, response)
+	assert.True(suite.T(), response[0].Current)
+	assert.False(suite.T(), response[1].Current)
+}
+
+// TestRevokeSession tests the termination of a session.
+func (suite *AuthServiceTestSuite) TestRevokeSession() {
+	tests := []struct {
+		name     string
+		repoErr  error
+		expected error
+	}{
+		{"terminated", nil, nil},
+		{"failure", errors.New("connection refused"), &auth.ErrOperationFailed},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.repoMock.EXPECT().
+				RevokeSession(uint(7), uint(3), gomock.Any()).
+				Return(tt.repoErr)
+
+			err := suite.authService.RevokeSession(7, 3)
+			assert.Equal(suite.T(), tt.expected, err)
+		})
+	}
+}
+
+// federatedCallback starts a login at the stub identity provider, authenticating the given user there,
+// and returns the input of the resulting callback.
+func (suite *AuthServiceTestSuite) federatedCallback(user oidctest.User) auth.CompleteFederatedLoginInput {
+	authURL, err := suite.authService.BeginFederatedLogin()
+	suite.Require().NoError(err)
+
+	code, state, err := suite.oidcServer.Authorize(authURL, user)
+	suite.Require().NoError(err)
+
+	return auth.CompleteFederatedLoginInput{Code: code, State: state, IP: "10.0.0.1"}
+}
+
+// TestBeginFederatedLogin_Disabled tests that federated logins are rejected without identity provider.
+func (suite *AuthServiceTestSuite) TestBeginFederatedLogin_Disabled() {
+	authService := auth.NewService(suite.repoMock, suite.cryptMock, nil, cache.NewManager(time.Minute), suite.mailMock, nil, auth.Policy{})
+
+	_, err := authService.BeginFederatedLogin()
+	assert.Equal(suite.T(), &auth.ErrFederationDisabled, err)
+
+	_, err = authService.CompleteFederatedLogin(auth.CompleteFederatedLoginInput{Code: "code", State: "state"})
+	assert.Equal(suite.T(), &auth.ErrFederationDisabled, err)
+}
+
+// TestCompleteFederatedLogin_InvalidState tests callbacks with an unknown state or a code rejected by the provider.
+func (suite *AuthServiceTestSuite) TestCompleteFederatedLogin_InvalidState() {
+	_, err := suite.authService.CompleteFederatedLogin(auth.CompleteFederatedLoginInput{Code: "code", State: "unknown"})
+	assert.Equal(suite.T(), &auth.ErrInvalidFederatedLogin, err)
+
+	input := suite.federatedCallback(oidctest.User{Subject: "42", Email: "user@example.com", EmailVerified: true})
+	input.Code = "forged-code"
+	_, err = suite.authService.CompleteFederatedLogin(input)
+	assert.Equal(suite.T(), &auth.ErrInvalidFederatedLogin, err)
+}
+
+// TestCompleteFederatedLogin_LinkedIdentity tests the login of an identity already linked to a user,
+// which can't be replayed with the same state.
+func (suite *AuthServiceTestSuite) TestCompleteFederatedLogin_LinkedIdentity() {
+	verifiedAt := time.Now()
+	user := &entity.User{Email: "user@example.com", Role: entity.RoleUser, VerifiedAt: &verifiedAt}
+	user.ID = 7
+
+	input := suite.federatedCallback(oidctest.User{Subject: "42", Email: "renamed@example.com", EmailVerified: true})
+
+	suite.repoMock.EXPECT().
+		GetFederatedIdentity(suite.oidcServer.URL, "42").
+		Return(&entity.FederatedIdentity{UserID: 7, Issuer: suite.oidcServer.URL, Subject: "42"}, nil)
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{ID: 7}).
+		Return(user, nil)
+
+	suite.repoMock.EXPECT().
+		CreateSession(gomock.Any()).
+		Return(nil)
+
+	response, err := suite.authService.CompleteFederatedLogin(input)
+	suite.Require().NoError(err)
+	assert.NotEmpty(suite.T(), response.JWTToken)
+	assert.Equal(suite.T(), uint(7), response.UserID)
+
+	_, err = suite.authService.CompleteFederatedLogin(input)
+	assert.Equal(suite.T(), &auth.ErrInvalidFederatedLogin, err)
+}
+
+// TestCompleteFederatedLogin_NewUser tests that unknown identities are registered as verified users without password.
+func (suite *AuthServiceTestSuite) TestCompleteFederatedLogin_NewUser() {
+	input := suite.federatedCallback(oidctest.User{Subject: "42", Email: "new@example.com", EmailVerified: true})
+
+	suite.repoMock.EXPECT().
+		GetFederatedIdentity(suite.oidcServer.URL, "42").
+		Return(nil, errors.New("record not found"))
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{Email: "new@example.com"}).
+		Return(nil, errors.New("record not found"))
+
+	suite.repoMock.EXPECT().
+		RegisterFederatedUser(gomock.Any(), &entity.FederatedIdentity{Issuer: suite.oidcServer.URL, Subject: "42", Email: "new@example.com"}).
+		DoAndReturn(func(user *entity.User, identity *entity.FederatedIdentity) error {
+			assert.Equal(suite.T(), "new@example.com", user.Email)
+			assert.Empty(suite.T(), user.Password)
+			assert.True(suite.T(), user.IsVerified())
+			user.ID = 8
+			return nil
+		})
+
+	suite.repoMock.EXPECT().
+		CreateSession(gomock.Any()).
+		Return(nil)
+
+	response, err := suite.authService.CompleteFederatedLogin(input)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), uint(8), response.UserID)
+}
+
+// TestCompleteFederatedLogin_UnverifiedEmail tests that identities aren't linked to existing accounts
+// by an email the provider didn't verify.
+func (suite *AuthServiceTestSuite) TestCompleteFederatedLogin_UnverifiedEmail() {
+	user := &entity.User{Email: "user@example.com"}
+	user.ID = 7
+
+	input := suite.federatedCallback(oidctest.User{Subject: "42", Email: "user@example.com"})
+
+	suite.repoMock.EXPECT().
+		GetFederatedIdentity(suite.oidcServer.URL, "42").
+		Return(nil, errors.New("record not found"))
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{Email: "user@example.com"}).
+		Return(user, nil)
+
+	_, err := suite.authService.CompleteFederatedLogin(input)
+	assert.Equal(suite.T(), &auth.ErrFederatedEmailUnverified, err)
+}
+
+// TestCompleteFederatedLogin_LinkExistingUser tests that a verified email links the identity to the existing account,
+// which still goes through its second factor.
+func (suite *AuthServiceTestSuite) TestCompleteFederatedLogin_LinkExistingUser() {
+	user := mfaUser()
+
+	input := suite.federatedCallback(oidctest.User{Subject: "42", Email: user.Email, EmailVerified: true})
+
+	suite.repoMock.EXPECT().
+		GetFederatedIdentity(suite.oidcServer.URL, "42").
+		Return(nil, errors.New("record not found"))
+
+	suite.repoMock.EXPECT().
+		GetUser(auth.GetUserFilter{Email: user.Email}).
+		Return(user, nil)
+
+	suite.repoMock.EXPECT().
+		CreateFederatedIdentity(&entity.FederatedIdentity{UserID: user.ID, Issuer: suite.oidcServer.URL, Subject: "42", Email: user.Email}).
+		Return(nil)
+
+	suite.repoMock.EXPECT().
+		MarkUserVerified(user.ID, gomock.Any()).
+		Return(nil)
+
+	response, err := suite.authService.CompleteFederatedLogin(input)
+	suite.Require().NoError(err)
+	assert.True(suite.T(), response.MFARequired)
+	assert.NotEmpty(suite.T(), response.MFAToken)
+	assert.Empty(suite.T(), response.JWTToken)
 }
 
 // TestAuthServiceTestSuite runs the test suite for the authentication service.
@@ -0,0 +1,183 @@
+package cacheadmin
+
+import (
+	"fmt"
+	"luizalabs-technical-test/internal/pkg/audit"
+	"luizalabs-technical-test/pkg/errors"
+	"luizalabs-technical-test/pkg/logger"
+	"luizalabs-technical-test/pkg/middleware"
+	"luizalabs-technical-test/pkg/server"
+	"luizalabs-technical-test/pkg/token"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// swagStatsResponse is used to work around Swagger's lack of support for Go generics.
+type swagStatsResponse = server.APIResponse[StatsResponse]
+
+// swagEntryResponse is used to work around Swagger's lack of support for Go generics.
+type swagEntryResponse = server.APIResponse[EntryResponse]
+
+// swagPurgeResponse is used to work around Swagger's lack of support for Go generics.
+type swagPurgeResponse = server.APIResponse[PurgeResponse]
+
+// HandlerImp defines the interface for handling server operations.
+// It embeds the server.HandlerImp interface, allowing for extended functionality and custom implementations.
+type HandlerImp interface {
+	server.HandlerImp
+}
+
+// handler struct holds a reference to the service layer, the middlewares restricting the routes to administrators
+// and the recorder of the audit log.
+type handler struct {
+	service    ServiceImp
+	tokenLayer middleware.Middleware
+	adminLayer middleware.Middleware
+	recorder   audit.Recorder
+}
+
+// NewHandler creates and returns a new handler instance.
+func NewHandler(service ServiceImp, tokenMiddleware, adminMiddleware middleware.Middleware, recorder audit.Recorder) HandlerImp {
+	return &handler{service, tokenMiddleware, adminMiddleware, recorder}
+}
+
+// Register sets up the routes for inspecting and purging the cache, restricted to administrators without organization
+// by the admin middleware, which must be created with middleware.NewGlobalAdminMiddleware.
+func (h *handler) Register(r *gin.RouterGroup) {
+	g := r.Group("/admin/cache", h.tokenLayer.Middleware(), h.adminLayer.Middleware())
+	g.GET("/stats", h.getStats)
+	g.GET("/entries", h.getEntry)
+	g.POST("/purge", h.postPurge)
+}
+
+// getStats returns the usage of the cache.
+//
+//	@Summary		Get cache statistics
+//	@Description	Returns the hits and misses counted by this replica, and the entries, bytes and evictions of the cache backend. Restricted to administrators without organization.
+//	@Tags			admin
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Authorization token"
+//	@Success		200				{object}	swagStatsResponse		"Cache statistics"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse	"Forbidden"
+//	@Router			/v1/admin/cache/stats [get]
+func (h *handler) getStats(c *gin.Context) {
+	c.JSON(http.StatusOK, swagStatsResponse{Data: h.service.GetStats()})
+}
+
+// getEntry returns a cached entry.
+//
+//	@Summary		Inspect a cache entry
+//	@Description	Returns the entry cached under the key, with the seconds left before it expires. JSON values are returned as is, other values as base64. Only cached responses (response:) and addresses (zipcode:) can be inspected. Restricted to administrators without organization.
+//	@Tags			admin
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Authorization token"
+//	@Param			key				query		string					true	"Cache key (e.g. zipcode:01001000)"
+//	@Success		200				{object}	swagEntryResponse		"Cache entry"
+//	@Failure		400				{object}	server.APIErrorResponse	"Missing or unmanaged key"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse	"Forbidden"
+//	@Failure		404				{object}	server.APIErrorResponse	"Key not cached"
+//	@Router			/v1/admin/cache/entries [get]
+func (h *handler) getEntry(c *gin.Context) {
+	key := c.Query("key")
+	if key == "" {
+		c.JSON(http.StatusBadRequest, server.APIErrorResponse{
+			Error: ErrInvalidKey.Error(),
+			Code:  ErrInvalidKey.Code,
+		})
+		return
+	}
+
+	response, err := h.service.GetEntry(key)
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, swagEntryResponse{Data: *response})
+}
+
+// postPurge purges the cache, recording the purge in the audit log.
+//
+//	@Summary		Purge the cache
+//	@Description	Removes one key, the keys starting with a prefix, the responses cached for a user (by email) or all the cached responses and addresses. Keys and prefixes must start with response: or zipcode:. Exactly one target must be given. Restricted to administrators without organization.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Authorization token"
+//	@Param			payload			body		PostPurgePayload		true	"Purge target"
+//	@Success		200				{object}	swagPurgeResponse		"Purged entries"
+//	@Failure		400				{object}	server.APIErrorResponse	"Bad request"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse	"Forbidden"
+//	@Router			/v1/admin/cache/purge [post]
+func (h *handler) postPurge(c *gin.Context) {
+	var payload PostPurgePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, server.APIErrorResponse{
+			Error: ErrInvalidPayload.WithErr(err).Error(),
+			Code:  ErrInvalidPayload.Code,
+		})
+		return
+	}
+
+	claims, _ := token.ClaimsFromContext(c)
+	event := audit.Event{ActorID: claims.UintKey("ID"), Actor: claims.StringKey("Email"), Action: audit.ActionCachePurge}
+
+	response, err := h.service.Purge(payload.ToPurgeInput())
+	if err != nil {
+		event.Outcome = audit.OutcomeFailure
+		event.Reason = err.(errors.ErrorImp).CodeStr()
+		h.recorder.Record(c, event)
+		h.abortWithError(c, err)
+		return
+	}
+
+	event.Outcome = audit.OutcomeSuccess
+	event.Reason = purgeReason(response.Target, purgedValue(payload), response.Purged)
+	h.recorder.Record(c, event)
+	logger.Warn(fmt.Sprintf("cache purged by %s: %s", event.Actor, event.Reason))
+
+	c.JSON(http.StatusOK, swagPurgeResponse{Data: *response})
+}
+
+// abortWithError maps service errors to their HTTP status codes.
+func (h *handler) abortWithError(c *gin.Context, err error) {
+	code := err.(errors.ErrorImp).CodeStr()
+
+	status := http.StatusInternalServerError
+	switch code {
+	case ErrCodeKeyNotFound:
+		status = http.StatusNotFound
+	case ErrCodeInvalidPayload, ErrCodeKeyNotManaged:
+		status = http.StatusBadRequest
+	}
+
+	c.AbortWithStatusJSON(status, server.APIErrorResponse{
+		Error: err.Error(),
+		Code:  code,
+	})
+}
+
+// purgeReason describes the purge in the audit log, as the target and its key, prefix or user, followed by the number of
+// entries purged.
+func purgeReason(target, value string, purged int64) string {
+	if target == TargetAll {
+		return fmt.Sprintf("%s purged=%d", target, purged)
+	}
+	return fmt.Sprintf("%s=%s purged=%d", target, value, purged)
+}
+
+// purgedValue returns the key, prefix or user given in the payload, empty when everything is purged.
+func purgedValue(payload PostPurgePayload) string {
+	switch {
+	case payload.Key != "":
+		return payload.Key
+	case payload.Prefix != "":
+		return payload.Prefix
+	default:
+		return payload.User
+	}
+}
@@ -0,0 +1,189 @@
+package cacheadmin_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"luizalabs-technical-test/internal/features/cacheadmin"
+	"luizalabs-technical-test/internal/features/cacheadmin/mock"
+	"luizalabs-technical-test/internal/pkg/audit"
+	auditMock "luizalabs-technical-test/internal/pkg/audit/mock"
+	middlewareMock "luizalabs-technical-test/internal/pkg/middleware/mock"
+	"luizalabs-technical-test/pkg/token"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// HandlerTestSuite is the struct for the test suite
+type HandlerTestSuite struct {
+	suite.Suite
+	ctrl    *gomock.Controller
+	router  *gin.Engine
+	mockSvc *mock.MockServiceImp
+	mockRec *auditMock.MockRecorder
+}
+
+// SetupTest initializes the test suite
+func (s *HandlerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	gin.SetMode(gin.TestMode)
+	s.router = gin.Default()
+	s.mockSvc = mock.NewMockServiceImp(s.ctrl)
+	s.mockRec = auditMock.NewMockRecorder(s.ctrl)
+
+	tokenMiddleware := middlewareMock.NewMockTokenMiddleware(s.ctrl)
+	tokenMiddleware.EXPECT().Middleware().Return(gin.HandlerFunc(func(c *gin.Context) {
+		c.Set(token.ClaimsHeaderName, &token.CustomClaims{CustomKeys: map[string]any{"ID": float64(1), "Email": "admin@example.com"}})
+		c.Next()
+	})).AnyTimes()
+
+	adminMiddleware := middlewareMock.NewMockAdminMiddleware(s.ctrl)
+	adminMiddleware.EXPECT().Middleware().Return(gin.HandlerFunc(func(c *gin.Context) { c.Next() })).AnyTimes()
+
+	cacheadmin.NewHandler(s.mockSvc, tokenMiddleware, adminMiddleware, s.mockRec).Register(s.router.Group("/v1"))
+}
+
+// TearDownTest cleans up after the test suite
+func (s *HandlerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// request performs a request against the router.
+func (s *HandlerTestSuite) request(method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// TestGetStats_Success tests the statistics of the cache
+func (s *HandlerTestSuite) TestGetStats_Success() {
+	s.mockSvc.EXPECT().GetStats().Return(cacheadmin.StatsResponse{Hits: 3, Misses: 1, HitRatio: 0.75, Entries: 2})
+
+	w := s.request(http.MethodGet, "/v1/admin/cache/stats", "")
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"hit_ratio":0.75`)
+}
+
+// TestGetEntry tests the status codes of the inspection of a key
+func (s *HandlerTestSuite) TestGetEntry() {
+	tests := []struct {
+		name     string
+		path     string
+		mock     func()
+		expected int
+	}{
+		{"missing key", "/v1/admin/cache/entries", func() {}, http.StatusBadRequest},
+		{"unknown key", "/v1/admin/cache/entries?key=zipcode:01001000", func() {
+			s.mockSvc.EXPECT().GetEntry("zipcode:01001000").Return(nil, &cacheadmin.ErrKeyNotFound)
+		}, http.StatusNotFound},
+		{"unmanaged key", "/v1/admin/cache/entries?key=auth:oidc-state:state", func() {
+			s.mockSvc.EXPECT().GetEntry("auth:oidc-state:state").Return(nil, &cacheadmin.ErrKeyNotManaged)
+		}, http.StatusBadRequest},
+		{"cached key", "/v1/admin/cache/entries?key=zipcode:01001000", func() {
+			s.mockSvc.EXPECT().GetEntry("zipcode:01001000").Return(&cacheadmin.EntryResponse{Key: "zipcode:01001000"}, nil)
+		}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mock()
+
+			w := s.request(http.MethodGet, tt.path, "")
+			assert.Equal(s.T(), tt.expected, w.Code)
+		})
+	}
+}
+
+// TestPostPurge_BadRequestError tests the error in parse payload params, which isn't recorded
+func (s *HandlerTestSuite) TestPostPurge_BadRequestError() {
+	tooLong := `{"key":"response:` + strings.Repeat("a", 400) + `"}`
+	for _, body := range []string{`{`, `{"user":"not-an-email"}`, tooLong} {
+		w := s.request(http.MethodPost, "/v1/admin/cache/purge", body)
+		assert.Equal(s.T(), http.StatusBadRequest, w.Code, body)
+	}
+}
+
+// TestPostPurge_InvalidTarget tests that purges without exactly one target are rejected and recorded as failures
+func (s *HandlerTestSuite) TestPostPurge_InvalidTarget() {
+	s.mockSvc.EXPECT().
+		Purge(cacheadmin.PurgeInput{Key: "a", All: true}).
+		Return(nil, &cacheadmin.ErrInvalidPayload)
+	s.mockRec.EXPECT().Record(gomock.Any(), audit.Event{
+		ActorID: 1,
+		Actor:   "admin@example.com",
+		Action:  audit.ActionCachePurge,
+		Outcome: audit.OutcomeFailure,
+		Reason:  cacheadmin.ErrCodeInvalidPayload,
+	})
+
+	w := s.request(http.MethodPost, "/v1/admin/cache/purge", `{"key":"a","all":true}`)
+
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+// TestPostPurge_Success tests that purges are recorded with their target, the purged key, prefix or user and the count
+func (s *HandlerTestSuite) TestPostPurge_Success() {
+	tests := []struct {
+		name     string
+		body     string
+		input    cacheadmin.PurgeInput
+		response cacheadmin.PurgeResponse
+		reason   string
+	}{
+		{
+			name:     "User",
+			body:     `{"user":"user@example.com"}`,
+			input:    cacheadmin.PurgeInput{User: "user@example.com"},
+			response: cacheadmin.PurgeResponse{Target: cacheadmin.TargetUser, Purged: 2},
+			reason:   "user=user@example.com purged=2",
+		},
+		{
+			name:     "Prefix",
+			body:     `{"prefix":"zipcode:01"}`,
+			input:    cacheadmin.PurgeInput{Prefix: "zipcode:01"},
+			response: cacheadmin.PurgeResponse{Target: cacheadmin.TargetPrefix, Purged: 5},
+			reason:   "prefix=zipcode:01 purged=5",
+		},
+		{
+			name:     "All",
+			body:     `{"all":true}`,
+			input:    cacheadmin.PurgeInput{All: true},
+			response: cacheadmin.PurgeResponse{Target: cacheadmin.TargetAll, Purged: 12},
+			reason:   "all purged=12",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.mockSvc.EXPECT().
+				Purge(tt.input).
+				Return(&tt.response, nil)
+			s.mockRec.EXPECT().Record(gomock.Any(), audit.Event{
+				ActorID: 1,
+				Actor:   "admin@example.com",
+				Action:  audit.ActionCachePurge,
+				Outcome: audit.OutcomeSuccess,
+				Reason:  tt.reason,
+			})
+
+			w := s.request(http.MethodPost, "/v1/admin/cache/purge", tt.body)
+
+			assert.Equal(s.T(), http.StatusOK, w.Code)
+			assert.Contains(s.T(), w.Body.String(), `"target":"`+tt.response.Target+`"`)
+		})
+	}
+}
+
+// TestHandlerTestSuite is the entry point for the test suite
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
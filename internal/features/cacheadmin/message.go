@@ -0,0 +1,37 @@
+package cacheadmin
+
+import "luizalabs-technical-test/pkg/errors"
+
+// Constants representing error codes related to cache management operations.
+const (
+	ErrCodeInvalidPayload = "ERR_CACHE_INVALID_PAYLOAD" // malformed request payload, or not exactly one purge target.
+	ErrCodeInvalidKey     = "ERR_CACHE_INVALID_KEY"     // missing key in the request query.
+	ErrCodeKeyNotFound    = "ERR_CACHE_KEY_NOT_FOUND"   // key not cached, or expired.
+	ErrCodeKeyNotManaged  = "ERR_CACHE_KEY_NOT_MANAGED" // key or prefix outside of the managed namespaces.
+)
+
+var (
+	// ErrInvalidPayload is triggered when the purge payload cannot be parsed or doesn't hold exactly one target.
+	ErrInvalidPayload = errors.Error{
+		Code:    ErrCodeInvalidPayload,
+		Message: "Informe exatamente um alvo para a limpeza do cache: chave, prefixo, usuário ou tudo.",
+	}
+
+	// ErrInvalidKey is triggered when the key to inspect is missing.
+	ErrInvalidKey = errors.Error{
+		Code:    ErrCodeInvalidKey,
+		Message: "Informe a chave do cache a ser consultada.",
+	}
+
+	// ErrKeyNotFound is triggered when the inspected key isn't cached.
+	ErrKeyNotFound = errors.Error{
+		Code:    ErrCodeKeyNotFound,
+		Message: "Chave não encontrada no cache.",
+	}
+
+	// ErrKeyNotManaged is triggered when the key or prefix is outside of the cached responses and addresses.
+	ErrKeyNotManaged = errors.Error{
+		Code:    ErrCodeKeyNotManaged,
+		Message: "Apenas as respostas e os endereços em cache podem ser consultados ou removidos.",
+	}
+)
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/features/cacheadmin/service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	cacheadmin "luizalabs-technical-test/internal/features/cacheadmin"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockServiceImp is a mock of ServiceImp interface.
+type MockServiceImp struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceImpMockRecorder
+}
+
+// MockServiceImpMockRecorder is the mock recorder for MockServiceImp.
+type MockServiceImpMockRecorder struct {
+	mock *MockServiceImp
+}
+
+// NewMockServiceImp creates a new mock instance.
+func NewMockServiceImp(ctrl *gomock.Controller) *MockServiceImp {
+	mock := &MockServiceImp{ctrl: ctrl}
+	mock.recorder = &MockServiceImpMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceImp) EXPECT() *MockServiceImpMockRecorder {
+	return m.recorder
+}
+
+// GetEntry mocks base method.
+func (m *MockServiceImp) GetEntry(key string) (*cacheadmin.EntryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntry", key)
+	ret0, _ := ret[0].(*cacheadmin.EntryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntry indicates an expected call of GetEntry.
+func (mr *MockServiceImpMockRecorder) GetEntry(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockServiceImp)(nil).GetEntry), key)
+}
+
+// GetStats mocks base method.
+func (m *MockServiceImp) GetStats() cacheadmin.StatsResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats")
+	ret0, _ := ret[0].(cacheadmin.StatsResponse)
+	return ret0
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockServiceImpMockRecorder) GetStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockServiceImp)(nil).GetStats))
+}
+
+// Purge mocks base method.
+func (m *MockServiceImp) Purge(input cacheadmin.PurgeInput) (*cacheadmin.PurgeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", input)
+	ret0, _ := ret[0].(*cacheadmin.PurgeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockServiceImpMockRecorder) Purge(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockServiceImp)(nil).Purge), input)
+}
@@ -0,0 +1,127 @@
+package cacheadmin
+
+import (
+	"encoding/json"
+	"time"
+
+	"luizalabs-technical-test/pkg/cache"
+)
+
+// Targets of a purge.
+const (
+	TargetKey    = "key"
+	TargetPrefix = "prefix"
+	TargetUser   = "user"
+	TargetAll    = "all"
+)
+
+// PostPurgePayload represents the payload for purging the cache. Exactly one target must be given:
+// a key, a key prefix, the email of a user whose cached responses are purged, or all the cached responses and addresses.
+type PostPurgePayload struct {
+	Key    string `json:"key"    binding:"max=400"`
+	Prefix string `json:"prefix" binding:"max=400"`
+	User   string `json:"user"   binding:"omitempty,email"`
+	All    bool   `json:"all"`
+}
+
+// PurgeInput represents the input structure in service layer for purging the cache.
+type PurgeInput struct {
+	Key    string
+	Prefix string
+	User   string
+	All    bool
+}
+
+// StatsResponse represents the usage of the cache. The hit ratio is zero until the first lookup.
+type StatsResponse struct {
+	Hits      uint64            `json:"hits"`
+	Misses    uint64            `json:"misses"`
+	HitRatio  float64           `json:"hit_ratio"`
+	Entries   int64             `json:"entries"`
+	Bytes     int64             `json:"bytes"`
+	Evictions EvictionsResponse `json:"evictions"`
+}
+
+// EvictionsResponse represents the entries dropped by the in-memory cache, by reason.
+type EvictionsResponse struct {
+	Capacity uint64 `json:"capacity"`
+	Memory   uint64 `json:"memory"`
+	Expired  uint64 `json:"expired"`
+}
+
+// EntryResponse represents a cached entry. JSON values are returned as is, other values as base64 in raw.
+type EntryResponse struct {
+	Key        string          `json:"key"`
+	TTLSeconds int64           `json:"ttl_seconds"`
+	Size       int             `json:"size"`
+	Value      json.RawMessage `json:"value,omitempty" swaggertype:"object"`
+	Raw        []byte          `json:"raw,omitempty"`
+}
+
+// PurgeResponse represents the outcome of a purge.
+type PurgeResponse struct {
+	Target string `json:"target"`
+	Purged int64  `json:"purged"`
+}
+
+// ToPurgeInput maps PostPurgePayload to PurgeInput.
+func (p *PostPurgePayload) ToPurgeInput() PurgeInput {
+	return PurgeInput{
+		Key:    p.Key,
+		Prefix: p.Prefix,
+		User:   p.User,
+		All:    p.All,
+	}
+}
+
+// Target returns the target of the purge, or false unless exactly one is given.
+func (i PurgeInput) Target() (string, bool) {
+	targets := make([]string, 0, 1)
+	if i.Key != "" {
+		targets = append(targets, TargetKey)
+	}
+	if i.Prefix != "" {
+		targets = append(targets, TargetPrefix)
+	}
+	if i.User != "" {
+		targets = append(targets, TargetUser)
+	}
+	if i.All {
+		targets = append(targets, TargetAll)
+	}
+
+	if len(targets) != 1 {
+		return "", false
+	}
+	return targets[0], true
+}
+
+// ToStatsResponse converts the statistics of the cache into their public view.
+func ToStatsResponse(stats cache.Stats) StatsResponse {
+	response := StatsResponse{
+		Hits:    stats.Hits,
+		Misses:  stats.Misses,
+		Entries: stats.Entries,
+		Bytes:   stats.Bytes,
+		Evictions: EvictionsResponse{
+			Capacity: stats.Evictions.Capacity,
+			Memory:   stats.Evictions.Memory,
+			Expired:  stats.Evictions.Expired,
+		},
+	}
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		response.HitRatio = float64(stats.Hits) / float64(lookups)
+	}
+	return response
+}
+
+// ToEntryResponse converts a cached entry into its public view.
+func ToEntryResponse(key string, data []byte, ttl time.Duration) EntryResponse {
+	response := EntryResponse{Key: key, TTLSeconds: int64(ttl / time.Second), Size: len(data)}
+	if json.Valid(data) {
+		response.Value = data
+	} else {
+		response.Raw = data
+	}
+	return response
+}
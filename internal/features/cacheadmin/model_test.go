@@ -0,0 +1,40 @@
+package cacheadmin
+
+import (
+	"testing"
+
+	"luizalabs-technical-test/pkg/cache"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPurgeInputTarget tests that purges need exactly one target.
+func TestPurgeInputTarget(t *testing.T) {
+	tests := []struct {
+		input    PurgeInput
+		expected string
+		ok       bool
+	}{
+		{PurgeInput{Key: "a"}, TargetKey, true},
+		{PurgeInput{Prefix: "a"}, TargetPrefix, true},
+		{PurgeInput{User: "user@example.com"}, TargetUser, true},
+		{PurgeInput{All: true}, TargetAll, true},
+		{PurgeInput{}, "", false},
+		{PurgeInput{Prefix: "a", All: true}, "", false},
+	}
+
+	for _, tt := range tests {
+		target, ok := tt.input.Target()
+		assert.Equal(t, tt.expected, target)
+		assert.Equal(t, tt.ok, ok)
+	}
+}
+
+// TestToStatsResponse tests the hit ratio of the statistics.
+func TestToStatsResponse(t *testing.T) {
+	assert.Zero(t, ToStatsResponse(cache.Stats{}).HitRatio)
+
+	response := ToStatsResponse(cache.Stats{Hits: 3, Misses: 1, Evictions: cache.Evictions{Expired: 2}})
+	assert.Equal(t, 0.75, response.HitRatio)
+	assert.Equal(t, uint64(2), response.Evictions.Expired)
+}
@@ -0,0 +1,96 @@
+package cacheadmin
+
+import (
+	"strings"
+
+	"luizalabs-technical-test/internal/pkg/middleware"
+	"luizalabs-technical-test/pkg/cache"
+)
+
+// ServiceImp defines the interface for the service layer, with methods to inspect and purge the cache.
+type ServiceImp interface {
+	GetStats() StatsResponse
+	GetEntry(key string) (*EntryResponse, error)
+	Purge(input PurgeInput) (*PurgeResponse, error)
+}
+
+// service struct implements the serviceImp interface and holds a reference to the cache, and to the key prefixes
+// of the entries it may inspect and purge.
+type service struct {
+	cacheManager cache.Manager
+	namespaces   []string
+}
+
+// NewService creates and returns a new service instance, injecting the cache dependency.
+// Note: the cache is shared with login attempts, OIDC states and quotas, so only the keys under the namespaces
+// (e.g. cached responses and addresses) are managed.
+func NewService(cacheManager cache.Manager, namespaces ...string) ServiceImp {
+	return &service{cacheManager, namespaces}
+}
+
+// GetStats returns the usage of the cache.
+func (s *service) GetStats() StatsResponse {
+	return ToStatsResponse(s.cacheManager.Stats())
+}
+
+// GetEntry returns the entry cached under the key, with the time left before it expires.
+// Note: the lookup counts as a hit, and refreshes the entry for the eviction of the in-memory cache.
+func (s *service) GetEntry(key string) (*EntryResponse, error) {
+	if !s.isManaged(key) {
+		return nil, &ErrKeyNotManaged
+	}
+
+	data, found := s.cacheManager.Get(key)
+	if !found {
+		return nil, &ErrKeyNotFound
+	}
+
+	ttl, found := s.cacheManager.TTL(key)
+	if !found {
+		return nil, &ErrKeyNotFound
+	}
+
+	response := ToEntryResponse(key, data, ttl)
+	return &response, nil
+}
+
+// Purge removes the entries of the target, returning how many were removed.
+// Purging a user removes the responses cached for them on every route and tenant, purging everything removes
+// the entries of every namespace.
+func (s *service) Purge(input PurgeInput) (*PurgeResponse, error) {
+	target, ok := input.Target()
+	if !ok {
+		return nil, &ErrInvalidPayload
+	}
+	if (target == TargetKey && !s.isManaged(input.Key)) || (target == TargetPrefix && !s.isManaged(input.Prefix)) {
+		return nil, &ErrKeyNotManaged
+	}
+
+	response := PurgeResponse{Target: target}
+	switch target {
+	case TargetKey:
+		if _, found := s.cacheManager.TTL(input.Key); found {
+			response.Purged = 1
+		}
+		s.cacheManager.Delete(input.Key)
+	case TargetPrefix:
+		response.Purged = int64(s.cacheManager.DeleteByPrefix(input.Prefix))
+	case TargetUser:
+		response.Purged = int64(s.cacheManager.DeleteByPrefix(middleware.UserCacheKeyPrefix(input.User)))
+	case TargetAll:
+		for _, namespace := range s.namespaces {
+			response.Purged += int64(s.cacheManager.DeleteByPrefix(namespace))
+		}
+	}
+	return &response, nil
+}
+
+// isManaged reports whether the key, or key prefix, is under one of the namespaces.
+func (s *service) isManaged(key string) bool {
+	for _, namespace := range s.namespaces {
+		if strings.HasPrefix(key, namespace) {
+			return true
+		}
+	}
+	return false
+}
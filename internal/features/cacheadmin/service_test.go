@@ -0,0 +1,99 @@
+package cacheadmin_test
+
+import (
+	"testing"
+	"time"
+
+	"luizalabs-technical-test/internal/features/cacheadmin"
+	"luizalabs-technical-test/pkg/cache"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// CacheAdminServiceTestSuite is a test suite for the cacheadmin service, run against the in-memory cache.
+type CacheAdminServiceTestSuite struct {
+	suite.Suite
+	cacheManager cache.Manager
+	service      cacheadmin.ServiceImp
+}
+
+// SetupTest initializes the test suite, filling a new cache.
+func (suite *CacheAdminServiceTestSuite) SetupTest() {
+	suite.cacheManager = cache.NewManager(time.Minute)
+	suite.service = cacheadmin.NewService(suite.cacheManager, "response:", "zipcode:")
+
+	suite.cacheManager.Set("zipcode:01001000", []byte(`{"city":"SÃO PAULO"}`), time.Hour)
+	suite.cacheManager.Set("zipcode:20040002", []byte(`{"city":"RIO DE JANEIRO"}`), time.Hour)
+	suite.cacheManager.Set("response:user:user@example.com:tenant:0:/v1/zipcode/01001000", []byte("body"), time.Hour)
+	suite.cacheManager.Set("response:user:user@example.com:tenant:3:/v1/zipcode/01001000", []byte("body"), time.Hour)
+	suite.cacheManager.Set("response:user:other@example.com:tenant:0:/v1/zipcode/01001000", []byte("body"), time.Hour)
+	suite.cacheManager.Set("auth:oidc-state:state", []byte("verifier"), time.Hour)
+}
+
+// TearDownTest closes the cache.
+func (suite *CacheAdminServiceTestSuite) TearDownTest() {
+	suite.NoError(suite.cacheManager.Close())
+}
+
+// TestGetEntry tests the inspection of JSON and non-JSON values, and of unknown keys.
+func (suite *CacheAdminServiceTestSuite) TestGetEntry() {
+	entry, err := suite.service.GetEntry("zipcode:01001000")
+	require.NoError(suite.T(), err)
+	assert.JSONEq(suite.T(), `{"city":"SÃO PAULO"}`, string(entry.Value))
+	assert.Nil(suite.T(), entry.Raw)
+	assert.InDelta(suite.T(), int64(time.Hour/time.Second), entry.TTLSeconds, 1)
+
+	entry, err = suite.service.GetEntry("response:user:other@example.com:tenant:0:/v1/zipcode/01001000")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []byte("body"), entry.Raw)
+	assert.Equal(suite.T(), 4, entry.Size)
+
+	_, err = suite.service.GetEntry("zipcode:unknown")
+	assert.Equal(suite.T(), &cacheadmin.ErrKeyNotFound, err)
+
+	_, err = suite.service.GetEntry("auth:oidc-state:state")
+	assert.Equal(suite.T(), &cacheadmin.ErrKeyNotManaged, err)
+}
+
+// TestPurge tests each purge target, and the rejection of zero or several targets, and of keys outside the namespaces.
+func (suite *CacheAdminServiceTestSuite) TestPurge() {
+	_, err := suite.service.Purge(cacheadmin.PurgeInput{})
+	assert.Equal(suite.T(), &cacheadmin.ErrInvalidPayload, err)
+	_, err = suite.service.Purge(cacheadmin.PurgeInput{Key: "a", Prefix: "b"})
+	assert.Equal(suite.T(), &cacheadmin.ErrInvalidPayload, err)
+	_, err = suite.service.Purge(cacheadmin.PurgeInput{Key: "auth:oidc-state:state"})
+	assert.Equal(suite.T(), &cacheadmin.ErrKeyNotManaged, err)
+	_, err = suite.service.Purge(cacheadmin.PurgeInput{Prefix: "auth:"})
+	assert.Equal(suite.T(), &cacheadmin.ErrKeyNotManaged, err)
+
+	response, err := suite.service.Purge(cacheadmin.PurgeInput{User: "user@example.com"})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), &cacheadmin.PurgeResponse{Target: cacheadmin.TargetUser, Purged: 2}, response)
+
+	response, err = suite.service.Purge(cacheadmin.PurgeInput{Key: "zipcode:01001000"})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), response.Purged)
+
+	response, err = suite.service.Purge(cacheadmin.PurgeInput{Key: "zipcode:01001000"})
+	require.NoError(suite.T(), err)
+	assert.Zero(suite.T(), response.Purged)
+
+	response, err = suite.service.Purge(cacheadmin.PurgeInput{Prefix: "zipcode:"})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), response.Purged)
+
+	response, err = suite.service.Purge(cacheadmin.PurgeInput{All: true})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), response.Purged)
+	assert.Equal(suite.T(), int64(1), suite.service.GetStats().Entries)
+
+	_, found := suite.cacheManager.Get("auth:oidc-state:state")
+	assert.True(suite.T(), found)
+}
+
+// TestCacheAdminServiceTestSuite runs the test suite.
+func TestCacheAdminServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(CacheAdminServiceTestSuite))
+}
@@ -17,19 +17,26 @@ type HandlerImp interface {
 	server.HandlerImp
 }
 
-// handler struct is an empty implementation of the Handler interface.
+// Readiness reports whether the service accepts requests, turning false as soon as it starts shutting down.
+type Readiness interface {
+	Ready() bool
+}
+
+// handler struct holds the readiness of the server.
 type handler struct {
+	readiness Readiness
 }
 
 // NewHandler creates and returns a new handler instance.
-func NewHandler() HandlerImp {
-	return &handler{}
+func NewHandler(readiness Readiness) HandlerImp {
+	return &handler{readiness}
 }
 
-// Register sets up the "/ping" route to handle health check requests.
+// Register sets up the "/ping" and "/ready" routes to handle health check requests.
 func (h *handler) Register(r *gin.RouterGroup) {
 	g := r.Group("/health")
 	g.GET("/ping", h.health)
+	g.GET("/ready", h.ready)
 	g.GET("/metrics", h.metricsHandler())
 }
 
@@ -49,6 +56,32 @@ func (h *handler) health(c *gin.Context) {
 	})
 }
 
+// ready handles the readiness check request, failing once the service is shutting down so no new traffic is routed to it.
+//
+//	@Summary		Readiness check
+//	@Description	Responds with a "ready" message while the service accepts requests, and with 503 once it is shutting down.
+//	@Tags			health
+//	@Produce		json
+//	@Success		200	{object}	swagHealthResponse
+//	@Failure		503	{object}	swagHealthResponse
+//	@Router			/v1/health/ready [get]
+func (h *handler) ready(c *gin.Context) {
+	if !h.readiness.Ready() {
+		c.JSON(http.StatusServiceUnavailable, swagHealthResponse{
+			Data: healthResponse{
+				Message: "shutting down",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, swagHealthResponse{
+		Data: healthResponse{
+			Message: "ready",
+		},
+	})
+}
+
 // metricsHandler serves Prometheus metrics endpoint.
 //
 //	@Summary		Expose Prometheus metrics
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -14,7 +15,9 @@ func TestHealthHandler(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	router := gin.New()
-	handler := NewHandler()
+	readiness := &stubReadiness{}
+	readiness.ready.Store(true)
+	handler := NewHandler(readiness)
 	handler.Register(router.Group("/v1"))
 
 	// Test /ping endpoint
@@ -32,6 +35,19 @@ func TestHealthHandler(t *testing.T) {
 		assert.Equal(t, "pong", response.Data.Message)
 	})
 
+	// Test /ready endpoint, before and after the shutdown starts
+	t.Run("GET /ready", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/v1/health/ready", nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		readiness.ready.Store(false)
+		recorder = httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	})
+
 	// Test /metrics endpoint
 	t.Run("GET /metrics", func(t *testing.T) {
 		req, _ := http.NewRequest(http.MethodGet, "/v1/health/metrics", nil)
@@ -42,3 +58,13 @@ func TestHealthHandler(t *testing.T) {
 		assert.Contains(t, recorder.Body.String(), "# HELP")
 	})
 }
+
+// stubReadiness is a Readiness switched by the tests.
+type stubReadiness struct {
+	ready atomic.Bool
+}
+
+// Ready reports the readiness set by the test.
+func (r *stubReadiness) Ready() bool {
+	return r.ready.Load()
+}
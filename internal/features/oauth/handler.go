@@ -0,0 +1,238 @@
+package oauth
+
+import (
+	"luizalabs-technical-test/pkg/errors"
+	"luizalabs-technical-test/pkg/middleware"
+	"luizalabs-technical-test/pkg/server"
+	"luizalabs-technical-test/pkg/token"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+)
+
+// swagRegisteredClientResponse is used to work around Swagger's lack of support for Go generics.
+type swagRegisteredClientResponse = server.APIResponse[RegisteredClientResponse]
+
+// swagListClientsResponse is used to work around Swagger's lack of support for Go generics.
+type swagListClientsResponse = server.APIResponse[[]ClientResponse]
+
+// HandlerImp defines the interface for handling server operations.
+// It embeds the server.HandlerImp interface, allowing for extended functionality and custom implementations.
+type HandlerImp interface {
+	server.HandlerImp
+}
+
+// handler struct holds a reference to the service layer.
+type handler struct {
+	service    ServiceImp
+	tokenLayer middleware.Middleware
+}
+
+// NewHandler creates and returns a new handler instance with the injected service.
+func NewHandler(service ServiceImp, tokenMiddleware middleware.Middleware) HandlerImp {
+	return &handler{service, tokenMiddleware}
+}
+
+// Register sets up the OAuth2 token endpoint and the client registration routes.
+func (h *handler) Register(r *gin.RouterGroup) {
+	g := r.Group("/oauth")
+	g.POST("/token", h.postToken)
+
+	clients := g.Group("/clients", h.tokenLayer.Middleware())
+	clients.POST("", h.postClient)
+	clients.GET("", h.getClients)
+	clients.DELETE("/:client-id", h.deleteClient)
+}
+
+// postToken issues an access token using the client credentials grant.
+//
+//	@Summary		Issue an OAuth2 access token
+//	@Description	Issues an access token for the client credentials grant (RFC 6749, section 4.4). Client credentials may be sent via HTTP Basic authentication or in the form body.
+//	@Tags			oauth
+//	@Accept			x-www-form-urlencoded
+//	@Produce		json
+//	@Param			Authorization	header		string				false	"HTTP Basic client credentials"
+//	@Param			grant_type		formData	string				true	"Must be client_credentials"
+//	@Param			client_id		formData	string				false	"Client ID (when not using HTTP Basic)"
+//	@Param			client_secret	formData	string				false	"Client secret (when not using HTTP Basic)"
+//	@Param			scope			formData	string				false	"Space-delimited list of scopes"
+//	@Success		200				{object}	TokenResponse		"Token generated successfully"
+//	@Failure		400				{object}	TokenErrorResponse	"Invalid request, grant type or scope"
+//	@Failure		401				{object}	TokenErrorResponse	"Client authentication failed"
+//	@Failure		500				{object}	TokenErrorResponse	"Internal server error"
+//	@Router			/v1/oauth/token [post]
+func (h *handler) postToken(c *gin.Context) {
+	// Note: token responses must never be stored by intermediaries (RFC 6749, section 5.1).
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+
+	var payload PostTokenPayload
+	if err := c.ShouldBind(&payload); err != nil {
+		h.abortWithTokenError(c, ErrInvalidRequest.WithErr(err))
+		return
+	}
+
+	if clientID, clientSecret, ok := c.Request.BasicAuth(); ok {
+		if payload.ClientSecret != "" {
+			h.abortWithTokenError(c, ErrInvalidRequest.WithStrErr("more than one client authentication method used"))
+			return
+		}
+		// Note: HTTP Basic credentials are form-urlencoded before being encoded (RFC 6749, section 2.3.1).
+		payload.ClientID, _ = url.QueryUnescape(clientID)
+		payload.ClientSecret, _ = url.QueryUnescape(clientSecret)
+	}
+
+	response, err := h.service.IssueToken(payload.ToIssueTokenInput())
+	if err != nil {
+		h.abortWithTokenError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// postClient registers a new OAuth2 client for the authenticated user.
+//
+//	@Summary		Register an OAuth2 client
+//	@Description	Registers a client allowed to use the client credentials grant. The client secret is only returned in this response.
+//	@Tags			oauth
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string							true	"Authorization token"
+//	@Param			payload			body		PostClientPayload				true	"Client data"
+//	@Success		201				{object}	swagRegisteredClientResponse	"Client successfully registered"
+//	@Failure		400				{object}	server.APIErrorResponse			"Bad request"
+//	@Failure		401				{object}	server.APIErrorResponse			"Unauthorized"
+//	@Failure		500				{object}	server.APIErrorResponse			"Internal server error"
+//	@Router			/v1/oauth/clients [post]
+func (h *handler) postClient(c *gin.Context) {
+	ownerID, ok := h.authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var payload PostClientPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, server.APIErrorResponse{
+			Error: ErrInvalidPayload.WithErr(err).Error(),
+			Code:  ErrInvalidPayload.Code,
+		})
+		return
+	}
+
+	input := payload.ToRegisterClientInput(ownerID)
+	// Note: clients act on behalf of the organization of their owner, carried in their access tokens.
+	if claims, err := token.ClaimsFromContext(c); err == nil {
+		input.TenantID = claims.UintKey("TenantID")
+	}
+
+	response, err := h.service.RegisterClient(input)
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, swagRegisteredClientResponse{Data: *response})
+}
+
+// getClients lists the OAuth2 clients registered by the authenticated user.
+//
+//	@Summary		List OAuth2 clients
+//	@Description	Lists every OAuth2 client registered by the authenticated user, without secrets.
+//	@Tags			oauth
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Authorization token"
+//	@Success		200				{object}	swagListClientsResponse	"OAuth2 clients"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		500				{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/oauth/clients [get]
+func (h *handler) getClients(c *gin.Context) {
+	ownerID, ok := h.authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.service.ListClients(ownerID)
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, swagListClientsResponse{Data: response})
+}
+
+// deleteClient removes an OAuth2 client.
+//
+//	@Summary		Delete an OAuth2 client
+//	@Description	Deletes the given OAuth2 client so it can no longer obtain access tokens.
+//	@Tags			oauth
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@Param			client-id		path	string	true	"Client ID"
+//	@Success		204				"Client successfully deleted"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		404				{object}	server.APIErrorResponse	"Client not found"
+//	@Router			/v1/oauth/clients/{client-id} [delete]
+func (h *handler) deleteClient(c *gin.Context) {
+	ownerID, ok := h.authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteClient(ownerID, c.Param("client-id")); err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// authenticatedUserID extracts the user ID from the claims set by the token middleware.
+func (h *handler) authenticatedUserID(c *gin.Context) (uint, bool) {
+	claims, err := token.ClaimsFromContext(c)
+	if err != nil || claims.UintKey("ID") == 0 {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, server.APIErrorResponse{
+			Error: ErrUnauthorizedUser.WithErr(err).Error(),
+			Code:  ErrUnauthorizedUser.Code,
+		})
+		return 0, false
+	}
+	return claims.UintKey("ID"), true
+}
+
+// abortWithTokenError replies with the standard OAuth2 error payload.
+func (h *handler) abortWithTokenError(c *gin.Context, err error) {
+	code := err.(errors.ErrorImp).CodeStr()
+
+	status := http.StatusBadRequest
+	switch code {
+	case ErrCodeInvalidClient:
+		status = http.StatusUnauthorized
+		c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+	case ErrCodeServerError:
+		status = http.StatusInternalServerError
+	}
+
+	c.AbortWithStatusJSON(status, TokenErrorResponse{
+		Error:            code,
+		ErrorDescription: err.Error(),
+	})
+}
+
+// abortWithError maps client management errors to their HTTP status codes.
+func (h *handler) abortWithError(c *gin.Context, err error) {
+	code := err.(errors.ErrorImp).CodeStr()
+
+	status := http.StatusInternalServerError
+	switch code {
+	case ErrCodeUnsupportedScope:
+		status = http.StatusBadRequest
+	case ErrCodeClientNotFound:
+		status = http.StatusNotFound
+	}
+
+	c.AbortWithStatusJSON(status, server.APIErrorResponse{
+		Error: err.Error(),
+		Code:  code,
+	})
+}
@@ -0,0 +1,169 @@
+package oauth_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"luizalabs-technical-test/internal/features/oauth"
+	"luizalabs-technical-test/internal/features/oauth/mock"
+	middlewareMock "luizalabs-technical-test/internal/pkg/middleware/mock"
+	"luizalabs-technical-test/pkg/token"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// HandlerTestSuite is the struct for the test suite
+type HandlerTestSuite struct {
+	suite.Suite
+	ctrl            *gomock.Controller
+	router          *gin.Engine
+	mockSvc         *mock.MockServiceImp
+	tokenMiddleware *middlewareMock.MockTokenMiddleware
+}
+
+// SetupTest initializes the test suite
+func (s *HandlerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	gin.SetMode(gin.TestMode)
+	s.router = gin.Default()
+	s.mockSvc = mock.NewMockServiceImp(s.ctrl)
+	s.tokenMiddleware = middlewareMock.NewMockTokenMiddleware(s.ctrl)
+
+	// Note: simulates an authenticated user on the client management routes.
+	s.tokenMiddleware.EXPECT().
+		Middleware().
+		Return(func(c *gin.Context) {
+			c.Set(token.ClaimsHeaderName, &token.CustomClaims{CustomKeys: map[string]any{"ID": float64(1), "TenantID": float64(3)}})
+			c.Next()
+		}).
+		AnyTimes()
+
+	oauth.NewHandler(s.mockSvc, s.tokenMiddleware).Register(s.router.Group("/v1"))
+}
+
+// TearDownTest cleans up after the test suite
+func (s *HandlerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// tokenRequest creates a form-encoded request to the token endpoint.
+func tokenRequest(form string) *http.Request {
+	req, _ := http.NewRequest(http.MethodPost, "/v1/oauth/token", strings.NewReader(form))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+// TestPostToken_BadRequestError tests a token request without grant type
+func (s *HandlerTestSuite) TestPostToken_BadRequestError() {
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, tokenRequest("client_id=lzc_client"))
+
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"error":"invalid_request"`)
+	assert.Equal(s.T(), "no-store", w.Header().Get("Cache-Control"))
+}
+
+// TestPostToken_InvalidClient tests a token request with invalid client credentials
+func (s *HandlerTestSuite) TestPostToken_InvalidClient() {
+	s.mockSvc.EXPECT().
+		IssueToken(gomock.Any()).
+		Return(nil, &oauth.ErrInvalidClient)
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, tokenRequest("grant_type=client_credentials&client_id=lzc_client&client_secret=wrong"))
+
+	assert.Equal(s.T(), http.StatusUnauthorized, w.Code)
+	assert.NotEmpty(s.T(), w.Header().Get("WWW-Authenticate"))
+}
+
+// TestPostToken_BasicAuthentication tests that HTTP Basic credentials are forwarded to the service
+func (s *HandlerTestSuite) TestPostToken_BasicAuthentication() {
+	s.mockSvc.EXPECT().
+		IssueToken(oauth.IssueTokenInput{
+			GrantType:    oauth.GrantTypeClientCredentials,
+			ClientID:     "lzc_client",
+			ClientSecret: "se cret",
+			Scopes:       []string{"address:read"},
+		}).
+		Return(&oauth.TokenResponse{AccessToken: "jwt", TokenType: oauth.TokenTypeBearer, ExpiresIn: 3600}, nil)
+
+	req := tokenRequest("grant_type=client_credentials&scope=address:read")
+	req.SetBasicAuth("lzc_client", "se+cret")
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.JSONEq(s.T(), `{"access_token":"jwt","token_type":"Bearer","expires_in":3600}`, w.Body.String())
+}
+
+// TestPostToken_MultipleAuthenticationMethods tests that credentials cannot be sent twice
+func (s *HandlerTestSuite) TestPostToken_MultipleAuthenticationMethods() {
+	req := tokenRequest("grant_type=client_credentials&client_secret=secret")
+	req.SetBasicAuth("lzc_client", "secret")
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+// TestPostClient_BadRequestError tests the error in parse payload params
+func (s *HandlerTestSuite) TestPostClient_BadRequestError() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/oauth/clients", bytes.NewBufferString(`{}`))
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+// TestPostClient_Success tests the successful registration of a client
+func (s *HandlerTestSuite) TestPostClient_Success() {
+	s.mockSvc.EXPECT().
+		RegisterClient(oauth.RegisterClientInput{OwnerID: 1, TenantID: 3, Name: "partner"}).
+		Return(&oauth.RegisteredClientResponse{ClientSecret: "secret"}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/v1/oauth/clients", bytes.NewBufferString(`{"name":"partner"}`))
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusCreated, w.Code)
+}
+
+// TestGetClients_Success tests the listing of clients
+func (s *HandlerTestSuite) TestGetClients_Success() {
+	s.mockSvc.EXPECT().
+		ListClients(uint(1)).
+		Return([]oauth.ClientResponse{{ClientID: "lzc_client"}}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/oauth/clients", nil)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), "lzc_client")
+}
+
+// TestDeleteClient_NotFoundError tests the deletion of an unknown client
+func (s *HandlerTestSuite) TestDeleteClient_NotFoundError() {
+	s.mockSvc.EXPECT().
+		DeleteClient(uint(1), "lzc_client").
+		Return(&oauth.ErrClientNotFound)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/oauth/clients/lzc_client", nil)
+
+	s.router.ServeHTTP(w, req)
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+}
+
+// TestHandlerTestSuite is the entry point for the test suite
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
@@ -0,0 +1,90 @@
+package oauth
+
+import "luizalabs-technical-test/pkg/errors"
+
+// Constants representing the standard OAuth2 error codes returned by the token endpoint (RFC 6749, section 5.2).
+const (
+	ErrCodeInvalidRequest       = "invalid_request"        // missing or malformed parameter.
+	ErrCodeInvalidClient        = "invalid_client"         // unknown client or wrong secret.
+	ErrCodeUnsupportedGrantType = "unsupported_grant_type" // grant type other than client_credentials.
+	ErrCodeInvalidScope         = "invalid_scope"          // scope not granted to the client.
+	ErrCodeServerError          = "server_error"           // failure issuing the access token.
+)
+
+// Constants representing error codes related to OAuth2 client management operations.
+const (
+	ErrCodeInvalidPayload     = "ERR_OAUTH_INVALID_PAYLOAD"   // malformed request payload.
+	ErrCodeClientNotFound     = "ERR_OAUTH_CLIENT_NOT_FOUND"  // client not found for the user.
+	ErrCodeCreationFailed     = "ERR_OAUTH_CREATION_FAILED"   // failure generating or storing the client.
+	ErrCodeOperationFailed    = "ERR_OAUTH_OPERATION_FAILED"  // failure listing or deleting clients.
+	ErrCodeUnauthorizedClient = "ERR_OAUTH_UNAUTHORIZED_USER" // no authenticated user in the request.
+	ErrCodeUnsupportedScope   = "ERR_OAUTH_UNSUPPORTED_SCOPE" // unknown scope requested on registration.
+)
+
+var (
+	// ErrInvalidRequest is triggered when the token request misses a required parameter.
+	ErrInvalidRequest = errors.Error{
+		Code:    ErrCodeInvalidRequest,
+		Message: "A requisição de token está incompleta ou mal formatada.",
+	}
+
+	// ErrInvalidClient is triggered when the client cannot be authenticated.
+	ErrInvalidClient = errors.Error{
+		Code:    ErrCodeInvalidClient,
+		Message: "Falha na autenticação do cliente. Verifique o client_id e o client_secret.",
+	}
+
+	// ErrUnsupportedGrantType is triggered when a grant type other than client_credentials is requested.
+	ErrUnsupportedGrantType = errors.Error{
+		Code:    ErrCodeUnsupportedGrantType,
+		Message: "O tipo de concessão solicitado não é suportado. Utilize client_credentials.",
+	}
+
+	// ErrInvalidScope is triggered when the client requests a scope it was not granted.
+	ErrInvalidScope = errors.Error{
+		Code:    ErrCodeInvalidScope,
+		Message: "O escopo solicitado é inválido ou excede o escopo concedido ao cliente.",
+	}
+
+	// ErrServerError is triggered when the system fails to issue the access token.
+	ErrServerError = errors.Error{
+		Code:    ErrCodeServerError,
+		Message: "Não foi possível emitir o token de acesso. Por favor, tente novamente mais tarde.",
+	}
+
+	// ErrInvalidPayload is triggered when the client registration payload cannot be parsed.
+	ErrInvalidPayload = errors.Error{
+		Code:    ErrCodeInvalidPayload,
+		Message: "Os dados informados para o cliente OAuth são inválidos. Verifique o payload e tente novamente.",
+	}
+
+	// ErrClientNotFound is triggered when the requested client does not exist or belongs to another user.
+	ErrClientNotFound = errors.Error{
+		Code:    ErrCodeClientNotFound,
+		Message: "Cliente OAuth não encontrado.",
+	}
+
+	// ErrCreationFailed is triggered when the system fails to register a new client.
+	ErrCreationFailed = errors.Error{
+		Code:    ErrCodeCreationFailed,
+		Message: "Não foi possível registrar o cliente OAuth. Por favor, tente novamente mais tarde.",
+	}
+
+	// ErrOperationFailed is triggered when listing or deleting clients fails.
+	ErrOperationFailed = errors.Error{
+		Code:    ErrCodeOperationFailed,
+		Message: "Não foi possível concluir a operação com o cliente OAuth. Por favor, tente novamente mais tarde.",
+	}
+
+	// ErrUnauthorizedUser is triggered when no authenticated user is found in the request context.
+	ErrUnauthorizedUser = errors.Error{
+		Code:    ErrCodeUnauthorizedClient,
+		Message: "Usuário não autenticado.",
+	}
+
+	// ErrUnsupportedScope is triggered when a scope outside of the supported list is requested on registration.
+	ErrUnsupportedScope = errors.Error{
+		Code:    ErrCodeUnsupportedScope,
+		Message: "Um ou mais escopos solicitados não são suportados. Verifique os escopos informados.",
+	}
+)
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/features/oauth/repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	oauth "luizalabs-technical-test/internal/features/oauth"
+	entity "luizalabs-technical-test/internal/pkg/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepositoryImp is a mock of RepositoryImp interface.
+type MockRepositoryImp struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryImpMockRecorder
+}
+
+// MockRepositoryImpMockRecorder is the mock recorder for MockRepositoryImp.
+type MockRepositoryImpMockRecorder struct {
+	mock *MockRepositoryImp
+}
+
+// NewMockRepositoryImp creates a new mock instance.
+func NewMockRepositoryImp(ctrl *gomock.Controller) *MockRepositoryImp {
+	mock := &MockRepositoryImp{ctrl: ctrl}
+	mock.recorder = &MockRepositoryImpMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepositoryImp) EXPECT() *MockRepositoryImpMockRecorder {
+	return m.recorder
+}
+
+// CreateClient mocks base method.
+func (m *MockRepositoryImp) CreateClient(client *entity.OAuthClient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateClient", client)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateClient indicates an expected call of CreateClient.
+func (mr *MockRepositoryImpMockRecorder) CreateClient(client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClient", reflect.TypeOf((*MockRepositoryImp)(nil).CreateClient), client)
+}
+
+// DeleteClient mocks base method.
+func (m *MockRepositoryImp) DeleteClient(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClient", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteClient indicates an expected call of DeleteClient.
+func (mr *MockRepositoryImpMockRecorder) DeleteClient(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClient", reflect.TypeOf((*MockRepositoryImp)(nil).DeleteClient), id)
+}
+
+// GetClient mocks base method.
+func (m *MockRepositoryImp) GetClient(filter oauth.GetClientFilter) (*entity.OAuthClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClient", filter)
+	ret0, _ := ret[0].(*entity.OAuthClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClient indicates an expected call of GetClient.
+func (mr *MockRepositoryImpMockRecorder) GetClient(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClient", reflect.TypeOf((*MockRepositoryImp)(nil).GetClient), filter)
+}
+
+// GetOwner mocks base method.
+func (m *MockRepositoryImp) GetOwner(ownerID uint) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwner", ownerID)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwner indicates an expected call of GetOwner.
+func (mr *MockRepositoryImpMockRecorder) GetOwner(ownerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwner", reflect.TypeOf((*MockRepositoryImp)(nil).GetOwner), ownerID)
+}
+
+// ListClients mocks base method.
+func (m *MockRepositoryImp) ListClients(ownerID uint) ([]entity.OAuthClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClients", ownerID)
+	ret0, _ := ret[0].([]entity.OAuthClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClients indicates an expected call of ListClients.
+func (mr *MockRepositoryImpMockRecorder) ListClients(ownerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClients", reflect.TypeOf((*MockRepositoryImp)(nil).ListClients), ownerID)
+}
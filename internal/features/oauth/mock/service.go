@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/features/oauth/service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	oauth "luizalabs-technical-test/internal/features/oauth"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockServiceImp is a mock of ServiceImp interface.
+type MockServiceImp struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceImpMockRecorder
+}
+
+// MockServiceImpMockRecorder is the mock recorder for MockServiceImp.
+type MockServiceImpMockRecorder struct {
+	mock *MockServiceImp
+}
+
+// NewMockServiceImp creates a new mock instance.
+func NewMockServiceImp(ctrl *gomock.Controller) *MockServiceImp {
+	mock := &MockServiceImp{ctrl: ctrl}
+	mock.recorder = &MockServiceImpMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceImp) EXPECT() *MockServiceImpMockRecorder {
+	return m.recorder
+}
+
+// DeleteClient mocks base method.
+func (m *MockServiceImp) DeleteClient(ownerID uint, clientID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClient", ownerID, clientID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteClient indicates an expected call of DeleteClient.
+func (mr *MockServiceImpMockRecorder) DeleteClient(ownerID, clientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClient", reflect.TypeOf((*MockServiceImp)(nil).DeleteClient), ownerID, clientID)
+}
+
+// IssueToken mocks base method.
+func (m *MockServiceImp) IssueToken(input oauth.IssueTokenInput) (*oauth.TokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueToken", input)
+	ret0, _ := ret[0].(*oauth.TokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueToken indicates an expected call of IssueToken.
+func (mr *MockServiceImpMockRecorder) IssueToken(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueToken", reflect.TypeOf((*MockServiceImp)(nil).IssueToken), input)
+}
+
+// ListClients mocks base method.
+func (m *MockServiceImp) ListClients(ownerID uint) ([]oauth.ClientResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClients", ownerID)
+	ret0, _ := ret[0].([]oauth.ClientResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClients indicates an expected call of ListClients.
+func (mr *MockServiceImpMockRecorder) ListClients(ownerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClients", reflect.TypeOf((*MockServiceImp)(nil).ListClients), ownerID)
+}
+
+// RegisterClient mocks base method.
+func (m *MockServiceImp) RegisterClient(input oauth.RegisterClientInput) (*oauth.RegisteredClientResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterClient", input)
+	ret0, _ := ret[0].(*oauth.RegisteredClientResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterClient indicates an expected call of RegisterClient.
+func (mr *MockServiceImpMockRecorder) RegisterClient(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterClient", reflect.TypeOf((*MockServiceImp)(nil).RegisterClient), input)
+}
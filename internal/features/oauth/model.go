@@ -0,0 +1,107 @@
+package oauth
+
+import (
+	"luizalabs-technical-test/internal/pkg/entity"
+	"strings"
+	"time"
+)
+
+// GrantTypeClientCredentials is the only grant type supported by the token endpoint.
+const GrantTypeClientCredentials = "client_credentials"
+
+// TokenTypeBearer is the type of every access token issued by the token endpoint.
+const TokenTypeBearer = "Bearer"
+
+// PostTokenPayload represents the form-encoded payload of the token endpoint (RFC 6749, section 4.4.2).
+// Client credentials may also be sent through HTTP Basic authentication.
+type PostTokenPayload struct {
+	GrantType    string `form:"grant_type"    binding:"required"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+	Scope        string `form:"scope"`
+}
+
+// PostClientPayload represents the payload for registering a new OAuth2 client.
+type PostClientPayload struct {
+	Name   string   `json:"name"   binding:"required,max=100"`
+	Scopes []string `json:"scopes"`
+}
+
+// IssueTokenInput represents the input structure in service layer for issuing an access token.
+type IssueTokenInput struct {
+	GrantType    string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+}
+
+// RegisterClientInput represents the input structure in service layer for registering a client.
+type RegisterClientInput struct {
+	OwnerID  uint
+	TenantID uint
+	Name     string
+	Scopes   []string
+}
+
+// TokenResponse represents the successful response of the token endpoint (RFC 6749, section 5.1).
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope,omitempty"`
+}
+
+// TokenErrorResponse represents the error response of the token endpoint (RFC 6749, section 5.2).
+type TokenErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// ClientResponse represents the public view of a registered client, without its secret.
+type ClientResponse struct {
+	ClientID  string    `json:"client_id"`
+	Name      string    `json:"name"`
+	Scopes    []string  `json:"scopes"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RegisteredClientResponse represents a newly registered client. The secret is only returned once.
+type RegisteredClientResponse struct {
+	ClientResponse
+	ClientSecret string `json:"client_secret"`
+}
+
+// GetClientFilter represents the filter criteria for querying clients.
+type GetClientFilter struct {
+	ClientID string
+	OwnerID  uint
+}
+
+// ToIssueTokenInput maps PostTokenPayload to IssueTokenInput, splitting the space-delimited scope list.
+func (p *PostTokenPayload) ToIssueTokenInput() IssueTokenInput {
+	return IssueTokenInput{
+		GrantType:    p.GrantType,
+		ClientID:     p.ClientID,
+		ClientSecret: p.ClientSecret,
+		Scopes:       strings.Fields(p.Scope),
+	}
+}
+
+// ToRegisterClientInput maps PostClientPayload to RegisterClientInput.
+func (p *PostClientPayload) ToRegisterClientInput(ownerID uint) RegisterClientInput {
+	return RegisterClientInput{
+		OwnerID: ownerID,
+		Name:    p.Name,
+		Scopes:  p.Scopes,
+	}
+}
+
+// ToClientResponse converts an OAuthClient entity to its public representation.
+func ToClientResponse(client entity.OAuthClient) ClientResponse {
+	return ClientResponse{
+		ClientID:  client.ClientID,
+		Name:      client.Name,
+		Scopes:    client.ScopeList(),
+		CreatedAt: client.CreatedAt,
+	}
+}
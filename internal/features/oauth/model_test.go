@@ -0,0 +1,47 @@
+package oauth
+
+import (
+	"testing"
+
+	"luizalabs-technical-test/internal/pkg/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestToIssueTokenInput tests the ToIssueTokenInput method of PostTokenPayload.
+func TestToIssueTokenInput(t *testing.T) {
+	payload := &PostTokenPayload{
+		GrantType:    GrantTypeClientCredentials,
+		ClientID:     "lzc_client",
+		ClientSecret: "secret",
+		Scope:        " address:read  address:write ",
+	}
+
+	input := payload.ToIssueTokenInput()
+
+	assert.Equal(t, payload.GrantType, input.GrantType)
+	assert.Equal(t, payload.ClientID, input.ClientID)
+	assert.Equal(t, payload.ClientSecret, input.ClientSecret)
+	assert.Equal(t, []string{"address:read", "address:write"}, input.Scopes)
+}
+
+// TestToRegisterClientInput tests the ToRegisterClientInput method of PostClientPayload.
+func TestToRegisterClientInput(t *testing.T) {
+	payload := &PostClientPayload{Name: "partner", Scopes: []string{"address:read"}}
+
+	input := payload.ToRegisterClientInput(1)
+
+	assert.Equal(t, uint(1), input.OwnerID)
+	assert.Equal(t, payload.Name, input.Name)
+	assert.Equal(t, payload.Scopes, input.Scopes)
+}
+
+// TestToClientResponse tests the conversion of an OAuthClient entity into its public view.
+func TestToClientResponse(t *testing.T) {
+	client := entity.OAuthClient{ClientID: "lzc_client", Name: "partner", HashedSecret: "hash", Scopes: "address:read"}
+
+	response := ToClientResponse(client)
+
+	assert.Equal(t, client.ClientID, response.ClientID)
+	assert.Equal(t, []string{"address:read"}, response.Scopes)
+}
@@ -0,0 +1,85 @@
+package oauth
+
+import (
+	"luizalabs-technical-test/internal/pkg/entity"
+
+	"gorm.io/gorm"
+)
+
+// RepositoryImp defines the interface for the repository layer,
+// which abstracts data access operations.
+type RepositoryImp interface {
+	CreateClient(client *entity.OAuthClient) error
+	GetClient(filter GetClientFilter) (*entity.OAuthClient, error)
+	ListClients(ownerID uint) ([]entity.OAuthClient, error)
+	DeleteClient(id uint) error
+	GetOwner(ownerID uint) (*entity.User, error)
+}
+
+// repository struct implements the repositoryImp interface,
+// that interacts with external entities such as databases or external APIs.
+type repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates and returns a new instance of the repository.
+func NewRepository(db *gorm.DB) RepositoryImp {
+	return &repository{db}
+}
+
+// CreateClient adds a new OAuth2 client to the database.
+func (r *repository) CreateClient(client *entity.OAuthClient) error {
+	tx := r.db.Table(entity.TbOAuthClient).Create(client)
+	if err := tx.Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetClient retrieves a single client matching the provided filter.
+func (r *repository) GetClient(filter GetClientFilter) (*entity.OAuthClient, error) {
+	fetchedClient := new(entity.OAuthClient)
+
+	tx := r.db.Where(&entity.OAuthClient{
+		ClientID: filter.ClientID,
+		OwnerID:  filter.OwnerID,
+	}).First(fetchedClient)
+	if err := tx.Error; err != nil {
+		return nil, err
+	}
+
+	return fetchedClient, nil
+}
+
+// ListClients retrieves every client registered by the given user, newest first.
+func (r *repository) ListClients(ownerID uint) ([]entity.OAuthClient, error) {
+	var clients []entity.OAuthClient
+
+	tx := r.db.Where("owner_id = ?", ownerID).Order("created_at DESC").Find(&clients)
+	if err := tx.Error; err != nil {
+		return nil, err
+	}
+
+	return clients, nil
+}
+
+// DeleteClient removes the client, preventing it from obtaining new tokens.
+func (r *repository) DeleteClient(id uint) error {
+	tx := r.db.Delete(&entity.OAuthClient{}, id)
+	if err := tx.Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetOwner retrieves the user who registered a client. Soft-deleted users are not found.
+func (r *repository) GetOwner(ownerID uint) (*entity.User, error) {
+	owner := new(entity.User)
+
+	tx := r.db.Where("id = ?", ownerID).First(owner)
+	if err := tx.Error; err != nil {
+		return nil, err
+	}
+
+	return owner, nil
+}
@@ -0,0 +1,125 @@
+package oauth
+
+import (
+	"context"
+	"testing"
+
+	"luizalabs-technical-test/internal/pkg/entity"
+	"luizalabs-technical-test/internal/pkg/scope"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type OAuthRepositoryTestSuite struct {
+	suite.Suite
+	db  *gorm.DB
+	ctx context.Context
+}
+
+func (s *OAuthRepositoryTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+
+	// Start PostgreSQL container
+	req := testcontainers.ContainerRequest{
+		Image:        "postgres:latest",
+		ExposedPorts: []string{"5432/tcp"},
+		Env: map[string]string{
+			"POSTGRES_USER":     "testuser",
+			"POSTGRES_PASSWORD": "testpass",
+			"POSTGRES_DB":       "testdb",
+		},
+		WaitingFor: wait.ForListeningPort("5432/tcp"),
+	}
+
+	// Create and start the container
+	postgresContainer, err := testcontainers.GenericContainer(s.ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	s.Require().NoError(err)
+
+	// Get the port and create the database connection
+	host, _ := postgresContainer.Host(s.ctx)
+	port, _ := postgresContainer.MappedPort(s.ctx, "5432")
+
+	dsn := "host=" + host + " port=" + port.Port() + " user=testuser password=testpass dbname=testdb sslmode=disable"
+	s.db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	s.Require().NoError(err)
+
+	// Auto-migrate the OAuthClient table
+	s.Require().NoError(s.db.AutoMigrate(&entity.User{}, &entity.OAuthClient{}))
+}
+
+func (s *OAuthRepositoryTestSuite) TearDownSuite() {
+	// Clean up the database connection
+	db, err := s.db.DB()
+	s.Require().NoError(err)
+	db.Close()
+}
+
+func (s *OAuthRepositoryTestSuite) TestCreateAndGetClient() {
+	repo := NewRepository(s.db)
+
+	client := entity.OAuthClient{
+		OwnerID:      1,
+		Name:         "partner",
+		ClientID:     "lzc_create",
+		HashedSecret: "hash",
+		Scopes:       scope.AddressRead,
+	}
+	s.NoError(repo.CreateClient(&client))
+
+	// Attempt to store the same client ID again; this should fail due to the unique index constraint.
+	duplicated := client
+	duplicated.ID = 0
+	s.Error(repo.CreateClient(&duplicated))
+
+	fetchedClient, err := repo.GetClient(GetClientFilter{ClientID: client.ClientID})
+	s.NoError(err)
+	s.Equal(client.ID, fetchedClient.ID)
+
+	// Verify that clients from other users are not fetched
+	_, err = repo.GetClient(GetClientFilter{ClientID: client.ClientID, OwnerID: 2})
+	s.Error(err)
+}
+
+func (s *OAuthRepositoryTestSuite) TestListAndDeleteClient() {
+	repo := NewRepository(s.db)
+
+	client := entity.OAuthClient{OwnerID: 3, Name: "batch", ClientID: "lzc_list", HashedSecret: "hash"}
+	s.Require().NoError(repo.CreateClient(&client))
+
+	clients, err := repo.ListClients(3)
+	s.NoError(err)
+	s.Len(clients, 1)
+
+	s.NoError(repo.DeleteClient(client.ID))
+
+	// Verify that deleted clients can no longer be fetched
+	_, err = repo.GetClient(GetClientFilter{ClientID: client.ClientID})
+	s.Error(err)
+}
+
+func (s *OAuthRepositoryTestSuite) TestGetOwner() {
+	repo := NewRepository(s.db)
+
+	owner := entity.User{Email: "owner@example.com"}
+	s.Require().NoError(s.db.Create(&owner).Error)
+
+	fetchedOwner, err := repo.GetOwner(owner.ID)
+	s.NoError(err)
+	s.Equal(owner.Email, fetchedOwner.Email)
+
+	// Verify that deleted owners are not fetched
+	s.Require().NoError(s.db.Delete(&owner).Error)
+	_, err = repo.GetOwner(owner.ID)
+	s.Error(err)
+}
+
+func TestOAuthRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(OAuthRepositoryTestSuite))
+}
@@ -0,0 +1,203 @@
+package oauth
+
+import (
+	"crypto/subtle"
+	"luizalabs-technical-test/internal/config"
+	"luizalabs-technical-test/internal/pkg/entity"
+	"luizalabs-technical-test/internal/pkg/scope"
+	"luizalabs-technical-test/pkg/crypt"
+	"luizalabs-technical-test/pkg/token"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const (
+	// clientIDPrefix identifies OAuth2 client IDs in logs and secret scanners.
+	clientIDPrefix = "lzc_"
+
+	// clientIDRandomBytes defines the amount of random bytes used to build the client ID.
+	clientIDRandomBytes = 16
+
+	// clientSecretRandomBytes defines the amount of random bytes used to build the client secret.
+	clientSecretRandomBytes = 32
+
+	// accessTokenExpiration defines the lifetime of the issued access tokens.
+	accessTokenExpiration = time.Hour
+
+	// tokenIssuer identifies this service as the issuer of the access tokens.
+	tokenIssuer = "luizalabs-technical-test"
+)
+
+// ServiceImp defines the interface for the service layer, with methods to register clients and issue tokens.
+type ServiceImp interface {
+	RegisterClient(input RegisterClientInput) (*RegisteredClientResponse, error)
+	ListClients(ownerID uint) ([]ClientResponse, error)
+	DeleteClient(ownerID uint, clientID string) error
+	IssueToken(input IssueTokenInput) (*TokenResponse, error)
+}
+
+// service struct implements the serviceImp interface and holds a reference to the repository.
+type service struct {
+	repository RepositoryImp
+}
+
+// NewService creates and returns a new service instance, injecting the repository dependency.
+func NewService(repository RepositoryImp) ServiceImp {
+	return &service{repository}
+}
+
+// RegisterClient creates a new client with random credentials and stores only the secret hash.
+func (s *service) RegisterClient(input RegisterClientInput) (*RegisteredClientResponse, error) {
+	scopes := input.Scopes
+	if len(scopes) == 0 {
+		scopes = scope.Default()
+	}
+	for _, requested := range scopes {
+		if !scope.IsSupported(requested) {
+			return nil, ErrUnsupportedScope.WithStrErr("unsupported scope: %s", requested)
+		}
+	}
+
+	clientID, err := crypt.GenerateRandomToken(clientIDRandomBytes)
+	if err != nil {
+		return nil, ErrCreationFailed.WithErr(err)
+	}
+
+	clientSecret, err := crypt.GenerateRandomToken(clientSecretRandomBytes)
+	if err != nil {
+		return nil, ErrCreationFailed.WithErr(err)
+	}
+
+	client := entity.OAuthClient{
+		OwnerID:      input.OwnerID,
+		TenantID:     input.TenantID,
+		Name:         input.Name,
+		ClientID:     clientIDPrefix + clientID,
+		HashedSecret: crypt.HashToken(clientSecret),
+	}
+	client.SetScopes(scopes)
+
+	if err := s.repository.CreateClient(&client); err != nil {
+		return nil, ErrCreationFailed.WithErr(err)
+	}
+
+	return &RegisteredClientResponse{
+		ClientResponse: ToClientResponse(client),
+		ClientSecret:   clientSecret,
+	}, nil
+}
+
+// ListClients returns every client registered by the user.
+func (s *service) ListClients(ownerID uint) ([]ClientResponse, error) {
+	clients, err := s.repository.ListClients(ownerID)
+	if err != nil {
+		return nil, ErrOperationFailed.WithErr(err)
+	}
+
+	response := make([]ClientResponse, 0, len(clients))
+	for _, client := range clients {
+		response = append(response, ToClientResponse(client))
+	}
+	return response, nil
+}
+
+// DeleteClient removes a client registered by the user.
+func (s *service) DeleteClient(ownerID uint, clientID string) error {
+	client, err := s.repository.GetClient(GetClientFilter{ClientID: clientID, OwnerID: ownerID})
+	if err != nil {
+		return ErrClientNotFound.WithErr(err)
+	}
+
+	if err := s.repository.DeleteClient(client.ID); err != nil {
+		return ErrOperationFailed.WithErr(err)
+	}
+	return nil
+}
+
+// IssueToken authenticates the client and issues an access token for the client credentials grant.
+func (s *service) IssueToken(input IssueTokenInput) (*TokenResponse, error) {
+	if input.GrantType != GrantTypeClientCredentials {
+		return nil, ErrUnsupportedGrantType.WithStrErr("unsupported grant type: %s", input.GrantType)
+	}
+
+	client, err := s.authenticateClient(input.ClientID, input.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes, err := s.grantedScopes(client, input.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	claims := token.CustomClaims{
+		StandardClaims: jwt.StandardClaims{
+			Subject:   client.ClientID,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(accessTokenExpiration).Unix(),
+			Issuer:    tokenIssuer,
+		},
+		CustomKeys: client.ToJSONClaims(scopes),
+	}
+
+	accessToken, err := token.CreateToken(config.GeneralConfig.SecretAuthTokenKey, claims)
+	if err != nil {
+		return nil, ErrServerError.WithErr(err)
+	}
+
+	return &TokenResponse{
+		AccessToken: accessToken,
+		TokenType:   TokenTypeBearer,
+		ExpiresIn:   int64(accessTokenExpiration.Seconds()),
+		Scope:       strings.Join(scopes, " "),
+	}, nil
+}
+
+// authenticateClient fetches the client and compares the provided secret in constant time.
+func (s *service) authenticateClient(clientID, clientSecret string) (*entity.OAuthClient, error) {
+	if clientID == "" || clientSecret == "" {
+		return nil, ErrInvalidRequest.WithStrErr("client credentials not provided")
+	}
+
+	client, err := s.repository.GetClient(GetClientFilter{ClientID: clientID})
+	if err != nil {
+		return nil, ErrInvalidClient.WithErr(err)
+	}
+
+	hashedSecret := crypt.HashToken(clientSecret)
+	if subtle.ConstantTimeCompare([]byte(hashedSecret), []byte(client.HashedSecret)) != 1 {
+		return nil, ErrInvalidClient.WithStrErr("invalid secret for client %s", clientID)
+	}
+
+	// Note: the tokens of clients carry no user, so they aren't revoked along with the account of the owner and
+	// must not be issued once it is deleted or disabled.
+	owner, err := s.repository.GetOwner(client.OwnerID)
+	if err != nil {
+		return nil, ErrInvalidClient.WithErr(err)
+	}
+	if owner.IsDisabled() {
+		return nil, ErrInvalidClient.WithStrErr("client %s belongs to a disabled user", clientID)
+	}
+
+	return client, nil
+}
+
+// grantedScopes checks the requested scopes against the client registration.
+// Note: when no scope is requested, every scope granted to the client is issued (RFC 6749, section 3.3).
+func (*service) grantedScopes(client *entity.OAuthClient, requested []string) ([]string, error) {
+	granted := client.ScopeList()
+	if len(requested) == 0 {
+		return granted, nil
+	}
+
+	for _, requestedScope := range requested {
+		if !slices.Contains(granted, requestedScope) {
+			return nil, ErrInvalidScope.WithStrErr("scope %s not granted to client %s", requestedScope, client.ClientID)
+		}
+	}
+	return requested, nil
+}
@@ -0,0 +1,195 @@
+package oauth_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"luizalabs-technical-test/internal/config"
+	"luizalabs-technical-test/internal/features/oauth"
+	oauthMock "luizalabs-technical-test/internal/features/oauth/mock"
+	"luizalabs-technical-test/internal/pkg/entity"
+	"luizalabs-technical-test/internal/pkg/scope"
+	"luizalabs-technical-test/pkg/crypt"
+	pkgErrors "luizalabs-technical-test/pkg/errors"
+	"luizalabs-technical-test/pkg/token"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+// OAuthServiceTestSuite is a test suite for the OAuth2 service.
+type OAuthServiceTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	repoMock *oauthMock.MockRepositoryImp
+	service  oauth.ServiceImp
+	client   *entity.OAuthClient
+}
+
+// SetupTest initializes the test suite, creating a new mock controller and instances of mocks.
+func (suite *OAuthServiceTestSuite) SetupTest() {
+	config.GeneralConfig.SecretAuthTokenKey = "test_secret_key"
+
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.repoMock = oauthMock.NewMockRepositoryImp(suite.ctrl)
+	suite.service = oauth.NewService(suite.repoMock)
+	suite.client = &entity.OAuthClient{
+		Model:        gorm.Model{ID: 1},
+		OwnerID:      2,
+		ClientID:     "lzc_client",
+		HashedSecret: crypt.HashToken("secret"),
+		Scopes:       scope.AddressRead,
+	}
+}
+
+// TearDownTest cleans up the mock controller after each test.
+func (suite *OAuthServiceTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+// TestRegisterClient_UnsupportedScope tests the registration of a client with an unknown scope.
+func (suite *OAuthServiceTestSuite) TestRegisterClient_UnsupportedScope() {
+	_, err := suite.service.RegisterClient(oauth.RegisterClientInput{OwnerID: 1, Name: "partner", Scopes: []string{"admin"}})
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), oauth.ErrUnsupportedScope.Error(), err.Error())
+}
+
+// TestRegisterClient_Success tests that only the secret hash is stored.
+func (suite *OAuthServiceTestSuite) TestRegisterClient_Success() {
+	var stored *entity.OAuthClient
+	suite.repoMock.EXPECT().
+		CreateClient(gomock.Any()).
+		DoAndReturn(func(client *entity.OAuthClient) error {
+			stored = client
+			return nil
+		})
+
+	response, err := suite.service.RegisterClient(oauth.RegisterClientInput{OwnerID: 1, TenantID: 3, Name: "partner"})
+
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), strings.HasPrefix(response.ClientID, "lzc_"))
+	assert.Equal(suite.T(), crypt.HashToken(response.ClientSecret), stored.HashedSecret)
+	assert.Equal(suite.T(), uint(3), stored.TenantID)
+	assert.Equal(suite.T(), []string{scope.AddressRead}, response.Scopes)
+}
+
+// TestDeleteClient_NotFound tests the deletion of a client owned by another user.
+func (suite *OAuthServiceTestSuite) TestDeleteClient_NotFound() {
+	suite.repoMock.EXPECT().
+		GetClient(oauth.GetClientFilter{ClientID: "lzc_client", OwnerID: 2}).
+		Return(nil, errors.New("record not found"))
+
+	err := suite.service.DeleteClient(2, "lzc_client")
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), oauth.ErrClientNotFound.Error(), err.Error())
+}
+
+// TestIssueToken_UnsupportedGrantType tests a grant type other than client_credentials.
+func (suite *OAuthServiceTestSuite) TestIssueToken_UnsupportedGrantType() {
+	_, err := suite.service.IssueToken(oauth.IssueTokenInput{GrantType: "password"})
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), oauth.ErrCodeUnsupportedGrantType, err.(*pkgErrors.Error).Code)
+}
+
+// TestIssueToken_MissingCredentials tests a token request without client credentials.
+func (suite *OAuthServiceTestSuite) TestIssueToken_MissingCredentials() {
+	_, err := suite.service.IssueToken(oauth.IssueTokenInput{GrantType: oauth.GrantTypeClientCredentials})
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), oauth.ErrCodeInvalidRequest, err.(*pkgErrors.Error).Code)
+}
+
+// TestIssueToken_InvalidSecret tests a token request with a wrong secret.
+func (suite *OAuthServiceTestSuite) TestIssueToken_InvalidSecret() {
+	suite.repoMock.EXPECT().GetClient(gomock.Any()).Return(suite.client, nil)
+
+	_, err := suite.service.IssueToken(oauth.IssueTokenInput{
+		GrantType:    oauth.GrantTypeClientCredentials,
+		ClientID:     "lzc_client",
+		ClientSecret: "wrong",
+	})
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), oauth.ErrCodeInvalidClient, err.(*pkgErrors.Error).Code)
+}
+
+// TestIssueToken_DeletedOwner tests a token request of a client whose owner was deleted.
+func (suite *OAuthServiceTestSuite) TestIssueToken_DeletedOwner() {
+	suite.repoMock.EXPECT().GetClient(gomock.Any()).Return(suite.client, nil)
+	suite.repoMock.EXPECT().GetOwner(uint(2)).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := suite.service.IssueToken(oauth.IssueTokenInput{
+		GrantType:    oauth.GrantTypeClientCredentials,
+		ClientID:     "lzc_client",
+		ClientSecret: "secret",
+	})
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), oauth.ErrCodeInvalidClient, err.(*pkgErrors.Error).Code)
+}
+
+// TestIssueToken_DisabledOwner tests a token request of a client whose owner was disabled.
+func (suite *OAuthServiceTestSuite) TestIssueToken_DisabledOwner() {
+	disabledAt := time.Now()
+	suite.repoMock.EXPECT().GetClient(gomock.Any()).Return(suite.client, nil)
+	suite.repoMock.EXPECT().GetOwner(uint(2)).Return(&entity.User{Model: gorm.Model{ID: 2}, DisabledAt: &disabledAt}, nil)
+
+	_, err := suite.service.IssueToken(oauth.IssueTokenInput{
+		GrantType:    oauth.GrantTypeClientCredentials,
+		ClientID:     "lzc_client",
+		ClientSecret: "secret",
+	})
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), oauth.ErrCodeInvalidClient, err.(*pkgErrors.Error).Code)
+}
+
+// TestIssueToken_InvalidScope tests a token request for a scope not granted to the client.
+func (suite *OAuthServiceTestSuite) TestIssueToken_InvalidScope() {
+	suite.repoMock.EXPECT().GetClient(gomock.Any()).Return(suite.client, nil)
+	suite.repoMock.EXPECT().GetOwner(uint(2)).Return(&entity.User{Model: gorm.Model{ID: 2}}, nil)
+
+	_, err := suite.service.IssueToken(oauth.IssueTokenInput{
+		GrantType:    oauth.GrantTypeClientCredentials,
+		ClientID:     "lzc_client",
+		ClientSecret: "secret",
+		Scopes:       []string{"address:write"},
+	})
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), oauth.ErrCodeInvalidScope, err.(*pkgErrors.Error).Code)
+}
+
+// TestIssueToken_Success tests that a valid request issues a token verifiable by pkg/token.
+func (suite *OAuthServiceTestSuite) TestIssueToken_Success() {
+	suite.repoMock.EXPECT().GetClient(oauth.GetClientFilter{ClientID: "lzc_client"}).Return(suite.client, nil)
+	suite.repoMock.EXPECT().GetOwner(uint(2)).Return(&entity.User{Model: gorm.Model{ID: 2}}, nil)
+
+	response, err := suite.service.IssueToken(oauth.IssueTokenInput{
+		GrantType:    oauth.GrantTypeClientCredentials,
+		ClientID:     "lzc_client",
+		ClientSecret: "secret",
+	})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), oauth.TokenTypeBearer, response.TokenType)
+	assert.Equal(suite.T(), int64(3600), response.ExpiresIn)
+	assert.Equal(suite.T(), scope.AddressRead, response.Scope)
+
+	claims, err := token.ValidateToken(config.GeneralConfig.SecretAuthTokenKey, response.AccessToken)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "lzc_client", claims.Subject)
+	assert.Equal(suite.T(), []string{scope.AddressRead}, claims.StringSliceKey("Scopes"))
+}
+
+// TestOAuthServiceTestSuite runs the test suite for the OAuth2 service.
+func TestOAuthServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(OAuthServiceTestSuite))
+}
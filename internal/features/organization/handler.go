@@ -0,0 +1,343 @@
+package organization
+
+import (
+	"luizalabs-technical-test/pkg/errors"
+	"luizalabs-technical-test/pkg/middleware"
+	"luizalabs-technical-test/pkg/server"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// swagOrganizationResponse is used to work around Swagger's lack of support for Go generics.
+type swagOrganizationResponse = server.APIResponse[OrganizationResponse]
+
+// swagListOrganizationsResponse is used to work around Swagger's lack of support for Go generics.
+type swagListOrganizationsResponse = server.APIResponse[[]OrganizationResponse]
+
+// swagListMembersResponse is used to work around Swagger's lack of support for Go generics.
+type swagListMembersResponse = server.APIResponse[[]MemberResponse]
+
+// HandlerImp defines the interface for handling server operations.
+// It embeds the server.HandlerImp interface, allowing for extended functionality and custom implementations.
+type HandlerImp interface {
+	server.HandlerImp
+}
+
+// handler struct holds a reference to the service layer.
+type handler struct {
+	service    ServiceImp
+	tokenLayer middleware.Middleware
+	adminLayer middleware.Middleware
+}
+
+// NewHandler creates and returns a new handler instance.
+func NewHandler(service ServiceImp, tokenMiddleware, adminMiddleware middleware.Middleware) HandlerImp {
+	return &handler{service, tokenMiddleware, adminMiddleware}
+}
+
+// Register sets up the routes for managing organizations, restricted to administrators without organization by the
+// admin middleware, which must be created with middleware.NewGlobalAdminMiddleware.
+func (h *handler) Register(r *gin.RouterGroup) {
+	g := r.Group("/admin/organizations", h.tokenLayer.Middleware(), h.adminLayer.Middleware())
+	g.POST("", h.postOrganization)
+	g.GET("", h.getOrganizations)
+	g.GET("/:id", h.getOrganization)
+	g.PATCH("/:id", h.patchOrganization)
+	g.DELETE("/:id", h.deleteOrganization)
+	g.GET("/:id/members", h.getMembers)
+	g.PUT("/:id/members/:user_id", h.putMember)
+	g.DELETE("/:id/members/:user_id", h.deleteMember)
+}
+
+// postOrganization creates an organization.
+//
+//	@Summary		Create an organization
+//	@Description	Creates an organization (tenant). Its daily request quota limits the requests its members may make to the address route, zero meaning unlimited. Restricted to administrators without organization.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Authorization token"
+//	@Param			payload			body		PostOrganizationPayload		true	"Organization data"
+//	@Success		201				{object}	swagOrganizationResponse	"Organization created"
+//	@Failure		400				{object}	server.APIErrorResponse		"Bad request"
+//	@Failure		401				{object}	server.APIErrorResponse		"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse		"Forbidden"
+//	@Failure		409				{object}	server.APIErrorResponse		"Name already in use"
+//	@Failure		500				{object}	server.APIErrorResponse		"Internal server error"
+//	@Router			/v1/admin/organizations [post]
+func (h *handler) postOrganization(c *gin.Context) {
+	var payload PostOrganizationPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, server.APIErrorResponse{
+			Error: ErrInvalidPayload.WithErr(err).Error(),
+			Code:  ErrInvalidPayload.Code,
+		})
+		return
+	}
+
+	response, err := h.service.CreateOrganization(payload.ToCreateOrganizationInput())
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, swagOrganizationResponse{Data: *response})
+}
+
+// getOrganizations lists the organizations.
+//
+//	@Summary		List organizations
+//	@Description	Lists every organization, ordered by name. Restricted to administrators without organization.
+//	@Tags			admin
+//	@Produce		json
+//	@Param			Authorization	header		string							true	"Authorization token"
+//	@Success		200				{object}	swagListOrganizationsResponse	"Organizations"
+//	@Failure		401				{object}	server.APIErrorResponse			"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse			"Forbidden"
+//	@Failure		500				{object}	server.APIErrorResponse			"Internal server error"
+//	@Router			/v1/admin/organizations [get]
+func (h *handler) getOrganizations(c *gin.Context) {
+	response, err := h.service.ListOrganizations()
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, swagListOrganizationsResponse{Data: response})
+}
+
+// getOrganization returns an organization.
+//
+//	@Summary		Get an organization
+//	@Description	Returns an organization. Restricted to administrators without organization.
+//	@Tags			admin
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Authorization token"
+//	@Param			id				path		int							true	"Organization ID"
+//	@Success		200				{object}	swagOrganizationResponse	"Organization"
+//	@Failure		400				{object}	server.APIErrorResponse		"Invalid organization ID"
+//	@Failure		401				{object}	server.APIErrorResponse		"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse		"Forbidden"
+//	@Failure		404				{object}	server.APIErrorResponse		"Organization not found"
+//	@Failure		500				{object}	server.APIErrorResponse		"Internal server error"
+//	@Router			/v1/admin/organizations/{id} [get]
+func (h *handler) getOrganization(c *gin.Context) {
+	organizationID, ok := h.idFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	response, err := h.service.GetOrganization(organizationID)
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, swagOrganizationResponse{Data: *response})
+}
+
+// patchOrganization updates an organization.
+//
+//	@Summary		Update an organization
+//	@Description	Renames an organization and/or changes its daily request quota. Omitted fields are left untouched. Restricted to administrators without organization.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Authorization token"
+//	@Param			id				path		int							true	"Organization ID"
+//	@Param			payload			body		PatchOrganizationPayload	true	"Changed fields"
+//	@Success		200				{object}	swagOrganizationResponse	"Organization updated"
+//	@Failure		400				{object}	server.APIErrorResponse		"Bad request"
+//	@Failure		401				{object}	server.APIErrorResponse		"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse		"Forbidden"
+//	@Failure		404				{object}	server.APIErrorResponse		"Organization not found"
+//	@Failure		409				{object}	server.APIErrorResponse		"Name already in use"
+//	@Failure		500				{object}	server.APIErrorResponse		"Internal server error"
+//	@Router			/v1/admin/organizations/{id} [patch]
+func (h *handler) patchOrganization(c *gin.Context) {
+	organizationID, ok := h.idFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	var payload PatchOrganizationPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, server.APIErrorResponse{
+			Error: ErrInvalidPayload.WithErr(err).Error(),
+			Code:  ErrInvalidPayload.Code,
+		})
+		return
+	}
+
+	response, err := h.service.UpdateOrganization(payload.ToUpdateOrganizationInput(organizationID))
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, swagOrganizationResponse{Data: *response})
+}
+
+// deleteOrganization removes an organization without members.
+//
+//	@Summary		Delete an organization
+//	@Description	Permanently removes an organization. Its members must be removed first. Restricted to administrators without organization.
+//	@Tags			admin
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@Param			id				path	int		true	"Organization ID"
+//	@Success		204				"Organization deleted"
+//	@Failure		400				{object}	server.APIErrorResponse	"Invalid organization ID"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse	"Forbidden"
+//	@Failure		404				{object}	server.APIErrorResponse	"Organization not found"
+//	@Failure		409				{object}	server.APIErrorResponse	"Organization still has members"
+//	@Failure		500				{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/admin/organizations/{id} [delete]
+func (h *handler) deleteOrganization(c *gin.Context) {
+	organizationID, ok := h.idFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteOrganization(organizationID); err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// getMembers lists the members of an organization.
+//
+//	@Summary		List the members of an organization
+//	@Description	Lists the users belonging to an organization, oldest first. Restricted to administrators without organization.
+//	@Tags			admin
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Authorization token"
+//	@Param			id				path		int						true	"Organization ID"
+//	@Success		200				{object}	swagListMembersResponse	"Members"
+//	@Failure		400				{object}	server.APIErrorResponse	"Invalid organization ID"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse	"Forbidden"
+//	@Failure		404				{object}	server.APIErrorResponse	"Organization not found"
+//	@Failure		500				{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/admin/organizations/{id}/members [get]
+func (h *handler) getMembers(c *gin.Context) {
+	organizationID, ok := h.idFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	response, err := h.service.ListMembers(organizationID)
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, swagListMembersResponse{Data: response})
+}
+
+// putMember adds a user to an organization.
+//
+//	@Summary		Add a member to an organization
+//	@Description	Moves a user into the organization, leaving any organization it belonged to. Its access tokens are revoked, so the new tenant applies on the next login. Restricted to administrators without organization.
+//	@Tags			admin
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@Param			id				path	int		true	"Organization ID"
+//	@Param			user_id			path	int		true	"User ID"
+//	@Success		204				"Member added"
+//	@Failure		400				{object}	server.APIErrorResponse	"Invalid organization or user ID"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse	"Forbidden"
+//	@Failure		404				{object}	server.APIErrorResponse	"Organization or user not found"
+//	@Failure		500				{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/admin/organizations/{id}/members/{user_id} [put]
+func (h *handler) putMember(c *gin.Context) {
+	organizationID, userID, ok := h.memberFromPath(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.AddMember(organizationID, userID); err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// deleteMember removes a user from an organization.
+//
+//	@Summary		Remove a member from an organization
+//	@Description	Removes a user from the organization and revokes its access tokens. Restricted to administrators without organization.
+//	@Tags			admin
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@Param			id				path	int		true	"Organization ID"
+//	@Param			user_id			path	int		true	"User ID"
+//	@Success		204				"Member removed"
+//	@Failure		400				{object}	server.APIErrorResponse	"Invalid organization or user ID"
+//	@Failure		401				{object}	server.APIErrorResponse	"Unauthorized"
+//	@Failure		403				{object}	server.APIErrorResponse	"Forbidden"
+//	@Failure		404				{object}	server.APIErrorResponse	"User not found in the organization"
+//	@Failure		500				{object}	server.APIErrorResponse	"Internal server error"
+//	@Router			/v1/admin/organizations/{id}/members/{user_id} [delete]
+func (h *handler) deleteMember(c *gin.Context) {
+	organizationID, userID, ok := h.memberFromPath(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.RemoveMember(organizationID, userID); err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// memberFromPath parses the organization and user IDs from the route parameters.
+func (h *handler) memberFromPath(c *gin.Context) (uint, uint, bool) {
+	organizationID, ok := h.idFromPath(c, "id")
+	if !ok {
+		return 0, 0, false
+	}
+
+	userID, ok := h.idFromPath(c, "user_id")
+	if !ok {
+		return 0, 0, false
+	}
+	return organizationID, userID, true
+}
+
+// idFromPath parses a positive ID from the given route parameter.
+func (h *handler) idFromPath(c *gin.Context, param string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(param), 10, 0)
+	if err != nil || id == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, server.APIErrorResponse{
+			Error: ErrInvalidID.WithErr(err).Error(),
+			Code:  ErrInvalidID.Code,
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// abortWithError maps service errors to their HTTP status codes.
+func (h *handler) abortWithError(c *gin.Context, err error) {
+	code := err.(errors.ErrorImp).CodeStr()
+
+	status := http.StatusInternalServerError
+	switch code {
+	case ErrCodeNotFound, ErrCodeUserNotFound:
+		status = http.StatusNotFound
+	case ErrCodeNameInUse, ErrCodeHasMembers:
+		status = http.StatusConflict
+	}
+
+	c.AbortWithStatusJSON(status, server.APIErrorResponse{
+		Error: err.Error(),
+		Code:  code,
+	})
+}
@@ -0,0 +1,144 @@
+package organization_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"luizalabs-technical-test/internal/features/organization"
+	"luizalabs-technical-test/internal/features/organization/mock"
+	middlewareMock "luizalabs-technical-test/internal/pkg/middleware/mock"
+	"luizalabs-technical-test/pkg/token"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// HandlerTestSuite is the struct for the test suite
+type HandlerTestSuite struct {
+	suite.Suite
+	ctrl    *gomock.Controller
+	router  *gin.Engine
+	mockSvc *mock.MockServiceImp
+}
+
+// SetupTest initializes the test suite
+func (s *HandlerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	gin.SetMode(gin.TestMode)
+	s.router = gin.Default()
+	s.mockSvc = mock.NewMockServiceImp(s.ctrl)
+
+	tokenMiddleware := middlewareMock.NewMockTokenMiddleware(s.ctrl)
+	tokenMiddleware.EXPECT().Middleware().Return(gin.HandlerFunc(func(c *gin.Context) {
+		c.Set(token.ClaimsHeaderName, &token.CustomClaims{CustomKeys: map[string]any{"ID": float64(1)}})
+		c.Next()
+	})).AnyTimes()
+
+	adminMiddleware := middlewareMock.NewMockAdminMiddleware(s.ctrl)
+	adminMiddleware.EXPECT().Middleware().Return(gin.HandlerFunc(func(c *gin.Context) { c.Next() })).AnyTimes()
+
+	organization.NewHandler(s.mockSvc, tokenMiddleware, adminMiddleware).Register(s.router.Group("/v1"))
+}
+
+// TearDownTest cleans up after the test suite
+func (s *HandlerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+// request performs a request against the router.
+func (s *HandlerTestSuite) request(method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// TestPostOrganization_BadRequestError tests the error in parse payload params
+func (s *HandlerTestSuite) TestPostOrganization_BadRequestError() {
+	for _, body := range []string{`{}`, `{"name":"acme","daily_request_quota":-1}`} {
+		w := s.request(http.MethodPost, "/v1/admin/organizations", body)
+		assert.Equal(s.T(), http.StatusBadRequest, w.Code, body)
+	}
+}
+
+// TestPostOrganization_Success tests the creation of an organization
+func (s *HandlerTestSuite) TestPostOrganization_Success() {
+	s.mockSvc.EXPECT().
+		CreateOrganization(organization.CreateOrganizationInput{Name: "acme", DailyRequestQuota: 100}).
+		Return(&organization.OrganizationResponse{ID: 3, Name: "acme", DailyRequestQuota: 100}, nil)
+
+	w := s.request(http.MethodPost, "/v1/admin/organizations", `{"name":"acme","daily_request_quota":100}`)
+
+	assert.Equal(s.T(), http.StatusCreated, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"daily_request_quota":100`)
+}
+
+// TestGetOrganizations_Success tests the listing of organizations
+func (s *HandlerTestSuite) TestGetOrganizations_Success() {
+	s.mockSvc.EXPECT().
+		ListOrganizations().
+		Return([]organization.OrganizationResponse{{ID: 3, Name: "acme"}}, nil)
+
+	w := s.request(http.MethodGet, "/v1/admin/organizations", "")
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"name":"acme"`)
+}
+
+// TestManageOrganization tests the status codes of the management of an organization and its members
+func (s *HandlerTestSuite) TestManageOrganization() {
+	name := "globex"
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		mock     func()
+		expected int
+	}{
+		{"get unknown organization", http.MethodGet, "/v1/admin/organizations/3", "", func() {
+			s.mockSvc.EXPECT().GetOrganization(uint(3)).Return(nil, &organization.ErrNotFound)
+		}, http.StatusNotFound},
+		{"invalid organization ID", http.MethodGet, "/v1/admin/organizations/abc", "", func() {}, http.StatusBadRequest},
+		{"rename to a name in use", http.MethodPatch, "/v1/admin/organizations/3", `{"name":"globex"}`, func() {
+			s.mockSvc.EXPECT().
+				UpdateOrganization(organization.UpdateOrganizationInput{ID: 3, Name: &name}).
+				Return(nil, &organization.ErrNameInUse)
+		}, http.StatusConflict},
+		{"delete with members", http.MethodDelete, "/v1/admin/organizations/3", "", func() {
+			s.mockSvc.EXPECT().DeleteOrganization(uint(3)).Return(&organization.ErrHasMembers)
+		}, http.StatusConflict},
+		{"delete", http.MethodDelete, "/v1/admin/organizations/3", "", func() {
+			s.mockSvc.EXPECT().DeleteOrganization(uint(3)).Return(nil)
+		}, http.StatusNoContent},
+		{"list members", http.MethodGet, "/v1/admin/organizations/3/members", "", func() {
+			s.mockSvc.EXPECT().ListMembers(uint(3)).Return([]organization.MemberResponse{}, nil)
+		}, http.StatusOK},
+		{"add member", http.MethodPut, "/v1/admin/organizations/3/members/42", "", func() {
+			s.mockSvc.EXPECT().AddMember(uint(3), uint(42)).Return(nil)
+		}, http.StatusNoContent},
+		{"invalid user ID", http.MethodPut, "/v1/admin/organizations/3/members/0", "", func() {}, http.StatusBadRequest},
+		{"remove unknown member", http.MethodDelete, "/v1/admin/organizations/3/members/42", "", func() {
+			s.mockSvc.EXPECT().RemoveMember(uint(3), uint(42)).Return(&organization.ErrUserNotFound)
+		}, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mock()
+
+			w := s.request(tt.method, tt.path, tt.body)
+			assert.Equal(s.T(), tt.expected, w.Code)
+		})
+	}
+}
+
+// TestHandlerTestSuite is the entry point for the test suite
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
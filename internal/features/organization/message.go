@@ -0,0 +1,58 @@
+package organization
+
+import "luizalabs-technical-test/pkg/errors"
+
+// Constants representing error codes related to organization management operations.
+const (
+	ErrCodeInvalidPayload  = "ERR_ORGANIZATION_INVALID_PAYLOAD"  // malformed request payload.
+	ErrCodeInvalidID       = "ERR_ORGANIZATION_INVALID_ID"       // malformed organization or user ID in the request path.
+	ErrCodeNotFound        = "ERR_ORGANIZATION_NOT_FOUND"        // organization not found.
+	ErrCodeNameInUse       = "ERR_ORGANIZATION_NAME_IN_USE"      // name already used by another organization.
+	ErrCodeHasMembers      = "ERR_ORGANIZATION_HAS_MEMBERS"      // deletion of an organization that still has members.
+	ErrCodeUserNotFound    = "ERR_ORGANIZATION_USER_NOT_FOUND"   // user not found, or not a member of the organization.
+	ErrCodeOperationFailed = "ERR_ORGANIZATION_OPERATION_FAILED" // failure reading or updating organizations.
+)
+
+var (
+	// ErrInvalidPayload is triggered when the request payload cannot be parsed.
+	ErrInvalidPayload = errors.Error{
+		Code:    ErrCodeInvalidPayload,
+		Message: "Os dados informados para a organização são inválidos. Verifique o payload e tente novamente.",
+	}
+
+	// ErrInvalidID is triggered when the organization or user ID in the request path is malformed.
+	ErrInvalidID = errors.Error{
+		Code:    ErrCodeInvalidID,
+		Message: "O identificador informado é inválido.",
+	}
+
+	// ErrNotFound is triggered when the requested organization does not exist.
+	ErrNotFound = errors.Error{
+		Code:    ErrCodeNotFound,
+		Message: "Organização não encontrada.",
+	}
+
+	// ErrNameInUse is triggered when the name is already used by another organization.
+	ErrNameInUse = errors.Error{
+		Code:    ErrCodeNameInUse,
+		Message: "Já existe uma organização com este nome.",
+	}
+
+	// ErrHasMembers is triggered when deleting an organization that still has members.
+	ErrHasMembers = errors.Error{
+		Code:    ErrCodeHasMembers,
+		Message: "A organização ainda possui membros. Remova-os antes de excluí-la.",
+	}
+
+	// ErrUserNotFound is triggered when the user does not exist or is not a member of the organization.
+	ErrUserNotFound = errors.Error{
+		Code:    ErrCodeUserNotFound,
+		Message: "Usuário não encontrado na organização.",
+	}
+
+	// ErrOperationFailed is triggered when reading or updating organizations fails.
+	ErrOperationFailed = errors.Error{
+		Code:    ErrCodeOperationFailed,
+		Message: "Não foi possível concluir a operação com a organização. Por favor, tente novamente mais tarde.",
+	}
+)
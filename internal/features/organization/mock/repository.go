@@ -0,0 +1,198 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/features/organization/repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	organization "luizalabs-technical-test/internal/features/organization"
+	entity "luizalabs-technical-test/internal/pkg/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepositoryImp is a mock of RepositoryImp interface.
+type MockRepositoryImp struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryImpMockRecorder
+}
+
+// MockRepositoryImpMockRecorder is the mock recorder for MockRepositoryImp.
+type MockRepositoryImpMockRecorder struct {
+	mock *MockRepositoryImp
+}
+
+// NewMockRepositoryImp creates a new mock instance.
+func NewMockRepositoryImp(ctrl *gomock.Controller) *MockRepositoryImp {
+	mock := &MockRepositoryImp{ctrl: ctrl}
+	mock.recorder = &MockRepositoryImpMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepositoryImp) EXPECT() *MockRepositoryImpMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockRepositoryImp) AddMember(organizationID, userID uint, changedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", organizationID, userID, changedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockRepositoryImpMockRecorder) AddMember(organizationID, userID, changedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockRepositoryImp)(nil).AddMember), organizationID, userID, changedAt)
+}
+
+// CountMembers mocks base method.
+func (m *MockRepositoryImp) CountMembers(organizationID uint) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountMembers", organizationID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountMembers indicates an expected call of CountMembers.
+func (mr *MockRepositoryImpMockRecorder) CountMembers(organizationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMembers", reflect.TypeOf((*MockRepositoryImp)(nil).CountMembers), organizationID)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockRepositoryImp) CreateOrganization(organization *entity.Organization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", organization)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockRepositoryImpMockRecorder) CreateOrganization(organization interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockRepositoryImp)(nil).CreateOrganization), organization)
+}
+
+// DeleteOrganization mocks base method.
+func (m *MockRepositoryImp) DeleteOrganization(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganization", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrganization indicates an expected call of DeleteOrganization.
+func (mr *MockRepositoryImpMockRecorder) DeleteOrganization(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganization", reflect.TypeOf((*MockRepositoryImp)(nil).DeleteOrganization), id)
+}
+
+// DeleteRequestUsageBefore mocks base method.
+func (m *MockRepositoryImp) DeleteRequestUsageBefore(cutoff time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRequestUsageBefore", cutoff)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRequestUsageBefore indicates an expected call of DeleteRequestUsageBefore.
+func (mr *MockRepositoryImpMockRecorder) DeleteRequestUsageBefore(cutoff interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRequestUsageBefore", reflect.TypeOf((*MockRepositoryImp)(nil).DeleteRequestUsageBefore), cutoff)
+}
+
+// GetOrganization mocks base method.
+func (m *MockRepositoryImp) GetOrganization(filter organization.GetOrganizationFilter) (*entity.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganization", filter)
+	ret0, _ := ret[0].(*entity.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganization indicates an expected call of GetOrganization.
+func (mr *MockRepositoryImpMockRecorder) GetOrganization(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganization", reflect.TypeOf((*MockRepositoryImp)(nil).GetOrganization), filter)
+}
+
+// IncrementRequestUsage mocks base method.
+func (m *MockRepositoryImp) IncrementRequestUsage(organizationID uint, day time.Time, limit int) (int, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementRequestUsage", organizationID, day, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// IncrementRequestUsage indicates an expected call of IncrementRequestUsage.
+func (mr *MockRepositoryImpMockRecorder) IncrementRequestUsage(organizationID, day, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementRequestUsage", reflect.TypeOf((*MockRepositoryImp)(nil).IncrementRequestUsage), organizationID, day, limit)
+}
+
+// ListMembers mocks base method.
+func (m *MockRepositoryImp) ListMembers(organizationID uint) ([]entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembers", organizationID)
+	ret0, _ := ret[0].([]entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockRepositoryImpMockRecorder) ListMembers(organizationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockRepositoryImp)(nil).ListMembers), organizationID)
+}
+
+// ListOrganizations mocks base method.
+func (m *MockRepositoryImp) ListOrganizations() ([]entity.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganizations")
+	ret0, _ := ret[0].([]entity.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrganizations indicates an expected call of ListOrganizations.
+func (mr *MockRepositoryImpMockRecorder) ListOrganizations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizations", reflect.TypeOf((*MockRepositoryImp)(nil).ListOrganizations))
+}
+
+// RemoveMember mocks base method.
+func (m *MockRepositoryImp) RemoveMember(organizationID, userID uint, changedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", organizationID, userID, changedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockRepositoryImpMockRecorder) RemoveMember(organizationID, userID, changedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockRepositoryImp)(nil).RemoveMember), organizationID, userID, changedAt)
+}
+
+// UpdateOrganization mocks base method.
+func (m *MockRepositoryImp) UpdateOrganization(id uint, changes map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrganization", id, changes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOrganization indicates an expected call of UpdateOrganization.
+func (mr *MockRepositoryImpMockRecorder) UpdateOrganization(id, changes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrganization", reflect.TypeOf((*MockRepositoryImp)(nil).UpdateOrganization), id, changes)
+}
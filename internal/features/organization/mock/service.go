@@ -0,0 +1,199 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/features/organization/service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	organization "luizalabs-technical-test/internal/features/organization"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockServiceImp is a mock of ServiceImp interface.
+type MockServiceImp struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceImpMockRecorder
+}
+
+// MockServiceImpMockRecorder is the mock recorder for MockServiceImp.
+type MockServiceImpMockRecorder struct {
+	mock *MockServiceImp
+}
+
+// NewMockServiceImp creates a new mock instance.
+func NewMockServiceImp(ctrl *gomock.Controller) *MockServiceImp {
+	mock := &MockServiceImp{ctrl: ctrl}
+	mock.recorder = &MockServiceImpMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceImp) EXPECT() *MockServiceImpMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockServiceImp) AddMember(organizationID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", organizationID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockServiceImpMockRecorder) AddMember(organizationID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockServiceImp)(nil).AddMember), organizationID, userID)
+}
+
+// ConsumeRequest mocks base method.
+func (m *MockServiceImp) ConsumeRequest(tenantID uint, day time.Time, limit int) (int, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeRequest", tenantID, day, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ConsumeRequest indicates an expected call of ConsumeRequest.
+func (mr *MockServiceImpMockRecorder) ConsumeRequest(tenantID, day, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeRequest", reflect.TypeOf((*MockServiceImp)(nil).ConsumeRequest), tenantID, day, limit)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockServiceImp) CreateOrganization(input organization.CreateOrganizationInput) (*organization.OrganizationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", input)
+	ret0, _ := ret[0].(*organization.OrganizationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockServiceImpMockRecorder) CreateOrganization(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockServiceImp)(nil).CreateOrganization), input)
+}
+
+// DeleteOrganization mocks base method.
+func (m *MockServiceImp) DeleteOrganization(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganization", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrganization indicates an expected call of DeleteOrganization.
+func (mr *MockServiceImpMockRecorder) DeleteOrganization(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganization", reflect.TypeOf((*MockServiceImp)(nil).DeleteOrganization), id)
+}
+
+// GetOrganization mocks base method.
+func (m *MockServiceImp) GetOrganization(id uint) (*organization.OrganizationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganization", id)
+	ret0, _ := ret[0].(*organization.OrganizationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganization indicates an expected call of GetOrganization.
+func (mr *MockServiceImpMockRecorder) GetOrganization(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganization", reflect.TypeOf((*MockServiceImp)(nil).GetOrganization), id)
+}
+
+// ListMembers mocks base method.
+func (m *MockServiceImp) ListMembers(organizationID uint) ([]organization.MemberResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembers", organizationID)
+	ret0, _ := ret[0].([]organization.MemberResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockServiceImpMockRecorder) ListMembers(organizationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockServiceImp)(nil).ListMembers), organizationID)
+}
+
+// ListOrganizations mocks base method.
+func (m *MockServiceImp) ListOrganizations() ([]organization.OrganizationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganizations")
+	ret0, _ := ret[0].([]organization.OrganizationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrganizations indicates an expected call of ListOrganizations.
+func (mr *MockServiceImpMockRecorder) ListOrganizations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizations", reflect.TypeOf((*MockServiceImp)(nil).ListOrganizations))
+}
+
+// PurgeExpiredUsage mocks base method.
+func (m *MockServiceImp) PurgeExpiredUsage() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredUsage")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpiredUsage indicates an expected call of PurgeExpiredUsage.
+func (mr *MockServiceImpMockRecorder) PurgeExpiredUsage() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredUsage", reflect.TypeOf((*MockServiceImp)(nil).PurgeExpiredUsage))
+}
+
+// RemoveMember mocks base method.
+func (m *MockServiceImp) RemoveMember(organizationID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", organizationID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockServiceImpMockRecorder) RemoveMember(organizationID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockServiceImp)(nil).RemoveMember), organizationID, userID)
+}
+
+// RequestQuota mocks base method.
+func (m *MockServiceImp) RequestQuota(tenantID uint) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestQuota", tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestQuota indicates an expected call of RequestQuota.
+func (mr *MockServiceImpMockRecorder) RequestQuota(tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestQuota", reflect.TypeOf((*MockServiceImp)(nil).RequestQuota), tenantID)
+}
+
+// UpdateOrganization mocks base method.
+func (m *MockServiceImp) UpdateOrganization(input organization.UpdateOrganizationInput) (*organization.OrganizationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrganization", input)
+	ret0, _ := ret[0].(*organization.OrganizationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrganization indicates an expected call of UpdateOrganization.
+func (mr *MockServiceImpMockRecorder) UpdateOrganization(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrganization", reflect.TypeOf((*MockServiceImp)(nil).UpdateOrganization), input)
+}
@@ -0,0 +1,96 @@
+package organization
+
+import (
+	"luizalabs-technical-test/internal/pkg/entity"
+	"time"
+)
+
+// PostOrganizationPayload represents the payload for creating an organization.
+type PostOrganizationPayload struct {
+	Name              string `json:"name"                binding:"required,max=100"`
+	DailyRequestQuota int    `json:"daily_request_quota" binding:"omitempty,min=0"`
+}
+
+// PatchOrganizationPayload represents the payload for updating an organization. Omitted fields are left untouched.
+type PatchOrganizationPayload struct {
+	Name              *string `json:"name"                binding:"omitempty,min=1,max=100"`
+	DailyRequestQuota *int    `json:"daily_request_quota" binding:"omitempty,min=0"`
+}
+
+// CreateOrganizationInput represents the input structure in service layer for creating an organization.
+type CreateOrganizationInput struct {
+	Name              string
+	DailyRequestQuota int
+}
+
+// UpdateOrganizationInput represents the input structure in service layer for updating an organization.
+type UpdateOrganizationInput struct {
+	ID                uint
+	Name              *string
+	DailyRequestQuota *int
+}
+
+// GetOrganizationFilter represents the filter criteria for querying an organization.
+type GetOrganizationFilter struct {
+	ID   uint
+	Name string
+}
+
+// OrganizationResponse represents an organization. A zero daily request quota means unlimited.
+type OrganizationResponse struct {
+	ID                uint      `json:"id"`
+	Name              string    `json:"name"`
+	DailyRequestQuota int       `json:"daily_request_quota"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// MemberResponse represents a member of an organization.
+type MemberResponse struct {
+	ID        uint      `json:"id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Policy defines how long the daily request counters are kept. A zero retention keeps them forever.
+// Note: retentions shorter than a day are raised to a day, as the counters of the current day enforce the quotas.
+type Policy struct {
+	UsageRetention time.Duration
+}
+
+// ToCreateOrganizationInput maps PostOrganizationPayload to CreateOrganizationInput.
+func (p *PostOrganizationPayload) ToCreateOrganizationInput() CreateOrganizationInput {
+	return CreateOrganizationInput{
+		Name:              p.Name,
+		DailyRequestQuota: p.DailyRequestQuota,
+	}
+}
+
+// ToUpdateOrganizationInput maps PatchOrganizationPayload to UpdateOrganizationInput.
+func (p *PatchOrganizationPayload) ToUpdateOrganizationInput(id uint) UpdateOrganizationInput {
+	return UpdateOrganizationInput{
+		ID:                id,
+		Name:              p.Name,
+		DailyRequestQuota: p.DailyRequestQuota,
+	}
+}
+
+// ToOrganizationResponse converts an Organization entity to its public representation.
+func ToOrganizationResponse(organization entity.Organization) OrganizationResponse {
+	return OrganizationResponse{
+		ID:                organization.ID,
+		Name:              organization.Name,
+		DailyRequestQuota: organization.DailyRequestQuota,
+		CreatedAt:         organization.CreatedAt,
+	}
+}
+
+// ToMemberResponse converts a User entity to its representation as a member of an organization.
+func ToMemberResponse(user entity.User) MemberResponse {
+	return MemberResponse{
+		ID:        user.ID,
+		Email:     user.Email,
+		Role:      user.Role,
+		CreatedAt: user.CreatedAt,
+	}
+}
@@ -0,0 +1,53 @@
+package organization
+
+import (
+	"testing"
+	"time"
+
+	"luizalabs-technical-test/internal/pkg/entity"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// TestToUpdateOrganizationInput tests that omitted fields are left unset.
+func TestToUpdateOrganizationInput(t *testing.T) {
+	quota := 100
+	payload := &PatchOrganizationPayload{DailyRequestQuota: &quota}
+
+	input := payload.ToUpdateOrganizationInput(3)
+
+	assert.Equal(t, uint(3), input.ID)
+	assert.Nil(t, input.Name)
+	assert.Equal(t, &quota, input.DailyRequestQuota)
+}
+
+// TestToOrganizationResponse tests the conversion of an Organization entity into its public view.
+func TestToOrganizationResponse(t *testing.T) {
+	createdAt := time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+	organization := entity.Organization{
+		Model:             gorm.Model{ID: 3, CreatedAt: createdAt},
+		Name:              "acme",
+		DailyRequestQuota: 100,
+	}
+
+	assert.Equal(t, OrganizationResponse{
+		ID:                3,
+		Name:              "acme",
+		DailyRequestQuota: 100,
+		CreatedAt:         createdAt,
+	}, ToOrganizationResponse(organization))
+}
+
+// TestToMemberResponse tests the conversion of a User entity into a member, without secret material.
+func TestToMemberResponse(t *testing.T) {
+	user := entity.User{
+		Model:          gorm.Model{ID: 7},
+		Email:          "member@example.com",
+		Password:       "hash",
+		Role:           entity.RoleUser,
+		OrganizationID: 3,
+	}
+
+	assert.Equal(t, MemberResponse{ID: 7, Email: "member@example.com", Role: entity.RoleUser}, ToMemberResponse(user))
+}
@@ -0,0 +1,177 @@
+package organization
+
+import (
+	"errors"
+	"luizalabs-technical-test/internal/pkg/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RepositoryImp defines the interface for the repository layer,
+// which abstracts data access operations.
+type RepositoryImp interface {
+	CreateOrganization(organization *entity.Organization) error
+	ListOrganizations() ([]entity.Organization, error)
+	GetOrganization(filter GetOrganizationFilter) (*entity.Organization, error)
+	UpdateOrganization(id uint, changes map[string]interface{}) error
+	DeleteOrganization(id uint) error
+	CountMembers(organizationID uint) (int64, error)
+	ListMembers(organizationID uint) ([]entity.User, error)
+	AddMember(organizationID, userID uint, changedAt time.Time) error
+	RemoveMember(organizationID, userID uint, changedAt time.Time) error
+	IncrementRequestUsage(organizationID uint, day time.Time, limit int) (int, bool, error)
+	DeleteRequestUsageBefore(cutoff time.Time) (int64, error)
+}
+
+// errMemberNotFound is returned when the user does not exist or is not a member of the organization.
+var errMemberNotFound = errors.New("user not found or not a member of the organization")
+
+// repository struct implements the repositoryImp interface,
+// that interacts with external entities such as databases or external APIs.
+type repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates and returns a new instance of the repository.
+func NewRepository(db *gorm.DB) RepositoryImp {
+	return &repository{db}
+}
+
+// CreateOrganization adds a new organization to the database.
+func (r *repository) CreateOrganization(organization *entity.Organization) error {
+	tx := r.db.Create(organization)
+	if err := tx.Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListOrganizations retrieves every organization, ordered by name.
+func (r *repository) ListOrganizations() ([]entity.Organization, error) {
+	var organizations []entity.Organization
+
+	tx := r.db.Order("name").Find(&organizations)
+	if err := tx.Error; err != nil {
+		return nil, err
+	}
+
+	return organizations, nil
+}
+
+// GetOrganization retrieves a single organization matching the provided filter.
+func (r *repository) GetOrganization(filter GetOrganizationFilter) (*entity.Organization, error) {
+	fetchedOrganization := new(entity.Organization)
+
+	tx := r.db.Where(&entity.Organization{
+		Model: gorm.Model{ID: filter.ID},
+		Name:  filter.Name,
+	}).First(fetchedOrganization)
+	if err := tx.Error; err != nil {
+		return nil, err
+	}
+
+	return fetchedOrganization, nil
+}
+
+// UpdateOrganization applies the changes, keyed by column name, to the organization.
+func (r *repository) UpdateOrganization(id uint, changes map[string]interface{}) error {
+	tx := r.db.Model(&entity.Organization{}).Where("id = ?", id).Updates(changes)
+	if err := tx.Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteOrganization permanently removes the organization, so that its name can be reused.
+func (r *repository) DeleteOrganization(id uint) error {
+	tx := r.db.Unscoped().Delete(&entity.Organization{}, id)
+	if err := tx.Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// CountMembers counts the users of the organization, including the soft deleted ones.
+func (r *repository) CountMembers(organizationID uint) (int64, error) {
+	var total int64
+
+	tx := r.db.Unscoped().Model(&entity.User{}).Where("organization_id = ?", organizationID).Count(&total)
+	if err := tx.Error; err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// ListMembers retrieves the users of the organization, oldest first.
+func (r *repository) ListMembers(organizationID uint) ([]entity.User, error) {
+	var users []entity.User
+
+	tx := r.db.Where("organization_id = ?", organizationID).Order("id").Find(&users)
+	if err := tx.Error; err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// AddMember moves the user into the organization, revoking the access tokens carrying its previous tenant.
+func (r *repository) AddMember(organizationID, userID uint, changedAt time.Time) error {
+	return r.updateMembership(r.db.Where("id = ?", userID), organizationID, changedAt)
+}
+
+// RemoveMember removes the user from the organization, revoking the access tokens carrying it as tenant.
+func (r *repository) RemoveMember(organizationID, userID uint, changedAt time.Time) error {
+	return r.updateMembership(r.db.Where("id = ? AND organization_id = ?", userID, organizationID), 0, changedAt)
+}
+
+// IncrementRequestUsage counts a request of the organization on the day unless the limit was reached, returning the
+// requests counted and whether this one was.
+// Note: the insert and the conditional increment are a single statement, so concurrent requests of every replica are
+// counted without exceeding the limit.
+func (r *repository) IncrementRequestUsage(organizationID uint, day time.Time, limit int) (int, bool, error) {
+	count := clause.Column{Table: entity.TbRequestUsage, Name: "count"}
+	usage := entity.RequestUsage{OrganizationID: organizationID, Day: day, Count: 1}
+
+	tx := r.db.Clauses(
+		clause.OnConflict{
+			Columns:   []clause.Column{{Name: "organization_id"}, {Name: "day"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"count": gorm.Expr("? + 1", count)}),
+			Where:     clause.Where{Exprs: []clause.Expression{clause.Lt{Column: count, Value: limit}}},
+		},
+		clause.Returning{Columns: []clause.Column{{Name: "count"}}},
+	).Create(&usage)
+	if err := tx.Error; err != nil {
+		return 0, false, err
+	}
+	if tx.RowsAffected == 0 {
+		return limit, false, nil
+	}
+	return usage.Count, true, nil
+}
+
+// DeleteRequestUsageBefore removes the request counters of the days before the cutoff, returning how many were removed.
+func (r *repository) DeleteRequestUsageBefore(cutoff time.Time) (int64, error) {
+	tx := r.db.Where("day < ?", cutoff).Delete(&entity.RequestUsage{})
+	if err := tx.Error; err != nil {
+		return 0, err
+	}
+	return tx.RowsAffected, nil
+}
+
+// updateMembership sets the organization of the users selected by the query.
+func (r *repository) updateMembership(query *gorm.DB, organizationID uint, changedAt time.Time) error {
+	tx := query.Model(&entity.User{}).Updates(map[string]interface{}{
+		"organization_id":        organizationID,
+		"credentials_changed_at": changedAt,
+	})
+	if err := tx.Error; err != nil {
+		return err
+	}
+	if tx.RowsAffected == 0 {
+		return errMemberNotFound
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package organization
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"luizalabs-technical-test/internal/pkg/entity"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type OrganizationRepositoryTestSuite struct {
+	suite.Suite
+	db  *gorm.DB
+	ctx context.Context
+}
+
+func (s *OrganizationRepositoryTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+
+	// Start PostgreSQL container
+	req := testcontainers.ContainerRequest{
+		Image:        "postgres:latest",
+		ExposedPorts: []string{"5432/tcp"},
+		Env: map[string]string{
+			"POSTGRES_USER":     "testuser",
+			"POSTGRES_PASSWORD": "testpass",
+			"POSTGRES_DB":       "testdb",
+		},
+		WaitingFor: wait.ForListeningPort("5432/tcp"),
+	}
+
+	// Create and start the container
+	postgresContainer, err := testcontainers.GenericContainer(s.ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	s.Require().NoError(err)
+
+	// Get the port and create the database connection
+	host, _ := postgresContainer.Host(s.ctx)
+	port, _ := postgresContainer.MappedPort(s.ctx, "5432")
+
+	dsn := "host=" + host + " port=" + port.Port() + " user=testuser password=testpass dbname=testdb sslmode=disable"
+	s.db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	s.Require().NoError(err)
+
+	// Auto-migrate the User, Organization and RequestUsage tables
+	s.Require().NoError(s.db.AutoMigrate(&entity.User{}, &entity.Organization{}, &entity.RequestUsage{}))
+}
+
+func (s *OrganizationRepositoryTestSuite) TearDownSuite() {
+	// Clean up the database connection
+	db, err := s.db.DB()
+	s.Require().NoError(err)
+	db.Close()
+}
+
+func (s *OrganizationRepositoryTestSuite) TestCreateUpdateAndDeleteOrganization() {
+	repo := NewRepository(s.db)
+
+	organization := entity.Organization{Name: "Beta", DailyRequestQuota: 10}
+	s.Require().NoError(repo.CreateOrganization(&organization))
+	s.NotZero(organization.ID)
+	s.Require().NoError(repo.CreateOrganization(&entity.Organization{Name: "Alpha"}))
+	s.Error(repo.CreateOrganization(&entity.Organization{Name: "Beta"}), "Expected names to be unique")
+
+	organizations, err := repo.ListOrganizations()
+	s.NoError(err)
+	s.Require().Len(organizations, 2)
+	s.Equal("Alpha", organizations[0].Name)
+
+	s.NoError(repo.UpdateOrganization(organization.ID, map[string]interface{}{"name": "Gamma", "daily_request_quota": 0}))
+	fetchedOrganization, err := repo.GetOrganization(GetOrganizationFilter{Name: "Gamma"})
+	s.Require().NoError(err)
+	s.Equal(organization.ID, fetchedOrganization.ID)
+	s.Zero(fetchedOrganization.DailyRequestQuota)
+
+	s.NoError(repo.DeleteOrganization(organization.ID))
+	_, err = repo.GetOrganization(GetOrganizationFilter{ID: organization.ID})
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+	s.NoError(repo.CreateOrganization(&entity.Organization{Name: "Gamma"}), "Expected the name to be reusable")
+}
+
+func (s *OrganizationRepositoryTestSuite) TestMembers() {
+	repo := NewRepository(s.db)
+
+	organization := entity.Organization{Name: "Members"}
+	s.Require().NoError(repo.CreateOrganization(&organization))
+	user := entity.User{Email: "member@example.com"}
+	s.Require().NoError(s.db.Create(&user).Error)
+
+	now := time.Now()
+	s.NoError(repo.AddMember(organization.ID, user.ID, now))
+	s.ErrorIs(repo.AddMember(organization.ID, 999, now), errMemberNotFound)
+
+	members, err := repo.ListMembers(organization.ID)
+	s.NoError(err)
+	s.Require().Len(members, 1)
+	s.Equal(user.ID, members[0].ID)
+	s.NotNil(members[0].CredentialsChangedAt, "Expected the tokens of the member to be revoked")
+
+	total, err := repo.CountMembers(organization.ID)
+	s.NoError(err)
+	s.Equal(int64(1), total)
+
+	s.ErrorIs(repo.RemoveMember(organization.ID+1, user.ID, now), errMemberNotFound)
+	s.NoError(repo.RemoveMember(organization.ID, user.ID, now))
+
+	total, err = repo.CountMembers(organization.ID)
+	s.NoError(err)
+	s.Zero(total)
+}
+
+func (s *OrganizationRepositoryTestSuite) TestIncrementRequestUsage() {
+	repo := NewRepository(s.db)
+	day := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	// Concurrent requests are all counted, up to the limit
+	const requests, limit = 20, 15
+	var wg sync.WaitGroup
+	allowed := make(chan bool, requests)
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, ok, err := repo.IncrementRequestUsage(7, day, limit)
+			s.NoError(err)
+			allowed <- ok
+		}()
+	}
+	wg.Wait()
+	close(allowed)
+
+	counted := 0
+	for ok := range allowed {
+		if ok {
+			counted++
+		}
+	}
+	s.Equal(limit, counted)
+
+	used, ok, err := repo.IncrementRequestUsage(7, day, limit)
+	s.NoError(err)
+	s.False(ok)
+	s.Equal(limit, used)
+
+	// The counter of another day starts over
+	used, ok, err = repo.IncrementRequestUsage(7, day.AddDate(0, 0, 1), limit)
+	s.NoError(err)
+	s.True(ok)
+	s.Equal(1, used)
+}
+
+func (s *OrganizationRepositoryTestSuite) TestDeleteRequestUsageBefore() {
+	repo := NewRepository(s.db)
+	day := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	for _, usageDay := range []time.Time{day.AddDate(0, 0, -2), day.AddDate(0, 0, -1), day} {
+		_, ok, err := repo.IncrementRequestUsage(8, usageDay, 10)
+		s.Require().NoError(err)
+		s.Require().True(ok)
+	}
+
+	purged, err := repo.DeleteRequestUsageBefore(day.Add(-12 * time.Hour))
+	s.NoError(err)
+	s.Equal(int64(2), purged)
+
+	// The counter of the day is kept
+	used, ok, err := repo.IncrementRequestUsage(8, day, 10)
+	s.NoError(err)
+	s.True(ok)
+	s.Equal(2, used)
+}
+
+func TestOrganizationRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationRepositoryTestSuite))
+}
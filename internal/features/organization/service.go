@@ -0,0 +1,237 @@
+package organization
+
+import (
+	"errors"
+	"fmt"
+	"luizalabs-technical-test/internal/pkg/entity"
+	"luizalabs-technical-test/internal/pkg/middleware"
+	"luizalabs-technical-test/pkg/logger"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ServiceImp defines the interface for the service layer, with methods to manage organizations and their members.
+// It also provides the daily request quotas enforced by the quota middleware.
+type ServiceImp interface {
+	middleware.QuotaProvider
+	CreateOrganization(input CreateOrganizationInput) (*OrganizationResponse, error)
+	ListOrganizations() ([]OrganizationResponse, error)
+	GetOrganization(id uint) (*OrganizationResponse, error)
+	UpdateOrganization(input UpdateOrganizationInput) (*OrganizationResponse, error)
+	DeleteOrganization(id uint) error
+	ListMembers(organizationID uint) ([]MemberResponse, error)
+	AddMember(organizationID, userID uint) error
+	RemoveMember(organizationID, userID uint) error
+	PurgeExpiredUsage() (int64, error)
+}
+
+// service struct implements the serviceImp interface and holds a reference to the repository.
+type service struct {
+	repository RepositoryImp
+	policy     Policy
+}
+
+// NewService creates and returns a new service instance, injecting the repository dependency and the retention
+// of the daily request counters.
+func NewService(repository RepositoryImp, policy Policy) ServiceImp {
+	return &service{repository, policy}
+}
+
+// CreateOrganization creates an organization, whose name must not be used by another one.
+func (s *service) CreateOrganization(input CreateOrganizationInput) (*OrganizationResponse, error) {
+	if err := s.ensureNameAvailable(input.Name, 0); err != nil {
+		return nil, err
+	}
+
+	organization := entity.Organization{Name: input.Name, DailyRequestQuota: input.DailyRequestQuota}
+	if err := s.repository.CreateOrganization(&organization); err != nil {
+		return nil, ErrOperationFailed.WithErr(err)
+	}
+
+	response := ToOrganizationResponse(organization)
+	return &response, nil
+}
+
+// ListOrganizations retrieves every organization, ordered by name.
+func (s *service) ListOrganizations() ([]OrganizationResponse, error) {
+	organizations, err := s.repository.ListOrganizations()
+	if err != nil {
+		return nil, ErrOperationFailed.WithErr(err)
+	}
+
+	response := make([]OrganizationResponse, 0, len(organizations))
+	for _, organization := range organizations {
+		response = append(response, ToOrganizationResponse(organization))
+	}
+	return response, nil
+}
+
+// GetOrganization retrieves a single organization.
+func (s *service) GetOrganization(id uint) (*OrganizationResponse, error) {
+	organization, err := s.organization(id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := ToOrganizationResponse(*organization)
+	return &response, nil
+}
+
+// UpdateOrganization renames the organization and/or changes its daily request quota.
+func (s *service) UpdateOrganization(input UpdateOrganizationInput) (*OrganizationResponse, error) {
+	organization, err := s.organization(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]interface{}{}
+	if input.Name != nil && *input.Name != organization.Name {
+		if err := s.ensureNameAvailable(*input.Name, organization.ID); err != nil {
+			return nil, err
+		}
+		changes["name"] = *input.Name
+		organization.Name = *input.Name
+	}
+	if input.DailyRequestQuota != nil {
+		changes["daily_request_quota"] = *input.DailyRequestQuota
+		organization.DailyRequestQuota = *input.DailyRequestQuota
+	}
+
+	if len(changes) > 0 {
+		if err := s.repository.UpdateOrganization(organization.ID, changes); err != nil {
+			return nil, ErrOperationFailed.WithErr(err)
+		}
+	}
+
+	response := ToOrganizationResponse(*organization)
+	return &response, nil
+}
+
+// DeleteOrganization removes an organization without members.
+// Note: members must be removed first, so that no account is left pointing to a missing tenant.
+func (s *service) DeleteOrganization(id uint) error {
+	if _, err := s.organization(id); err != nil {
+		return err
+	}
+
+	members, err := s.repository.CountMembers(id)
+	if err != nil {
+		return ErrOperationFailed.WithErr(err)
+	}
+	if members > 0 {
+		return ErrHasMembers.WithStrErr("organization %d still has %d members", id, members)
+	}
+
+	if err := s.repository.DeleteOrganization(id); err != nil {
+		return ErrOperationFailed.WithErr(err)
+	}
+
+	logger.Warn(fmt.Sprintf("organization %d deleted", id))
+	return nil
+}
+
+// ListMembers retrieves the members of the organization, oldest first.
+func (s *service) ListMembers(organizationID uint) ([]MemberResponse, error) {
+	if _, err := s.organization(organizationID); err != nil {
+		return nil, err
+	}
+
+	users, err := s.repository.ListMembers(organizationID)
+	if err != nil {
+		return nil, ErrOperationFailed.WithErr(err)
+	}
+
+	response := make([]MemberResponse, 0, len(users))
+	for _, user := range users {
+		response = append(response, ToMemberResponse(user))
+	}
+	return response, nil
+}
+
+// AddMember moves the user into the organization, leaving any organization it belonged to.
+// The access tokens of the user are revoked, as they carry its previous tenant.
+func (s *service) AddMember(organizationID, userID uint) error {
+	if _, err := s.organization(organizationID); err != nil {
+		return err
+	}
+
+	if err := s.repository.AddMember(organizationID, userID, time.Now()); err != nil {
+		return s.membershipError(err)
+	}
+
+	logger.Warn(fmt.Sprintf("account %d added to organization %d", userID, organizationID))
+	return nil
+}
+
+// RemoveMember removes the user from the organization, revoking its access tokens.
+func (s *service) RemoveMember(organizationID, userID uint) error {
+	if err := s.repository.RemoveMember(organizationID, userID, time.Now()); err != nil {
+		return s.membershipError(err)
+	}
+
+	logger.Warn(fmt.Sprintf("account %d removed from organization %d", userID, organizationID))
+	return nil
+}
+
+// RequestQuota returns the daily request quota of the organization. A zero quota means unlimited.
+func (s *service) RequestQuota(tenantID uint) (int, error) {
+	organization, err := s.organization(tenantID)
+	if err != nil {
+		return 0, err
+	}
+	return organization.DailyRequestQuota, nil
+}
+
+// ConsumeRequest counts a request of the organization on the day (UTC) unless the limit was reached, returning the
+// requests counted and whether this one was.
+func (s *service) ConsumeRequest(tenantID uint, day time.Time, limit int) (int, bool, error) {
+	return s.repository.IncrementRequestUsage(tenantID, day, limit)
+}
+
+// PurgeExpiredUsage removes the daily request counters older than the retention, returning how many were removed.
+// Note: nothing is removed when the retention is disabled.
+func (s *service) PurgeExpiredUsage() (int64, error) {
+	if s.policy.UsageRetention <= 0 {
+		return 0, nil
+	}
+
+	retention := max(s.policy.UsageRetention, 24*time.Hour)
+	purged, err := s.repository.DeleteRequestUsageBefore(time.Now().UTC().Add(-retention))
+	if err != nil {
+		return 0, ErrOperationFailed.WithErr(err)
+	}
+	return purged, nil
+}
+
+// organization retrieves the organization, distinguishing unknown organizations from repository failures.
+func (s *service) organization(id uint) (*entity.Organization, error) {
+	organization, err := s.repository.GetOrganization(GetOrganizationFilter{ID: id})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound.WithErr(err)
+		}
+		return nil, ErrOperationFailed.WithErr(err)
+	}
+	return organization, nil
+}
+
+// ensureNameAvailable checks that no organization other than the given one uses the name.
+func (s *service) ensureNameAvailable(name string, id uint) error {
+	existing, err := s.repository.GetOrganization(GetOrganizationFilter{Name: name})
+	if err == nil && existing.ID != id {
+		return ErrNameInUse.WithStrErr("name %q already used by organization %d", name, existing.ID)
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrOperationFailed.WithErr(err)
+	}
+	return nil
+}
+
+// membershipError maps the errors of membership changes.
+func (s *service) membershipError(err error) error {
+	if errors.Is(err, errMemberNotFound) {
+		return ErrUserNotFound.WithErr(err)
+	}
+	return ErrOperationFailed.WithErr(err)
+}
@@ -0,0 +1,253 @@
+package organization_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"luizalabs-technical-test/internal/features/organization"
+	organizationMock "luizalabs-technical-test/internal/features/organization/mock"
+	"luizalabs-technical-test/internal/pkg/entity"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+// OrganizationServiceTestSuite is a test suite for the organization service.
+type OrganizationServiceTestSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	repoMock     *organizationMock.MockRepositoryImp
+	service      organization.ServiceImp
+	organization *entity.Organization
+}
+
+// SetupTest initializes the test suite, creating a new mock controller and instances of mocks.
+func (suite *OrganizationServiceTestSuite) SetupTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.repoMock = organizationMock.NewMockRepositoryImp(suite.ctrl)
+	suite.service = organization.NewService(suite.repoMock, organization.Policy{UsageRetention: 7 * 24 * time.Hour})
+	suite.organization = &entity.Organization{Model: gorm.Model{ID: 3}, Name: "acme", DailyRequestQuota: 100}
+}
+
+// TearDownTest cleans up the mock controller after each test.
+func (suite *OrganizationServiceTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+// TestCreateOrganization_NameInUse tests the creation of an organization with a name already in use.
+func (suite *OrganizationServiceTestSuite) TestCreateOrganization_NameInUse() {
+	suite.repoMock.EXPECT().
+		GetOrganization(organization.GetOrganizationFilter{Name: "acme"}).
+		Return(suite.organization, nil)
+
+	_, err := suite.service.CreateOrganization(organization.CreateOrganizationInput{Name: "acme"})
+
+	assert.Equal(suite.T(), &organization.ErrNameInUse, err)
+}
+
+// TestCreateOrganization_Success tests the creation of an organization.
+func (suite *OrganizationServiceTestSuite) TestCreateOrganization_Success() {
+	suite.repoMock.EXPECT().
+		GetOrganization(organization.GetOrganizationFilter{Name: "acme"}).
+		Return(nil, gorm.ErrRecordNotFound)
+
+	suite.repoMock.EXPECT().
+		CreateOrganization(&entity.Organization{Name: "acme", DailyRequestQuota: 100}).
+		DoAndReturn(func(created *entity.Organization) error {
+			created.ID = 3
+			return nil
+		})
+
+	response, err := suite.service.CreateOrganization(organization.CreateOrganizationInput{Name: "acme", DailyRequestQuota: 100})
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), uint(3), response.ID)
+	assert.Equal(suite.T(), 100, response.DailyRequestQuota)
+}
+
+// TestGetOrganization tests that unknown organizations are told apart from repository failures.
+func (suite *OrganizationServiceTestSuite) TestGetOrganization() {
+	tests := []struct {
+		name     string
+		repoErr  error
+		expected error
+	}{
+		{"not found", gorm.ErrRecordNotFound, &organization.ErrNotFound},
+		{"failure", errors.New("connection refused"), &organization.ErrOperationFailed},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.repoMock.EXPECT().
+				GetOrganization(organization.GetOrganizationFilter{ID: 3}).
+				Return(nil, tt.repoErr)
+
+			_, err := suite.service.GetOrganization(3)
+			assert.Equal(suite.T(), tt.expected, err)
+		})
+	}
+}
+
+// TestUpdateOrganization_Success tests that only the provided fields are changed.
+func (suite *OrganizationServiceTestSuite) TestUpdateOrganization_Success() {
+	quota := 0
+	suite.repoMock.EXPECT().
+		GetOrganization(organization.GetOrganizationFilter{ID: 3}).
+		Return(suite.organization, nil)
+
+	suite.repoMock.EXPECT().
+		UpdateOrganization(uint(3), map[string]interface{}{"daily_request_quota": 0}).
+		Return(nil)
+
+	response, err := suite.service.UpdateOrganization(organization.UpdateOrganizationInput{ID: 3, DailyRequestQuota: &quota})
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "acme", response.Name)
+	assert.Zero(suite.T(), response.DailyRequestQuota)
+}
+
+// TestDeleteOrganization_HasMembers tests that organizations with members are not deleted.
+func (suite *OrganizationServiceTestSuite) TestDeleteOrganization_HasMembers() {
+	suite.repoMock.EXPECT().
+		GetOrganization(organization.GetOrganizationFilter{ID: 3}).
+		Return(suite.organization, nil)
+
+	suite.repoMock.EXPECT().
+		CountMembers(uint(3)).
+		Return(int64(2), nil)
+
+	assert.Equal(suite.T(), &organization.ErrHasMembers, suite.service.DeleteOrganization(3))
+}
+
+// TestDeleteOrganization_Success tests the deletion of an organization without members.
+func (suite *OrganizationServiceTestSuite) TestDeleteOrganization_Success() {
+	suite.repoMock.EXPECT().
+		GetOrganization(organization.GetOrganizationFilter{ID: 3}).
+		Return(suite.organization, nil)
+
+	suite.repoMock.EXPECT().
+		CountMembers(uint(3)).
+		Return(int64(0), nil)
+
+	suite.repoMock.EXPECT().
+		DeleteOrganization(uint(3)).
+		Return(nil)
+
+	assert.NoError(suite.T(), suite.service.DeleteOrganization(3))
+}
+
+// TestAddMember_OrganizationNotFound tests adding a member to an unknown organization.
+func (suite *OrganizationServiceTestSuite) TestAddMember_OrganizationNotFound() {
+	suite.repoMock.EXPECT().
+		GetOrganization(organization.GetOrganizationFilter{ID: 3}).
+		Return(nil, gorm.ErrRecordNotFound)
+
+	assert.Equal(suite.T(), &organization.ErrNotFound, suite.service.AddMember(3, 42))
+}
+
+// TestAddMember_Success tests adding a member to an organization.
+func (suite *OrganizationServiceTestSuite) TestAddMember_Success() {
+	suite.repoMock.EXPECT().
+		GetOrganization(organization.GetOrganizationFilter{ID: 3}).
+		Return(suite.organization, nil)
+
+	suite.repoMock.EXPECT().
+		AddMember(uint(3), uint(42), gomock.Any()).
+		Return(nil)
+
+	assert.NoError(suite.T(), suite.service.AddMember(3, 42))
+}
+
+// TestRemoveMember_Failure tests the removal of a member when the repository fails.
+func (suite *OrganizationServiceTestSuite) TestRemoveMember_Failure() {
+	suite.repoMock.EXPECT().
+		RemoveMember(uint(3), uint(42), gomock.Any()).
+		Return(errors.New("connection refused"))
+
+	assert.Equal(suite.T(), &organization.ErrOperationFailed, suite.service.RemoveMember(3, 42))
+}
+
+// TestRequestQuota tests that the daily request quota of the organization is provided.
+func (suite *OrganizationServiceTestSuite) TestRequestQuota() {
+	suite.repoMock.EXPECT().
+		GetOrganization(organization.GetOrganizationFilter{ID: 3}).
+		Return(suite.organization, nil)
+
+	quota, err := suite.service.RequestQuota(3)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 100, quota)
+}
+
+// TestConsumeRequest tests that the requests of the organization are counted by the repository.
+func (suite *OrganizationServiceTestSuite) TestConsumeRequest() {
+	day := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	suite.repoMock.EXPECT().
+		IncrementRequestUsage(uint(3), day, 100).
+		Return(42, true, nil)
+
+	used, allowed, err := suite.service.ConsumeRequest(3, day, 100)
+
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), allowed)
+	assert.Equal(suite.T(), 42, used)
+}
+
+// TestPurgeExpiredUsage tests that the daily request counters older than the retention are removed.
+func (suite *OrganizationServiceTestSuite) TestPurgeExpiredUsage() {
+	suite.repoMock.EXPECT().
+		DeleteRequestUsageBefore(gomock.Any()).
+		DoAndReturn(func(cutoff time.Time) (int64, error) {
+			assert.WithinDuration(suite.T(), time.Now().Add(-7*24*time.Hour), cutoff, time.Minute)
+			return 3, nil
+		})
+
+	purged, err := suite.service.PurgeExpiredUsage()
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(3), purged)
+}
+
+// TestPurgeExpiredUsage_ShortRetention tests that the counters of the current day are kept by retentions under a day.
+func (suite *OrganizationServiceTestSuite) TestPurgeExpiredUsage_ShortRetention() {
+	service := organization.NewService(suite.repoMock, organization.Policy{UsageRetention: time.Minute})
+	suite.repoMock.EXPECT().
+		DeleteRequestUsageBefore(gomock.Any()).
+		DoAndReturn(func(cutoff time.Time) (int64, error) {
+			assert.WithinDuration(suite.T(), time.Now().Add(-24*time.Hour), cutoff, time.Minute)
+			return 0, nil
+		})
+
+	_, err := service.PurgeExpiredUsage()
+
+	assert.NoError(suite.T(), err)
+}
+
+// TestPurgeExpiredUsage_RetentionDisabled tests that nothing is removed when the retention is disabled.
+func (suite *OrganizationServiceTestSuite) TestPurgeExpiredUsage_RetentionDisabled() {
+	service := organization.NewService(suite.repoMock, organization.Policy{})
+
+	purged, err := service.PurgeExpiredUsage()
+
+	assert.NoError(suite.T(), err)
+	assert.Zero(suite.T(), purged)
+}
+
+// TestPurgeExpiredUsage_Failure tests the scenario where the counters cannot be removed.
+func (suite *OrganizationServiceTestSuite) TestPurgeExpiredUsage_Failure() {
+	suite.repoMock.EXPECT().
+		DeleteRequestUsageBefore(gomock.Any()).
+		Return(int64(0), errors.New("database unavailable"))
+
+	_, err := suite.service.PurgeExpiredUsage()
+
+	assert.Equal(suite.T(), &organization.ErrOperationFailed, err)
+}
+
+// TestOrganizationServiceTestSuite runs the test suite for the organization service.
+func TestOrganizationServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationServiceTestSuite))
+}
@@ -23,24 +23,29 @@ type HandlerImp interface {
 
 // handler struct holds a reference to the service layer.
 type handler struct {
-	svc        ServiceImp
-	cacheLayer middleware.Middleware
-	tokenLayer middleware.Middleware
+	svc         ServiceImp
+	cacheLayer  middleware.Middleware
+	tokenLayer  middleware.Middleware
+	apiKeyLayer middleware.Middleware
+	quotaLayer  middleware.Middleware
 }
 
 // NewHandler creates and returns a new handler instance with the injected service.
-func NewHandler(svc ServiceImp, cacheMiddleware middleware.Middleware, tokenMiddleware middleware.Middleware) HandlerImp {
+func NewHandler(svc ServiceImp, cacheMiddleware middleware.Middleware, tokenMiddleware middleware.Middleware, apiKeyMiddleware middleware.Middleware, quotaMiddleware middleware.Middleware) HandlerImp {
 	return &handler{
 		svc,
 		cacheMiddleware,
 		tokenMiddleware,
+		apiKeyMiddleware,
+		quotaMiddleware,
 	}
 }
 
 // Register sets up the route for retrieving ZipCode information.
+// Note: the quota runs ahead of the cache, so cached responses are also counted against the quota of the tenant.
 func (h *handler) Register(r *gin.RouterGroup) {
 	g := r.Group("/address")
-	g.GET("/:zip-code", h.tokenLayer.Middleware(), h.cacheLayer.Middleware(), h.getAddressByZipCode)
+	g.GET("/:zip-code", h.apiKeyLayer.Middleware(), h.tokenLayer.Middleware(), h.quotaLayer.Middleware(), h.cacheLayer.Middleware(), h.getAddressByZipCode)
 }
 
 // getAddressByZipCode handles the request to retrieve CEP information.
@@ -50,12 +55,16 @@ func (h *handler) Register(r *gin.RouterGroup) {
 //	@Tags			Address
 //	@Accept			json
 //	@Produce		json
-//	@Param			Authorization	header		string	true	"Authorization token"
-//	@Param			X-Cache-Control	header		string	false	"Cache control directive (e.g., 'no-cache')"
+//	@Param			Authorization	header		string	false	"Authorization token (required when X-API-Key is not provided)"
+//	@Param			X-API-Key		header		string	false	"API key granted with the address:read scope"
+//	@Param			Cache-Control	header		string	false	"Cache directives: no-cache, no-store or max-age (e.g., 'no-cache')"
+//	@Param			If-None-Match	header		string	false	"ETag of a previously received response"
 //	@Param			zip-code		path		string	true	"ZIP Code"
 //	@Success		200				{object}	swagGetAddressByZipCodeResponse
+//	@Success		304				"Not modified, the response matching If-None-Match is still fresh"
 //	@Failure		400				{object}	server.APIErrorResponse	"Invalid ZIP code format"
 //	@Failure		404				{object}	server.APIErrorResponse	"ZIP code not found"
+//	@Failure		429				{object}	server.APIErrorResponse	"Daily request quota of the organization exceeded"
 //	@Router			/v1/address/{zip-code} [get]
 func (h *handler) getAddressByZipCode(c *gin.Context) {
 	zipCode := c.Param("zip-code")
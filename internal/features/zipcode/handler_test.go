@@ -19,11 +19,13 @@ import (
 // ZipcodeTestSuite defines the structure for the test suite.
 type ZipcodeTestSuite struct {
 	suite.Suite
-	ctrl            *gomock.Controller
-	router          *gin.Engine
-	mockSvc         *zipcodeMock.MockServiceImp
-	tokenMiddleware *middlewareMock.MockTokenMiddleware
-	cacheMiddleware *middlewareMock.MockCacheMiddleware
+	ctrl             *gomock.Controller
+	router           *gin.Engine
+	mockSvc          *zipcodeMock.MockServiceImp
+	tokenMiddleware  *middlewareMock.MockTokenMiddleware
+	apiKeyMiddleware *middlewareMock.MockAPIKeyMiddleware
+	cacheMiddleware  *middlewareMock.MockCacheMiddleware
+	quotaMiddleware  *middlewareMock.MockQuotaMiddleware
 }
 
 // SetupTest is called before each test, setting up common dependencies.
@@ -36,6 +38,8 @@ func (suite *ZipcodeTestSuite) SetupTest() {
 	suite.mockSvc = zipcodeMock.NewMockServiceImp(suite.ctrl)
 	suite.tokenMiddleware = middlewareMock.NewMockTokenMiddleware(suite.ctrl)
 	suite.cacheMiddleware = middlewareMock.NewMockCacheMiddleware(suite.ctrl)
+	suite.apiKeyMiddleware = middlewareMock.NewMockAPIKeyMiddleware(suite.ctrl)
+	suite.quotaMiddleware = middlewareMock.NewMockQuotaMiddleware(suite.ctrl)
 
 	// Set up middleware mocks
 	suite.tokenMiddleware.EXPECT().
@@ -52,8 +56,22 @@ func (suite *ZipcodeTestSuite) SetupTest() {
 		}).
 		AnyTimes()
 
+	suite.apiKeyMiddleware.EXPECT().
+		Middleware().
+		Return(func(c *gin.Context) {
+			c.Next()
+		}).
+		AnyTimes()
+
+	suite.quotaMiddleware.EXPECT().
+		Middleware().
+		Return(func(c *gin.Context) {
+			c.Next()
+		}).
+		AnyTimes()
+
 	// Initialize the handler with mocks and register the route
-	handler := zipcode.NewHandler(suite.mockSvc, suite.cacheMiddleware, suite.tokenMiddleware)
+	handler := zipcode.NewHandler(suite.mockSvc, suite.cacheMiddleware, suite.tokenMiddleware, suite.apiKeyMiddleware, suite.quotaMiddleware)
 	handler.Register(suite.router.Group("/v1"))
 }
 
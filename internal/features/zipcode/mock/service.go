@@ -48,3 +48,17 @@ func (mr *MockServiceImpMockRecorder) GetAddressByZipCode(zipCode interface{}) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddressByZipCode", reflect.TypeOf((*MockServiceImp)(nil).GetAddressByZipCode), zipCode)
 }
+
+// WarmUp mocks base method.
+func (m *MockServiceImp) WarmUp(zipCodes []string) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WarmUp", zipCodes)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// WarmUp indicates an expected call of WarmUp.
+func (mr *MockServiceImpMockRecorder) WarmUp(zipCodes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WarmUp", reflect.TypeOf((*MockServiceImp)(nil).WarmUp), zipCodes)
+}
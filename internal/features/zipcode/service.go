@@ -1,27 +1,66 @@
 package zipcode
 
 import (
+	"fmt"
 	"time"
+
+	"luizalabs-technical-test/pkg/cache"
+	"luizalabs-technical-test/pkg/logger"
 )
 
+// AddressCacheKeyPrefix namespaces the addresses among the other entries of the cache.
+const AddressCacheKeyPrefix = "zipcode:"
+
 // ServiceImp defines the interface for the service layer, with a method to retrieve a CEP.
 type ServiceImp interface {
 	GetAddressByZipCode(zipCode string) (*GetAddressByZipCodeResponse, error)
+	// WarmUp fetches the addresses of the zip codes from the providers into the cache, returning how many were found.
+	WarmUp(zipCodes []string) int
 }
 
 // service struct implements the serviceImp interface and holds a reference to the repository.
 type service struct {
 	repository RepositoryImp
+	addresses  *cache.Typed[GetAddressByZipCodeResponse]
+	expiration time.Duration
 }
 
 // NewService creates and returns a new service instance, injecting the repository dependency.
-func NewService(repository RepositoryImp) ServiceImp {
-	return &service{repository}
+// Addresses found by the providers are cached for the given expiration, shared by every caller; zero disables it.
+func NewService(repository RepositoryImp, cacheManager cache.Manager, expiration time.Duration) ServiceImp {
+	return &service{
+		repository: repository,
+		addresses:  cache.NewTyped[GetAddressByZipCodeResponse](cacheManager),
+		expiration: expiration,
+	}
 }
 
 // GetAddressByZipCode makes concurrent API calls to retrieve the address by zip code.
 // The first successful response is used, and errors are printed if encountered.
+// Note: addresses are looked up in the cache first, so the providers are only called once per zip code.
 func (s *service) GetAddressByZipCode(zipCode string) (*GetAddressByZipCodeResponse, error) {
+	if address, found := s.addresses.Get(AddressCacheKeyPrefix + zipCode); found {
+		return &address, nil
+	}
+	return s.lookup(zipCode)
+}
+
+// WarmUp looks the zip codes up one at a time, bypassing the cache so the addresses cached earlier are refreshed.
+// Note: zip codes that fail are only logged, so the others are still warmed.
+func (s *service) WarmUp(zipCodes []string) int {
+	warmed := 0
+	for _, zipCode := range zipCodes {
+		if _, err := s.lookup(zipCode); err != nil {
+			logger.Error(fmt.Errorf("failed to warm up the zip code %s: %w", zipCode, err))
+			continue
+		}
+		warmed++
+	}
+	return warmed
+}
+
+// lookup calls the providers concurrently, caching the first successful response.
+func (s *service) lookup(zipCode string) (*GetAddressByZipCodeResponse, error) {
 	responseChan := make(chan *GetAddressByZipCodeUnifiedResponse, 1)
 
 	apiCalls := []func(zipCode string) (*GetAddressByZipCodeUnifiedResponse, error){
@@ -36,7 +75,7 @@ func (s *service) GetAddressByZipCode(zipCode string) (*GetAddressByZipCodeRespo
 			response, err := call(zipCode)
 			if err != nil {
 				// Note: Do not return in cases of instability or errors, to avoid stopping the request flow.
-				ErrZipCodeNotFound.WithErr(err).Error()
+				_ = ErrZipCodeNotFound.WithErr(err).Error()
 				return
 			}
 			responseChan <- response
@@ -48,6 +87,9 @@ func (s *service) GetAddressByZipCode(zipCode string) (*GetAddressByZipCodeRespo
 	select {
 	case apiSuccessfulResponse := <-responseChan:
 		res := apiSuccessfulResponse.ToGetAddressByZipCodeResponse()
+		if s.expiration > 0 {
+			s.addresses.Set(AddressCacheKeyPrefix+zipCode, res, s.expiration)
+		}
 		return &res, nil
 	case <-time.After(searchTimeout):
 		return nil, ErrTimeoutOperation.WithStrErr("timeout waiting for address retrieval")
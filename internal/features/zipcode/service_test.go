@@ -3,9 +3,11 @@ package zipcode_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"luizalabs-technical-test/internal/features/zipcode"
 	"luizalabs-technical-test/internal/features/zipcode/mock"
+	"luizalabs-technical-test/pkg/cache"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -19,13 +21,15 @@ type ZipcodeServiceTestSuite struct {
 	ctrl     *gomock.Controller
 	service  zipcode.ServiceImp
 	mockRepo *mock.MockRepositoryImp
+	cache    cache.Manager
 }
 
 // SetupTest initializes the test suite, creating the mock repository and service.
 func (suite *ZipcodeServiceTestSuite) SetupTest() {
 	suite.ctrl = gomock.NewController(suite.T())
 	suite.mockRepo = mock.NewMockRepositoryImp(suite.ctrl)
-	suite.service = zipcode.NewService(suite.mockRepo)
+	suite.cache = cache.NewManager(time.Minute)
+	suite.service = zipcode.NewService(suite.mockRepo, suite.cache, time.Hour)
 }
 
 // TearDownTest cleans up after each test.
@@ -107,6 +111,84 @@ func (suite *ZipcodeServiceTestSuite) TestGetAddressByZipCodeMultipleAPICalls()
 	assert.Equal(suite.T(), expected.ToGetAddressByZipCodeResponse(), *actual)
 }
 
+// TestGetAddressByZipCodeCached tests that found addresses are served from the cache, without calling the providers again.
+func (suite *ZipcodeServiceTestSuite) TestGetAddressByZipCodeCached() {
+	var (
+		zipCode  = "12345678"
+		mockErr  = errors.New("error returned.")
+		expected = &zipcode.GetAddressByZipCodeUnifiedResponse{
+			City:  "SÃO PAULO",
+			State: "SP",
+		}
+	)
+
+	suite.mockRepo.EXPECT().GetAddressByZipCodeViaCep(zipCode).Return(expected, nil).Times(1)
+	suite.mockRepo.EXPECT().GetAddressByZipCodeAPICep(zipCode).Return(nil, mockErr).Times(1)
+	suite.mockRepo.EXPECT().GetAddressByZipCodeOpenCep(zipCode).Return(nil, mockErr).Times(1)
+	suite.mockRepo.EXPECT().GetAddressByZipCodeBrasilAPI(zipCode).Return(nil, mockErr).Times(1)
+
+	first, err := suite.service.GetAddressByZipCode(zipCode)
+	require.NoError(suite.T(), err)
+
+	ttl, found := suite.cache.TTL("zipcode:" + zipCode)
+	assert.True(suite.T(), found)
+	assert.InDelta(suite.T(), time.Hour, ttl, float64(time.Second))
+
+	// Note: the calls of the first lookup are given time to finish, so a second round of calls would fail the mock.
+	time.Sleep(50 * time.Millisecond)
+	second, err := suite.service.GetAddressByZipCode(zipCode)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), first, second)
+}
+
+// TestGetAddressByZipCodeNotCachedOnTimeout tests that failed lookups aren't cached.
+func (suite *ZipcodeServiceTestSuite) TestGetAddressByZipCodeNotCachedOnTimeout() {
+	var (
+		zipCode = "12345678"
+		mockErr = errors.New("error returned.")
+	)
+
+	suite.mockRepo.EXPECT().GetAddressByZipCodeViaCep(zipCode).Return(nil, mockErr).Times(1)
+	suite.mockRepo.EXPECT().GetAddressByZipCodeAPICep(zipCode).Return(nil, mockErr).Times(1)
+	suite.mockRepo.EXPECT().GetAddressByZipCodeOpenCep(zipCode).Return(nil, mockErr).Times(1)
+	suite.mockRepo.EXPECT().GetAddressByZipCodeBrasilAPI(zipCode).Return(nil, mockErr).Times(1)
+
+	_, err := suite.service.GetAddressByZipCode(zipCode)
+	assert.Error(suite.T(), err)
+
+	_, found := suite.cache.TTL("zipcode:" + zipCode)
+	assert.False(suite.T(), found)
+}
+
+// TestWarmUp tests that the warm-up refreshes the cached addresses from the providers, skipping the zip codes not found.
+func (suite *ZipcodeServiceTestSuite) TestWarmUp() {
+	var (
+		mockErr  = errors.New("error returned.")
+		expected = &zipcode.GetAddressByZipCodeUnifiedResponse{
+			City:  "SÃO PAULO",
+			State: "SP",
+		}
+	)
+
+	stale := zipcode.GetAddressByZipCodeResponse{}
+	cache.NewTyped[zipcode.GetAddressByZipCodeResponse](suite.cache).Set("zipcode:12345678", stale, time.Minute)
+
+	suite.mockRepo.EXPECT().GetAddressByZipCodeViaCep("12345678").Return(expected, nil).Times(1)
+	suite.mockRepo.EXPECT().GetAddressByZipCodeAPICep(gomock.Any()).Return(nil, mockErr).Times(2)
+	suite.mockRepo.EXPECT().GetAddressByZipCodeOpenCep(gomock.Any()).Return(nil, mockErr).Times(2)
+	suite.mockRepo.EXPECT().GetAddressByZipCodeBrasilAPI(gomock.Any()).Return(nil, mockErr).Times(2)
+	suite.mockRepo.EXPECT().GetAddressByZipCodeViaCep("87654321").Return(nil, mockErr).Times(1)
+
+	warmed := suite.service.WarmUp([]string{"12345678", "87654321"})
+	assert.Equal(suite.T(), 1, warmed)
+
+	// Note: the calls of the warm-up are given time to finish, so the lookup below would fail the mock if not cached.
+	time.Sleep(50 * time.Millisecond)
+	actual, err := suite.service.GetAddressByZipCode("12345678")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expected.ToGetAddressByZipCodeResponse(), *actual)
+}
+
 // Run the test suite
 func TestZipcodeServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(ZipcodeServiceTestSuite))
@@ -0,0 +1,41 @@
+package audit
+
+import "github.com/gin-gonic/gin"
+
+// Actions recorded in the audit log.
+const (
+	ActionRegister       = "register"
+	ActionLogin          = "login"
+	ActionLoginMFA       = "login_mfa"
+	ActionLoginFederated = "login_federated"
+	ActionPasswordChange = "password_change"
+	ActionPasswordReset  = "password_reset"
+	ActionTokenRejected  = "token_rejected"
+	ActionCachePurge     = "cache_purge"
+)
+
+// Outcomes of the recorded actions. A login is challenged when the second factor is still required.
+const (
+	OutcomeSuccess    = "success"
+	OutcomeFailure    = "failure"
+	OutcomeChallenged = "challenged"
+)
+
+// Event represents an action to be recorded. The actor is identified by its user ID, when known,
+// and by the email or client ID it presented. TenantID holds the organization of the actor, when known.
+// Reason holds the error code of failed actions, and the target (key, prefix, user or all) of cache purges along with
+// the purged key, prefix or user and the number of entries purged.
+type Event struct {
+	ActorID  uint
+	TenantID uint
+	Actor    string
+	Action   string
+	Outcome  string
+	Reason   string
+}
+
+// Recorder records events in the audit log, along with the client IP, User-Agent and correlation ID of the request.
+// Note: recording never fails the request, errors are only logged.
+type Recorder interface {
+	Record(c *gin.Context, event Event)
+}
@@ -33,7 +33,9 @@ var (
 		"Cache-Control",
 		"X-Requested-With",
 		"User-Agent",
-		"X-Cache-Control",
+		"If-None-Match",
+		"X-API-Key",
+		"X-Correlation-ID",
 		"Access-Control-Allow-Origin",
 		"Access-Control-Allow-Headers",
 		"Access-Control-Allow-Methods",
@@ -44,5 +46,9 @@ var (
 	// exposedHeaders lists the headers that are exposed to the browser and can be accessed in JavaScript.
 	exposedHeaders = []string{
 		"Content-Length",
+		"X-Correlation-ID",
+		"ETag",
+		"Age",
+		"X-Cache",
 	}
 )
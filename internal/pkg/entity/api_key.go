@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TbAPIKey defines the name of the table for the APIKey entity in the PostgreSQL database.
+const TbAPIKey = "Tb_API_Key"
+
+// scopeSeparator defines the separator used to persist the list of scopes in a single column.
+const scopeSeparator = ","
+
+// APIKey represents a named machine-to-machine credential owned by a user.
+// Only the SHA-256 hash of the key is persisted, the plain value is shown once at creation time.
+type APIKey struct {
+	gorm.Model
+	UserID     uint      `gorm:"index"`
+	User       User      `gorm:"foreignKey:UserID"`
+	Name       string    `gorm:"size:100"`
+	Prefix     string    `gorm:"size:20"`
+	HashedKey  string    `gorm:"size:64;uniqueIndex"`
+	Scopes     string    `gorm:"size:255"`
+	ExpiresAt  time.Time `gorm:"index"`
+	RevokedAt  *time.Time
+	LastUsedAt *time.Time
+}
+
+// TableName returns the name of the table for the APIKey model.
+func (APIKey) TableName() string {
+	return TbAPIKey
+}
+
+// ScopeList returns the scopes granted to the key as a slice.
+func (k *APIKey) ScopeList() []string {
+	if k.Scopes == "" {
+		return []string{}
+	}
+	return strings.Split(k.Scopes, scopeSeparator)
+}
+
+// SetScopes stores the provided scopes in the entity.
+func (k *APIKey) SetScopes(scopes []string) {
+	k.Scopes = strings.Join(scopes, scopeSeparator)
+}
+
+// IsActive reports whether the key is neither revoked nor expired at the given time.
+func (k *APIKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && now.Before(k.ExpiresAt)
+}
+
+// ToJSONClaims formats the key and its owner into the same claims issued for JWT users.
+func (k *APIKey) ToJSONClaims() map[string]interface{} {
+	claims := k.User.ToJSONClaims()
+	claims["ID"] = k.UserID
+	claims["APIKeyID"] = k.ID
+	claims["Scopes"] = k.ScopeList()
+	return claims
+}
@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestAPIKeyTableName(t *testing.T) {
+	var key APIKey
+	assert.Equal(t, TbAPIKey, key.TableName())
+}
+
+func TestAPIKeyScopes(t *testing.T) {
+	var key APIKey
+	assert.Empty(t, key.ScopeList())
+
+	key.SetScopes([]string{"address:read", "address:write"})
+	assert.Equal(t, "address:read,address:write", key.Scopes)
+	assert.Equal(t, []string{"address:read", "address:write"}, key.ScopeList())
+}
+
+func TestAPIKeyIsActive(t *testing.T) {
+	now := time.Now()
+	revokedAt := now.Add(-time.Minute)
+
+	tests := []struct {
+		name     string
+		key      APIKey
+		expected bool
+	}{
+		{"Active key", APIKey{ExpiresAt: now.Add(time.Hour)}, true},
+		{"Expired key", APIKey{ExpiresAt: now.Add(-time.Hour)}, false},
+		{"Revoked key", APIKey{ExpiresAt: now.Add(time.Hour), RevokedAt: &revokedAt}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.key.IsActive(now))
+		})
+	}
+}
+
+func TestAPIKeyToJSONClaims(t *testing.T) {
+	key := APIKey{
+		Model:  gorm.Model{ID: 5},
+		UserID: 1,
+		User:   User{Model: gorm.Model{ID: 1}, Email: "test@example.com"},
+		Scopes: "address:read",
+	}
+
+	claims := key.ToJSONClaims()
+	assert.Equal(t, uint(1), claims["ID"])
+	assert.Equal(t, "test@example.com", claims["Email"])
+	assert.Equal(t, uint(5), claims["APIKeyID"])
+	assert.Equal(t, []string{"address:read"}, claims["Scopes"])
+}
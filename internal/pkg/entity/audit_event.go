@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// TbAuditEvent defines the name of the table for the AuditEvent entity in the PostgreSQL database.
+const TbAuditEvent = "Tb_Audit_Event"
+
+// AuditEvent represents an authentication related action, recorded for later investigation.
+// Note: events are append-only, they are never updated and only deleted once past the retention period.
+type AuditEvent struct {
+	ID            uint      `gorm:"primarykey"`
+	CreatedAt     time.Time `gorm:"index"`
+	ActorID       uint      `gorm:"index"`
+	TenantID      uint      `gorm:"index;default:0"`
+	Actor         string    `gorm:"size:255;index"`
+	Action        string    `gorm:"size:64;index"`
+	Outcome       string    `gorm:"size:16;index"`
+	Reason        string    `gorm:"size:512"`
+	IP            string    `gorm:"size:45"`
+	UserAgent     string    `gorm:"size:512"`
+	CorrelationID string    `gorm:"size:64;index"`
+}
+
+// TableName returns the name of the table for the AuditEvent model.
+func (AuditEvent) TableName() string {
+	return TbAuditEvent
+}
@@ -0,0 +1,12 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditEventTableName(t *testing.T) {
+	var event AuditEvent
+	assert.Equal(t, TbAuditEvent, event.TableName())
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "gorm.io/gorm"
+
+// TbFederatedIdentity defines the name of the table for the FederatedIdentity entity in the PostgreSQL database.
+const TbFederatedIdentity = "Tb_Federated_Identity"
+
+// FederatedIdentity links an account of an external OpenID Connect provider, identified by issuer and subject, to a user.
+type FederatedIdentity struct {
+	gorm.Model
+	UserID  uint   `gorm:"index"`
+	Issuer  string `gorm:"size:255;uniqueIndex:idx_federated_identity_subject"`
+	Subject string `gorm:"size:255;uniqueIndex:idx_federated_identity_subject"`
+	// Email records the address stated by the provider when the identity was linked.
+	Email string `gorm:"size:100"`
+}
+
+// TableName returns the name of the table for the FederatedIdentity model.
+func (FederatedIdentity) TableName() string {
+	return TbFederatedIdentity
+}
@@ -0,0 +1,12 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFederatedIdentityTableName(t *testing.T) {
+	var identity FederatedIdentity
+	assert.Equal(t, TbFederatedIdentity, identity.TableName())
+}
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TbMFARecoveryCode defines the name of the table for the MFARecoveryCode entity in the PostgreSQL database.
+const TbMFARecoveryCode = "Tb_MFA_Recovery_Code"
+
+// MFARecoveryCode represents a single-use code allowing a user to log in without the authenticator app.
+// Only the SHA-256 hash of the code is persisted, the plain value is only shown on enrolment.
+type MFARecoveryCode struct {
+	gorm.Model
+	UserID     uint   `gorm:"index"`
+	HashedCode string `gorm:"size:64;index"`
+	UsedAt     *time.Time
+}
+
+// TableName returns the name of the table for the MFARecoveryCode model.
+func (MFARecoveryCode) TableName() string {
+	return TbMFARecoveryCode
+}
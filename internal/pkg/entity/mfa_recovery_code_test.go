@@ -0,0 +1,12 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMFARecoveryCodeTableName(t *testing.T) {
+	var recoveryCode MFARecoveryCode
+	assert.Equal(t, TbMFARecoveryCode, recoveryCode.TableName())
+}
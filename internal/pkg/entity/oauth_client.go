@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// TbOAuthClient defines the name of the table for the OAuthClient entity in the PostgreSQL database.
+const TbOAuthClient = "Tb_OAuth_Client"
+
+// OAuthClient represents a registered OAuth2 client allowed to use the client credentials grant.
+// Only the SHA-256 hash of the client secret is persisted.
+type OAuthClient struct {
+	gorm.Model
+	OwnerID      uint   `gorm:"index"`
+	TenantID     uint   `gorm:"index;default:0"`
+	Name         string `gorm:"size:100"`
+	ClientID     string `gorm:"size:64;uniqueIndex"`
+	HashedSecret string `gorm:"size:64"`
+	Scopes       string `gorm:"size:255"`
+}
+
+// TableName returns the name of the table for the OAuthClient model.
+func (OAuthClient) TableName() string {
+	return TbOAuthClient
+}
+
+// ScopeList returns the scopes granted to the client as a slice.
+func (c *OAuthClient) ScopeList() []string {
+	if c.Scopes == "" {
+		return []string{}
+	}
+	return strings.Split(c.Scopes, scopeSeparator)
+}
+
+// SetScopes stores the provided scopes in the entity.
+func (c *OAuthClient) SetScopes(scopes []string) {
+	c.Scopes = strings.Join(scopes, scopeSeparator)
+}
+
+// ToJSONClaims formats the client into the claims of the access tokens issued to it.
+func (c *OAuthClient) ToJSONClaims(scopes []string) map[string]interface{} {
+	return map[string]interface{}{
+		"ClientID": c.ClientID,
+		"TenantID": c.TenantID,
+		"Scopes":   scopes,
+	}
+}
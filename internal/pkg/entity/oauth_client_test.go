@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOAuthClientTableName(t *testing.T) {
+	var client OAuthClient
+	assert.Equal(t, TbOAuthClient, client.TableName())
+}
+
+func TestOAuthClientScopes(t *testing.T) {
+	var client OAuthClient
+	assert.Empty(t, client.ScopeList())
+
+	client.SetScopes([]string{"address:read"})
+	assert.Equal(t, []string{"address:read"}, client.ScopeList())
+}
+
+func TestOAuthClientToJSONClaims(t *testing.T) {
+	client := OAuthClient{ClientID: "lzc_client", TenantID: 3}
+
+	claims := client.ToJSONClaims([]string{"address:read"})
+	assert.Equal(t, "lzc_client", claims["ClientID"])
+	assert.Equal(t, uint(3), claims["TenantID"])
+	assert.Equal(t, []string{"address:read"}, claims["Scopes"])
+}
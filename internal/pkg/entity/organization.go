@@ -0,0 +1,20 @@
+package entity
+
+import "gorm.io/gorm"
+
+// TbOrganization defines the name of the table for the Organization entity in the PostgreSQL database.
+const TbOrganization = "Tb_Organization"
+
+// Organization represents a tenant sharing the deployment. The users, cache entries, audit events
+// and request quota of each organization are kept apart from the others.
+type Organization struct {
+	gorm.Model
+	Name string `gorm:"size:100;uniqueIndex"`
+	// DailyRequestQuota limits the requests its members may make per day to the routes subject to quotas; zero means unlimited.
+	DailyRequestQuota int `gorm:"default:0"`
+}
+
+// TableName returns the name of the table for the Organization model.
+func (Organization) TableName() string {
+	return TbOrganization
+}
@@ -0,0 +1,12 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganizationTableName(t *testing.T) {
+	var organization Organization
+	assert.Equal(t, TbOrganization, organization.TableName())
+}
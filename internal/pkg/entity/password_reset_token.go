@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TbPasswordResetToken defines the name of the table for the PasswordResetToken entity in the PostgreSQL database.
+const TbPasswordResetToken = "Tb_Password_Reset_Token"
+
+// PasswordResetToken represents a single-use token allowing a user to choose a new password.
+// Only the SHA-256 hash of the token is persisted, the plain value is only sent by email.
+type PasswordResetToken struct {
+	gorm.Model
+	UserID      uint      `gorm:"index"`
+	User        User      `gorm:"foreignKey:UserID"`
+	HashedToken string    `gorm:"size:64;uniqueIndex"`
+	ExpiresAt   time.Time `gorm:"index"`
+	UsedAt      *time.Time
+}
+
+// TableName returns the name of the table for the PasswordResetToken model.
+func (PasswordResetToken) TableName() string {
+	return TbPasswordResetToken
+}
+
+// IsUsable reports whether the token is neither used nor expired at the given time.
+func (t *PasswordResetToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
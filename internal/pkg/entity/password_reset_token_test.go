@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordResetTokenTableName(t *testing.T) {
+	var resetToken PasswordResetToken
+	assert.Equal(t, TbPasswordResetToken, resetToken.TableName())
+}
+
+func TestPasswordResetTokenIsUsable(t *testing.T) {
+	now := time.Now()
+	usedAt := now.Add(-time.Minute)
+
+	tests := []struct {
+		name       string
+		resetToken PasswordResetToken
+		expected   bool
+	}{
+		{"Usable token", PasswordResetToken{ExpiresAt: now.Add(time.Hour)}, true},
+		{"Expired token", PasswordResetToken{ExpiresAt: now.Add(-time.Hour)}, false},
+		{"Used token", PasswordResetToken{ExpiresAt: now.Add(time.Hour), UsedAt: &usedAt}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.resetToken.IsUsable(now))
+		})
+	}
+}
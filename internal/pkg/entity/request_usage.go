@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// TbRequestUsage defines the name of the table for the RequestUsage entity in the PostgreSQL database.
+const TbRequestUsage = "Tb_Request_Usage"
+
+// RequestUsage counts the requests the members of an organization made on a day (UTC) to the routes subject to quotas.
+// Note: the counter is kept in the database, rather than in the cache, so every replica increments the same row.
+// Rows past the usage retention are purged, as only the counter of the current day is needed.
+type RequestUsage struct {
+	OrganizationID uint      `gorm:"primaryKey;autoIncrement:false"`
+	Day            time.Time `gorm:"primaryKey;type:date"`
+	Count          int       `gorm:"not null;default:0"`
+}
+
+// TableName returns the name of the table for the RequestUsage model.
+func (RequestUsage) TableName() string {
+	return TbRequestUsage
+}
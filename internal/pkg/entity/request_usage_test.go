@@ -0,0 +1,12 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestUsageTableName(t *testing.T) {
+	var usage RequestUsage
+	assert.Equal(t, TbRequestUsage, usage.TableName())
+}
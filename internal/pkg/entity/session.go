@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TbSession defines the name of the table for the Session entity in the PostgreSQL database.
+const TbSession = "Tb_Session"
+
+// Session represents a login of a user, bound to the access token carrying its TokenID as the jti claim.
+type Session struct {
+	gorm.Model
+	UserID     uint   `gorm:"index"`
+	TokenID    string `gorm:"size:32;uniqueIndex"`
+	UserAgent  string `gorm:"size:512"`
+	IP         string `gorm:"size:45"`
+	LastSeenAt time.Time
+	ExpiresAt  time.Time `gorm:"index"`
+	RevokedAt  *time.Time
+}
+
+// TableName returns the name of the table for the Session model.
+func (Session) TableName() string {
+	return TbSession
+}
+
+// IsActive reports whether the session is neither revoked nor expired at the given time.
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionTableName(t *testing.T) {
+	var session Session
+	assert.Equal(t, TbSession, session.TableName())
+}
+
+func TestSessionIsActive(t *testing.T) {
+	now := time.Now()
+	revokedAt := now.Add(-time.Minute)
+
+	tests := []struct {
+		name     string
+		session  Session
+		expected bool
+	}{
+		{"Active session", Session{ExpiresAt: now.Add(time.Hour)}, true},
+		{"Expired session", Session{ExpiresAt: now.Add(-time.Hour)}, false},
+		{"Revoked session", Session{ExpiresAt: now.Add(time.Hour), RevokedAt: &revokedAt}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.session.IsActive(now))
+		})
+	}
+}
@@ -1,17 +1,42 @@
 package entity
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
 // TbUser defines the name of the table for the User entity in the PostgreSQL database.
 const TbUser = "Tb_User"
 
+// Constants representing the roles a user can hold.
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 // User represents a user in the system.
 type User struct {
 	gorm.Model
-	Email    string `gorm:"size:100;uniqueIndex"`
-	Password string `gorm:"size:100"`
+	Email               string `gorm:"size:100;uniqueIndex"`
+	Password            string `gorm:"size:100"`
+	Role                string `gorm:"size:20;default:user"`
+	FailedLoginAttempts int    `gorm:"default:0"`
+	LockedUntil         *time.Time
+	VerifiedAt          *time.Time
+	// CredentialsChangedAt records the last password change; access tokens issued before it are revoked.
+	CredentialsChangedAt *time.Time
+	// MFASecret holds the TOTP secret, pending until MFAEnabledAt is set by the enrolment confirmation.
+	MFASecret    string `gorm:"size:64"`
+	MFAEnabledAt *time.Time
+	// MFALastCounter holds the period of the last TOTP code accepted, so codes can't be replayed.
+	MFALastCounter int64 `gorm:"default:0"`
+	// DisabledAt records when an administrator disabled the account, blocking logins and revoking access tokens.
+	DisabledAt *time.Time
+	// PasswordResetRequired blocks logins until the password is reset, when forced by an administrator.
+	PasswordResetRequired bool `gorm:"default:false"`
+	// OrganizationID identifies the tenant of the user, carried in its access tokens; zero when it belongs to no organization.
+	OrganizationID uint `gorm:"index;default:0"`
 }
 
 // TableName returns the name of the table for the User model.
@@ -19,10 +44,37 @@ func (User) TableName() string {
 	return TbUser
 }
 
+// IsLocked reports whether the account is temporarily locked at the given time.
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
+}
+
+// IsVerified reports whether the user has confirmed ownership of the email address.
+func (u *User) IsVerified() bool {
+	return u.VerifiedAt != nil
+}
+
+// IsDisabled reports whether the account was disabled by an administrator.
+func (u *User) IsDisabled() bool {
+	return u.DisabledAt != nil
+}
+
+// IsMFAEnabled reports whether the login requires a TOTP code in addition to the password.
+func (u *User) IsMFAEnabled() bool {
+	return u.MFAEnabledAt != nil
+}
+
+// IsTokenRevoked reports whether an access token issued at the given unix time predates the last credentials change.
+func (u *User) IsTokenRevoked(issuedAt int64) bool {
+	return u.CredentialsChangedAt != nil && issuedAt < u.CredentialsChangedAt.Unix()
+}
+
 // ToJSONClaims formats a user entity to string mapper.
 func (u *User) ToJSONClaims() map[string]interface{} {
 	return map[string]interface{}{
-		"ID":    u.ID,
-		"Email": u.Email,
+		"ID":       u.ID,
+		"Email":    u.Email,
+		"Role":     u.Role,
+		"TenantID": u.OrganizationID,
 	}
 }
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
@@ -18,15 +19,57 @@ func TestTableName(t *testing.T) {
 
 func TestToJSONClaims(t *testing.T) {
 	user := &User{
-		Model:    gorm.Model{ID: 1},
-		Email:    "test@example.com",
-		Password: "password123",
+		Model:          gorm.Model{ID: 1},
+		Email:          "test@example.com",
+		Password:       "password123",
+		Role:           RoleAdmin,
+		OrganizationID: 3,
 	}
 	expectedClaims := map[string]interface{}{
-		"ID":    user.ID,
-		"Email": user.Email,
+		"ID":       user.ID,
+		"Email":    user.Email,
+		"Role":     user.Role,
+		"TenantID": user.OrganizationID,
 	}
 
 	claims := user.ToJSONClaims()
 	assert.Equal(t, expectedClaims, claims)
 }
+
+func TestIsLocked(t *testing.T) {
+	now := time.Now()
+	lockedUntil := now.Add(time.Minute)
+
+	assert.False(t, (&User{}).IsLocked(now))
+	assert.True(t, (&User{LockedUntil: &lockedUntil}).IsLocked(now))
+	assert.False(t, (&User{LockedUntil: &lockedUntil}).IsLocked(lockedUntil.Add(time.Second)))
+}
+
+func TestIsVerified(t *testing.T) {
+	verifiedAt := time.Now()
+
+	assert.False(t, (&User{}).IsVerified())
+	assert.True(t, (&User{VerifiedAt: &verifiedAt}).IsVerified())
+}
+
+func TestIsDisabled(t *testing.T) {
+	disabledAt := time.Now()
+
+	assert.False(t, (&User{}).IsDisabled())
+	assert.True(t, (&User{DisabledAt: &disabledAt}).IsDisabled())
+}
+
+func TestIsMFAEnabled(t *testing.T) {
+	enabledAt := time.Now()
+
+	assert.False(t, (&User{MFASecret: "JBSWY3DPEHPK3PXP"}).IsMFAEnabled(), "Expected pending enrolment not to enable MFA")
+	assert.True(t, (&User{MFASecret: "JBSWY3DPEHPK3PXP", MFAEnabledAt: &enabledAt}).IsMFAEnabled())
+}
+
+func TestIsTokenRevoked(t *testing.T) {
+	changedAt := time.Now()
+
+	assert.False(t, (&User{}).IsTokenRevoked(0))
+	assert.True(t, (&User{CredentialsChangedAt: &changedAt}).IsTokenRevoked(changedAt.Add(-time.Minute).Unix()))
+	assert.False(t, (&User{CredentialsChangedAt: &changedAt}).IsTokenRevoked(changedAt.Unix()))
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"luizalabs-technical-test/internal/pkg/entity"
+	"luizalabs-technical-test/pkg/middleware"
+	"luizalabs-technical-test/pkg/token"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminMiddleware is an interface that extends the base middleware.Middleware interface.
+// It restricts routes to users holding the admin role, and must run after the token middleware.
+type AdminMiddleware interface {
+	middleware.Middleware
+}
+
+type adminMiddleware struct {
+	global bool
+}
+
+// NewAdminMiddleware creates a new instance of adminMiddleware, which only lets administrators through.
+func NewAdminMiddleware() AdminMiddleware {
+	return &adminMiddleware{}
+}
+
+// NewGlobalAdminMiddleware creates a new instance of adminMiddleware, which only lets administrators belonging to no
+// organization through, for the routes managing resources shared between organizations.
+func NewGlobalAdminMiddleware() AdminMiddleware {
+	return &adminMiddleware{global: true}
+}
+
+// Middleware checks the role claim set by the token middleware, aborting with a forbidden status for non-admins,
+// and for administrators of an organization when restricted to global administrators.
+func (a *adminMiddleware) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := token.ClaimsFromContext(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+
+		if claims.StringKey("Role") != entity.RoleAdmin {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+			return
+		}
+
+		if a.global && claims.UintKey("TenantID") != 0 {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"luizalabs-technical-test/internal/pkg/entity"
+	"luizalabs-technical-test/pkg/token"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type AdminMiddlewareTestSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+func (suite *AdminMiddlewareTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+
+	// Note: the role and the tenant are taken from headers to simulate the claims set by the token middleware.
+	setClaims := func(c *gin.Context) {
+		if role := c.GetHeader("X-Test-Role"); role != "" {
+			claims := map[string]any{"Role": role}
+			if c.GetHeader("X-Test-Tenant") != "" {
+				claims["TenantID"] = float64(3)
+			}
+			c.Set(token.ClaimsHeaderName, &token.CustomClaims{CustomKeys: claims})
+		}
+	}
+
+	suite.router = gin.New()
+	suite.router.GET("/admin", setClaims, NewAdminMiddleware().Middleware(), func(c *gin.Context) { c.Status(http.StatusOK) })
+	suite.router.GET("/global", setClaims, NewGlobalAdminMiddleware().Middleware(), func(c *gin.Context) { c.Status(http.StatusOK) })
+}
+
+func (suite *AdminMiddlewareTestSuite) TestAdminMiddleware() {
+	tests := []struct {
+		name         string
+		role         string
+		tenant       bool
+		expectedCode int
+	}{
+		{name: "Missing claims", expectedCode: http.StatusUnauthorized},
+		{name: "Regular user", role: entity.RoleUser, expectedCode: http.StatusForbidden},
+		{name: "Administrator", role: entity.RoleAdmin, expectedCode: http.StatusOK},
+		{name: "Administrator of an organization", role: entity.RoleAdmin, tenant: true, expectedCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := suite.request("/admin", tt.role, tt.tenant)
+			assert.Equal(suite.T(), tt.expectedCode, w.Code)
+		})
+	}
+}
+
+func (suite *AdminMiddlewareTestSuite) TestGlobalAdminMiddleware() {
+	tests := []struct {
+		name         string
+		role         string
+		tenant       bool
+		expectedCode int
+	}{
+		{name: "Missing claims", expectedCode: http.StatusUnauthorized},
+		{name: "Regular user", role: entity.RoleUser, expectedCode: http.StatusForbidden},
+		{name: "Administrator of an organization", role: entity.RoleAdmin, tenant: true, expectedCode: http.StatusForbidden},
+		{name: "Global administrator", role: entity.RoleAdmin, expectedCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := suite.request("/global", tt.role, tt.tenant)
+			assert.Equal(suite.T(), tt.expectedCode, w.Code)
+		})
+	}
+}
+
+// request performs a request against the route, with the role and the tenant of the simulated claims.
+func (suite *AdminMiddlewareTestSuite) request(path, role string, tenant bool) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	if role != "" {
+		req.Header.Set("X-Test-Role", role)
+	}
+	if tenant {
+		req.Header.Set("X-Test-Tenant", "3")
+	}
+
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAdminMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(AdminMiddlewareTestSuite))
+}
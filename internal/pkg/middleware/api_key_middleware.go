@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"luizalabs-technical-test/pkg/constants/str"
+	"luizalabs-technical-test/pkg/middleware"
+	"luizalabs-technical-test/pkg/token"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeaderName is the header used by machine-to-machine clients to send their API key.
+const APIKeyHeaderName = "X-API-Key"
+
+// APIKeyMiddleware is an interface that extends the base middleware.Middleware interface.
+// It authenticates requests carrying an API key, setting the same claims as the token middleware.
+type APIKeyMiddleware interface {
+	middleware.Middleware
+}
+
+// APIKeyValidator resolves a plain API key into the claims of its owner.
+type APIKeyValidator interface {
+	ValidateAPIKey(rawKey string) (*token.CustomClaims, error)
+}
+
+type apiKeyMiddleware struct {
+	validator     APIKeyValidator
+	requiredScope string
+}
+
+// NewAPIKeyMiddleware creates a new instance of apiKeyMiddleware, which accepts keys granted with the required scope.
+func NewAPIKeyMiddleware(validator APIKeyValidator, requiredScope string) APIKeyMiddleware {
+	return &apiKeyMiddleware{validator, requiredScope}
+}
+
+// Middleware validates the API key in incoming requests. If valid, the owner claims are added to the context.
+// Requests without the header are passed along untouched, so the token middleware can still authenticate them.
+func (a *apiKeyMiddleware) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rawKey := c.GetHeader(APIKeyHeaderName)
+		if rawKey == str.EmptyString {
+			c.Next()
+			return
+		}
+
+		claims, err := a.validator.ValidateAPIKey(rawKey)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid api key"})
+			return
+		}
+
+		if !slices.Contains(claims.StringSliceKey("Scopes"), a.requiredScope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient scope"})
+			return
+		}
+
+		// Set key claims in the context for further use in the request lifecycle
+		c.Set(token.ClaimsHeaderName, claims)
+		c.Next()
+	}
+}
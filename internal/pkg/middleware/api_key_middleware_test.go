@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"luizalabs-technical-test/internal/config"
+	"luizalabs-technical-test/pkg/token"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeAPIKeyValidator resolves a single known key into fixed claims.
+type fakeAPIKeyValidator struct {
+	validKey string
+	scopes   []string
+}
+
+func (f *fakeAPIKeyValidator) ValidateAPIKey(rawKey string) (*token.CustomClaims, error) {
+	if rawKey != f.validKey {
+		return nil, errors.New("invalid key")
+	}
+	return &token.CustomClaims{CustomKeys: map[string]any{"ID": uint(1), "Email": "test@example.com", "Scopes": f.scopes}}, nil
+}
+
+type APIKeyMiddlewareTestSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+func (suite *APIKeyMiddlewareTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	config.GeneralConfig.SecretAuthTokenKey = "test_secret_key"
+
+	validator := &fakeAPIKeyValidator{validKey: "lzk_valid", scopes: []string{"address:read"}}
+	readOnlyValidator := &fakeAPIKeyValidator{validKey: "lzk_other", scopes: []string{"other:read"}}
+
+	suite.router = gin.New()
+	suite.router.GET("/protected",
+		NewAPIKeyMiddleware(validator, "address:read").Middleware(),
+		NewTokenMiddleware(fakeRevocationChecker{}, &fakeRecorder{}).Middleware(),
+		func(c *gin.Context) {
+			claims, _ := token.ClaimsFromContext(c)
+			c.JSON(http.StatusOK, gin.H{"email": claims.StringKey("Email")})
+		},
+	)
+	suite.router.GET("/scoped",
+		NewAPIKeyMiddleware(readOnlyValidator, "address:read").Middleware(),
+		func(c *gin.Context) { c.Status(http.StatusOK) },
+	)
+}
+
+func (suite *APIKeyMiddlewareTestSuite) TestAPIKeyMiddleware() {
+	tests := []struct {
+		name         string
+		path         string
+		apiKey       string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "No key falls back to the token middleware",
+			path:         "/protected",
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"error":"Unauthorized"}`,
+		},
+		{
+			name:         "Invalid key provided",
+			path:         "/protected",
+			apiKey:       "lzk_invalid",
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"error":"invalid api key"}`,
+		},
+		{
+			name:         "Valid key provided",
+			path:         "/protected",
+			apiKey:       "lzk_valid",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"email":"test@example.com"}`,
+		},
+		{
+			name:         "Key without the required scope",
+			path:         "/scoped",
+			apiKey:       "lzk_other",
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"error":"insufficient scope"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.apiKey != "" {
+				req.Header.Set(APIKeyHeaderName, tt.apiKey)
+			}
+
+			w := httptest.NewRecorder()
+			suite.router.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedCode, w.Code)
+			assert.JSONEq(suite.T(), tt.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestAPIKeyMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyMiddlewareTestSuite))
+}
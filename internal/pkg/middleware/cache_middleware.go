@@ -1,26 +1,67 @@
 package middleware
 
 import (
+	"bufio"
 	"bytes"
-	"encoding/json"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"luizalabs-technical-test/internal/config"
 	"luizalabs-technical-test/pkg/cache"
 	"luizalabs-technical-test/pkg/constants/str"
+	"luizalabs-technical-test/pkg/logger"
 	"luizalabs-technical-test/pkg/middleware"
 	"luizalabs-technical-test/pkg/token"
+	"net"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"golang.org/x/sync/singleflight"
 )
 
 const (
-	defaultCacheTimeout time.Duration = time.Minute * 30
-	cacheHeaderKey      string        = "X-Cache-Control"
-	noCache             string        = "no-cache"
+	defaultCacheTimeout         time.Duration = time.Minute * 30
+	defaultNegativeCacheTimeout time.Duration = time.Minute
+)
+
+// Headers of the HTTP caching semantics (RFC 9111), and the X-Cache values telling how a response was served.
+const (
+	cacheControlHeaderKey = "Cache-Control"
+	etagHeaderKey         = "ETag"
+	ageHeaderKey          = "Age"
+	ifNoneMatchHeaderKey  = "If-None-Match"
+	cacheStatusHeaderKey  = "X-Cache"
+
+	cacheHit   = "HIT"
+	cacheMiss  = "MISS"
+	cacheStale = "STALE"
+)
+
+// CacheScope defines which requests share a cached response.
+type CacheScope string
+
+// Scopes of the cached responses: per user, per organization, or shared by every caller.
+// Note: only routes whose responses don't depend on the caller may be cached globally.
+const (
+	UserCacheScope   CacheScope = "user"
+	TenantCacheScope CacheScope = "tenant"
+	GlobalCacheScope CacheScope = "global"
+)
+
+// ResponseCacheKeyPrefix namespaces the cached responses among the other entries of the cache.
+const ResponseCacheKeyPrefix = "response:"
+
+// defaultCachedStatusClasses and defaultReplayedHeaders are used when the policy leaves them empty.
+var (
+	defaultCachedStatusClasses = []int{2}
+	defaultReplayedHeaders     = []string{"Content-Type"}
 )
 
 // CacheMiddleware is an interface that extends the base middleware.Middleware interface.
@@ -30,25 +71,142 @@ type CacheMiddleware interface {
 	middleware.Middleware
 }
 
+// CachePolicy holds the settings of the cache middleware, zero values falling back to the defaults.
+// StatusClasses lists the classes of the cached statuses (2 for 2xx), and error responses (4xx and 5xx) are kept
+// for NegativeExpiration instead of Expiration. Headers lists the response headers stored and replayed on hits.
+// StaleWhileRevalidate is how long expired responses are still served while a single background request
+// refreshes them; zero disables it, so expired responses are fetched again by the request missing them.
+// Scope tells which callers share the cached responses, responses being cached per user by default.
+type CachePolicy struct {
+	Expiration           time.Duration
+	NegativeExpiration   time.Duration
+	StatusClasses        []int
+	Headers              []string
+	StaleWhileRevalidate time.Duration
+	Scope                CacheScope
+}
+
+// withDefaults returns the policy with its zero values replaced by the defaults.
+func (p CachePolicy) withDefaults() CachePolicy {
+	if p.Expiration <= 0 {
+		p.Expiration = defaultCacheTimeout
+	}
+	if p.NegativeExpiration <= 0 {
+		p.NegativeExpiration = defaultNegativeCacheTimeout
+	}
+	if len(p.StatusClasses) == 0 {
+		p.StatusClasses = defaultCachedStatusClasses
+	}
+	if len(p.Headers) == 0 {
+		p.Headers = defaultReplayedHeaders
+	}
+	if p.Scope != TenantCacheScope && p.Scope != GlobalCacheScope {
+		p.Scope = UserCacheScope
+	}
+	return p
+}
+
+// expiration returns how long a response with the status is cached, or zero when it must not be cached.
+func (p CachePolicy) expiration(status int) time.Duration {
+	if !slices.Contains(p.StatusClasses, status/100) {
+		return 0
+	}
+	if status >= http.StatusBadRequest {
+		return p.NegativeExpiration
+	}
+	return p.Expiration
+}
+
 type cacheMiddleware struct {
-	cacheManager cache.Manager
+	responses  *cache.Typed[cachedResponse]
+	policy     CachePolicy
+	group      *singleflight.Group
+	refreshing *sync.Map
+}
+
+// cachedResponse represents a response stored in the cache, fresh until the given time.
+// Note: the body is kept as raw bytes, so it is replayed byte for byte whatever its content type.
+type cachedResponse struct {
+	Status     int         `json:"status"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	ETag       string      `json:"etag"`
+	StoredAt   time.Time   `json:"stored_at"`
+	FreshUntil time.Time   `json:"fresh_until"`
 }
 
-type cacheWriter struct {
-	gin.ResponseWriter
-	body *bytes.Buffer
+// cacheDirectives represents the Cache-Control directives of a request. A negative max age means none was sent.
+type cacheDirectives struct {
+	noCache bool
+	noStore bool
+	maxAge  time.Duration
 }
 
+// parseCacheDirectives parses the Cache-Control header of a request, ignoring unknown and malformed directives.
+func parseCacheDirectives(value string) cacheDirectives {
+	directives := cacheDirectives{maxAge: -1}
+	for _, directive := range strings.Split(value, ",") {
+		name, argument, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-cache":
+			directives.noCache = true
+		case "no-store":
+			directives.noStore = true
+		case "max-age":
+			if seconds, err := strconv.Atoi(strings.Trim(argument, `"`)); err == nil && seconds >= 0 {
+				directives.maxAge = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	return directives
+}
+
+// accepts reports whether the request allows the cached response to be served without reaching the handler.
+func (d cacheDirectives) accepts(response cachedResponse) bool {
+	if d.noCache {
+		return false
+	}
+	return d.maxAge < 0 || time.Since(response.StoredAt) <= d.maxAge
+}
+
+// etagMatches reports whether the If-None-Match header of a request lists the entity tag, using the weak comparison.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// errHandlerPanicked is shared with the requests coalesced with a request whose handler panicked.
+var errHandlerPanicked = errors.New("cached handler panicked")
+
 // NewCacheMiddleware creates a new instance of the cache middleware
-func NewCacheMiddleware(cacheManager cache.Manager) CacheMiddleware {
-	return &cacheMiddleware{cacheManager: cacheManager}
+func NewCacheMiddleware(cacheManager cache.Manager, policy CachePolicy) CacheMiddleware {
+	return &cacheMiddleware{
+		responses:  cache.NewTyped[cachedResponse](cacheManager),
+		policy:     policy.withDefaults(),
+		group:      &singleflight.Group{},
+		refreshing: &sync.Map{},
+	}
 }
 
 // Middleware is the function that provides the cache middleware logic to be used in the Gin router.
 // It checks for a cache hit, and if found, returns the cached value. Otherwise, it processes the request and caches the response.
+// The no-cache, no-store and max-age request directives are honoured, and responses carry validators so conditional
+// requests are answered with 304.
+// Note: concurrent misses of the same key are coalesced, so only one of them reaches the handler and the upstream services.
 func (c *cacheMiddleware) Middleware() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		if !c.shouldHandleRequest(ctx) {
+		if ctx.Request.Method != http.MethodGet {
+			ctx.Next()
+			return
+		}
+
+		directives := parseCacheDirectives(ctx.GetHeader(cacheControlHeaderKey))
+		if directives.noStore {
 			ctx.Next()
 			return
 		}
@@ -59,81 +217,245 @@ func (c *cacheMiddleware) Middleware() gin.HandlerFunc {
 			return
 		}
 
-		if cache, exists := c.cacheManager.Get(cacheKey); exists {
-			ctx.AbortWithStatusJSON(http.StatusOK, c.parseCachedValue(cache))
+		if cached, exists := c.responses.Get(cacheKey); exists && directives.accepts(cached) {
+			if time.Now().After(cached.FreshUntil) {
+				c.revalidate(ctx, cacheKey)
+				c.writeResponse(ctx, cached, cacheStale)
+				return
+			}
+			c.writeResponse(ctx, cached, cacheHit)
 			return
 		}
 
-		cacheWriter := newCacheWriter(ctx.Writer)
-		ctx.Writer = cacheWriter
+		leader := false
+		writer := newBufferedWriter()
+		var panicked any
+		value, err, _ := c.group.Do(cacheKey, func() (response any, err error) {
+			leader = true
+			original := ctx.Writer
+			ctx.Writer = writer
+			// Note: a panic inside the group would crash the process, so it is recovered and raised again below.
+			defer func() {
+				ctx.Writer = original
+				if panicked = recover(); panicked != nil {
+					err = errHandlerPanicked
+				}
+			}()
+
+			ctx.Next()
+			return c.cacheResponse(cacheKey, writer.Status(), writer.Header(), writer.body.Bytes()), nil
+		})
 
-		ctx.Next()
-		c.cacheResponse(cacheKey, cacheWriter.body.Bytes())
+		switch {
+		case leader && panicked != nil:
+			panic(panicked)
+		case err != nil:
+			ctx.Next()
+			return
+		case leader:
+			// Note: the request reaching the handler gets all of its headers, only the selected ones are stored.
+			for name, values := range writer.Header() {
+				ctx.Writer.Header()[name] = values
+			}
+		}
+		c.writeResponse(ctx, value.(cachedResponse), cacheMiss)
 	}
 }
 
-// NewCacheWriter creates a new cache writer that intercepts the response body for caching
-func newCacheWriter(w gin.ResponseWriter) *cacheWriter {
-	return &cacheWriter{ResponseWriter: w, body: bytes.NewBuffer(nil)}
+// Revalidate refreshes the stale response of the key in the background, unless it is already being refreshed.
+// Note: the refresh runs the route handler alone on a copy of the request, so this middleware must come last.
+func (c *cacheMiddleware) revalidate(ctx *gin.Context, key string) {
+	if _, refreshing := c.refreshing.LoadOrStore(key, struct{}{}); refreshing {
+		return
+	}
+
+	handler := ctx.Handler()
+	refreshCtx := ctx.Copy()
+	refreshCtx.Request = ctx.Request.WithContext(context.WithoutCancel(ctx.Request.Context()))
+	writer := newBufferedWriter()
+	refreshCtx.Writer = writer
+
+	go func() {
+		defer c.refreshing.Delete(key)
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error(fmt.Errorf("failed to refresh the cached response of %s: %v", key, r))
+			}
+		}()
+
+		_, _, _ = c.group.Do(key, func() (any, error) {
+			handler(refreshCtx)
+			return c.cacheResponse(key, writer.Status(), writer.Header(), writer.body.Bytes()), nil
+		})
+	}()
 }
 
-// Write intercepts the response body and writes both to the buffer and the original response writer
-func (w *cacheWriter) Write(data []byte) (int, error) {
-	w.body.Write(data)
-	return w.ResponseWriter.Write(data)
+// WriteResponse answers the request with the cached response and its caching headers,
+// or with 304 when the request already holds the same representation
+func (c *cacheMiddleware) writeResponse(ctx *gin.Context, response cachedResponse, cacheStatus string) {
+	header := ctx.Writer.Header()
+	header.Set(cacheStatusHeaderKey, cacheStatus)
+	header.Set(cacheControlHeaderKey, c.responseDirectives(response))
+	if cacheStatus != cacheMiss {
+		header.Set(ageHeaderKey, strconv.FormatInt(int64(time.Since(response.StoredAt)/time.Second), 10))
+	}
+
+	if response.ETag != str.EmptyString {
+		header.Set(etagHeaderKey, response.ETag)
+		if etagMatches(ctx.GetHeader(ifNoneMatchHeaderKey), response.ETag) {
+			ctx.Status(http.StatusNotModified)
+			ctx.Writer.WriteHeaderNow()
+			ctx.Abort()
+			return
+		}
+	}
+
+	for name, values := range response.Header {
+		header[name] = values
+	}
+	ctx.Status(response.Status)
+	ctx.Writer.WriteHeaderNow()
+	if _, err := ctx.Writer.Write(response.Body); err != nil {
+		logger.Error(err)
+	}
+	ctx.Abort()
 }
 
-// ShouldHandleRequest checks if the request method is GET and if caching is allowed based on request headers
-func (c *cacheMiddleware) shouldHandleRequest(ctx *gin.Context) bool {
-	if ctx.Request.Method != http.MethodGet {
-		return false
+// ResponseDirectives returns the Cache-Control directives of the response, so clients in front of the API keep it
+// as long as this cache does. Responses that aren't cached must not be stored by them either.
+// Note: the API requires authentication, so shared caches must not store the responses, even when cached globally.
+func (c *cacheMiddleware) responseDirectives(response cachedResponse) string {
+	if response.FreshUntil.IsZero() {
+		return "no-store"
 	}
 
-	value := ctx.GetHeader(cacheHeaderKey)
-	return value == str.EmptyString || strings.ToLower(value) == noCache
+	directives := fmt.Sprintf("private, max-age=%d", int64(response.FreshUntil.Sub(response.StoredAt)/time.Second))
+	if c.policy.StaleWhileRevalidate > 0 {
+		directives += fmt.Sprintf(", stale-while-revalidate=%d", int64(c.policy.StaleWhileRevalidate/time.Second))
+	}
+	return directives
 }
 
-// ParseCachedValue attempts to unmarshal cached data into either a map or an array and returns the parsed value
-func (c *cacheMiddleware) parseCachedValue(value interface{}) interface{} {
-	var dataMap map[string]interface{}
-	if err := json.Unmarshal(value.([]byte), &dataMap); err == nil {
-		return dataMap
+// CacheResponse stores the response with the selected headers, when the policy caches its status.
+// It is kept for the stale window after it expires, and returned so coalesced requests can replay it.
+func (c *cacheMiddleware) cacheResponse(key string, status int, header http.Header, body []byte) cachedResponse {
+	response := cachedResponse{Status: status, Header: http.Header{}, Body: body, StoredAt: time.Now()}
+	for _, name := range c.policy.Headers {
+		if values := header.Values(name); len(values) > 0 {
+			response.Header[http.CanonicalHeaderKey(name)] = slices.Clone(values)
+		}
 	}
 
-	var dataArray []map[string]interface{}
-	if err := json.Unmarshal(value.([]byte), &dataArray); err == nil {
-		return dataArray
+	// Note: only successful responses get a validator, as conditional requests only apply to them.
+	if status/100 == 2 {
+		sum := sha256.Sum256(body)
+		response.ETag = `"` + hex.EncodeToString(sum[:16]) + `"`
 	}
 
-	return nil
-}
+	expiration := c.policy.expiration(status)
+	if expiration <= 0 {
+		return response
+	}
 
-// CacheResponse stores the response body in the cache with a predefined timeout
-func (c *cacheMiddleware) cacheResponse(key string, body []byte) {
-	c.cacheManager.Set(key, body, defaultCacheTimeout)
+	response.FreshUntil = response.StoredAt.Add(expiration)
+	c.responses.Set(key, response, expiration+c.policy.StaleWhileRevalidate)
+	return response
 }
 
-// CreateCacheKeyFromRequest generates a cache key using the user's hash and the request URL
+// CreateCacheKeyFromRequest generates a cache key from the scope of the policy and the request URL.
+// Note: user and tenant scoped keys carry the tenant, so cached responses are never shared between organizations.
 func (c *cacheMiddleware) createCacheKeyFromRequest(ctx *gin.Context) (string, error) {
-	userHash, err := c.getUserHashFromTokenClaims(ctx)
-	if err != nil {
-		return str.EmptyString, err
+	if c.policy.Scope == GlobalCacheScope {
+		return fmt.Sprintf("%sglobal:%s", ResponseCacheKeyPrefix, ctx.Request.URL), nil
 	}
 
-	return fmt.Sprintf("%s%s", userHash, ctx.Request.URL), nil
-}
-
-// GetUserHashFromTokenClaims extracts the user hash from token claims in the request context
-func (c *cacheMiddleware) getUserHashFromTokenClaims(ctx *gin.Context) (string, error) {
 	claims, err := token.ExtractTokenClaimsFromContext(ctx, config.GeneralConfig.SecretAuthTokenKey)
 	if err != nil {
 		return str.EmptyString, err
 	}
 
-	hash, ok := claims.CustomKeys["Email"].(string)
-	if !ok {
-		return hash, errors.New("no hash found")
+	if c.policy.Scope == TenantCacheScope {
+		return fmt.Sprintf("%stenant:%d:%s", ResponseCacheKeyPrefix, claims.UintKey("TenantID"), ctx.Request.URL), nil
+	}
+
+	email := claims.StringKey("Email")
+	if email == str.EmptyString {
+		return str.EmptyString, errors.New("no user found in the token claims")
 	}
+	return fmt.Sprintf("%stenant:%d:%s", UserCacheKeyPrefix(email), claims.UintKey("TenantID"), ctx.Request.URL), nil
+}
+
+// UserCacheKeyPrefix returns the prefix of the responses cached for the user, whatever the route and the tenant,
+// so they can be purged together.
+func UserCacheKeyPrefix(email string) string {
+	return fmt.Sprintf("%suser:%s:", ResponseCacheKeyPrefix, email)
+}
+
+// bufferedWriter buffers a response, so its caching headers can be set once the handler is done
+// and background refreshes can run without a client to answer.
+type bufferedWriter struct {
+	header http.Header
+	body   *bytes.Buffer
+	status int
+}
+
+// NewBufferedWriter creates a new buffered writer, defaulting to the status gin defaults to
+func newBufferedWriter() *bufferedWriter {
+	return &bufferedWriter{header: http.Header{}, body: bytes.NewBuffer(nil), status: http.StatusOK}
+}
+
+// Header returns the headers of the buffered response
+func (w *bufferedWriter) Header() http.Header {
+	return w.header
+}
+
+// Write buffers the response body
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+// WriteString buffers the response body
+func (w *bufferedWriter) WriteString(data string) (int, error) {
+	return w.body.WriteString(data)
+}
+
+// WriteHeader records the response status
+func (w *bufferedWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+// WriteHeaderNow does nothing, the buffered response is sent by the middleware
+func (w *bufferedWriter) WriteHeaderNow() {}
+
+// Status returns the response status
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+// Size returns the amount of bytes of the buffered body
+func (w *bufferedWriter) Size() int {
+	return w.body.Len()
+}
+
+// Written reports whether the body was written
+func (w *bufferedWriter) Written() bool {
+	return w.body.Len() > 0
+}
+
+// Flush does nothing, the buffered response is sent by the middleware
+func (w *bufferedWriter) Flush() {}
+
+// Pusher returns nil, as there is no connection to push to
+func (w *bufferedWriter) Pusher() http.Pusher {
+	return nil
+}
+
+// CloseNotify returns a channel that is never notified, as there is no connection to close
+func (w *bufferedWriter) CloseNotify() <-chan bool {
+	return make(chan bool)
+}
 
-	return hash, nil
+// Hijack fails, as there is no connection to take over
+func (w *bufferedWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, errors.New("buffered responses can't be hijacked")
 }
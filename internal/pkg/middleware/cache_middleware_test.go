@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -36,7 +38,7 @@ func (s *CacheMiddlewareSuite) TestCacheMiddleware() {
 	userToken, err := token.CreateToken(secretKey, token.CustomClaims{CustomKeys: map[string]any{"Email": "test@example.com"}})
 	assert.NoError(s.T(), err)
 
-	cacheMiddleware := NewCacheMiddleware(s.cacheManager)
+	cacheMiddleware := NewCacheMiddleware(s.cacheManager, CachePolicy{})
 	router.Use(cacheMiddleware.Middleware())
 	router.GET("/test", func(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, gin.H{"message": "Hello, World!"})
@@ -65,11 +67,80 @@ func (s *CacheMiddlewareSuite) TestCacheMiddleware() {
 	assert.Equal(s.T(), "Hello, World!", response2["message"])
 }
 
+func (s *CacheMiddlewareSuite) TestCreateCacheKeyFromRequest() {
+	gin.SetMode(gin.TestMode)
+	secretKey := "test-secret"
+	userToken, err := token.CreateToken(secretKey, token.CustomClaims{CustomKeys: map[string]any{"Email": "test@example.com", "TenantID": 3}})
+	assert.NoError(s.T(), err)
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request, _ = http.NewRequest(http.MethodGet, "/test?page=1", nil)
+	ctx.Request.Header.Set("Authorization", "Bearer "+userToken)
+
+	cacheMiddleware := NewCacheMiddleware(s.cacheManager, CachePolicy{}).(*cacheMiddleware)
+	key, err := cacheMiddleware.createCacheKeyFromRequest(ctx)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "response:user:test@example.com:tenant:3:/test?page=1", key)
+}
+
+func (s *CacheMiddlewareSuite) TestCreateCacheKeyFromRequestScopes() {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name     string
+		scope    CacheScope
+		claims   map[string]any
+		expected string
+	}{
+		{"user by default", "", map[string]any{"Email": "test@example.com", "TenantID": 3}, "response:user:test@example.com:tenant:3:/test"},
+		{"tenant", TenantCacheScope, map[string]any{"Email": "test@example.com", "TenantID": 3}, "response:tenant:3:/test"},
+		{"tenant without organization", TenantCacheScope, map[string]any{"Email": "test@example.com"}, "response:tenant:0:/test"},
+		{"global", GlobalCacheScope, map[string]any{"Email": "test@example.com", "TenantID": 3}, "response:global:/test"},
+		{"global without token", GlobalCacheScope, nil, "response:global:/test"},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request, _ = http.NewRequest(http.MethodGet, "/test", nil)
+			if tt.claims != nil {
+				userToken, err := token.CreateToken("test-secret", token.CustomClaims{CustomKeys: tt.claims})
+				assert.NoError(s.T(), err)
+				ctx.Request.Header.Set("Authorization", "Bearer "+userToken)
+			}
+
+			cacheMiddleware := NewCacheMiddleware(s.cacheManager, CachePolicy{Scope: tt.scope}).(*cacheMiddleware)
+			key, err := cacheMiddleware.createCacheKeyFromRequest(ctx)
+
+			assert.NoError(s.T(), err)
+			assert.Equal(s.T(), tt.expected, key)
+		})
+	}
+}
+
+func (s *CacheMiddlewareSuite) TestCacheMiddlewareGlobalScope() {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	calls := 0
+	router.Use(NewCacheMiddleware(cache.NewManager(time.Minute), CachePolicy{Scope: GlobalCacheScope}).Middleware())
+	router.GET("/test", func(ctx *gin.Context) {
+		calls++
+		ctx.JSON(http.StatusOK, gin.H{"message": "Hello, World!"})
+	})
+
+	s.request(router, "first@example.com", nil)
+	recorder := s.request(router, "second@example.com", nil)
+
+	assert.Equal(s.T(), 1, calls, "Expected the response to be shared between users")
+	assert.Equal(s.T(), "HIT", recorder.Header().Get("X-Cache"))
+}
+
 func (s *CacheMiddlewareSuite) TestCacheMiddlewareInvalidToken() {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 
-	cacheMiddleware := NewCacheMiddleware(s.cacheManager)
+	cacheMiddleware := NewCacheMiddleware(s.cacheManager, CachePolicy{})
 	router.Use(cacheMiddleware.Middleware())
 	router.GET("/test", func(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, gin.H{"message": "Hello, World!"})
@@ -87,6 +158,251 @@ func (s *CacheMiddlewareSuite) TestCacheMiddlewareInvalidToken() {
 	assert.Equal(s.T(), "Hello, World!", response["message"])
 }
 
+func (s *CacheMiddlewareSuite) TestCacheMiddlewareCoalescesMisses() {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	userToken, err := token.CreateToken("test-secret", token.CustomClaims{CustomKeys: map[string]any{"Email": "coalesced@example.com"}})
+	assert.NoError(s.T(), err)
+
+	calls := atomic.Int32{}
+	release := make(chan struct{})
+	router.Use(NewCacheMiddleware(cache.NewManager(time.Minute), CachePolicy{}).Middleware())
+	router.GET("/test", func(ctx *gin.Context) {
+		calls.Add(1)
+		<-release
+		ctx.JSON(http.StatusNotFound, gin.H{"message": "Not Found"})
+	})
+
+	recorders := make([]*httptest.ResponseRecorder, 10)
+	wg := sync.WaitGroup{}
+	for i := range recorders {
+		recorders[i] = httptest.NewRecorder()
+		wg.Add(1)
+		go func(recorder *httptest.ResponseRecorder) {
+			defer wg.Done()
+			req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set("Authorization", "Bearer "+userToken)
+			router.ServeHTTP(recorder, req)
+		}(recorders[i])
+	}
+
+	// Note: the requests are given time to join the one reaching the handler.
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(s.T(), int32(1), calls.Load())
+	for _, recorder := range recorders {
+		assert.Equal(s.T(), http.StatusNotFound, recorder.Code)
+		assert.JSONEq(s.T(), `{"message":"Not Found"}`, recorder.Body.String())
+	}
+}
+
+func (s *CacheMiddlewareSuite) TestCacheMiddlewareStaleWhileRevalidate() {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	userToken, err := token.CreateToken("test-secret", token.CustomClaims{CustomKeys: map[string]any{"Email": "stale@example.com"}})
+	assert.NoError(s.T(), err)
+
+	calls := atomic.Int32{}
+	release := make(chan struct{})
+	cacheMiddleware := NewCacheMiddleware(cache.NewManager(time.Minute), CachePolicy{StaleWhileRevalidate: time.Minute}).(*cacheMiddleware)
+	router.Use(cacheMiddleware.Middleware())
+	router.GET("/test", func(ctx *gin.Context) {
+		calls.Add(1)
+		<-release
+		ctx.JSON(http.StatusOK, gin.H{"message": "fresh"})
+	})
+
+	const key = "response:user:stale@example.com:tenant:0:/test"
+	cacheMiddleware.responses.Set(key, cachedResponse{
+		Status:     http.StatusOK,
+		Body:       []byte(`{"message":"stale"}`),
+		FreshUntil: time.Now().Add(-time.Second),
+	}, time.Minute)
+
+	// Both requests are answered with the stale response, while a single refresh is running.
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("Authorization", "Bearer "+userToken)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(s.T(), http.StatusOK, recorder.Code)
+		assert.Equal(s.T(), "STALE", recorder.Header().Get("X-Cache"))
+		assert.JSONEq(s.T(), `{"message":"stale"}`, recorder.Body.String())
+	}
+	close(release)
+
+	assert.Eventually(s.T(), func() bool {
+		cached, found := cacheMiddleware.responses.Get(key)
+		return found && string(cached.Body) == `{"message":"fresh"}` && time.Now().Before(cached.FreshUntil)
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(s.T(), int32(1), calls.Load())
+}
+
+// request performs an authenticated request against the router, with the given headers
+func (s *CacheMiddlewareSuite) request(router *gin.Engine, email string, header map[string]string) *httptest.ResponseRecorder {
+	userToken, err := token.CreateToken("test-secret", token.CustomClaims{CustomKeys: map[string]any{"Email": email}})
+	assert.NoError(s.T(), err)
+
+	req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+userToken)
+	for name, value := range header {
+		req.Header.Set(name, value)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func (s *CacheMiddlewareSuite) TestCacheMiddlewareReplaysResponses() {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	calls := 0
+	router.Use(NewCacheMiddleware(cache.NewManager(time.Minute), CachePolicy{}).Middleware())
+	router.GET("/test", func(ctx *gin.Context) {
+		calls++
+		ctx.Header("X-Request-Id", "abc")
+		ctx.Data(http.StatusCreated, "application/problem+json", []byte("{\"a\":  1}\n"))
+	})
+
+	first := s.request(router, "replay@example.com", nil)
+	second := s.request(router, "replay@example.com", nil)
+
+	assert.Equal(s.T(), 1, calls)
+	assert.Equal(s.T(), http.StatusCreated, second.Code)
+	assert.Equal(s.T(), first.Body.Bytes(), second.Body.Bytes())
+	assert.Equal(s.T(), "application/problem+json", second.Header().Get("Content-Type"))
+	assert.Empty(s.T(), second.Header().Get("X-Request-Id"), "Expected only the selected headers to be replayed")
+}
+
+func (s *CacheMiddlewareSuite) TestCacheMiddlewareStatusClasses() {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name          string
+		policy        CachePolicy
+		expectedCalls int
+		maxTTL        time.Duration
+	}{
+		{"errors aren't cached by default", CachePolicy{}, 2, 0},
+		{"negative caching", CachePolicy{StatusClasses: []int{2, 4}, NegativeExpiration: time.Minute}, 1, time.Minute},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			cacheManager := cache.NewManager(time.Minute)
+			router := gin.New()
+
+			calls := 0
+			router.Use(NewCacheMiddleware(cacheManager, tt.policy).Middleware())
+			router.GET("/test", func(ctx *gin.Context) {
+				calls++
+				ctx.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+			})
+
+			s.request(router, "negative@example.com", nil)
+			recorder := s.request(router, "negative@example.com", nil)
+
+			assert.Equal(s.T(), tt.expectedCalls, calls)
+			assert.Equal(s.T(), http.StatusNotFound, recorder.Code)
+			ttl, _ := cacheManager.TTL("response:user:negative@example.com:tenant:0:/test")
+			assert.LessOrEqual(s.T(), ttl, tt.maxTTL)
+		})
+	}
+}
+
+func (s *CacheMiddlewareSuite) TestCacheMiddlewareCachingHeaders() {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	calls := 0
+	router.Use(NewCacheMiddleware(cache.NewManager(time.Minute), CachePolicy{StaleWhileRevalidate: time.Minute}).Middleware())
+	router.GET("/test", func(ctx *gin.Context) {
+		calls++
+		ctx.JSON(http.StatusOK, gin.H{"message": "Hello, World!"})
+	})
+
+	miss := s.request(router, "headers@example.com", nil)
+	assert.Equal(s.T(), "MISS", miss.Header().Get("X-Cache"))
+	assert.Equal(s.T(), "private, max-age=1800, stale-while-revalidate=60", miss.Header().Get("Cache-Control"))
+	assert.Empty(s.T(), miss.Header().Get("Age"))
+	etag := miss.Header().Get("ETag")
+	assert.Regexp(s.T(), `^"[0-9a-f]{32}"$`, etag)
+
+	hit := s.request(router, "headers@example.com", nil)
+	assert.Equal(s.T(), "HIT", hit.Header().Get("X-Cache"))
+	assert.Equal(s.T(), "0", hit.Header().Get("Age"))
+	assert.Equal(s.T(), etag, hit.Header().Get("ETag"))
+
+	notModified := s.request(router, "headers@example.com", map[string]string{"If-None-Match": `"other", W/` + etag})
+	assert.Equal(s.T(), http.StatusNotModified, notModified.Code)
+	assert.Empty(s.T(), notModified.Body.String())
+	assert.Equal(s.T(), etag, notModified.Header().Get("ETag"))
+
+	assert.Equal(s.T(), 1, calls)
+}
+
+func (s *CacheMiddlewareSuite) TestCacheMiddlewareRequestDirectives() {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	calls := 0
+	router.Use(NewCacheMiddleware(cache.NewManager(time.Minute), CachePolicy{}).Middleware())
+	router.GET("/test", func(ctx *gin.Context) {
+		calls++
+		ctx.JSON(http.StatusOK, gin.H{"calls": calls})
+	})
+
+	tests := []struct {
+		name          string
+		cacheControl  string
+		expectedCalls int
+		expectedCache string
+	}{
+		{"first request", "", 1, "MISS"},
+		{"cached", "", 1, "HIT"},
+		{"old enough", "max-age=60", 1, "HIT"},
+		{"too old", "max-age=0", 2, "MISS"},
+		{"no-cache", "no-cache", 3, "MISS"},
+		{"no-store", "no-store", 4, ""},
+		{"refreshed by no-cache", "", 4, "HIT"},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			recorder := s.request(router, "directives@example.com", map[string]string{"Cache-Control": tt.cacheControl})
+
+			assert.Equal(s.T(), tt.expectedCalls, calls)
+			assert.Equal(s.T(), tt.expectedCache, recorder.Header().Get("X-Cache"))
+		})
+	}
+
+	recorder := s.request(router, "directives@example.com", nil)
+	assert.JSONEq(s.T(), `{"calls":3}`, recorder.Body.String(), "Expected no-store responses not to be cached")
+}
+
+func TestParseCacheDirectives(t *testing.T) {
+	assert.Equal(t, cacheDirectives{maxAge: -1}, parseCacheDirectives(""))
+	assert.Equal(t, cacheDirectives{noCache: true, maxAge: -1}, parseCacheDirectives("No-Cache"))
+	assert.Equal(t, cacheDirectives{noStore: true, maxAge: time.Minute}, parseCacheDirectives(`no-store, max-age="60"`))
+	assert.Equal(t, cacheDirectives{maxAge: -1}, parseCacheDirectives("max-age=abc, private"))
+}
+
+func TestCachePolicyExpiration(t *testing.T) {
+	policy := CachePolicy{StatusClasses: []int{2, 4}, Expiration: time.Hour, NegativeExpiration: time.Minute}.withDefaults()
+
+	assert.Equal(t, time.Hour, policy.expiration(http.StatusOK))
+	assert.Equal(t, time.Hour, policy.expiration(http.StatusNoContent))
+	assert.Equal(t, time.Minute, policy.expiration(http.StatusNotFound))
+	assert.Zero(t, policy.expiration(http.StatusMovedPermanently))
+	assert.Zero(t, policy.expiration(http.StatusInternalServerError))
+
+	assert.Equal(t, defaultCacheTimeout, CachePolicy{}.withDefaults().expiration(http.StatusOK))
+	assert.Zero(t, CachePolicy{}.withDefaults().expiration(http.StatusNotFound))
+}
+
 func TestCacheMiddlewareSuite(t *testing.T) {
 	suite.Run(t, new(CacheMiddlewareSuite))
 }
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"luizalabs-technical-test/pkg/crypt"
+	"luizalabs-technical-test/pkg/logger"
+	"luizalabs-technical-test/pkg/middleware"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// CorrelationIDHeader is the header carrying the correlation ID of a request, in both directions.
+	CorrelationIDHeader = "X-Correlation-ID"
+
+	// CorrelationIDKey is the key used to store the correlation ID of a request in the Gin context.
+	CorrelationIDKey = "correlation_id"
+
+	// correlationIDRandomBytes defines the amount of random bytes used to build generated correlation IDs.
+	correlationIDRandomBytes = 16
+)
+
+// validCorrelationID restricts the correlation IDs accepted from clients, so they can be safely logged and stored.
+var validCorrelationID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// CorrelationMiddleware is an interface that extends the base middleware.Middleware interface.
+// It tags every request with a correlation ID, so its log lines and audit events can be tied together.
+type CorrelationMiddleware interface {
+	middleware.Middleware
+}
+
+type correlationMiddleware struct{}
+
+// NewCorrelationMiddleware creates a new instance of correlationMiddleware.
+func NewCorrelationMiddleware() CorrelationMiddleware {
+	return &correlationMiddleware{}
+}
+
+// Middleware reuses the correlation ID sent by the client, or generates a new one, and echoes it in the response.
+func (m *correlationMiddleware) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		correlationID := c.GetHeader(CorrelationIDHeader)
+		if !validCorrelationID.MatchString(correlationID) {
+			generatedID, err := crypt.GenerateRandomToken(correlationIDRandomBytes)
+			if err != nil {
+				logger.Error(err)
+			}
+			correlationID = generatedID
+		}
+
+		c.Set(CorrelationIDKey, correlationID)
+		c.Header(CorrelationIDHeader, correlationID)
+		c.Next()
+	}
+}
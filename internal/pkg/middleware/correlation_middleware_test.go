@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type CorrelationMiddlewareTestSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+func (suite *CorrelationMiddlewareTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+
+	// Note: the handler echoes the correlation ID stored in the context, to compare it with the response header.
+	suite.router = gin.New()
+	suite.router.Use(NewCorrelationMiddleware().Middleware())
+	suite.router.GET("/correlated", func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString(CorrelationIDKey))
+	})
+}
+
+func (suite *CorrelationMiddlewareTestSuite) TestCorrelationMiddleware() {
+	tests := []struct {
+		name          string
+		correlationID string
+		reused        bool
+	}{
+		{name: "Missing correlation ID", reused: false},
+		{name: "Valid correlation ID", correlationID: "req-123.abc_DEF", reused: true},
+		{name: "Correlation ID with invalid characters", correlationID: "id\nInjected: header", reused: false},
+		{name: "Correlation ID too long", correlationID: strings.Repeat("a", 65), reused: false},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			req, _ := http.NewRequest(http.MethodGet, "/correlated", nil)
+			req.Header.Set(CorrelationIDHeader, tt.correlationID)
+			w := httptest.NewRecorder()
+
+			suite.router.ServeHTTP(w, req)
+
+			correlationID := w.Header().Get(CorrelationIDHeader)
+			assert.NotEmpty(suite.T(), correlationID)
+			assert.Equal(suite.T(), correlationID, w.Body.String())
+			assert.Equal(suite.T(), tt.reused, correlationID == tt.correlationID)
+		})
+	}
+}
+
+func TestCorrelationMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(CorrelationMiddlewareTestSuite))
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/pkg/middleware/admin_middleware.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAdminMiddleware is a mock of AdminMiddleware interface.
+type MockAdminMiddleware struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminMiddlewareMockRecorder
+}
+
+// MockAdminMiddlewareMockRecorder is the mock recorder for MockAdminMiddleware.
+type MockAdminMiddlewareMockRecorder struct {
+	mock *MockAdminMiddleware
+}
+
+// NewMockAdminMiddleware creates a new mock instance.
+func NewMockAdminMiddleware(ctrl *gomock.Controller) *MockAdminMiddleware {
+	mock := &MockAdminMiddleware{ctrl: ctrl}
+	mock.recorder = &MockAdminMiddlewareMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminMiddleware) EXPECT() *MockAdminMiddlewareMockRecorder {
+	return m.recorder
+}
+
+// Middleware mocks base method.
+func (m *MockAdminMiddleware) Middleware() gin.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Middleware")
+	ret0, _ := ret[0].(gin.HandlerFunc)
+	return ret0
+}
+
+// Middleware indicates an expected call of Middleware.
+func (mr *MockAdminMiddlewareMockRecorder) Middleware() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Middleware", reflect.TypeOf((*MockAdminMiddleware)(nil).Middleware))
+}
@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/pkg/middleware/api_key_middleware.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	token "luizalabs-technical-test/pkg/token"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAPIKeyMiddleware is a mock of APIKeyMiddleware interface.
+type MockAPIKeyMiddleware struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyMiddlewareMockRecorder
+}
+
+// MockAPIKeyMiddlewareMockRecorder is the mock recorder for MockAPIKeyMiddleware.
+type MockAPIKeyMiddlewareMockRecorder struct {
+	mock *MockAPIKeyMiddleware
+}
+
+// NewMockAPIKeyMiddleware creates a new mock instance.
+func NewMockAPIKeyMiddleware(ctrl *gomock.Controller) *MockAPIKeyMiddleware {
+	mock := &MockAPIKeyMiddleware{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyMiddlewareMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyMiddleware) EXPECT() *MockAPIKeyMiddlewareMockRecorder {
+	return m.recorder
+}
+
+// Middleware mocks base method.
+func (m *MockAPIKeyMiddleware) Middleware() gin.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Middleware")
+	ret0, _ := ret[0].(gin.HandlerFunc)
+	return ret0
+}
+
+// Middleware indicates an expected call of Middleware.
+func (mr *MockAPIKeyMiddlewareMockRecorder) Middleware() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Middleware", reflect.TypeOf((*MockAPIKeyMiddleware)(nil).Middleware))
+}
+
+// MockAPIKeyValidator is a mock of APIKeyValidator interface.
+type MockAPIKeyValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyValidatorMockRecorder
+}
+
+// MockAPIKeyValidatorMockRecorder is the mock recorder for MockAPIKeyValidator.
+type MockAPIKeyValidatorMockRecorder struct {
+	mock *MockAPIKeyValidator
+}
+
+// NewMockAPIKeyValidator creates a new mock instance.
+func NewMockAPIKeyValidator(ctrl *gomock.Controller) *MockAPIKeyValidator {
+	mock := &MockAPIKeyValidator{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyValidator) EXPECT() *MockAPIKeyValidatorMockRecorder {
+	return m.recorder
+}
+
+// ValidateAPIKey mocks base method.
+func (m *MockAPIKeyValidator) ValidateAPIKey(rawKey string) (*token.CustomClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateAPIKey", rawKey)
+	ret0, _ := ret[0].(*token.CustomClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateAPIKey indicates an expected call of ValidateAPIKey.
+func (mr *MockAPIKeyValidatorMockRecorder) ValidateAPIKey(rawKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAPIKey", reflect.TypeOf((*MockAPIKeyValidator)(nil).ValidateAPIKey), rawKey)
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/pkg/middleware/correlation_middleware.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCorrelationMiddleware is a mock of CorrelationMiddleware interface.
+type MockCorrelationMiddleware struct {
+	ctrl     *gomock.Controller
+	recorder *MockCorrelationMiddlewareMockRecorder
+}
+
+// MockCorrelationMiddlewareMockRecorder is the mock recorder for MockCorrelationMiddleware.
+type MockCorrelationMiddlewareMockRecorder struct {
+	mock *MockCorrelationMiddleware
+}
+
+// NewMockCorrelationMiddleware creates a new mock instance.
+func NewMockCorrelationMiddleware(ctrl *gomock.Controller) *MockCorrelationMiddleware {
+	mock := &MockCorrelationMiddleware{ctrl: ctrl}
+	mock.recorder = &MockCorrelationMiddlewareMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCorrelationMiddleware) EXPECT() *MockCorrelationMiddlewareMockRecorder {
+	return m.recorder
+}
+
+// Middleware mocks base method.
+func (m *MockCorrelationMiddleware) Middleware() gin.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Middleware")
+	ret0, _ := ret[0].(gin.HandlerFunc)
+	return ret0
+}
+
+// Middleware indicates an expected call of Middleware.
+func (mr *MockCorrelationMiddlewareMockRecorder) Middleware() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Middleware", reflect.TypeOf((*MockCorrelationMiddleware)(nil).Middleware))
+}
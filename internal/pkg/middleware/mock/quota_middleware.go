@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/pkg/middleware/quota_middleware.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockQuotaMiddleware is a mock of QuotaMiddleware interface.
+type MockQuotaMiddleware struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuotaMiddlewareMockRecorder
+}
+
+// MockQuotaMiddlewareMockRecorder is the mock recorder for MockQuotaMiddleware.
+type MockQuotaMiddlewareMockRecorder struct {
+	mock *MockQuotaMiddleware
+}
+
+// NewMockQuotaMiddleware creates a new mock instance.
+func NewMockQuotaMiddleware(ctrl *gomock.Controller) *MockQuotaMiddleware {
+	mock := &MockQuotaMiddleware{ctrl: ctrl}
+	mock.recorder = &MockQuotaMiddlewareMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuotaMiddleware) EXPECT() *MockQuotaMiddlewareMockRecorder {
+	return m.recorder
+}
+
+// Middleware mocks base method.
+func (m *MockQuotaMiddleware) Middleware() gin.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Middleware")
+	ret0, _ := ret[0].(gin.HandlerFunc)
+	return ret0
+}
+
+// Middleware indicates an expected call of Middleware.
+func (mr *MockQuotaMiddlewareMockRecorder) Middleware() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Middleware", reflect.TypeOf((*MockQuotaMiddleware)(nil).Middleware))
+}
+
+// MockQuotaProvider is a mock of QuotaProvider interface.
+type MockQuotaProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuotaProviderMockRecorder
+}
+
+// MockQuotaProviderMockRecorder is the mock recorder for MockQuotaProvider.
+type MockQuotaProviderMockRecorder struct {
+	mock *MockQuotaProvider
+}
+
+// NewMockQuotaProvider creates a new mock instance.
+func NewMockQuotaProvider(ctrl *gomock.Controller) *MockQuotaProvider {
+	mock := &MockQuotaProvider{ctrl: ctrl}
+	mock.recorder = &MockQuotaProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuotaProvider) EXPECT() *MockQuotaProviderMockRecorder {
+	return m.recorder
+}
+
+// ConsumeRequest mocks base method.
+func (m *MockQuotaProvider) ConsumeRequest(tenantID uint, day time.Time, limit int) (int, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeRequest", tenantID, day, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ConsumeRequest indicates an expected call of ConsumeRequest.
+func (mr *MockQuotaProviderMockRecorder) ConsumeRequest(tenantID, day, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeRequest", reflect.TypeOf((*MockQuotaProvider)(nil).ConsumeRequest), tenantID, day, limit)
+}
+
+// RequestQuota mocks base method.
+func (m *MockQuotaProvider) RequestQuota(tenantID uint) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestQuota", tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestQuota indicates an expected call of RequestQuota.
+func (mr *MockQuotaProviderMockRecorder) RequestQuota(tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestQuota", reflect.TypeOf((*MockQuotaProvider)(nil).RequestQuota), tenantID)
+}
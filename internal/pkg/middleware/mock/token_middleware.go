@@ -5,6 +5,7 @@
 package mock
 
 import (
+	token "luizalabs-technical-test/pkg/token"
 	reflect "reflect"
 
 	gin "github.com/gin-gonic/gin"
@@ -47,3 +48,40 @@ func (mr *MockTokenMiddlewareMockRecorder) Middleware() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Middleware", reflect.TypeOf((*MockTokenMiddleware)(nil).Middleware))
 }
+
+// MockTokenRevocationChecker is a mock of TokenRevocationChecker interface.
+type MockTokenRevocationChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenRevocationCheckerMockRecorder
+}
+
+// MockTokenRevocationCheckerMockRecorder is the mock recorder for MockTokenRevocationChecker.
+type MockTokenRevocationCheckerMockRecorder struct {
+	mock *MockTokenRevocationChecker
+}
+
+// NewMockTokenRevocationChecker creates a new mock instance.
+func NewMockTokenRevocationChecker(ctrl *gomock.Controller) *MockTokenRevocationChecker {
+	mock := &MockTokenRevocationChecker{ctrl: ctrl}
+	mock.recorder = &MockTokenRevocationCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenRevocationChecker) EXPECT() *MockTokenRevocationCheckerMockRecorder {
+	return m.recorder
+}
+
+// IsTokenRevoked mocks base method.
+func (m *MockTokenRevocationChecker) IsTokenRevoked(claims *token.CustomClaims) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTokenRevoked", claims)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsTokenRevoked indicates an expected call of IsTokenRevoked.
+func (mr *MockTokenRevocationCheckerMockRecorder) IsTokenRevoked(claims interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenRevoked", reflect.TypeOf((*MockTokenRevocationChecker)(nil).IsTokenRevoked), claims)
+}
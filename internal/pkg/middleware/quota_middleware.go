@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"fmt"
+	"luizalabs-technical-test/pkg/cache"
+	"luizalabs-technical-test/pkg/logger"
+	"luizalabs-technical-test/pkg/middleware"
+	"luizalabs-technical-test/pkg/token"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// quotaLimitHeaderKey reports the daily request quota of the tenant.
+	quotaLimitHeaderKey = "X-Quota-Limit"
+
+	// quotaRemainingHeaderKey reports how many requests the tenant may still make today.
+	quotaRemainingHeaderKey = "X-Quota-Remaining"
+
+	// quotaLimitTTL defines how long the quota of a tenant is kept before being loaded again.
+	quotaLimitTTL = time.Minute
+
+	// quotaExceededMessage is the error answered once the quota of the tenant is exhausted.
+	quotaExceededMessage = "A cota diária de requisições da organização foi excedida. Tente novamente amanhã."
+)
+
+// QuotaMiddleware is an interface that extends the base middleware.Middleware interface.
+// It limits the requests each tenant may make per day, and must run after the token or api key middlewares.
+type QuotaMiddleware interface {
+	middleware.Middleware
+}
+
+// QuotaProvider resolves the daily request quota of a tenant, and counts its requests. A zero quota means unlimited.
+type QuotaProvider interface {
+	RequestQuota(tenantID uint) (int, error)
+	// ConsumeRequest atomically counts a request of the tenant on the day unless the limit was reached, returning
+	// the requests counted and whether this one was.
+	ConsumeRequest(tenantID uint, day time.Time, limit int) (int, bool, error)
+}
+
+type quotaMiddleware struct {
+	provider QuotaProvider
+	limits   *cache.Typed[int]
+}
+
+// NewQuotaMiddleware creates a new instance of quotaMiddleware, caching the quota of each tenant.
+// Note: the requests are counted by the provider, as the cache is best-effort and may drop the counters.
+func NewQuotaMiddleware(provider QuotaProvider, cacheManager cache.Manager) QuotaMiddleware {
+	return &quotaMiddleware{provider, cache.NewTyped[int](cacheManager)}
+}
+
+// Middleware counts the request against the daily quota of the tenant, aborting with a too many requests status
+// once it is exhausted. Requests of users without a tenant, or of tenants without a quota, are never limited.
+func (q *quotaMiddleware) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := token.ClaimsFromContext(c)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		tenantID := claims.UintKey("TenantID")
+		if tenantID == 0 {
+			c.Next()
+			return
+		}
+
+		limit := q.requestQuota(tenantID)
+		if limit <= 0 {
+			c.Next()
+			return
+		}
+
+		// Note: like the quota, failing to count the request must not block it.
+		used, allowed, err := q.consume(tenantID, limit, time.Now().UTC())
+		if err != nil {
+			logger.Error(err)
+			c.Next()
+			return
+		}
+
+		c.Header(quotaLimitHeaderKey, strconv.Itoa(limit))
+		c.Header(quotaRemainingHeaderKey, strconv.Itoa(limit-used))
+		if !allowed {
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": quotaExceededMessage})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// requestQuota returns the quota of the tenant, loading it from the provider when it is not cached.
+// Note: failing to load the quota must not block the request, so the tenant is left unlimited.
+func (q *quotaMiddleware) requestQuota(tenantID uint) int {
+	key := fmt.Sprintf("quota:limit:%d", tenantID)
+	if limit, exists := q.limits.Get(key); exists {
+		return limit
+	}
+
+	limit, err := q.provider.RequestQuota(tenantID)
+	if err != nil {
+		logger.Error(err)
+		return 0
+	}
+
+	q.limits.Set(key, limit, quotaLimitTTL)
+	return limit
+}
+
+// consume counts a request of the tenant for the current day, returning how many were counted so far
+// and whether the request fits in the quota. Rejected requests are not counted.
+// Note: the day is taken in UTC, so the quota is renewed at midnight (UTC).
+func (q *quotaMiddleware) consume(tenantID uint, limit int, now time.Time) (int, bool, error) {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return q.provider.ConsumeRequest(tenantID, day, limit)
+}
@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"luizalabs-technical-test/pkg/cache"
+	"luizalabs-technical-test/pkg/token"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeQuotaProvider serves fixed quotas, counting how many times they were loaded, and the requests of each tenant
+// per day.
+type fakeQuotaProvider struct {
+	quotas map[uint]int
+	loads  int
+	usage  map[string]int
+	err    error
+}
+
+func (f *fakeQuotaProvider) RequestQuota(tenantID uint) (int, error) {
+	f.loads++
+	quota, ok := f.quotas[tenantID]
+	if !ok {
+		return 0, errors.New("organization not found")
+	}
+	return quota, nil
+}
+
+func (f *fakeQuotaProvider) ConsumeRequest(tenantID uint, day time.Time, limit int) (int, bool, error) {
+	if f.err != nil {
+		return 0, false, f.err
+	}
+
+	key := strconv.Itoa(int(tenantID)) + ":" + day.Format(time.DateOnly)
+	if f.usage[key] >= limit {
+		return limit, false, nil
+	}
+	f.usage[key]++
+	return f.usage[key], true, nil
+}
+
+type QuotaMiddlewareTestSuite struct {
+	suite.Suite
+	provider *fakeQuotaProvider
+	router   *gin.Engine
+}
+
+func (suite *QuotaMiddlewareTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.provider = &fakeQuotaProvider{quotas: map[uint]int{3: 2, 4: 0}, usage: map[string]int{}}
+
+	// Note: the tenant is taken from a header to simulate the claims set by the token middleware.
+	suite.router = gin.New()
+	suite.router.GET("/quota",
+		func(c *gin.Context) {
+			if tenant := c.GetHeader("X-Test-Tenant"); tenant != "" {
+				tenantID, _ := strconv.Atoi(tenant)
+				c.Set(token.ClaimsHeaderName, &token.CustomClaims{CustomKeys: map[string]any{"TenantID": float64(tenantID)}})
+			}
+		},
+		NewQuotaMiddleware(suite.provider, cache.NewManager(time.Minute)).Middleware(),
+		func(c *gin.Context) { c.Status(http.StatusOK) },
+	)
+}
+
+func (suite *QuotaMiddlewareTestSuite) request(tenant string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodGet, "/quota", nil)
+	if tenant != "" {
+		req.Header.Set("X-Test-Tenant", tenant)
+	}
+
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *QuotaMiddlewareTestSuite) TestQuotaMiddleware_Exhausted() {
+	w := suite.request("3")
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), "2", w.Header().Get("X-Quota-Limit"))
+	assert.Equal(suite.T(), "1", w.Header().Get("X-Quota-Remaining"))
+
+	w = suite.request("3")
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), "0", w.Header().Get("X-Quota-Remaining"))
+
+	w = suite.request("3")
+	assert.Equal(suite.T(), http.StatusTooManyRequests, w.Code)
+	assert.Equal(suite.T(), "0", w.Header().Get("X-Quota-Remaining"))
+	assert.Contains(suite.T(), w.Body.String(), quotaExceededMessage)
+
+	assert.Equal(suite.T(), 1, suite.provider.loads, "Expected the quota to be cached")
+}
+
+func (suite *QuotaMiddlewareTestSuite) TestQuotaMiddleware_Unlimited() {
+	tests := []struct {
+		name   string
+		tenant string
+	}{
+		{name: "Missing claims"},
+		{name: "User without tenant", tenant: "0"},
+		{name: "Tenant without quota", tenant: "4"},
+		{name: "Unknown tenant", tenant: "5"},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			for range 3 {
+				w := suite.request(tt.tenant)
+				assert.Equal(suite.T(), http.StatusOK, w.Code)
+				assert.Empty(suite.T(), w.Header().Get("X-Quota-Limit"))
+			}
+		})
+	}
+}
+
+func (suite *QuotaMiddlewareTestSuite) TestQuotaMiddleware_CountingFailure() {
+	suite.provider.err = errors.New("database unavailable")
+
+	w := suite.request("3")
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Empty(suite.T(), w.Header().Get("X-Quota-Limit"))
+}
+
+func (suite *QuotaMiddlewareTestSuite) TestConsume_RenewedEveryDay() {
+	q := NewQuotaMiddleware(suite.provider, cache.NewManager(time.Minute)).(*quotaMiddleware)
+	today := time.Date(2024, 1, 31, 23, 59, 0, 0, time.UTC)
+
+	_, allowed, err := q.consume(3, 1, today)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), allowed)
+	_, allowed, _ = q.consume(3, 1, today.Add(30*time.Second))
+	assert.False(suite.T(), allowed)
+
+	used, allowed, _ := q.consume(3, 1, today.Add(2*time.Minute))
+	assert.True(suite.T(), allowed)
+	assert.Equal(suite.T(), 1, used)
+}
+
+func TestQuotaMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(QuotaMiddlewareTestSuite))
+}
@@ -2,10 +2,12 @@ package middleware
 
 import (
 	"luizalabs-technical-test/internal/config"
+	"luizalabs-technical-test/internal/pkg/audit"
 	"luizalabs-technical-test/pkg/constants/str"
 	"luizalabs-technical-test/pkg/middleware"
 	"luizalabs-technical-test/pkg/token"
 	"net/http"
+	"slices"
 
 	"github.com/gin-gonic/gin"
 )
@@ -17,17 +19,47 @@ type TokenMiddleware interface {
 	middleware.Middleware
 }
 
-type tokenMiddleware struct{}
+// TokenRevocationChecker reports whether a valid token was revoked after being issued (e.g. by a password reset or a terminated session).
+type TokenRevocationChecker interface {
+	IsTokenRevoked(claims *token.CustomClaims) bool
+}
+
+// Reasons recorded in the audit log when a token is rejected.
+const (
+	auditReasonInvalidToken      = "invalid_token"
+	auditReasonRevokedToken      = "revoked_token"
+	auditReasonInsufficientScope = "insufficient_scope"
+)
+
+type tokenMiddleware struct {
+	revocationChecker TokenRevocationChecker
+	recorder          audit.Recorder
+	requiredScope     string
+}
+
+// NewTokenMiddleware creates a new instance of tokenMiddleware, which validates tokens for authentication.
+// Only tokens issued to users are accepted, the ones issued to OAuth2 clients are rejected.
+// Rejected tokens are recorded in the audit log, missing ones are not.
+func NewTokenMiddleware(revocationChecker TokenRevocationChecker, recorder audit.Recorder) TokenMiddleware {
+	return &tokenMiddleware{revocationChecker, recorder, str.EmptyString}
+}
 
-// NewTokenMiddleware creates a new instance of tokenMiddleware, which validates tokens for authentication
-func NewTokenMiddleware() TokenMiddleware {
-	return &tokenMiddleware{}
+// NewScopedTokenMiddleware creates a new instance of tokenMiddleware which, besides the tokens issued to users,
+// accepts the tokens issued to OAuth2 clients granted with the required scope.
+func NewScopedTokenMiddleware(revocationChecker TokenRevocationChecker, recorder audit.Recorder, requiredScope string) TokenMiddleware {
+	return &tokenMiddleware{revocationChecker, recorder, requiredScope}
 }
 
 // Middleware validates the Bearer token in incoming requests. If valid, the token claims are added to the context.
 // If the token is missing or invalid, it aborts the request with an unauthorized status.
 func (t *tokenMiddleware) Middleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Note: requests already authenticated by another middleware (e.g. API keys) skip the bearer validation.
+		if _, exists := c.Get(token.ClaimsHeaderName); exists {
+			c.Next()
+			return
+		}
+
 		tokenString := token.ExtractBearerToken(c.Request)
 		if tokenString == str.EmptyString {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
@@ -35,14 +67,51 @@ func (t *tokenMiddleware) Middleware() gin.HandlerFunc {
 		}
 
 		claims, err := token.ValidateToken(config.GeneralConfig.SecretAuthTokenKey, tokenString)
-		if err != nil {
+		if err != nil || claims.StringKey(token.PurposeClaimName) != str.EmptyString {
+			t.recordRejection(c, nil, auditReasonInvalidToken)
 			// Customize the error message for unauthorized access
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
 			return
 		}
 
+		if t.revocationChecker.IsTokenRevoked(claims) {
+			t.recordRejection(c, claims, auditReasonRevokedToken)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "revoked token"})
+			return
+		}
+
+		if !t.isAllowed(claims) {
+			t.recordRejection(c, claims, auditReasonInsufficientScope)
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient scope"})
+			return
+		}
+
 		// Set token claims in the context for further use in the request lifecycle
 		c.Set(token.ClaimsHeaderName, claims)
 		c.Next()
 	}
 }
+
+// isAllowed reports whether the token may access the route. Tokens issued to OAuth2 clients are limited to the
+// routes requiring one of their scopes.
+func (t *tokenMiddleware) isAllowed(claims *token.CustomClaims) bool {
+	if claims.StringKey("ClientID") == str.EmptyString {
+		return true
+	}
+	return t.requiredScope != str.EmptyString && slices.Contains(claims.StringSliceKey("Scopes"), t.requiredScope)
+}
+
+// recordRejection records the rejected token in the audit log, identifying the actor when the claims are trustworthy.
+func (t *tokenMiddleware) recordRejection(c *gin.Context, claims *token.CustomClaims, reason string) {
+	event := audit.Event{
+		Action:  audit.ActionTokenRejected,
+		Outcome: audit.OutcomeFailure,
+		Reason:  reason,
+	}
+	if claims != nil {
+		event.ActorID = claims.UintKey("ID")
+		event.TenantID = claims.UintKey("TenantID")
+		event.Actor = claims.StringKey("Email")
+	}
+	t.recorder.Record(c, event)
+}
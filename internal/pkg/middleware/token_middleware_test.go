@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"luizalabs-technical-test/internal/config"
+	"luizalabs-technical-test/internal/pkg/audit"
 	"luizalabs-technical-test/pkg/constants/str"
 	"luizalabs-technical-test/pkg/middleware"
 	"luizalabs-technical-test/pkg/token"
@@ -17,16 +18,34 @@ import (
 	"github.com/stretchr/testify/suite"
 )
 
+// fakeRevocationChecker revokes every token issued for the "revoked" subject.
+type fakeRevocationChecker struct{}
+
+func (fakeRevocationChecker) IsTokenRevoked(claims *token.CustomClaims) bool {
+	return claims.Subject == "revoked"
+}
+
+// fakeRecorder keeps the recorded events in memory.
+type fakeRecorder struct {
+	events []audit.Event
+}
+
+func (r *fakeRecorder) Record(_ *gin.Context, event audit.Event) {
+	r.events = append(r.events, event)
+}
+
 type TokenMiddlewareTestSuite struct {
 	suite.Suite
-	router *gin.Engine
-	mw     middleware.Middleware
+	router   *gin.Engine
+	mw       middleware.Middleware
+	recorder *fakeRecorder
 }
 
 func (suite *TokenMiddlewareTestSuite) SetupSuite() {
 	// Set up Gin router and middleware
 	suite.router = gin.New()
-	suite.mw = NewTokenMiddleware()
+	suite.recorder = &fakeRecorder{}
+	suite.mw = NewTokenMiddleware(fakeRevocationChecker{}, suite.recorder)
 	suite.router.Use(suite.mw.Middleware())
 	suite.router.GET("/protected", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"message": "success"})
@@ -38,10 +57,11 @@ func (suite *TokenMiddlewareTestSuite) SetupSuite() {
 
 func (suite *TokenMiddlewareTestSuite) TestTokenMiddleware() {
 	tests := []struct {
-		name         string
-		authHeader   string
-		expectedCode int
-		expectedBody string
+		name          string
+		authHeader    string
+		expectedCode  int
+		expectedBody  string
+		expectedAudit []audit.Event
 	}{
 		{
 			name:         "No token provided",
@@ -54,10 +74,40 @@ func (suite *TokenMiddlewareTestSuite) TestTokenMiddleware() {
 			authHeader:   "Bearer invalidtoken",
 			expectedCode: http.StatusUnauthorized,
 			expectedBody: `{"error":"invalid token"}`,
+			expectedAudit: []audit.Event{
+				{Action: audit.ActionTokenRejected, Outcome: audit.OutcomeFailure, Reason: "invalid_token"},
+			},
+		},
+		{
+			name:         "Single-purpose token provided",
+			authHeader:   "Bearer " + suite.createToken(map[string]any{token.PurposeClaimName: "email_verification"}),
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"error":"invalid token"}`,
+			expectedAudit: []audit.Event{
+				{Action: audit.ActionTokenRejected, Outcome: audit.OutcomeFailure, Reason: "invalid_token"},
+			},
+		},
+		{
+			name:         "Revoked token provided",
+			authHeader:   "Bearer " + suite.createTokenFor("revoked", map[string]any{"ID": 7, "TenantID": 3, "Email": "user@example.com"}),
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"error":"revoked token"}`,
+			expectedAudit: []audit.Event{
+				{ActorID: 7, TenantID: 3, Actor: "user@example.com", Action: audit.ActionTokenRejected, Outcome: audit.OutcomeFailure, Reason: "revoked_token"},
+			},
+		},
+		{
+			name:         "Client token provided",
+			authHeader:   "Bearer " + suite.createToken(map[string]any{"ClientID": "client", "TenantID": 3, "Scopes": []string{"address:read"}}),
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"error":"insufficient scope"}`,
+			expectedAudit: []audit.Event{
+				{TenantID: 3, Action: audit.ActionTokenRejected, Outcome: audit.OutcomeFailure, Reason: "insufficient_scope"},
+			},
 		},
 		{
 			name:         "Valid token provided",
-			authHeader:   "Bearer " + suite.createValidToken(),
+			authHeader:   "Bearer " + suite.createToken(map[string]any{"foo": "bar"}),
 			expectedCode: http.StatusOK,
 			expectedBody: `{"message":"success"}`,
 		},
@@ -65,6 +115,8 @@ func (suite *TokenMiddlewareTestSuite) TestTokenMiddleware() {
 
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
+			suite.recorder.events = nil
+
 			// Create a request with the appropriate Authorization header
 			req := httptest.NewRequest(http.MethodGet, "/protected", nil)
 			if tt.authHeader != "" {
@@ -78,18 +130,54 @@ func (suite *TokenMiddlewareTestSuite) TestTokenMiddleware() {
 			// Assert the response
 			assert.Equal(suite.T(), tt.expectedCode, w.Code)
 			assert.JSONEq(suite.T(), tt.expectedBody, w.Body.String())
+			assert.Equal(suite.T(), tt.expectedAudit, suite.recorder.events)
+		})
+	}
+}
+
+func (suite *TokenMiddlewareTestSuite) TestScopedTokenMiddleware() {
+	router := gin.New()
+	router.Use(NewScopedTokenMiddleware(fakeRevocationChecker{}, &fakeRecorder{}, "address:read").Middleware())
+	router.GET("/scoped", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	tests := []struct {
+		name         string
+		customKeys   map[string]any
+		expectedCode int
+	}{
+		{"User token", map[string]any{"ID": 7}, http.StatusOK},
+		{"Client token granted with the scope", map[string]any{"ClientID": "client", "Scopes": []string{"address:read"}}, http.StatusOK},
+		{"Client token without the scope", map[string]any{"ClientID": "client", "Scopes": []string{"other:read"}}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			req := httptest.NewRequest(http.MethodGet, "/scoped", nil)
+			req.Header.Set("Authorization", "Bearer "+suite.createToken(tt.customKeys))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedCode, w.Code)
 		})
 	}
 }
 
-// Helper function to create a valid token
-func (suite *TokenMiddlewareTestSuite) createValidToken() string {
+// Helper function to create a valid token with the given custom keys
+func (suite *TokenMiddlewareTestSuite) createToken(customKeys map[string]any) string {
+	return suite.createTokenFor("1234567890", customKeys)
+}
+
+// Helper function to create a valid token for the given subject
+func (suite *TokenMiddlewareTestSuite) createTokenFor(subject string, customKeys map[string]any) string {
 	claims := token.CustomClaims{
 		StandardClaims: jwt.StandardClaims{
-			Subject:   "1234567890",
+			Subject:   subject,
 			ExpiresAt: time.Now().Add(time.Hour * 72).Unix(),
 		},
-		CustomKeys: map[string]any{"foo": "bar"},
+		CustomKeys: customKeys,
 	}
 	tokenString, _ := token.CreateToken(config.GeneralConfig.SecretAuthTokenKey, claims)
 	return tokenString
@@ -0,0 +1,19 @@
+package scope
+
+// AddressRead grants access to the address lookup routes.
+const AddressRead = "address:read"
+
+// supported lists every scope that can be granted to machine-to-machine credentials.
+var supported = map[string]bool{
+	AddressRead: true,
+}
+
+// IsSupported reports whether the given scope can be granted.
+func IsSupported(scope string) bool {
+	return supported[scope]
+}
+
+// Default returns the scopes granted when a client does not request any.
+func Default() []string {
+	return []string{AddressRead}
+}
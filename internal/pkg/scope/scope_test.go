@@ -0,0 +1,16 @@
+package scope
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSupported(t *testing.T) {
+	assert.True(t, IsSupported(AddressRead))
+	assert.False(t, IsSupported("admin:all"))
+}
+
+func TestDefault(t *testing.T) {
+	assert.Equal(t, []string{AddressRead}, Default())
+}
@@ -1,35 +1,172 @@
 package cache
 
 import (
+	"container/heap"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // Manager defines the interface for cache management operations.
+// Values are opaque bytes, so callers choose how they are serialised and any backend can store them.
+// Note: the cache is best-effort, backend failures are logged and reported as misses.
 type Manager interface {
-	Get(key string) (interface{}, bool)
-	Set(key string, data interface{}, expiration time.Duration)
+	Get(key string) ([]byte, bool)
+	// Set stores the data for the given duration; non-positive durations remove the entry.
+	Set(key string, data []byte, expiration time.Duration)
+	Delete(key string)
+	// DeleteByPrefix removes the entries whose key starts with the prefix, returning how many were removed.
+	DeleteByPrefix(prefix string) int
+	Clear()
+	// TTL returns the time left before the entry expires, or false when the key isn't cached.
+	TTL(key string) (time.Duration, bool)
+	Stats() Stats
+	// Close releases the resources of the backend; the manager must not be used afterwards.
+	Close() error
 }
 
-// entry represents a single entry in the cache.
+// Stats summarises the usage of a cache. Hits and misses are counted by the process, while the size
+// is the one of the backend, so it includes the entries written by other replicas of shared backends.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Entries   int64
+	Bytes     int64 // approximate, zero when the backend doesn't report it
+	Evictions Evictions
+}
+
+// counters tracks the hits and misses of a manager.
+type counters struct {
+	hits   *atomic.Uint64
+	misses *atomic.Uint64
+}
+
+// newCounters creates zeroed counters.
+func newCounters() counters {
+	return counters{hits: &atomic.Uint64{}, misses: &atomic.Uint64{}}
+}
+
+// record counts the outcome of a lookup.
+func (c counters) record(found bool) {
+	if found {
+		c.hits.Add(1)
+		return
+	}
+	c.misses.Add(1)
+}
+
+// EvictionPolicy defines which entry a bounded cache drops first once a limit is reached.
+type EvictionPolicy string
+
+const (
+	// LRU evicts the least recently used entry.
+	LRU EvictionPolicy = "lru"
+	// LFU evicts the least frequently used entry, the least recently used one among ties.
+	LFU EvictionPolicy = "lfu"
+)
+
+const (
+	// DefaultShards defines the amount of independently locked partitions of the in-memory cache.
+	DefaultShards = 16
+
+	// entryOverhead approximates the bookkeeping bytes of an entry (map slot, heap item and pointers),
+	// accounted on top of the key and the data.
+	entryOverhead = 96
+)
+
+// Limits bounds the in-memory cache. Zero values mean unlimited, and an empty policy means LRU.
+// Note: the limits are split evenly between the shards, so eviction may start slightly before the
+// configured limits when the keys are unevenly spread.
+type Limits struct {
+	MaxEntries int
+	MaxBytes   int64
+	Eviction   EvictionPolicy
+	Shards     int
+}
+
+// Evictions counts the entries dropped by the in-memory cache, by reason.
+type Evictions struct {
+	Capacity uint64 // evicted to stay within the maximum entry count
+	Memory   uint64 // evicted to stay within the byte budget
+	Expired  uint64 // removed after expiring
+}
+
+// entry represents a single entry in the cache, positioned in the eviction heap of its shard.
 type entry struct {
-	Data       interface{}
+	Key        string
+	Data       []byte
 	Expiration time.Time
+	hits       uint64
+	lastUsed   uint64
+	index      int
+}
+
+// size returns the bytes accounted for the entry.
+func (e *entry) size() int64 {
+	return int64(len(e.Key)+len(e.Data)) + entryOverhead
+}
+
+// shard is a partition of the in-memory cache, with its own lock, limits and eviction order.
+type shard struct {
+	mutex      *sync.Mutex
+	entries    map[string]*entry
+	queue      evictionQueue
+	bytes      int64
+	clock      uint64
+	maxEntries int
+	maxBytes   int64
 }
 
-// manager is responsible for managing the cache
+// evictionQueue is a min-heap of the entries of a shard, the next entry to evict on top.
+type evictionQueue struct {
+	items []*entry
+	lfu   bool
+}
+
+// manager is the in-memory implementation of the Manager interface, local to the process.
 type manager struct {
-	cache  map[string]entry
-	mutex  *sync.RWMutex
-	ticker *time.Ticker
+	shards   []*shard
+	ticker   *time.Ticker
+	done     chan struct{}
+	once     *sync.Once
+	counters counters
+	capacity *atomic.Uint64
+	memory   *atomic.Uint64
+	expired  *atomic.Uint64
 }
 
-// NewManager creates a new CacheManager with the specified cleanup interval
+// NewManager creates a new unbounded in-memory Manager with the specified cleanup interval
 func NewManager(cleanupInterval time.Duration) Manager {
+	return NewBoundedManager(cleanupInterval, Limits{})
+}
+
+// NewBoundedManager creates a new in-memory Manager holding at most the given entries and bytes.
+// Keys are spread over shards, so concurrent writes to different keys don't contend on a single lock.
+func NewBoundedManager(cleanupInterval time.Duration, limits Limits) Manager {
+	shardCount := limits.Shards
+	if shardCount <= 0 {
+		shardCount = DefaultShards
+	}
+
 	cacheManager := &manager{
-		cache:  make(map[string]entry),
-		mutex:  &sync.RWMutex{},
-		ticker: time.NewTicker(cleanupInterval),
+		shards:   make([]*shard, shardCount),
+		ticker:   time.NewTicker(cleanupInterval),
+		done:     make(chan struct{}),
+		once:     &sync.Once{},
+		counters: newCounters(),
+		capacity: &atomic.Uint64{},
+		memory:   &atomic.Uint64{},
+		expired:  &atomic.Uint64{},
+	}
+	for i := range cacheManager.shards {
+		cacheManager.shards[i] = &shard{
+			mutex:      &sync.Mutex{},
+			entries:    make(map[string]*entry),
+			queue:      evictionQueue{lfu: limits.Eviction == LFU},
+			maxEntries: splitLimit(int64(limits.MaxEntries), shardCount),
+			maxBytes:   int64(splitLimit(limits.MaxBytes, shardCount)),
+		}
 	}
 
 	go cacheManager.startCleanupRoutine()
@@ -37,44 +174,258 @@ func NewManager(cleanupInterval time.Duration) Manager {
 	return cacheManager
 }
 
-// Set adds a new entry to the cache with the specified key, data, and expiration time
-func (c *manager) Set(key string, data interface{}, expiration time.Duration) {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
+// Set adds a new entry to the cache with the specified key, data, and expiration time,
+// evicting entries of the shard to keep it within its limits.
+func (c *manager) Set(key string, data []byte, expiration time.Duration) {
+	s := c.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if existing, found := s.entries[key]; found {
+		s.remove(existing)
+	}
+	if expiration <= 0 {
+		return
+	}
+
+	// Note: the data is copied, so callers reusing their buffers don't change the cached value.
+	e := &entry{Key: key, Data: append([]byte(nil), data...), Expiration: time.Now().Add(expiration)}
+	if s.maxBytes > 0 && e.size() > s.maxBytes {
+		// Note: values larger than the budget of a shard would evict everything and still not fit.
+		c.memory.Add(1)
+		return
+	}
+
+	// Note: room is made ahead of the insertion, so a new entry is never its own eviction candidate under LFU.
+	c.evict(s, e.size())
 
-	expirationTime := time.Now().Add(expiration)
-	c.cache[key] = entry{Data: data, Expiration: expirationTime}
+	s.touch(e)
+	s.entries[key] = e
+	s.bytes += e.size()
+	heap.Push(&s.queue, e)
 }
 
 // Get retrieves the cached data associated with the specified key
-func (c *manager) Get(key string) (interface{}, bool) {
-	c.mutex.RLock()
-	defer c.mutex.RUnlock()
+func (c *manager) Get(key string) ([]byte, bool) {
+	s := c.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
-	entry, found := c.cache[key]
-	if !found || time.Now().After(entry.Expiration) {
+	e, found := s.entries[key]
+	if found && time.Now().After(e.Expiration) {
+		s.remove(e)
+		c.expired.Add(1)
+		found = false
+	}
+	c.counters.record(found)
+	if !found {
 		return nil, false
 	}
 
-	return entry.Data, true
+	e.hits++
+	s.touch(e)
+	heap.Fix(&s.queue, e.index)
+
+	return append([]byte(nil), e.Data...), true
+}
+
+// Delete removes the entry of the key from the cache
+func (c *manager) Delete(key string) {
+	c.Set(key, nil, 0)
+}
+
+// DeleteByPrefix removes the entries whose key starts with the prefix, one shard at a time
+func (c *manager) DeleteByPrefix(prefix string) int {
+	removed := 0
+	for _, s := range c.shards {
+		s.mutex.Lock()
+		for key, e := range s.entries {
+			if strings.HasPrefix(key, prefix) {
+				s.remove(e)
+				removed++
+			}
+		}
+		s.mutex.Unlock()
+	}
+	return removed
+}
+
+// Clear removes every entry from the cache
+func (c *manager) Clear() {
+	for _, s := range c.shards {
+		s.mutex.Lock()
+		s.entries = make(map[string]*entry)
+		s.queue.items = nil
+		s.bytes = 0
+		s.mutex.Unlock()
+	}
+}
+
+// TTL returns the time left before the entry of the key expires
+func (c *manager) TTL(key string) (time.Duration, bool) {
+	s := c.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	e, found := s.entries[key]
+	if !found {
+		return 0, false
+	}
+
+	ttl := time.Until(e.Expiration)
+	if ttl <= 0 {
+		return 0, false
+	}
+	return ttl, true
+}
+
+// Stats returns the usage of the cache since it was created
+func (c *manager) Stats() Stats {
+	stats := Stats{
+		Hits:   c.counters.hits.Load(),
+		Misses: c.counters.misses.Load(),
+		Evictions: Evictions{
+			Capacity: c.capacity.Load(),
+			Memory:   c.memory.Load(),
+			Expired:  c.expired.Load(),
+		},
+	}
+
+	for _, s := range c.shards {
+		s.mutex.Lock()
+		stats.Entries += int64(len(s.entries))
+		stats.Bytes += s.bytes
+		s.mutex.Unlock()
+	}
+	return stats
+}
+
+// Close stops the cleanup routine. The entries are kept, so in-flight requests are still served.
+func (c *manager) Close() error {
+	c.once.Do(func() {
+		c.ticker.Stop()
+		close(c.done)
+	})
+	return nil
+}
+
+// evict drops entries from the shard until an entry of the given size fits within its limits.
+// Expired entries are counted as such.
+func (c *manager) evict(s *shard, size int64) {
+	for len(s.entries) > 0 {
+		overCapacity := s.maxEntries > 0 && len(s.entries)+1 > s.maxEntries
+		overMemory := s.maxBytes > 0 && s.bytes+size > s.maxBytes
+		if !overCapacity && !overMemory {
+			return
+		}
+
+		e := s.queue.items[0]
+		s.remove(e)
+		switch {
+		case time.Now().After(e.Expiration):
+			c.expired.Add(1)
+		case overCapacity:
+			c.capacity.Add(1)
+		default:
+			c.memory.Add(1)
+		}
+	}
+}
+
+// shard returns the shard holding the key, hashing it with FNV-1a.
+func (c *manager) shard(key string) *shard {
+	const (
+		offset = 2166136261
+		prime  = 16777619
+	)
+
+	hash := uint32(offset)
+	for i := 0; i < len(key); i++ {
+		hash ^= uint32(key[i])
+		hash *= prime
+	}
+	return c.shards[hash%uint32(len(c.shards))]
 }
 
 // startCleanupRoutine periodically cleans up expired entries from the cache
 func (c *manager) startCleanupRoutine() {
-	for range c.ticker.C {
-		c.cleanupExpiredEntries()
+	for {
+		select {
+		case <-c.ticker.C:
+			c.cleanupExpiredEntries()
+		case <-c.done:
+			return
+		}
 	}
 }
 
-// cleanupExpiredEntries removes entries from the cache that have expired
+// cleanupExpiredEntries removes entries from the cache that have expired, one shard at a time
 func (c *manager) cleanupExpiredEntries() {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-
-	currentTime := time.Now()
-	for key, entry := range c.cache {
-		if currentTime.After(entry.Expiration) {
-			delete(c.cache, key)
+	for _, s := range c.shards {
+		s.mutex.Lock()
+		currentTime := time.Now()
+		for _, e := range s.entries {
+			if currentTime.After(e.Expiration) {
+				s.remove(e)
+				c.expired.Add(1)
+			}
 		}
+		s.mutex.Unlock()
+	}
+}
+
+// touch marks the entry as the most recently used of the shard.
+func (s *shard) touch(e *entry) {
+	s.clock++
+	e.lastUsed = s.clock
+}
+
+// remove drops the entry from the shard.
+func (s *shard) remove(e *entry) {
+	heap.Remove(&s.queue, e.index)
+	delete(s.entries, e.Key)
+	s.bytes -= e.size()
+}
+
+// splitLimit divides a limit between the shards, rounding up so small limits still allow one entry per shard.
+func splitLimit(limit int64, shards int) int {
+	if limit <= 0 {
+		return 0
+	}
+	return int((limit + int64(shards) - 1) / int64(shards))
+}
+
+// Len implements heap.Interface.
+func (q evictionQueue) Len() int { return len(q.items) }
+
+// Less orders the entries by use count under LFU, then by last use.
+func (q evictionQueue) Less(i, j int) bool {
+	a, b := q.items[i], q.items[j]
+	if q.lfu && a.hits != b.hits {
+		return a.hits < b.hits
 	}
+	return a.lastUsed < b.lastUsed
+}
+
+// Swap implements heap.Interface.
+func (q evictionQueue) Swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.items[i].index = i
+	q.items[j].index = j
+}
+
+// Push implements heap.Interface.
+func (q *evictionQueue) Push(x any) {
+	e := x.(*entry)
+	e.index = len(q.items)
+	q.items = append(q.items, e)
+}
+
+// Pop implements heap.Interface.
+func (q *evictionQueue) Pop() any {
+	last := len(q.items) - 1
+	e := q.items[last]
+	q.items[last] = nil
+	q.items = q.items[:last]
+	return e
 }
@@ -1,6 +1,9 @@
 package cache
 
 import (
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -23,12 +26,12 @@ func (suite *CacheSuite) TestSetAndGet() {
 		expiration = 2 * time.Second
 	)
 
-	suite.cacheManager.Set(key, data, expiration)
+	suite.cacheManager.Set(key, []byte(data), expiration)
 
 	cachedData, found := suite.cacheManager.Get(key)
 
 	suite.True(found)
-	suite.Equal(data, cachedData)
+	suite.Equal([]byte(data), cachedData)
 }
 
 func (suite *CacheSuite) TestExpiredEntryNotReturned() {
@@ -38,7 +41,7 @@ func (suite *CacheSuite) TestExpiredEntryNotReturned() {
 		expiration = 1 * time.Second
 	)
 
-	suite.cacheManager.Set(key, data, expiration)
+	suite.cacheManager.Set(key, []byte(data), expiration)
 
 	// Wait for the entry to expire
 	time.Sleep(2 * time.Second)
@@ -49,6 +52,198 @@ func (suite *CacheSuite) TestExpiredEntryNotReturned() {
 	suite.Nil(cachedData)
 }
 
+func (suite *CacheSuite) TestSetNonPositiveExpirationRemovesEntry() {
+	suite.cacheManager.Set("testKey", []byte("testData"), time.Minute)
+	suite.cacheManager.Set("testKey", nil, 0)
+
+	_, found := suite.cacheManager.Get("testKey")
+	suite.False(found)
+}
+
+func (suite *CacheSuite) TestCachedDataIsCopied() {
+	data := []byte("testData")
+	suite.cacheManager.Set("testKey", data, time.Minute)
+	data[0] = 'T'
+
+	cachedData, found := suite.cacheManager.Get("testKey")
+	suite.True(found)
+	suite.Equal([]byte("testData"), cachedData)
+}
+
+func (suite *CacheSuite) TestLRUEvictsLeastRecentlyUsed() {
+	cacheManager := NewBoundedManager(time.Minute, Limits{MaxEntries: 2, Eviction: LRU, Shards: 1})
+
+	cacheManager.Set("a", []byte("a"), time.Minute)
+	cacheManager.Set("b", []byte("b"), time.Minute)
+	cacheManager.Get("a")
+	cacheManager.Set("c", []byte("c"), time.Minute)
+
+	_, found := cacheManager.Get("b")
+	suite.False(found)
+	_, found = cacheManager.Get("a")
+	suite.True(found)
+	_, found = cacheManager.Get("c")
+	suite.True(found)
+	suite.Equal(Evictions{Capacity: 1}, cacheManager.Stats().Evictions)
+}
+
+func (suite *CacheSuite) TestLFUEvictsLeastFrequentlyUsed() {
+	cacheManager := NewBoundedManager(time.Minute, Limits{MaxEntries: 2, Eviction: LFU, Shards: 1})
+
+	cacheManager.Set("a", []byte("a"), time.Minute)
+	cacheManager.Set("b", []byte("b"), time.Minute)
+	cacheManager.Get("a")
+	cacheManager.Get("a")
+	cacheManager.Get("b")
+	cacheManager.Set("c", []byte("c"), time.Minute)
+	cacheManager.Set("d", []byte("d"), time.Minute)
+
+	_, found := cacheManager.Get("a")
+	suite.True(found)
+	_, found = cacheManager.Get("b")
+	suite.False(found)
+	_, found = cacheManager.Get("c")
+	suite.False(found)
+	_, found = cacheManager.Get("d")
+	suite.True(found)
+	suite.Equal(Evictions{Capacity: 2}, cacheManager.Stats().Evictions)
+}
+
+func (suite *CacheSuite) TestByteBudget() {
+	value := []byte(strings.Repeat("x", 100))
+	cacheManager := NewBoundedManager(time.Minute, Limits{MaxBytes: 3 * (100 + 1 + entryOverhead), Shards: 1})
+
+	for _, key := range []string{"a", "b", "c", "d"} {
+		cacheManager.Set(key, value, time.Minute)
+	}
+
+	_, found := cacheManager.Get("a")
+	suite.False(found)
+	for _, key := range []string{"b", "c", "d"} {
+		_, found := cacheManager.Get(key)
+		suite.True(found, key)
+	}
+
+	cacheManager.Set("e", []byte(strings.Repeat("x", 1000)), time.Minute)
+	_, found = cacheManager.Get("e")
+	suite.False(found, "Expected values larger than the budget not to be stored")
+	_, found = cacheManager.Get("d")
+	suite.True(found, "Expected values larger than the budget not to evict other entries")
+	suite.Equal(Evictions{Memory: 2}, cacheManager.Stats().Evictions)
+}
+
+func (suite *CacheSuite) TestOverwriteKeepsAccounting() {
+	cacheManager := NewBoundedManager(time.Minute, Limits{MaxEntries: 2, Shards: 1})
+
+	for i := 0; i < 10; i++ {
+		cacheManager.Set("a", []byte(fmt.Sprint(i)), time.Minute)
+	}
+	cacheManager.Set("b", []byte("b"), time.Minute)
+
+	cachedData, found := cacheManager.Get("a")
+	suite.True(found)
+	suite.Equal([]byte("9"), cachedData)
+	suite.Equal(Evictions{}, cacheManager.Stats().Evictions)
+}
+
+func (suite *CacheSuite) TestExpiredEntriesCounted() {
+	cacheManager := NewBoundedManager(10*time.Millisecond, Limits{})
+
+	cacheManager.Set("a", []byte("a"), time.Millisecond)
+	cacheManager.Set("b", []byte("b"), time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+
+	_, found := cacheManager.Get("a")
+	suite.False(found)
+	suite.Equal(Evictions{Expired: 2}, cacheManager.Stats().Evictions)
+}
+
+func (suite *CacheSuite) TestConcurrentAccess() {
+	cacheManager := NewBoundedManager(time.Minute, Limits{MaxEntries: 100, Eviction: LFU})
+
+	wg := sync.WaitGroup{}
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := fmt.Sprintf("%d-%d", worker, i%50)
+				cacheManager.Set(key, []byte(key), time.Minute)
+				cacheManager.Get(key)
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	stored := 0
+	for worker := 0; worker < 8; worker++ {
+		for i := 0; i < 50; i++ {
+			if _, found := cacheManager.Get(fmt.Sprintf("%d-%d", worker, i)); found {
+				stored++
+			}
+		}
+	}
+	suite.LessOrEqual(stored, 100+DefaultShards)
+	suite.Positive(stored)
+}
+
+func (suite *CacheSuite) TestDelete() {
+	suite.cacheManager.Set("user:1:a", []byte("a"), time.Minute)
+	suite.cacheManager.Set("user:1:b", []byte("b"), time.Minute)
+	suite.cacheManager.Set("user:2:a", []byte("a"), time.Minute)
+	suite.cacheManager.Set("other", []byte("c"), time.Minute)
+
+	suite.cacheManager.Delete("other")
+	_, found := suite.cacheManager.Get("other")
+	suite.False(found)
+
+	suite.Equal(2, suite.cacheManager.DeleteByPrefix("user:1:"))
+	_, found = suite.cacheManager.Get("user:1:a")
+	suite.False(found)
+	_, found = suite.cacheManager.Get("user:2:a")
+	suite.True(found)
+
+	suite.cacheManager.Clear()
+	_, found = suite.cacheManager.Get("user:2:a")
+	suite.False(found)
+	suite.Zero(suite.cacheManager.Stats().Entries)
+	suite.Zero(suite.cacheManager.Stats().Bytes)
+}
+
+func (suite *CacheSuite) TestTTL() {
+	suite.cacheManager.Set("testKey", []byte("testData"), time.Minute)
+
+	ttl, found := suite.cacheManager.TTL("testKey")
+	suite.True(found)
+	suite.InDelta(time.Minute, ttl, float64(time.Second))
+
+	_, found = suite.cacheManager.TTL("missingKey")
+	suite.False(found)
+}
+
+func (suite *CacheSuite) TestStats() {
+	suite.cacheManager.Set("a", []byte("testData"), time.Minute)
+	suite.cacheManager.Get("a")
+	suite.cacheManager.Get("a")
+	suite.cacheManager.Get("b")
+
+	stats := suite.cacheManager.Stats()
+	suite.Equal(uint64(2), stats.Hits)
+	suite.Equal(uint64(1), stats.Misses)
+	suite.Equal(int64(1), stats.Entries)
+	suite.Equal(int64(len("a")+len("testData")+entryOverhead), stats.Bytes)
+}
+
+func (suite *CacheSuite) TestClose() {
+	suite.cacheManager.Set("testKey", []byte("testData"), time.Minute)
+
+	suite.NoError(suite.cacheManager.Close())
+	suite.NoError(suite.cacheManager.Close(), "Expected Close to be idempotent")
+
+	_, found := suite.cacheManager.Get("testKey")
+	suite.True(found)
+}
+
 func TestCacheSuite(t *testing.T) {
 	suite.Run(t, new(CacheSuite))
 }
@@ -0,0 +1,191 @@
+package cache
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"luizalabs-technical-test/pkg/logger"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TbCacheEntry defines the name of the table storing the entries of the Postgres cache.
+const TbCacheEntry = "Tb_Cache_Entry"
+
+// postgresEntry represents a single entry of the Postgres cache.
+type postgresEntry struct {
+	Key       string `gorm:"column:cache_key;type:text;primaryKey"`
+	Data      []byte
+	ExpiresAt time.Time `gorm:"index"`
+}
+
+// TableName returns the name of the table for the postgresEntry model.
+func (postgresEntry) TableName() string {
+	return TbCacheEntry
+}
+
+// postgresManager is an implementation of the Manager interface backed by a database table,
+// so the cache is shared by every replica of the application without any other server.
+type postgresManager struct {
+	db       *gorm.DB
+	ticker   *time.Ticker
+	done     chan struct{}
+	once     *sync.Once
+	counters counters
+	expired  *atomic.Uint64
+}
+
+// NewPostgresManager creates a new Manager storing the entries in the cache table, which is migrated on creation.
+// Expired entries are ignored on read and deleted at every cleanup interval.
+func NewPostgresManager(db *gorm.DB, cleanupInterval time.Duration) (Manager, error) {
+	if err := db.AutoMigrate(&postgresEntry{}); err != nil {
+		return nil, fmt.Errorf("failed to migrate the cache table: %w", err)
+	}
+
+	cacheManager := &postgresManager{
+		db:       db,
+		ticker:   time.NewTicker(cleanupInterval),
+		done:     make(chan struct{}),
+		once:     &sync.Once{},
+		counters: newCounters(),
+		expired:  &atomic.Uint64{},
+	}
+	go cacheManager.startCleanupRoutine()
+
+	return cacheManager, nil
+}
+
+// Get retrieves the cached data associated with the specified key, unless it expired.
+func (p *postgresManager) Get(key string) ([]byte, bool) {
+	var cached postgresEntry
+
+	tx := p.db.Where("cache_key = ? AND expires_at > ?", key, time.Now()).Limit(1).Find(&cached)
+	if err := tx.Error; err != nil {
+		logger.Error(err)
+	}
+	found := tx.Error == nil && tx.RowsAffected > 0
+	p.counters.record(found)
+	if !found {
+		return nil, false
+	}
+
+	return cached.Data, true
+}
+
+// Set stores the data under the specified key, replacing any previous entry.
+func (p *postgresManager) Set(key string, data []byte, expiration time.Duration) {
+	if expiration <= 0 {
+		p.deleteWhere("cache_key = ?", key)
+		return
+	}
+
+	cached := postgresEntry{Key: key, Data: data, ExpiresAt: time.Now().Add(expiration)}
+	err := p.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "cache_key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"data", "expires_at"}),
+	}).Create(&cached).Error
+	if err != nil {
+		logger.Error(err)
+	}
+}
+
+// Delete removes the entry of the key.
+func (p *postgresManager) Delete(key string) {
+	p.deleteWhere("cache_key = ?", key)
+}
+
+// DeleteByPrefix removes the entries whose key starts with the prefix.
+func (p *postgresManager) DeleteByPrefix(prefix string) int {
+	return int(p.deleteWhere(`cache_key LIKE ? ESCAPE '\'`, escapeLike(prefix)+"%"))
+}
+
+// Clear removes every entry of the cache table.
+func (p *postgresManager) Clear() {
+	p.deleteWhere("1 = 1")
+}
+
+// TTL returns the time left before the entry of the key expires.
+func (p *postgresManager) TTL(key string) (time.Duration, bool) {
+	var cached postgresEntry
+
+	tx := p.db.Select("expires_at").Where("cache_key = ? AND expires_at > ?", key, time.Now()).Limit(1).Find(&cached)
+	if err := tx.Error; err != nil {
+		logger.Error(err)
+		return 0, false
+	}
+	if tx.RowsAffected == 0 {
+		return 0, false
+	}
+
+	return time.Until(cached.ExpiresAt), true
+}
+
+// Stats returns the hits and misses of the process, and the size of the unexpired entries of the table.
+func (p *postgresManager) Stats() Stats {
+	stats := Stats{
+		Hits:      p.counters.hits.Load(),
+		Misses:    p.counters.misses.Load(),
+		Evictions: Evictions{Expired: p.expired.Load()},
+	}
+
+	var size struct {
+		Entries int64
+		Bytes   int64
+	}
+	err := p.db.Model(&postgresEntry{}).
+		Select("COUNT(*) AS entries, COALESCE(SUM(LENGTH(cache_key) + LENGTH(data)), 0) AS bytes").
+		Where("expires_at > ?", time.Now()).
+		Scan(&size).Error
+	if err != nil {
+		logger.Error(err)
+		return stats
+	}
+
+	stats.Entries, stats.Bytes = size.Entries, size.Bytes
+	return stats
+}
+
+// Close stops the cleanup routine. The database connection is owned by the caller, so it is left open.
+func (p *postgresManager) Close() error {
+	p.once.Do(func() {
+		p.ticker.Stop()
+		close(p.done)
+	})
+	return nil
+}
+
+// startCleanupRoutine periodically deletes the expired entries from the cache table.
+func (p *postgresManager) startCleanupRoutine() {
+	for {
+		select {
+		case <-p.ticker.C:
+			p.cleanupExpiredEntries()
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// cleanupExpiredEntries deletes the entries that have expired.
+func (p *postgresManager) cleanupExpiredEntries() {
+	p.expired.Add(uint64(p.deleteWhere("expires_at <= ?", time.Now())))
+}
+
+// deleteWhere deletes the entries matching the condition, returning how many were deleted.
+func (p *postgresManager) deleteWhere(query string, args ...interface{}) int64 {
+	tx := p.db.Where(query, args...).Delete(&postgresEntry{})
+	if err := tx.Error; err != nil {
+		logger.Error(err)
+		return 0
+	}
+	return tx.RowsAffected
+}
+
+// escapeLike escapes the wildcards of LIKE patterns, so prefixes are matched literally.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type PostgresManagerSuite struct {
+	suite.Suite
+	db           *gorm.DB
+	ctx          context.Context
+	cacheManager Manager
+}
+
+func (suite *PostgresManagerSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	// Start PostgreSQL container
+	req := testcontainers.ContainerRequest{
+		Image:        "postgres:latest",
+		ExposedPorts: []string{"5432/tcp"},
+		Env: map[string]string{
+			"POSTGRES_USER":     "testuser",
+			"POSTGRES_PASSWORD": "testpass",
+			"POSTGRES_DB":       "testdb",
+		},
+		WaitingFor: wait.ForListeningPort("5432/tcp"),
+	}
+
+	// Create and start the container
+	postgresContainer, err := testcontainers.GenericContainer(suite.ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	suite.Require().NoError(err)
+
+	// Get the port and create the database connection
+	host, _ := postgresContainer.Host(suite.ctx)
+	port, _ := postgresContainer.MappedPort(suite.ctx, "5432")
+
+	dsn := "host=" + host + " port=" + port.Port() + " user=testuser password=testpass dbname=testdb sslmode=disable"
+	suite.db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	suite.cacheManager, err = NewPostgresManager(suite.db, time.Hour)
+	suite.Require().NoError(err)
+}
+
+func (suite *PostgresManagerSuite) TearDownSuite() {
+	db, err := suite.db.DB()
+	suite.Require().NoError(err)
+	db.Close()
+}
+
+func (suite *PostgresManagerSuite) TestSetAndGet() {
+	suite.cacheManager.Set("testKey", []byte("first"), time.Minute)
+	suite.cacheManager.Set("testKey", []byte("second"), time.Minute)
+
+	cachedData, found := suite.cacheManager.Get("testKey")
+	suite.True(found)
+	suite.Equal([]byte("second"), cachedData)
+
+	suite.cacheManager.Set("testKey", nil, 0)
+	_, found = suite.cacheManager.Get("testKey")
+	suite.False(found)
+}
+
+func (suite *PostgresManagerSuite) TestExpiredEntries() {
+	suite.cacheManager.Set("expiredKey", []byte("testData"), time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+
+	_, found := suite.cacheManager.Get("expiredKey")
+	suite.False(found)
+
+	suite.cacheManager.(*postgresManager).cleanupExpiredEntries()
+	var total int64
+	suite.NoError(suite.db.Model(&postgresEntry{}).Where("cache_key = ?", "expiredKey").Count(&total).Error)
+	suite.Zero(total)
+}
+
+func (suite *PostgresManagerSuite) TestDelete() {
+	for _, key := range []string{"user:1:a", "user:1:b", "user:%:a", "user_1:a", "other"} {
+		suite.cacheManager.Set(key, []byte("testData"), time.Minute)
+	}
+
+	suite.cacheManager.Delete("other")
+	_, found := suite.cacheManager.Get("other")
+	suite.False(found)
+
+	suite.Equal(2, suite.cacheManager.DeleteByPrefix("user:1:"))
+	suite.Equal(1, suite.cacheManager.DeleteByPrefix("user:%"), "Expected the prefix to be matched literally")
+	_, found = suite.cacheManager.Get("user_1:a")
+	suite.True(found)
+
+	suite.cacheManager.Clear()
+	suite.Zero(suite.cacheManager.Stats().Entries)
+}
+
+func (suite *PostgresManagerSuite) TestTTLAndStats() {
+	suite.cacheManager.Clear()
+	suite.cacheManager.Set("a", []byte("testData"), time.Minute)
+
+	ttl, found := suite.cacheManager.TTL("a")
+	suite.True(found)
+	suite.InDelta(time.Minute, ttl, float64(time.Second))
+
+	_, found = suite.cacheManager.TTL("b")
+	suite.False(found)
+
+	stats := suite.cacheManager.Stats()
+	suite.Equal(int64(1), stats.Entries)
+	suite.Equal(int64(len("a")+len("testData")), stats.Bytes)
+}
+
+func TestPostgresManagerSuite(t *testing.T) {
+	suite.Run(t, new(PostgresManagerSuite))
+}
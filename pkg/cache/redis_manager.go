@@ -0,0 +1,170 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"luizalabs-technical-test/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisManager is an implementation of the Manager interface backed by a Redis-protocol server,
+// so the cache is shared by every replica of the application.
+type redisManager struct {
+	client   redis.UniversalClient
+	timeout  time.Duration
+	counters counters
+}
+
+// scanBatchSize defines the amount of keys requested per SCAN call when deleting by prefix.
+const scanBatchSize = 1000
+
+// NewRedisManager creates a new Manager storing the entries in the server behind the client.
+// Entries expire through the TTL of the server, so no cleanup routine is needed.
+// Each call to the server is given up after the timeout (none when not positive), so a stalled server degrades into
+// misses instead of blocking the requests. The client must enable ContextTimeoutEnabled for the timeout to cut short
+// a pending reply.
+func NewRedisManager(client redis.UniversalClient, timeout time.Duration) Manager {
+	return &redisManager{client: client, timeout: timeout, counters: newCounters()}
+}
+
+// Get retrieves the cached data associated with the specified key.
+func (r *redisManager) Get(key string) ([]byte, bool) {
+	ctx, cancel := r.context()
+	defer cancel()
+
+	data, err := r.client.Get(ctx, key).Bytes()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		logger.Error(err)
+	}
+	r.counters.record(err == nil)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Set stores the data under the specified key with the expiration as TTL.
+func (r *redisManager) Set(key string, data []byte, expiration time.Duration) {
+	ctx, cancel := r.context()
+	defer cancel()
+
+	var err error
+	if expiration <= 0 {
+		err = r.client.Del(ctx, key).Err()
+	} else {
+		// Note: the server only accepts whole milliseconds, shorter expirations would be rejected.
+		err = r.client.Set(ctx, key, data, max(expiration, time.Millisecond)).Err()
+	}
+	if err != nil {
+		logger.Error(err)
+	}
+}
+
+// Delete removes the entry of the key.
+func (r *redisManager) Delete(key string) {
+	r.Set(key, nil, 0)
+}
+
+// DeleteByPrefix scans the keys matching the prefix, deleting them batch by batch.
+// Note: SCAN doesn't block the server like KEYS, but keys written during the scan may be missed.
+func (r *redisManager) DeleteByPrefix(prefix string) int {
+	pattern := escapeGlob(prefix) + "*"
+
+	removed := 0
+	var cursor uint64
+	for {
+		deleted, next, err := r.deleteBatch(cursor, pattern)
+		removed += deleted
+		if err != nil {
+			logger.Error(err)
+			return removed
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return removed
+		}
+	}
+}
+
+// deleteBatch scans a batch of keys matching the pattern from the cursor and deletes them, each batch being given
+// its own timeout.
+func (r *redisManager) deleteBatch(cursor uint64, pattern string) (int, uint64, error) {
+	ctx, cancel := r.context()
+	defer cancel()
+
+	keys, next, err := r.client.Scan(ctx, cursor, pattern, scanBatchSize).Result()
+	if err != nil || len(keys) == 0 {
+		return 0, next, err
+	}
+
+	deleted, err := r.client.Del(ctx, keys...).Result()
+	return int(deleted), next, err
+}
+
+// Clear removes every key of the selected database, which is expected to be dedicated to the cache.
+func (r *redisManager) Clear() {
+	ctx, cancel := r.context()
+	defer cancel()
+
+	if err := r.client.FlushDB(ctx).Err(); err != nil {
+		logger.Error(err)
+	}
+}
+
+// TTL returns the time left before the server expires the key.
+func (r *redisManager) TTL(key string) (time.Duration, bool) {
+	ctx, cancel := r.context()
+	defer cancel()
+
+	ttl, err := r.client.PTTL(ctx, key).Result()
+	if err != nil {
+		logger.Error(err)
+		return 0, false
+	}
+
+	// Note: the server answers negative durations for missing keys and keys without expiration.
+	if ttl <= 0 {
+		return 0, false
+	}
+	return ttl, true
+}
+
+// Stats returns the hits and misses of the process, and the amount of keys of the selected database.
+// Note: evictions and memory usage are managed by the server, see its INFO command.
+func (r *redisManager) Stats() Stats {
+	stats := Stats{Hits: r.counters.hits.Load(), Misses: r.counters.misses.Load()}
+
+	ctx, cancel := r.context()
+	defer cancel()
+
+	entries, err := r.client.DBSize(ctx).Result()
+	if err != nil {
+		logger.Error(err)
+		return stats
+	}
+	stats.Entries = entries
+	return stats
+}
+
+// Close closes the connections of the client.
+func (r *redisManager) Close() error {
+	return r.client.Close()
+}
+
+// context returns the context of a call to the server, done once the timeout is over.
+func (r *redisManager) context() (context.Context, context.CancelFunc) {
+	if r.timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), r.timeout)
+}
+
+// escapeGlob escapes the characters with a special meaning in the patterns of the server.
+func escapeGlob(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`, "]", `\]`).Replace(value)
+}
@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type RedisManagerSuite struct {
+	suite.Suite
+	server       *miniredis.Miniredis
+	cacheManager Manager
+}
+
+func (suite *RedisManagerSuite) SetupTest() {
+	suite.server = miniredis.RunT(suite.T())
+	suite.cacheManager = NewRedisManager(redis.NewClient(&redis.Options{
+		Addr:                  suite.server.Addr(),
+		ContextTimeoutEnabled: true,
+	}), 100*time.Millisecond)
+}
+
+func (suite *RedisManagerSuite) TestSetAndGet() {
+	suite.cacheManager.Set("testKey", []byte("testData"), time.Minute)
+
+	cachedData, found := suite.cacheManager.Get("testKey")
+	suite.True(found)
+	suite.Equal([]byte("testData"), cachedData)
+	suite.Equal(time.Minute, suite.server.TTL("testKey"))
+}
+
+func (suite *RedisManagerSuite) TestExpiredEntryNotReturned() {
+	suite.cacheManager.Set("testKey", []byte("testData"), time.Second)
+	suite.server.FastForward(2 * time.Second)
+
+	cachedData, found := suite.cacheManager.Get("testKey")
+	suite.False(found)
+	suite.Nil(cachedData)
+}
+
+func (suite *RedisManagerSuite) TestSetNonPositiveExpirationRemovesEntry() {
+	suite.cacheManager.Set("testKey", []byte("testData"), time.Minute)
+	suite.cacheManager.Set("testKey", nil, -time.Second)
+
+	_, found := suite.cacheManager.Get("testKey")
+	suite.False(found)
+	suite.False(suite.server.Exists("testKey"))
+}
+
+func (suite *RedisManagerSuite) TestUnreachableServerIsAMiss() {
+	suite.server.Close()
+
+	suite.cacheManager.Set("testKey", []byte("testData"), time.Minute)
+	_, found := suite.cacheManager.Get("testKey")
+	suite.False(found)
+}
+
+func (suite *RedisManagerSuite) TestStalledServerIsAMiss() {
+	suite.cacheManager.Set("testKey", []byte("testData"), time.Minute)
+
+	// Note: the server doesn't answer any command while locked.
+	suite.server.Lock()
+	defer suite.server.Unlock()
+
+	start := time.Now()
+	_, found := suite.cacheManager.Get("testKey")
+	suite.False(found)
+	suite.Less(time.Since(start), time.Second)
+}
+
+func (suite *RedisManagerSuite) TestDelete() {
+	for _, key := range []string{"user:1:a", "user:1:b", "user:*:a", "user:2:a", "other"} {
+		suite.cacheManager.Set(key, []byte("testData"), time.Minute)
+	}
+
+	suite.cacheManager.Delete("other")
+	suite.False(suite.server.Exists("other"))
+
+	suite.Equal(2, suite.cacheManager.DeleteByPrefix("user:1:"))
+	suite.Equal(1, suite.cacheManager.DeleteByPrefix("user:*"), "Expected the prefix to be matched literally")
+	suite.Equal([]string{"user:2:a"}, suite.server.Keys())
+
+	suite.cacheManager.Clear()
+	suite.Empty(suite.server.Keys())
+}
+
+func (suite *RedisManagerSuite) TestTTL() {
+	suite.cacheManager.Set("testKey", []byte("testData"), time.Minute)
+
+	ttl, found := suite.cacheManager.TTL("testKey")
+	suite.True(found)
+	suite.Equal(time.Minute, ttl)
+
+	_, found = suite.cacheManager.TTL("missingKey")
+	suite.False(found)
+}
+
+func (suite *RedisManagerSuite) TestStats() {
+	suite.cacheManager.Set("a", []byte("testData"), time.Minute)
+	suite.cacheManager.Get("a")
+	suite.cacheManager.Get("b")
+
+	stats := suite.cacheManager.Stats()
+	suite.Equal(Stats{Hits: 1, Misses: 1, Entries: 1}, stats)
+}
+
+func (suite *RedisManagerSuite) TestClose() {
+	suite.NoError(suite.cacheManager.Close())
+
+	_, found := suite.cacheManager.Get("testKey")
+	suite.False(found)
+}
+
+func TestRedisManagerSuite(t *testing.T) {
+	suite.Run(t, new(RedisManagerSuite))
+}
@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Snapshotter is implemented by the backends local to the process, whose entries would otherwise be lost on restart.
+// Snapshots are JSON lines, one unexpired entry per line with its expiration, so the TTLs survive the restart.
+type Snapshotter interface {
+	// Snapshot writes the unexpired entries, returning how many were written.
+	Snapshot(w io.Writer) (int, error)
+	// Restore loads the entries of a snapshot, skipping the ones expired since, and returns how many were loaded.
+	Restore(r io.Reader) (int, error)
+}
+
+// snapshotEntry represents a line of a snapshot.
+type snapshotEntry struct {
+	Key       string    `json:"key"`
+	Data      []byte    `json:"data"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Snapshot writes the unexpired entries of the cache, one shard at a time, so requests are only held by one shard.
+func (c *manager) Snapshot(w io.Writer) (int, error) {
+	writer := bufio.NewWriter(w)
+	encoder := json.NewEncoder(writer)
+
+	written := 0
+	for _, s := range c.shards {
+		s.mutex.Lock()
+		now := time.Now()
+		entries := make([]snapshotEntry, 0, len(s.entries))
+		for _, e := range s.entries {
+			if now.Before(e.Expiration) {
+				entries = append(entries, snapshotEntry{Key: e.Key, Data: e.Data, ExpiresAt: e.Expiration})
+			}
+		}
+		s.mutex.Unlock()
+
+		// Note: the data slices are never changed in place, so they are encoded after the shard is released.
+		for _, e := range entries {
+			if err := encoder.Encode(e); err != nil {
+				return written, err
+			}
+			written++
+		}
+	}
+	return written, writer.Flush()
+}
+
+// Restore loads the entries of the snapshot through Set, so the limits of the cache still apply.
+func (c *manager) Restore(r io.Reader) (int, error) {
+	decoder := json.NewDecoder(r)
+
+	restored := 0
+	for {
+		var e snapshotEntry
+		if err := decoder.Decode(&e); err != nil {
+			if errors.Is(err, io.EOF) {
+				return restored, nil
+			}
+			return restored, fmt.Errorf("malformed cache snapshot: %w", err)
+		}
+
+		if ttl := time.Until(e.ExpiresAt); ttl > 0 {
+			c.Set(e.Key, e.Data, ttl)
+			restored++
+		}
+	}
+}
+
+// SaveSnapshot writes the snapshot of the cache to the file at path, returning how many entries were saved.
+// Note: the snapshot is written to a temporary file renamed over the previous one, so a crash never leaves it truncated.
+func SaveSnapshot(snapshotter Snapshotter, path string) (int, error) {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(file.Name())
+
+	saved, err := snapshotter.Snapshot(file)
+	if err != nil {
+		file.Close()
+		return 0, err
+	}
+	if err := file.Close(); err != nil {
+		return 0, err
+	}
+	return saved, os.Rename(file.Name(), path)
+}
+
+// LoadSnapshot restores the snapshot saved at path into the cache, returning how many entries were loaded.
+// A missing file isn't an error, as there is nothing to restore on the first start.
+func LoadSnapshot(snapshotter Snapshotter, path string) (int, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	return snapshotter.Restore(file)
+}
@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSnapshotRestore tests that unexpired entries are restored with the TTL they had when saved.
+func TestSnapshotRestore(t *testing.T) {
+	source := NewManager(time.Minute)
+	source.Set("a", []byte("first"), time.Hour)
+	source.Set("b", []byte("second"), time.Minute)
+	source.Set("expired", []byte("third"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	buffer := &bytes.Buffer{}
+	written, err := source.(Snapshotter).Snapshot(buffer)
+	require.NoError(t, err)
+	assert.Equal(t, 2, written)
+	assert.Equal(t, 2, strings.Count(buffer.String(), "\n"), "Expected one JSON line per entry")
+
+	target := NewManager(time.Minute)
+	restored, err := target.(Snapshotter).Restore(buffer)
+	require.NoError(t, err)
+	assert.Equal(t, 2, restored)
+
+	data, found := target.Get("a")
+	assert.True(t, found)
+	assert.Equal(t, []byte("first"), data)
+
+	ttl, found := target.TTL("b")
+	assert.True(t, found)
+	assert.InDelta(t, time.Minute, ttl, float64(time.Second))
+
+	_, found = target.Get("expired")
+	assert.False(t, found)
+}
+
+// TestRestoreSkipsExpiredEntries tests that entries expired while the process was down aren't restored.
+func TestRestoreSkipsExpiredEntries(t *testing.T) {
+	snapshot := `{"key":"old","data":"ZGF0YQ==","expires_at":"2000-01-01T00:00:00Z"}` + "\n" +
+		`{"key":"new","data":"ZGF0YQ==","expires_at":"` + time.Now().Add(time.Hour).Format(time.RFC3339Nano) + `"}` + "\n"
+
+	cacheManager := NewManager(time.Minute)
+	restored, err := cacheManager.(Snapshotter).Restore(strings.NewReader(snapshot))
+	require.NoError(t, err)
+	assert.Equal(t, 1, restored)
+
+	data, found := cacheManager.Get("new")
+	assert.True(t, found)
+	assert.Equal(t, []byte("data"), data)
+}
+
+// TestRestoreMalformedSnapshot tests that the entries ahead of a malformed line are kept.
+func TestRestoreMalformedSnapshot(t *testing.T) {
+	snapshot := `{"key":"new","data":"ZGF0YQ==","expires_at":"` + time.Now().Add(time.Hour).Format(time.RFC3339Nano) + `"}` + "\n{"
+
+	cacheManager := NewManager(time.Minute)
+	restored, err := cacheManager.(Snapshotter).Restore(strings.NewReader(snapshot))
+	assert.Error(t, err)
+	assert.Equal(t, 1, restored)
+}
+
+// TestSaveAndLoadSnapshot tests the snapshot files, and that a missing file restores nothing.
+func TestSaveAndLoadSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.jsonl")
+
+	loaded, err := LoadSnapshot(NewManager(time.Minute).(Snapshotter), path)
+	require.NoError(t, err)
+	assert.Zero(t, loaded)
+
+	source := NewManager(time.Minute)
+	source.Set("key", []byte("data"), time.Hour)
+	saved, err := SaveSnapshot(source.(Snapshotter), path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, saved)
+
+	files, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, files, 1, "Expected the temporary file to be renamed")
+
+	target := NewManager(time.Minute)
+	loaded, err = LoadSnapshot(target.(Snapshotter), path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, loaded)
+
+	data, found := target.Get("key")
+	assert.True(t, found)
+	assert.Equal(t, []byte("data"), data)
+}
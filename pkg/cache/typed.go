@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"encoding/json"
+	"time"
+
+	"luizalabs-technical-test/pkg/logger"
+)
+
+// Typed stores values of type T in a Manager, encoded as JSON, so callers don't decode the cached bytes themselves.
+// Note: values that fail to encode aren't cached, and entries that fail to decode are reported as misses.
+type Typed[T any] struct {
+	manager Manager
+}
+
+// NewTyped creates a new Typed cache over the given manager.
+func NewTyped[T any](manager Manager) *Typed[T] {
+	return &Typed[T]{manager: manager}
+}
+
+// Get retrieves the value cached under the key.
+func (t *Typed[T]) Get(key string) (T, bool) {
+	var value T
+
+	data, found := t.manager.Get(key)
+	if !found {
+		return value, false
+	}
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		logger.Error(err)
+		var zero T
+		return zero, false
+	}
+	return value, true
+}
+
+// Set stores the value under the key for the given duration; non-positive durations remove the entry.
+func (t *Typed[T]) Set(key string, value T, expiration time.Duration) {
+	if expiration <= 0 {
+		t.manager.Delete(key)
+		return
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	t.manager.Set(key, data, expiration)
+}
+
+// Delete removes the value cached under the key.
+func (t *Typed[T]) Delete(key string) {
+	t.manager.Delete(key)
+}
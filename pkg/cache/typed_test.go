@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type typedValue struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type TypedSuite struct {
+	suite.Suite
+	cacheManager Manager
+	typed        *Typed[typedValue]
+}
+
+func (suite *TypedSuite) SetupTest() {
+	suite.cacheManager = NewManager(time.Minute)
+	suite.typed = NewTyped[typedValue](suite.cacheManager)
+}
+
+func (suite *TypedSuite) TestSetAndGet() {
+	suite.typed.Set("testKey", typedValue{Name: "test", Count: 2}, time.Minute)
+
+	value, found := suite.typed.Get("testKey")
+	suite.True(found)
+	suite.Equal(typedValue{Name: "test", Count: 2}, value)
+
+	cachedData, _ := suite.cacheManager.Get("testKey")
+	suite.JSONEq(`{"name":"test","count":2}`, string(cachedData))
+}
+
+func (suite *TypedSuite) TestUndecodableEntryIsAMiss() {
+	suite.cacheManager.Set("testKey", []byte("not json"), time.Minute)
+
+	value, found := suite.typed.Get("testKey")
+	suite.False(found)
+	suite.Zero(value)
+}
+
+func (suite *TypedSuite) TestDelete() {
+	suite.typed.Set("testKey", typedValue{Name: "test"}, time.Minute)
+	suite.typed.Delete("testKey")
+	_, found := suite.typed.Get("testKey")
+	suite.False(found)
+
+	suite.typed.Set("testKey", typedValue{Name: "test"}, time.Minute)
+	suite.typed.Set("testKey", typedValue{}, 0)
+	_, found = suite.typed.Get("testKey")
+	suite.False(found)
+}
+
+func TestTypedSuite(t *testing.T) {
+	suite.Run(t, new(TypedSuite))
+}
@@ -0,0 +1,140 @@
+package crypt
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// argon2idPrefix identifies argon2id hashes in the PHC string format.
+const argon2idPrefix = "$argon2id$"
+
+// Default argon2id parameters (RFC 9106, second recommended option), used for the unset fields of Argon2idParams.
+const (
+	defaultArgon2Memory      = 64 * 1024
+	defaultArgon2Iterations  = 3
+	defaultArgon2Parallelism = 2
+	defaultArgon2SaltLength  = 16
+	defaultArgon2KeyLength   = 32
+)
+
+// errInvalidArgon2idHash is returned when a hash doesn't follow the argon2id PHC string format.
+var errInvalidArgon2idHash = errors.New("invalid argon2id hash")
+
+// Argon2idParams holds the argon2id cost parameters. Memory is expressed in KiB.
+type Argon2idParams struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// argon2idHasher is an implementation of the PasswordHasher interface using argon2id.
+type argon2idHasher struct {
+	params Argon2idParams
+}
+
+// NewArgon2idHasher creates a new argon2id password hasher, filling unset parameters with their defaults.
+func NewArgon2idHasher(params Argon2idParams) PasswordHasher {
+	if params.Memory == 0 {
+		params.Memory = defaultArgon2Memory
+	}
+	if params.Iterations == 0 {
+		params.Iterations = defaultArgon2Iterations
+	}
+	if params.Parallelism == 0 {
+		params.Parallelism = defaultArgon2Parallelism
+	}
+	if params.SaltLength == 0 {
+		params.SaltLength = defaultArgon2SaltLength
+	}
+	if params.KeyLength == 0 {
+		params.KeyLength = defaultArgon2KeyLength
+	}
+	return &argon2idHasher{params}
+}
+
+// HashPassword hashes a password using argon2id, encoding the result in the PHC string format:
+// $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<key>
+func (a *argon2idHasher) HashPassword(password string) (string, error) {
+	salt := make([]byte, a.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, a.params.Iterations, a.params.Memory, a.params.Parallelism, a.params.KeyLength)
+	return encodeArgon2idHash(a.params, salt, key), nil
+}
+
+// CheckPasswordHash verifies if the provided password matches the hash.
+func (a *argon2idHasher) CheckPasswordHash(password, hash string) bool {
+	return checkPasswordHash(password, hash)
+}
+
+// NeedsRehash reports whether the hash uses another algorithm, version or set of parameters.
+func (a *argon2idHasher) NeedsRehash(hash string) bool {
+	version, params, _, _, err := decodeArgon2idHash(hash)
+	return err != nil || version != argon2.Version || params != a.params
+}
+
+// checkArgon2idHash derives the key with the parameters stored in the hash and compares it in constant time.
+func checkArgon2idHash(password, hash string) bool {
+	_, params, salt, key, err := decodeArgon2idHash(hash)
+	if err != nil {
+		return false
+	}
+
+	derivedKey := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	return subtle.ConstantTimeCompare(key, derivedKey) == 1
+}
+
+// encodeArgon2idHash formats the parameters, salt and key as a PHC string.
+func encodeArgon2idHash(params Argon2idParams, salt, key []byte) string {
+	return fmt.Sprintf(
+		"%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version, params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key),
+	)
+}
+
+// decodeArgon2idHash parses a PHC string, returning its version, parameters, salt and key.
+func decodeArgon2idHash(hash string) (int, Argon2idParams, []byte, []byte, error) {
+	var (
+		version int
+		params  Argon2idParams
+	)
+
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != AlgorithmArgon2id {
+		return 0, params, nil, nil, errInvalidArgon2idHash
+	}
+
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return 0, params, nil, nil, errInvalidArgon2idHash
+	}
+
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism)
+	if err != nil || params.Iterations == 0 || params.Parallelism == 0 {
+		return 0, params, nil, nil, errInvalidArgon2idHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return 0, params, nil, nil, errInvalidArgon2idHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return 0, params, nil, nil, errInvalidArgon2idHash
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+	return version, params, salt, key, nil
+}
@@ -0,0 +1,65 @@
+package crypt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testArgon2idParams keeps the argon2id cost low so the tests run fast.
+var testArgon2idParams = Argon2idParams{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestArgon2idHasher_HashPassword(t *testing.T) {
+	hasher := NewArgon2idHasher(testArgon2idParams)
+
+	hashedPassword, err := hasher.HashPassword("my_secret_password")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hashedPassword, "$argon2id$v=19$m=1024,t=1,p=1$"), hashedPassword)
+
+	other, err := hasher.HashPassword("my_secret_password")
+	assert.NoError(t, err)
+	assert.NotEqual(t, hashedPassword, other, "Expected salts to differ")
+}
+
+func TestArgon2idHasher_CheckPasswordHash(t *testing.T) {
+	hasher := NewArgon2idHasher(testArgon2idParams)
+
+	hashedPassword, err := hasher.HashPassword("my_secret_password")
+	assert.NoError(t, err)
+	assert.True(t, hasher.CheckPasswordHash("my_secret_password", hashedPassword))
+	assert.False(t, hasher.CheckPasswordHash("wrong_password", hashedPassword))
+	assert.False(t, hasher.CheckPasswordHash("my_secret_password", "$argon2id$v=19$m=1024,t=0,p=1$c2FsdA$a2V5"))
+
+	bcryptHash, err := NewBcryptHasher(bcrypt.MinCost).HashPassword("my_secret_password")
+	assert.NoError(t, err)
+	assert.True(t, hasher.CheckPasswordHash("my_secret_password", bcryptHash), "Expected legacy bcrypt hashes to be verified")
+}
+
+func TestArgon2idHasher_NeedsRehash(t *testing.T) {
+	hasher := NewArgon2idHasher(testArgon2idParams)
+
+	hashedPassword, err := hasher.HashPassword("my_secret_password")
+	assert.NoError(t, err)
+	assert.False(t, hasher.NeedsRehash(hashedPassword))
+
+	stronger := testArgon2idParams
+	stronger.Iterations = 2
+	assert.True(t, NewArgon2idHasher(stronger).NeedsRehash(hashedPassword), "Expected outdated parameters to be upgraded")
+
+	bcryptHash, err := NewBcryptHasher(bcrypt.MinCost).HashPassword("my_secret_password")
+	assert.NoError(t, err)
+	assert.True(t, hasher.NeedsRehash(bcryptHash), "Expected bcrypt hashes to be upgraded")
+}
+
+func TestNewArgon2idHasher_Defaults(t *testing.T) {
+	expected := &argon2idHasher{Argon2idParams{
+		Memory:      defaultArgon2Memory,
+		Iterations:  defaultArgon2Iterations,
+		Parallelism: defaultArgon2Parallelism,
+		SaltLength:  defaultArgon2SaltLength,
+		KeyLength:   defaultArgon2KeyLength,
+	}}
+	assert.Equal(t, expected, NewArgon2idHasher(Argon2idParams{}))
+}
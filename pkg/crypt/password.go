@@ -1,31 +1,65 @@
 package crypt
 
 import (
+	"strings"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
+// Supported password hashing algorithms.
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
+
+// BcryptMaxPasswordLength is the longest password bcrypt hashes, in bytes; longer passwords are rejected.
+const BcryptMaxPasswordLength = 72
+
 // PasswordHasher defines the interface for password hashing operations.
 type PasswordHasher interface {
 	HashPassword(password string) (string, error)
 	CheckPasswordHash(password, hash string) bool
+	NeedsRehash(hash string) bool
+}
+
+// checkPasswordHash verifies the password against a hash of any supported algorithm,
+// so stored hashes keep working after the configured algorithm changes.
+func checkPasswordHash(password, hash string) bool {
+	if strings.HasPrefix(hash, argon2idPrefix) {
+		return checkArgon2idHash(password, hash)
+	}
+
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	return err == nil
 }
 
-// passwordHasher is an implementation of the PasswordHasher interface using bcrypt.
-type passwordHasher struct{}
+// bcryptHasher is an implementation of the PasswordHasher interface using bcrypt.
+type bcryptHasher struct {
+	cost int
+}
 
-// NewPasswordHasher creates a new instance of BcryptPasswordHasher.
-func NewPasswordHasher() PasswordHasher {
-	return &passwordHasher{}
+// NewBcryptHasher creates a new bcrypt password hasher.
+// Note: costs outside of the bcrypt range fall back to bcrypt.DefaultCost.
+func NewBcryptHasher(cost int) PasswordHasher {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = bcrypt.DefaultCost
+	}
+	return &bcryptHasher{cost}
 }
 
 // HashPassword hashes a password using bcrypt.
-func (b *passwordHasher) HashPassword(password string) (string, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+func (b *bcryptHasher) HashPassword(password string) (string, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
 	return string(hashedPassword), err
 }
 
 // CheckPasswordHash verifies if the provided password matches the hash.
-func (b *passwordHasher) CheckPasswordHash(password, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	return err == nil
+func (b *bcryptHasher) CheckPasswordHash(password, hash string) bool {
+	return checkPasswordHash(password, hash)
+}
+
+// NeedsRehash reports whether the hash uses another algorithm or another bcrypt cost.
+func (b *bcryptHasher) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != b.cost
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // PasswordHasherSuite is a test suite for password hashing functionalities.
@@ -15,7 +16,7 @@ type PasswordHasherSuite struct {
 
 // SetupSuite sets up the test suite.
 func (s *PasswordHasherSuite) SetupSuite() {
-	s.hasher = &passwordHasher{}
+	s.hasher = NewBcryptHasher(bcrypt.MinCost)
 }
 
 // TestHashPassword tests the Hash function of the PasswordHasher.
@@ -44,6 +45,39 @@ func (s *PasswordHasherSuite) TestCheckPasswordHash() {
 	assert.False(s.T(), s.hasher.CheckPasswordHash("wrong_password", hashedPassword), "Expected wrong password not to match the hash")
 }
 
+// TestCheckPasswordHash_Argon2id tests that argon2id hashes are still verified by the bcrypt hasher.
+func (s *PasswordHasherSuite) TestCheckPasswordHash_Argon2id() {
+	// ARRANGE & ACT
+	hashedPassword, err := NewArgon2idHasher(Argon2idParams{Memory: 1024, Iterations: 1}).HashPassword("my_secret_password")
+	if err != nil {
+		s.T().Fatalf("Failed to hash password: %v", err)
+	}
+
+	// ASSERT
+	assert.True(s.T(), s.hasher.CheckPasswordHash("my_secret_password", hashedPassword))
+	assert.True(s.T(), s.hasher.NeedsRehash(hashedPassword), "Expected argon2id hash to be upgraded to bcrypt")
+}
+
+// TestNeedsRehash tests the detection of hashes using another bcrypt cost.
+func (s *PasswordHasherSuite) TestNeedsRehash() {
+	// ARRANGE & ACT
+	hashedPassword, err := s.hasher.HashPassword("my_secret_password")
+	if err != nil {
+		s.T().Fatalf("Failed to hash password: %v", err)
+	}
+
+	// ASSERT
+	assert.False(s.T(), s.hasher.NeedsRehash(hashedPassword), "Expected hash with current cost to be kept")
+	assert.True(s.T(), NewBcryptHasher(bcrypt.MinCost+1).NeedsRehash(hashedPassword), "Expected hash with outdated cost to be upgraded")
+	assert.True(s.T(), s.hasher.NeedsRehash("invalid-hash"))
+}
+
+// TestNewBcryptHasher_InvalidCost tests the fallback to the default cost.
+func (s *PasswordHasherSuite) TestNewBcryptHasher_InvalidCost() {
+	assert.Equal(s.T(), &bcryptHasher{bcrypt.DefaultCost}, NewBcryptHasher(bcrypt.MaxCost+1))
+	assert.Equal(s.T(), &bcryptHasher{bcrypt.DefaultCost}, NewBcryptHasher(0))
+}
+
 // TestPasswordHasherSuite runs the test suite.
 func TestPasswordHasherSuite(t *testing.T) {
 	suite.Run(t, new(PasswordHasherSuite))
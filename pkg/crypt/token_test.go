@@ -0,0 +1,25 @@
+package crypt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateRandomToken(t *testing.T) {
+	first, err := GenerateRandomToken(32)
+	assert.NoError(t, err)
+	assert.Len(t, first, 43)
+
+	second, err := GenerateRandomToken(32)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, second, "Expected random tokens to differ")
+}
+
+func TestHashToken(t *testing.T) {
+	hash := HashToken("my_token")
+
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, HashToken("my_token"), "Expected hash to be deterministic")
+	assert.NotEqual(t, hash, HashToken("other_token"))
+}
@@ -0,0 +1,34 @@
+package env
+
+import (
+	"strconv"
+	"time"
+)
+
+// ParseInt converts an environment value into an int, returning the fallback when it is empty or malformed.
+func ParseInt(value string, fallback int) int {
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return fallback
+	}
+	return parsed
+}
+
+// ParseDuration converts an environment value (e.g. "15m") into a time.Duration,
+// returning the fallback when it is empty or malformed.
+func ParseDuration(value string, fallback time.Duration) time.Duration {
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return fallback
+	}
+	return parsed
+}
+
+// ParseBool converts an environment value into a bool, returning the fallback when it is empty or malformed.
+func ParseBool(value string, fallback bool) bool {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return fallback
+	}
+	return parsed
+}
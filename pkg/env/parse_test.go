@@ -0,0 +1,30 @@
+package env_test
+
+import (
+	"luizalabs-technical-test/pkg/env"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseInt tests the ParseInt function with valid, empty and malformed values.
+func TestParseInt(t *testing.T) {
+	assert.Equal(t, 10, env.ParseInt("10", 5))
+	assert.Equal(t, 5, env.ParseInt("", 5))
+	assert.Equal(t, 5, env.ParseInt("ten", 5))
+}
+
+// TestParseDuration tests the ParseDuration function with valid, empty and malformed values.
+func TestParseDuration(t *testing.T) {
+	assert.Equal(t, 15*time.Minute, env.ParseDuration("15m", time.Minute))
+	assert.Equal(t, time.Minute, env.ParseDuration("", time.Minute))
+	assert.Equal(t, time.Minute, env.ParseDuration("15", time.Minute))
+}
+
+// TestParseBool tests the ParseBool function with valid, empty and malformed values.
+func TestParseBool(t *testing.T) {
+	assert.True(t, env.ParseBool("true", false))
+	assert.False(t, env.ParseBool("", false))
+	assert.True(t, env.ParseBool("yes", true))
+}
@@ -0,0 +1,13 @@
+package mail
+
+// Message represents a plain-text email.
+type Message struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Mailer defines the interface for sending emails.
+type Mailer interface {
+	Send(message Message) error
+}
@@ -0,0 +1,56 @@
+package mail
+
+import (
+	"encoding/json"
+	"fmt"
+	"luizalabs-technical-test/pkg/logger"
+	"os"
+	"sync"
+	"time"
+)
+
+// outboxEntry represents a message written to the outbox file.
+type outboxEntry struct {
+	Message
+	SentAt time.Time `json:"sent_at"`
+}
+
+// outboxMailer is an implementation of the Mailer interface that keeps messages locally instead of delivering them.
+// It is meant for local and test runs, where no SMTP server is available.
+type outboxMailer struct {
+	path  string
+	mutex *sync.Mutex
+}
+
+// NewOutboxMailer creates a new Mailer appending messages as JSON lines to the given file.
+// When no path is provided, messages are written to the application log instead.
+func NewOutboxMailer(path string) Mailer {
+	return &outboxMailer{path, &sync.Mutex{}}
+}
+
+// Send appends the message to the outbox.
+func (m *outboxMailer) Send(message Message) error {
+	line, err := json.Marshal(outboxEntry{message, time.Now()})
+	if err != nil {
+		return fmt.Errorf("failed to encode email to %s: %w", message.To, err)
+	}
+
+	if m.path == "" {
+		logger.Warn("outbox email: " + string(line))
+		return nil
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	file, err := os.OpenFile(m.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open outbox file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write email to outbox file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package mail
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOutboxMailerSend tests that messages are appended to the outbox file as JSON lines.
+func TestOutboxMailerSend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+	mailer := NewOutboxMailer(path)
+
+	require.NoError(t, mailer.Send(Message{To: "first@example.com", Subject: "First", Body: "1"}))
+	require.NoError(t, mailer.Send(Message{To: "second@example.com", Subject: "Second", Body: "2"}))
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var entries []outboxEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry outboxEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+
+	require.Len(t, entries, 2)
+	assert.Equal(t, "first@example.com", entries[0].To)
+	assert.Equal(t, "Second", entries[1].Subject)
+	assert.False(t, entries[1].SentAt.IsZero())
+}
+
+// TestOutboxMailerSend_Log tests that messages are logged when no outbox file is configured.
+func TestOutboxMailerSend_Log(t *testing.T) {
+	assert.NoError(t, NewOutboxMailer("").Send(Message{To: "user@example.com"}))
+}
@@ -0,0 +1,53 @@
+package mail
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// headerSanitizer strips line breaks from header values, preventing header injection.
+var headerSanitizer = strings.NewReplacer("\r", "", "\n", "")
+
+// sendMailFunc defines the signature of smtp.SendMail, allowing the delivery to be mocked in tests.
+type sendMailFunc func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+
+// smtpMailer is an implementation of the Mailer interface delivering messages through an SMTP server.
+type smtpMailer struct {
+	addr     string
+	auth     smtp.Auth
+	from     string
+	sendMail sendMailFunc
+}
+
+// NewSMTPMailer creates a new Mailer backed by the given SMTP server.
+// Authentication is skipped when no username is provided; STARTTLS is used whenever the server supports it.
+func NewSMTPMailer(host, port, username, password, from string) Mailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &smtpMailer{net.JoinHostPort(host, port), auth, from, smtp.SendMail}
+}
+
+// Send delivers the message to the SMTP server.
+func (m *smtpMailer) Send(message Message) error {
+	if err := m.sendMail(m.addr, m.auth, m.from, []string{message.To}, m.format(message)); err != nil {
+		return fmt.Errorf("failed to send email to %s: %w", message.To, err)
+	}
+	return nil
+}
+
+// format builds the RFC 5322 representation of the message.
+func (m *smtpMailer) format(message Message) []byte {
+	var builder strings.Builder
+	builder.WriteString("From: " + headerSanitizer.Replace(m.from) + "\r\n")
+	builder.WriteString("To: " + headerSanitizer.Replace(message.To) + "\r\n")
+	builder.WriteString("Subject: " + headerSanitizer.Replace(message.Subject) + "\r\n")
+	builder.WriteString("MIME-Version: 1.0\r\n")
+	builder.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	builder.WriteString("\r\n")
+	builder.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+	return []byte(builder.String())
+}
@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"errors"
+	"net/smtp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSMTPMailerSend tests that the message is formatted and handed to the SMTP server.
+func TestSMTPMailerSend(t *testing.T) {
+	var (
+		sentAddr string
+		sentTo   []string
+		sentMsg  string
+	)
+
+	mailer := NewSMTPMailer("localhost", "2525", "", "", "no-reply@example.com").(*smtpMailer)
+	mailer.sendMail = func(addr string, _ smtp.Auth, _ string, to []string, msg []byte) error {
+		sentAddr, sentTo, sentMsg = addr, to, string(msg)
+		return nil
+	}
+
+	err := mailer.Send(Message{To: "user@example.com", Subject: "Hello\r\nBcc: evil@example.com", Body: "line 1\nline 2"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost:2525", sentAddr)
+	assert.Equal(t, []string{"user@example.com"}, sentTo)
+	assert.Contains(t, sentMsg, "Subject: HelloBcc: evil@example.com\r\n")
+	assert.Contains(t, sentMsg, "\r\n\r\nline 1\r\nline 2")
+}
+
+// TestSMTPMailerSend_Error tests that delivery failures are reported.
+func TestSMTPMailerSend_Error(t *testing.T) {
+	mailer := NewSMTPMailer("localhost", "2525", "user", "pass", "no-reply@example.com").(*smtpMailer)
+	mailer.sendMail = func(string, smtp.Auth, string, []string, []byte) error {
+		return errors.New("connection refused")
+	}
+
+	assert.Error(t, mailer.Send(Message{To: "user@example.com"}))
+}
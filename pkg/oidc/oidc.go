@@ -0,0 +1,369 @@
+package oidc
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const (
+	// discoveryPath is the path of the provider metadata, relative to the issuer.
+	discoveryPath = "/.well-known/openid-configuration"
+
+	// codeChallengeMethod is the only PKCE method sent to the provider.
+	codeChallengeMethod = "S256"
+
+	// signingAlgorithm is the only algorithm accepted for the ID tokens.
+	signingAlgorithm = "RS256"
+
+	// clockSkew defines the tolerance applied to the time claims of the ID tokens.
+	clockSkew = time.Minute
+)
+
+// Config holds the client registration of the application at the identity provider.
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// Identity represents the user authenticated by the identity provider, as stated by the ID token.
+type Identity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+}
+
+// Provider defines the interface of an OpenID Connect provider, following the authorization code flow with PKCE.
+type Provider interface {
+	// AuthCodeURL builds the URL the user is redirected to in order to authenticate at the provider.
+	AuthCodeURL(state, nonce, codeVerifier string) (string, error)
+	// Authenticate exchanges the authorization code for the ID token, returning the identity it states.
+	Authenticate(code, codeVerifier, nonce string) (*Identity, error)
+}
+
+// discoveryDocument represents the subset of the provider metadata used by the client.
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// jsonWebKey represents an RSA public key published by the provider.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// tokenResponse represents the subset of the token endpoint response used by the client.
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// idTokenClaims represents the claims of an ID token.
+type idTokenClaims struct {
+	Issuer        string   `json:"iss"`
+	Subject       string   `json:"sub"`
+	Audience      audience `json:"aud"`
+	ExpiresAt     int64    `json:"exp"`
+	IssuedAt      int64    `json:"iat"`
+	Nonce         string   `json:"nonce"`
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"email_verified"`
+}
+
+// Valid checks the time claims of the ID token, the remaining claims are checked against the request.
+func (c *idTokenClaims) Valid() error {
+	now := time.Now()
+	if c.ExpiresAt == 0 || now.After(time.Unix(c.ExpiresAt, 0).Add(clockSkew)) {
+		return errors.New("ID token expired")
+	}
+	if now.Add(clockSkew).Before(time.Unix(c.IssuedAt, 0)) {
+		return errors.New("ID token issued in the future")
+	}
+	return nil
+}
+
+// audience represents the aud claim, which providers send either as a string or as a list.
+type audience []string
+
+// UnmarshalJSON decodes the aud claim in both of its forms.
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+	return nil
+}
+
+// contains reports whether the audience includes the client.
+func (a audience) contains(clientID string) bool {
+	for _, value := range a {
+		if value == clientID {
+			return true
+		}
+	}
+	return false
+}
+
+// provider is an implementation of the Provider interface backed by the discovery document of the issuer.
+type provider struct {
+	config    Config
+	client    *http.Client
+	mutex     *sync.Mutex
+	discovery *discoveryDocument
+	keys      map[string]*rsa.PublicKey
+}
+
+// NewProvider creates a new Provider for the given client registration.
+// The discovery document and the signing keys are fetched on first use, so the provider may be unreachable at startup.
+func NewProvider(config Config, client *http.Client) Provider {
+	return &provider{config: config, client: client, mutex: &sync.Mutex{}}
+}
+
+// AuthCodeURL builds the URL of the authorization endpoint, with the S256 challenge of the code verifier.
+func (p *provider) AuthCodeURL(state, nonce, codeVerifier string) (string, error) {
+	discovery, err := p.discover()
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(discovery.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", CodeChallenge(codeVerifier))
+	query.Set("code_challenge_method", codeChallengeMethod)
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+// Authenticate exchanges the authorization code at the token endpoint and validates the returned ID token.
+func (p *provider) Authenticate(code, codeVerifier, nonce string) (*Identity, error) {
+	discovery, err := p.discover()
+	if err != nil {
+		return nil, err
+	}
+
+	rawIDToken, err := p.exchange(discovery.TokenEndpoint, code, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.validateIDToken(discovery, rawIDToken, nonce)
+}
+
+// validateIDToken checks that the ID token was signed by the provider for this client and this login attempt.
+func (p *provider) validateIDToken(discovery *discoveryDocument, rawIDToken, nonce string) (*Identity, error) {
+	claims, err := p.verify(rawIDToken, discovery.JWKSURI)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Issuer != discovery.Issuer {
+		return nil, fmt.Errorf("ID token issued by %s", claims.Issuer)
+	}
+	if !claims.Audience.contains(p.config.ClientID) {
+		return nil, errors.New("ID token issued to another client")
+	}
+	if nonce == "" || claims.Nonce != nonce {
+		return nil, errors.New("ID token nonce mismatch")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("ID token without subject")
+	}
+
+	return &Identity{
+		Issuer:        claims.Issuer,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+	}, nil
+}
+
+// discover fetches the discovery document of the issuer once, checking that it belongs to the configured issuer.
+func (p *provider) discover() (*discoveryDocument, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	discovery := new(discoveryDocument)
+	if err := p.getJSON(strings.TrimSuffix(p.config.Issuer, "/")+discoveryPath, discovery); err != nil {
+		return nil, fmt.Errorf("failed to fetch the discovery document: %w", err)
+	}
+
+	if strings.TrimSuffix(discovery.Issuer, "/") != strings.TrimSuffix(p.config.Issuer, "/") {
+		return nil, fmt.Errorf("discovery document of another issuer: %s", discovery.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, errors.New("incomplete discovery document")
+	}
+
+	p.discovery = discovery
+	return discovery, nil
+}
+
+// exchange redeems the authorization code at the token endpoint, returning the raw ID token.
+func (p *provider) exchange(tokenEndpoint, code, codeVerifier string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.config.RedirectURL},
+		"code_verifier": {codeVerifier},
+	}
+
+	request, err := http.NewRequest(http.MethodPost, tokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	request.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange the authorization code: %w", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the token response: %w", err)
+	}
+
+	tokens := new(tokenResponse)
+	if err := json.Unmarshal(body, tokens); err != nil {
+		return "", fmt.Errorf("failed to decode the token response (status %d): %w", response.StatusCode, err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("authorization code rejected (status %d): %s %s", response.StatusCode, tokens.Error, tokens.ErrorDescription)
+	}
+	if tokens.IDToken == "" {
+		return "", errors.New("token response without ID token")
+	}
+	return tokens.IDToken, nil
+}
+
+// verify checks the signature and the time claims of the ID token.
+func (p *provider) verify(rawIDToken, jwksURI string) (*idTokenClaims, error) {
+	claims := new(idTokenClaims)
+	parser := &jwt.Parser{ValidMethods: []string{signingAlgorithm}}
+
+	_, err := parser.ParseWithClaims(rawIDToken, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.signingKey(jwksURI, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID token: %w", err)
+	}
+	return claims, nil
+}
+
+// signingKey returns the public key with the given ID, fetching the key set again when the key is unknown,
+// so keys rotated by the provider are picked up.
+func (p *provider) signingKey(jwksURI, kid string) (*rsa.PublicKey, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if key, found := p.keys[kid]; found {
+		return key, nil
+	}
+
+	keySet := struct {
+		Keys []jsonWebKey `json:"keys"`
+	}{}
+	if err := p.getJSON(jwksURI, &keySet); err != nil {
+		return nil, fmt.Errorf("failed to fetch the signing keys: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(keySet.Keys))
+	for _, jwk := range keySet.Keys {
+		if jwk.Kty != "RSA" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid signing key %s: %w", jwk.Kid, err)
+		}
+		keys[jwk.Kid] = key
+	}
+	p.keys = keys
+
+	if key, found := keys[kid]; found {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key: %s", kid)
+}
+
+// getJSON fetches the URL, decoding the JSON response into the target.
+func (p *provider) getJSON(url string, target interface{}) error {
+	response, err := p.client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", response.StatusCode, url)
+	}
+	return json.NewDecoder(response.Body).Decode(target)
+}
+
+// publicKey decodes the modulus and the exponent of the key.
+func (k jsonWebKey) publicKey() (*rsa.PublicKey, error) {
+	modulus, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, err
+	}
+	exponent, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, err
+	}
+	if len(modulus) == 0 || len(exponent) == 0 || len(exponent) > 4 {
+		return nil, errors.New("malformed RSA key")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(modulus),
+		E: int(new(big.Int).SetBytes(exponent).Int64()),
+	}, nil
+}
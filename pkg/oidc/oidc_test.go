@@ -0,0 +1,178 @@
+package oidc
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"luizalabs-technical-test/pkg/oidc/oidctest"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStubProvider starts a stub identity provider and a Provider registered at it.
+func newStubProvider(t *testing.T) (*oidctest.Server, Provider) {
+	server := oidctest.NewServer("client", "secret")
+	t.Cleanup(server.Close)
+
+	return server, NewProvider(Config{
+		Issuer:       server.URL,
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost/v1/auth/oidc/callback",
+		Scopes:       []string{"openid", "email"},
+	}, &http.Client{Timeout: 5 * time.Second})
+}
+
+// TestAuthCodeURL tests that the authorization URL carries the client, the state, the nonce and the PKCE challenge.
+func TestAuthCodeURL(t *testing.T) {
+	server, provider := newStubProvider(t)
+
+	authURL, err := provider.AuthCodeURL("state", "nonce", "verifier")
+	require.NoError(t, err)
+
+	parsedURL, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/authorize", parsedURL.Scheme+"://"+parsedURL.Host+parsedURL.Path)
+
+	query := parsedURL.Query()
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, "client", query.Get("client_id"))
+	assert.Equal(t, "openid email", query.Get("scope"))
+	assert.Equal(t, "state", query.Get("state"))
+	assert.Equal(t, "nonce", query.Get("nonce"))
+	assert.Equal(t, CodeChallenge("verifier"), query.Get("code_challenge"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+}
+
+// TestAuthenticate tests the authorization code flow against the stub provider.
+func TestAuthenticate(t *testing.T) {
+	server, provider := newStubProvider(t)
+	user := oidctest.User{Subject: "42", Email: "user@example.com", EmailVerified: true}
+
+	verifier, err := NewCodeVerifier()
+	require.NoError(t, err)
+	authURL, err := provider.AuthCodeURL("state", "nonce", verifier)
+	require.NoError(t, err)
+
+	code, state, err := server.Authorize(authURL, user)
+	require.NoError(t, err)
+	assert.Equal(t, "state", state)
+
+	identity, err := provider.Authenticate(code, verifier, "nonce")
+	require.NoError(t, err)
+	assert.Equal(t, &Identity{Issuer: server.URL, Subject: "42", Email: "user@example.com", EmailVerified: true}, identity)
+
+	_, err = provider.Authenticate(code, verifier, "nonce")
+	assert.Error(t, err, "Expected authorization codes to be single-use")
+}
+
+// TestAuthenticate_Rejected tests that codes redeemed with another verifier or nonce are rejected.
+func TestAuthenticate_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		verifier string
+		nonce    string
+	}{
+		{"wrong code verifier", "another-verifier", "nonce"},
+		{"wrong nonce", "verifier", "another-nonce"},
+		{"missing nonce", "verifier", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, provider := newStubProvider(t)
+
+			authURL, err := provider.AuthCodeURL("state", "nonce", "verifier")
+			require.NoError(t, err)
+			code, _, err := server.Authorize(authURL, oidctest.User{Subject: "42"})
+			require.NoError(t, err)
+
+			_, err = provider.Authenticate(code, tt.verifier, tt.nonce)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestValidateIDToken tests the checks applied to the claims of the ID tokens.
+func TestValidateIDToken(t *testing.T) {
+	server, stubProvider := newStubProvider(t)
+	p := stubProvider.(*provider)
+	discovery, err := p.discover()
+	require.NoError(t, err)
+
+	now := time.Now()
+	claims := func(overrides jwt.MapClaims) jwt.MapClaims {
+		base := jwt.MapClaims{
+			"iss":   server.URL,
+			"sub":   "42",
+			"aud":   "client",
+			"iat":   now.Unix(),
+			"exp":   now.Add(time.Minute).Unix(),
+			"nonce": "nonce",
+		}
+		for key, value := range overrides {
+			base[key] = value
+		}
+		return base
+	}
+
+	_, err = p.validateIDToken(discovery, server.SignIDToken(claims(jwt.MapClaims{"aud": []string{"other", "client"}})), "nonce")
+	assert.NoError(t, err, "Expected audience lists including the client to be accepted")
+
+	tests := []struct {
+		name   string
+		claims jwt.MapClaims
+	}{
+		{"another issuer", claims(jwt.MapClaims{"iss": "https://issuer.example.com"})},
+		{"another client", claims(jwt.MapClaims{"aud": "other"})},
+		{"expired", claims(jwt.MapClaims{"exp": now.Add(-time.Hour).Unix()})},
+		{"issued in the future", claims(jwt.MapClaims{"iat": now.Add(time.Hour).Unix()})},
+		{"without subject", claims(jwt.MapClaims{"sub": ""})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.validateIDToken(discovery, server.SignIDToken(tt.claims), "nonce")
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("tampered signature", func(t *testing.T) {
+		idToken := server.SignIDToken(claims(nil))
+		_, err := p.validateIDToken(discovery, idToken[:len(idToken)-4]+"AAAA", "nonce")
+		assert.Error(t, err)
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		idToken, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims(nil)).SignedString(jwt.UnsafeAllowNoneSignatureType)
+		require.NoError(t, err)
+		_, err = p.validateIDToken(discovery, idToken, "nonce")
+		assert.Error(t, err)
+	})
+}
+
+// TestDiscover_Unreachable tests that an unreachable issuer fails the flow without being cached.
+func TestDiscover_Unreachable(t *testing.T) {
+	server, stubProvider := newStubProvider(t)
+	server.Close()
+
+	_, err := stubProvider.AuthCodeURL("state", "nonce", "verifier")
+	assert.Error(t, err)
+	assert.Nil(t, stubProvider.(*provider).discovery)
+}
+
+// TestCodeChallenge tests the S256 derivation of the PKCE code challenge.
+func TestCodeChallenge(t *testing.T) {
+	verifier, err := NewCodeVerifier()
+	require.NoError(t, err)
+	assert.Len(t, verifier, 43)
+
+	challenge := CodeChallenge(verifier)
+	assert.Len(t, challenge, 43)
+	assert.Equal(t, challenge, CodeChallenge(verifier))
+	assert.NotEqual(t, challenge, CodeChallenge(verifier+"x"))
+}
@@ -0,0 +1,201 @@
+// Package oidctest provides an in-process OpenID Connect provider, standing in for a real identity provider in tests.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const (
+	// KeyID identifies the key signing the ID tokens.
+	KeyID = "oidctest"
+
+	// idTokenExpiration defines the lifetime of the issued ID tokens.
+	idTokenExpiration = 5 * time.Minute
+)
+
+// User represents the account authenticated at the stub provider.
+type User struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+}
+
+// grant represents an authorization code waiting to be exchanged.
+type grant struct {
+	user          User
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+}
+
+// Server is an OpenID Connect provider serving the discovery document, the signing keys and the token endpoint.
+// The consent of the user is simulated with Authorize, which returns the authorization code directly.
+type Server struct {
+	*httptest.Server
+	ClientID     string
+	ClientSecret string
+	key          *rsa.PrivateKey
+	mutex        *sync.Mutex
+	grants       map[string]grant
+}
+
+// NewServer starts a stub provider accepting the given client. It must be closed once the test finishes.
+func NewServer(clientID, clientSecret string) *Server {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	s := &Server{ClientID: clientID, ClientSecret: clientSecret, key: key, mutex: &sync.Mutex{}, grants: map[string]grant{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("/jwks", s.jwks)
+	mux.HandleFunc("/token", s.token)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Authorize simulates the user authenticating at the authorization URL, returning the authorization code
+// and the state to be sent to the callback.
+func (s *Server) Authorize(authURL string, user User) (code, state string, err error) {
+	parsedURL, err := url.Parse(authURL)
+	if err != nil {
+		return "", "", err
+	}
+
+	query := parsedURL.Query()
+	if query.Get("client_id") != s.ClientID || query.Get("response_type") != "code" {
+		return "", "", errors.New("unexpected authorization request")
+	}
+	if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		return "", "", errors.New("authorization request without PKCE")
+	}
+
+	code = randomString()
+	s.mutex.Lock()
+	s.grants[code] = grant{
+		user:          user,
+		redirectURI:   query.Get("redirect_uri"),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+	}
+	s.mutex.Unlock()
+
+	return code, query.Get("state"), nil
+}
+
+// SignIDToken signs the given claims with the key of the provider, allowing tests to forge tampered tokens.
+func (s *Server) SignIDToken(claims jwt.MapClaims) string {
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	idToken.Header["kid"] = KeyID
+
+	signed, err := idToken.SignedString(s.key)
+	if err != nil {
+		panic(err)
+	}
+	return signed
+}
+
+// discovery serves the provider metadata.
+func (s *Server) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                s.URL,
+		"authorization_endpoint":                s.URL + "/authorize",
+		"token_endpoint":                        s.URL + "/token",
+		"jwks_uri":                              s.URL + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+// jwks serves the public key signing the ID tokens.
+func (s *Server) jwks(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": KeyID,
+			"n":   base64.RawURLEncoding.EncodeToString(s.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+		}},
+	})
+}
+
+// token redeems the authorization codes, checking the client credentials and the PKCE code verifier.
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	clientID, clientSecret, _ := r.BasicAuth()
+	clientID, _ = url.QueryUnescape(clientID)
+	clientSecret, _ = url.QueryUnescape(clientSecret)
+	if clientID != s.ClientID || clientSecret != s.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	s.mutex.Lock()
+	code := r.PostForm.Get("code")
+	authorization, found := s.grants[code]
+	delete(s.grants, code)
+	s.mutex.Unlock()
+
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !found || authorization.redirectURI != r.PostForm.Get("redirect_uri") ||
+		base64.RawURLEncoding.EncodeToString(challenge[:]) != authorization.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   int(idTokenExpiration.Seconds()),
+		"id_token": s.SignIDToken(jwt.MapClaims{
+			"iss":            s.URL,
+			"sub":            authorization.user.Subject,
+			"aud":            s.ClientID,
+			"iat":            now.Unix(),
+			"exp":            now.Add(idTokenExpiration).Unix(),
+			"nonce":          authorization.nonce,
+			"email":          authorization.user.Email,
+			"email_verified": authorization.user.EmailVerified,
+		}),
+	})
+}
+
+// writeJSON writes the value as the JSON response.
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// randomString returns a random hex string, used for authorization codes and access tokens.
+func randomString() string {
+	buffer := make([]byte, 16)
+	if _, err := rand.Read(buffer); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(buffer)
+}
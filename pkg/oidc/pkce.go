@@ -0,0 +1,22 @@
+package oidc
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+
+	"luizalabs-technical-test/pkg/crypt"
+)
+
+// codeVerifierRandomBytes defines the amount of random bytes of the code verifiers, encoding to 43 characters.
+const codeVerifierRandomBytes = 32
+
+// NewCodeVerifier generates a random PKCE code verifier (RFC 7636).
+func NewCodeVerifier() (string, error) {
+	return crypt.GenerateRandomToken(codeVerifierRandomBytes)
+}
+
+// CodeChallenge derives the S256 PKCE code challenge of the code verifier.
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
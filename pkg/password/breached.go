@@ -0,0 +1,99 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	// hashPrefixLength defines the size of the SHA-1 prefix used to bucket the list, as in the k-anonymity range API.
+	hashPrefixLength = 5
+
+	// sha1HexLength defines the size of a hex encoded SHA-1 hash.
+	sha1HexLength = 40
+)
+
+// BreachedList defines the interface for checking passwords against known-compromised passwords.
+type BreachedList interface {
+	Contains(password string) bool
+}
+
+// breachedList keeps SHA-1 hash suffixes bucketed by their prefix.
+// Note: lookups follow the k-anonymity model, only the suffixes sharing the password hash prefix are compared.
+type breachedList struct {
+	ranges map[string]map[string]struct{}
+}
+
+// LoadBreachedList reads a breached passwords file. An empty path returns an empty list.
+func LoadBreachedList(path string) (BreachedList, error) {
+	if path == "" {
+		return &breachedList{map[string]map[string]struct{}{}}, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached passwords file: %w", err)
+	}
+	defer file.Close()
+
+	return NewBreachedList(file)
+}
+
+// NewBreachedList parses SHA-1 hashes, one per line, optionally followed by ":<count>" as in the
+// Have I Been Pwned downloads. Blank lines and lines starting with "#" are ignored.
+func NewBreachedList(reader io.Reader) (BreachedList, error) {
+	list := &breachedList{map[string]map[string]struct{}{}}
+
+	scanner := bufio.NewScanner(reader)
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+
+		hash, _, _ := strings.Cut(entry, ":")
+		hash = strings.ToUpper(hash)
+		if _, err := hex.DecodeString(hash); err != nil || len(hash) != sha1HexLength {
+			return nil, fmt.Errorf("invalid SHA-1 hash at line %d of breached passwords list", line)
+		}
+
+		list.add(hash)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read breached passwords list: %w", err)
+	}
+	return list, nil
+}
+
+// Contains reports whether the password hash is present in the list.
+func (l *breachedList) Contains(password string) bool {
+	hash := hashPassword(password)
+	_, found := l.rangeFor(hash[:hashPrefixLength])[hash[hashPrefixLength:]]
+	return found
+}
+
+// rangeFor returns every hash suffix sharing the given prefix.
+func (l *breachedList) rangeFor(prefix string) map[string]struct{} {
+	return l.ranges[prefix]
+}
+
+// add stores the hash, bucketed by its prefix.
+func (l *breachedList) add(hash string) {
+	prefix, suffix := hash[:hashPrefixLength], hash[hashPrefixLength:]
+	if l.ranges[prefix] == nil {
+		l.ranges[prefix] = map[string]struct{}{}
+	}
+	l.ranges[prefix][suffix] = struct{}{}
+}
+
+// hashPassword returns the upper-case hex encoded SHA-1 hash of the password.
+func hashPassword(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
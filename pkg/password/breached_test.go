@@ -0,0 +1,52 @@
+package password
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// passwordSHA1 is the SHA-1 hash of "password".
+const passwordSHA1 = "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8"
+
+// TestNewBreachedList tests parsing hashes with and without counts.
+func TestNewBreachedList(t *testing.T) {
+	list, err := NewBreachedList(strings.NewReader(
+		"# breached passwords\n" + strings.ToLower(passwordSHA1) + ":3861493\n\n" + hashPassword("123456") + "\n",
+	))
+	require.NoError(t, err)
+
+	assert.True(t, list.Contains("password"))
+	assert.True(t, list.Contains("123456"))
+	assert.False(t, list.Contains("correct horse battery staple"))
+}
+
+// TestNewBreachedList_InvalidHash tests that malformed lines are reported.
+func TestNewBreachedList_InvalidHash(t *testing.T) {
+	_, err := NewBreachedList(strings.NewReader("password\n"))
+	assert.Error(t, err)
+}
+
+// TestLoadBreachedList tests loading the list from a file.
+func TestLoadBreachedList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	require.NoError(t, os.WriteFile(path, []byte(passwordSHA1+"\n"), 0o600))
+
+	list, err := LoadBreachedList(path)
+	require.NoError(t, err)
+	assert.True(t, list.Contains("password"))
+
+	_, err = LoadBreachedList(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
+
+// TestLoadBreachedList_EmptyPath tests that no list is loaded without a path.
+func TestLoadBreachedList_EmptyPath(t *testing.T) {
+	list, err := LoadBreachedList("")
+	require.NoError(t, err)
+	assert.False(t, list.Contains("password"))
+}
@@ -0,0 +1,118 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// minEmailSubstringLength defines the shortest email local part checked by the no-email-substring rule,
+// avoiding false positives for very short addresses.
+const minEmailSubstringLength = 3
+
+// Errors representing each password policy violation.
+var (
+	ErrTooShort      = errors.New("password shorter than the minimum length")
+	ErrTooLong       = errors.New("password longer than the maximum length")
+	ErrMissingUpper  = errors.New("password without uppercase letters")
+	ErrMissingLower  = errors.New("password without lowercase letters")
+	ErrMissingDigit  = errors.New("password without digits")
+	ErrMissingSymbol = errors.New("password without symbols")
+	ErrContainsEmail = errors.New("password contains the email address")
+	ErrBreached      = errors.New("password found in breached passwords list")
+)
+
+// Policy defines the rules a password must follow. MinLength and MaxLength are counted in characters,
+// while MaxBytes caps the encoded length, for hashers limiting their input (e.g. bcrypt).
+type Policy struct {
+	MinLength     int
+	MaxLength     int
+	MaxBytes      int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	ForbidEmail   bool
+}
+
+// Validator defines the interface for checking passwords against the policy.
+type Validator interface {
+	Validate(password, email string) error
+}
+
+// validator is an implementation of the Validator interface backed by a Policy and a breached passwords list.
+type validator struct {
+	policy   Policy
+	breached BreachedList
+}
+
+// NewValidator creates a new Validator enforcing the policy and rejecting passwords present in the breached list.
+func NewValidator(policy Policy, breached BreachedList) Validator {
+	return &validator{policy, breached}
+}
+
+// Validate checks the password, returning the first violated rule.
+func (v *validator) Validate(password, email string) error {
+	length := utf8.RuneCountInString(password)
+	if length < v.policy.MinLength {
+		return ErrTooShort
+	}
+	if v.policy.MaxLength > 0 && length > v.policy.MaxLength {
+		return ErrTooLong
+	}
+	if v.policy.MaxBytes > 0 && len(password) > v.policy.MaxBytes {
+		return ErrTooLong
+	}
+
+	if err := v.validateCharacterClasses(password); err != nil {
+		return err
+	}
+
+	if v.policy.ForbidEmail && containsEmail(password, email) {
+		return ErrContainsEmail
+	}
+
+	if v.breached.Contains(password) {
+		return ErrBreached
+	}
+	return nil
+}
+
+// validateCharacterClasses checks the character classes required by the policy.
+func (v *validator) validateCharacterClasses(password string) error {
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, char := range password {
+		switch {
+		case unicode.IsUpper(char):
+			hasUpper = true
+		case unicode.IsLower(char):
+			hasLower = true
+		case unicode.IsDigit(char):
+			hasDigit = true
+		case unicode.IsPunct(char), unicode.IsSymbol(char), unicode.IsSpace(char):
+			hasSymbol = true
+		}
+	}
+
+	switch {
+	case v.policy.RequireUpper && !hasUpper:
+		return ErrMissingUpper
+	case v.policy.RequireLower && !hasLower:
+		return ErrMissingLower
+	case v.policy.RequireDigit && !hasDigit:
+		return ErrMissingDigit
+	case v.policy.RequireSymbol && !hasSymbol:
+		return ErrMissingSymbol
+	}
+	return nil
+}
+
+// containsEmail reports whether the password contains the local part of the email, case-insensitively.
+func containsEmail(password, email string) bool {
+	localPart, _, _ := strings.Cut(strings.ToLower(email), "@")
+	if utf8.RuneCountInString(localPart) < minEmailSubstringLength {
+		return false
+	}
+	return strings.Contains(strings.ToLower(password), localPart)
+}
@@ -0,0 +1,64 @@
+package password
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestValidate tests each rule of the password policy.
+func TestValidate(t *testing.T) {
+	policy := Policy{
+		MinLength:     8,
+		MaxLength:     16,
+		MaxBytes:      20,
+		RequireUpper:  true,
+		RequireLower:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+		ForbidEmail:   true,
+	}
+	breached := &breachedList{map[string]map[string]struct{}{}}
+	breached.add(hashPassword("Breached#2024"))
+
+	v := NewValidator(policy, breached)
+
+	tests := []struct {
+		name     string
+		password string
+		email    string
+		expected error
+	}{
+		{"Too short", "Ab1#", "user@example.com", ErrTooShort},
+		{"Too long", "Abcdefgh1#abcdefgh", "user@example.com", ErrTooLong},
+		{"Too long in bytes", "Sêñhåçãõ#123456", "user@example.com", ErrTooLong},
+		{"Missing uppercase", "abcdefg1#", "user@example.com", ErrMissingUpper},
+		{"Missing lowercase", "ABCDEFG1#", "user@example.com", ErrMissingLower},
+		{"Missing digit", "Abcdefgh#", "user@example.com", ErrMissingDigit},
+		{"Missing symbol", "Abcdefgh1", "user@example.com", ErrMissingSymbol},
+		{"Contains email", "xJohnDoe1#", "johndoe@example.com", ErrContainsEmail},
+		{"Breached", "Breached#2024", "user@example.com", ErrBreached},
+		{"Valid", "Str0ng#Secret", "user@example.com", nil},
+		{"Valid multi-byte", "Sêñhå#1234", "user@example.com", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, v.Validate(tt.password, tt.email))
+		})
+	}
+}
+
+// TestValidate_EmptyPolicy tests that an empty policy only checks the breached list.
+func TestValidate_EmptyPolicy(t *testing.T) {
+	v := NewValidator(Policy{}, &breachedList{map[string]map[string]struct{}{}})
+
+	assert.NoError(t, v.Validate("a", "a@example.com"))
+}
+
+// TestContainsEmail tests the no-email-substring rule.
+func TestContainsEmail(t *testing.T) {
+	assert.True(t, containsEmail("my-JOHN-pass", "john@example.com"))
+	assert.False(t, containsEmail("my-jo-pass", "jo@example.com"), "Expected short local parts to be ignored")
+	assert.False(t, containsEmail("unrelated", "john@example.com"))
+}
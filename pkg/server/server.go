@@ -1,12 +1,25 @@
 package server
 
 import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
 	"github.com/gin-gonic/gin"
 )
 
 // GinServerImp interface defines the methods required for a server.
 type GinServerImp interface {
 	Run(addr string) error
+	// Shutdown turns the server unready, keeps accepting connections for the delay, then stops accepting them and
+	// waits for the in-flight requests until the context is done.
+	Shutdown(ctx context.Context, delay time.Duration) error
+	// Ready reports whether the server is running and accepting requests.
+	Ready() bool
 	SetupCustom(configureHandlers func(router *gin.Engine))
 	SetupHandlers(version string, handlers ...func(*gin.RouterGroup))
 	SetupMiddleware(middleware ...gin.HandlerFunc)
@@ -14,23 +27,83 @@ type GinServerImp interface {
 
 // ginServer struct implements the Server interface.
 type ginServer struct {
-	router *gin.Engine
+	router     *gin.Engine
+	mutex      *sync.Mutex
+	httpServer *http.Server
+	stopped    bool
+	ready      *atomic.Bool
 }
 
 // NewGinServer creates a new instance of the Gin server.
 func NewGinServer() GinServerImp {
 	router := gin.Default()
 	router.HandleMethodNotAllowed = true
-
-	return &ginServer{router: router}
+	return &ginServer{router: router, mutex: &sync.Mutex{}, ready: &atomic.Bool{}}
 }
 
-// Run starts the server on the specified address.
+// Run starts the server on the specified address, blocking until it fails or is shut down.
+// The server turns ready once it listens on the address. A server stopped by Shutdown returns no error.
 func (s *ginServer) Run(addr string) error {
-	return s.router.Run(addr)
+	s.mutex.Lock()
+	switch {
+	case s.stopped:
+		s.mutex.Unlock()
+		return nil
+	case s.httpServer != nil:
+		s.mutex.Unlock()
+		return errors.New("server already started")
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		s.mutex.Unlock()
+		return err
+	}
+	s.httpServer = &http.Server{Addr: addr, Handler: s.router}
+	s.ready.Store(true)
+	s.mutex.Unlock()
+
+	err = s.httpServer.Serve(listener)
+	s.ready.Store(false)
+
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Shutdown turns the server unready, closes its listener once the delay is over and waits for the in-flight requests
+// to finish. A server shut down before running never starts.
+// Note: the listener stays open during the delay, so the readiness probe answers as unready and the load balancer
+// stops routing requests before connections are refused. When the context is done first, the delay is cut short,
+// the remaining connections are left open and the context error is returned.
+func (s *ginServer) Shutdown(ctx context.Context, delay time.Duration) error {
+	s.mutex.Lock()
+	s.stopped = true
+	s.ready.Store(false)
+	httpServer := s.httpServer
+	s.mutex.Unlock()
+
+	if httpServer == nil {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	return httpServer.Shutdown(ctx)
+}
+
+// Ready reports whether the server is running and hasn't been asked to shut down.
+func (s *ginServer) Ready() bool {
+	return s.ready.Load()
 }
 
 // SetupMiddleware sets up middleware for the router.
+// Note: middlewares only apply to the routes registered after them, so they must be set up before the handlers.
 func (s *ginServer) SetupMiddleware(middleware ...gin.HandlerFunc) {
 	for _, mw := range middleware {
 		s.router.Use(mw)
@@ -40,7 +113,6 @@ func (s *ginServer) SetupMiddleware(middleware ...gin.HandlerFunc) {
 // SetupHandlers registers multiple hander setup functions in the server.
 func (s *ginServer) SetupHandlers(version string, handlers ...func(*gin.RouterGroup)) {
 	apiVersioning := s.router.Group("/" + version)
-
 	for _, route := range handlers {
 		route(apiVersioning)
 	}
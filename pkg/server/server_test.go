@@ -1,6 +1,8 @@
 package server
 
 import (
+	"context"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -99,6 +101,141 @@ func (suite *ServerTestSuite) TestRun() {
 	assert.NoError(suite.T(), err)
 }
 
+// TestShutdown tests that in-flight requests are drained, and that the server turns unready as soon as it shuts down.
+func (suite *ServerTestSuite) TestShutdown() {
+	server := NewGinServer()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server.SetupHandlers("v1", func(rg *gin.RouterGroup) {
+		rg.GET("/slow", func(c *gin.Context) {
+			close(started)
+			<-release
+			c.JSON(http.StatusOK, gin.H{"status": "done"})
+		})
+	})
+
+	// Note: a free port is reserved and released, so the server can listen on a known address.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	addr := listener.Addr().String()
+	suite.Require().NoError(listener.Close())
+
+	runErr := make(chan error, 1)
+	go func() { runErr <- server.Run(addr) }()
+	suite.Require().Eventually(server.Ready, time.Second, 10*time.Millisecond)
+
+	responseCode := make(chan int, 1)
+	go func() {
+		response, err := http.Get("http://" + addr + "/v1/slow")
+		if err != nil {
+			responseCode <- 0
+			return
+		}
+		response.Body.Close()
+		responseCode <- response.StatusCode
+	}()
+	<-started
+
+	shutdownErr := make(chan error, 1)
+	go func() { shutdownErr <- server.Shutdown(context.Background(), 0) }()
+	suite.Eventually(func() bool { return !server.Ready() }, time.Second, 10*time.Millisecond)
+
+	_, err = net.Dial("tcp", addr)
+	suite.Error(err, "Expected the listener to be closed while draining")
+
+	close(release)
+	suite.Equal(http.StatusOK, <-responseCode)
+	suite.NoError(<-shutdownErr)
+	suite.NoError(<-runErr)
+}
+
+// TestShutdownTimeout tests that the drain is given up once the context is done.
+func (suite *ServerTestSuite) TestShutdownTimeout() {
+	server := NewGinServer()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	server.SetupHandlers("v1", func(rg *gin.RouterGroup) {
+		rg.GET("/stuck", func(c *gin.Context) {
+			close(started)
+			<-release
+		})
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	addr := listener.Addr().String()
+	suite.Require().NoError(listener.Close())
+
+	go func() { _ = server.Run(addr) }()
+	suite.Require().Eventually(server.Ready, time.Second, 10*time.Millisecond)
+	go func() {
+		if response, err := http.Get("http://" + addr + "/v1/stuck"); err == nil {
+			response.Body.Close()
+		}
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	suite.ErrorIs(server.Shutdown(ctx, 0), context.DeadlineExceeded)
+}
+
+// TestShutdownDelay tests that the server keeps answering as unready during the delay, before closing its listener.
+func (suite *ServerTestSuite) TestShutdownDelay() {
+	server := NewGinServer()
+	server.SetupHandlers("v1", func(rg *gin.RouterGroup) {
+		rg.GET("/ready", func(c *gin.Context) {
+			if !server.Ready() {
+				c.Status(http.StatusServiceUnavailable)
+				return
+			}
+			c.Status(http.StatusOK)
+		})
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	addr := listener.Addr().String()
+	suite.Require().NoError(listener.Close())
+
+	runErr := make(chan error, 1)
+	go func() { runErr <- server.Run(addr) }()
+	suite.Require().Eventually(server.Ready, time.Second, 10*time.Millisecond)
+
+	shutdownErr := make(chan error, 1)
+	go func() { shutdownErr <- server.Shutdown(context.Background(), 300*time.Millisecond) }()
+	suite.Require().Eventually(func() bool { return !server.Ready() }, time.Second, 10*time.Millisecond)
+
+	response, err := http.Get("http://" + addr + "/v1/ready")
+	suite.Require().NoError(err, "Expected the listener to stay open during the delay")
+	response.Body.Close()
+	suite.Equal(http.StatusServiceUnavailable, response.StatusCode)
+
+	suite.NoError(<-shutdownErr)
+	suite.NoError(<-runErr)
+}
+
+// TestRunAddressInUse tests that a server failing to listen returns the error without turning ready.
+func (suite *ServerTestSuite) TestRunAddressInUse() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	defer listener.Close()
+
+	server := NewGinServer()
+	suite.Error(server.Run(listener.Addr().String()))
+	suite.False(server.Ready())
+}
+
+// TestShutdownBeforeRun tests that a server shut down before running never starts.
+func (suite *ServerTestSuite) TestShutdownBeforeRun() {
+	server := NewGinServer()
+
+	suite.NoError(server.Shutdown(context.Background(), 0))
+	suite.NoError(server.Run("127.0.0.1:0"))
+	suite.False(server.Ready())
+}
+
 // TestMain runs the test suite.
 func TestMain(m *testing.T) {
 	suite.Run(m, new(ServerTestSuite))
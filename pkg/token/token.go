@@ -24,6 +24,10 @@ const (
 
 	// BearerTokenParts defines the length between auth attribute and JWT token when the string is splitted.
 	BearerTokenParts = 2
+
+	// PurposeClaimName is the custom key marking single-purpose tokens (e.g. email verification),
+	// which must never be accepted as access tokens.
+	PurposeClaimName = "Purpose"
 )
 
 // CreateToken generates a JWT token using a secret key and custom claims.
@@ -59,6 +63,11 @@ func ExtractTokenClaimsFromContext(ctx context.Context, secretKey string) (Custo
 		return CustomClaims{}, errors.New("failed to parse context to gin context")
 	}
 
+	// Note: claims already set by an authentication middleware (e.g. API keys) take precedence over the bearer token.
+	if claims, err := ClaimsFromContext(ginContext); err == nil {
+		return *claims, nil
+	}
+
 	token := ExtractBearerToken(ginContext.Request)
 	if token == str.EmptyString {
 		return CustomClaims{}, errors.New("token not found")
@@ -72,6 +81,60 @@ func ExtractTokenClaimsFromContext(ctx context.Context, secretKey string) (Custo
 	return *tokenClaims, nil
 }
 
+// ClaimsFromContext retrieves the claims stored in the Gin context by an authentication middleware.
+func ClaimsFromContext(c *gin.Context) (*CustomClaims, error) {
+	value, exists := c.Get(ClaimsHeaderName)
+	if !exists {
+		return nil, errors.New("claims not found in context")
+	}
+
+	claims, ok := value.(*CustomClaims)
+	if !ok {
+		return nil, errors.New("failed to parse claims from context")
+	}
+
+	return claims, nil
+}
+
+// StringKey returns the custom claim stored under the given name as a string.
+func (c *CustomClaims) StringKey(name string) string {
+	value, _ := c.CustomKeys[name].(string)
+	return value
+}
+
+// UintKey returns the custom claim stored under the given name as an unsigned integer.
+// Note: numbers decoded from JSON tokens are float64, while freshly built claims keep their original type.
+func (c *CustomClaims) UintKey(name string) uint {
+	switch value := c.CustomKeys[name].(type) {
+	case float64:
+		return uint(value)
+	case uint:
+		return value
+	case int:
+		return uint(value)
+	default:
+		return 0
+	}
+}
+
+// StringSliceKey returns the custom claim stored under the given name as a slice of strings.
+func (c *CustomClaims) StringSliceKey(name string) []string {
+	switch value := c.CustomKeys[name].(type) {
+	case []string:
+		return value
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
 // ExtractBearerToken extracts the Bearer token from the Authorization header of the HTTP request.
 func ExtractBearerToken(r *http.Request) string {
 	authHeader := r.Header.Get("Authorization")
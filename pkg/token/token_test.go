@@ -218,6 +218,51 @@ func (suite *TokenTestSuite) TestExtractBearerToken() {
 	}
 }
 
+func (suite *TokenTestSuite) TestClaimsFromContext() {
+	ginContext := &gin.Context{}
+
+	_, err := token.ClaimsFromContext(ginContext)
+	assert.Error(suite.T(), err)
+
+	ginContext.Set(token.ClaimsHeaderName, "not-claims")
+	_, err = token.ClaimsFromContext(ginContext)
+	assert.Error(suite.T(), err)
+
+	expected := &token.CustomClaims{CustomKeys: map[string]any{"Email": "test@example.com"}}
+	ginContext.Set(token.ClaimsHeaderName, expected)
+	claims, err := token.ClaimsFromContext(ginContext)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expected, claims)
+
+	// Claims in context take precedence over the (missing) bearer token.
+	extracted, err := token.ExtractTokenClaimsFromContext(ginContext, "secret_key")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), *expected, extracted)
+}
+
+func (suite *TokenTestSuite) TestCustomClaimsKeys() {
+	claims := token.CustomClaims{
+		CustomKeys: map[string]any{
+			"Email":     "test@example.com",
+			"FloatID":   float64(42),
+			"UintID":    uint(7),
+			"IntID":     3,
+			"Scopes":    []interface{}{"address:read", 10},
+			"RawScopes": []string{"address:read"},
+		},
+	}
+
+	assert.Equal(suite.T(), "test@example.com", claims.StringKey("Email"))
+	assert.Equal(suite.T(), str.EmptyString, claims.StringKey("Missing"))
+	assert.Equal(suite.T(), uint(42), claims.UintKey("FloatID"))
+	assert.Equal(suite.T(), uint(7), claims.UintKey("UintID"))
+	assert.Equal(suite.T(), uint(3), claims.UintKey("IntID"))
+	assert.Equal(suite.T(), uint(0), claims.UintKey("Email"))
+	assert.Equal(suite.T(), []string{"address:read"}, claims.StringSliceKey("Scopes"))
+	assert.Equal(suite.T(), []string{"address:read"}, claims.StringSliceKey("RawScopes"))
+	assert.Nil(suite.T(), claims.StringSliceKey("Missing"))
+}
+
 // Helper function to create a gin context with a mock request containing a bearer token
 func createGinContextWithToken(token string) context.Context {
 	ginContext := &gin.Context{
@@ -0,0 +1,107 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parameters of the generated codes, matching the defaults of the common authenticator apps (RFC 6238).
+const (
+	Digits = 6
+	Period = 30 * time.Second
+)
+
+const (
+	// secretSize defines the amount of random bytes of a secret, matching the SHA-1 block recommendation (RFC 4226).
+	secretSize = 20
+
+	// allowedSkew defines how many periods before and after the current one are accepted, tolerating clock drift.
+	allowedSkew = 1
+)
+
+// encoding is the base32 alphabet used by authenticator apps, without padding.
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random secret, base32 encoded.
+func GenerateSecret() (string, error) {
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(secret), nil
+}
+
+// URI builds the otpauth URI used by authenticator apps to enrol the secret, usually rendered as a QR code.
+func URI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", strconv.Itoa(Digits))
+	query.Set("period", strconv.Itoa(int(Period.Seconds())))
+
+	label := url.PathEscape(issuer + ":" + account)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// Code returns the code of the secret for the period containing the given time.
+func Code(secret string, at time.Time) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+	return hotp(key, uint64(at.Unix()/int64(Period.Seconds()))), nil
+}
+
+// Validate reports whether the code matches the secret at the given time, accepting the adjacent periods.
+func Validate(secret, code string, at time.Time) bool {
+	_, ok := Match(secret, code, at)
+	return ok
+}
+
+// Match is like Validate, also returning the counter of the period the code matched,
+// so callers can refuse a code whose counter is not greater than the last accepted one.
+func Match(secret, code string, at time.Time) (int64, bool) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(code) != Digits {
+		return 0, false
+	}
+
+	counter := at.Unix() / int64(Period.Seconds())
+	for skew := -allowedSkew; skew <= allowedSkew; skew++ {
+		expected := hotp(key, uint64(counter+int64(skew)))
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return counter + int64(skew), true
+		}
+	}
+	return 0, false
+}
+
+// hotp computes the HMAC-based one-time password of the counter (RFC 4226, section 5.3).
+func hotp(key []byte, counter uint64) string {
+	message := make([]byte, 8)
+	binary.BigEndian.PutUint64(message, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(message)
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation: the low nibble of the last byte selects 4 bytes of the digest
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for range Digits {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", Digits, value%modulo)
+}
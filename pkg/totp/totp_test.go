@@ -0,0 +1,94 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfcSecret is the SHA-1 secret of the RFC 6238 test vectors ("12345678901234567890").
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	// Note: RFC 6238 (appendix B) lists 8 digit codes, so only their last 6 digits are expected.
+	tests := []struct {
+		unix     int64
+		expected string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := Code(rfcSecret, time.Unix(tt.unix, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, code, "unix time %d", tt.unix)
+	}
+}
+
+func TestCode_InvalidSecret(t *testing.T) {
+	_, err := Code("not base32!", time.Now())
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+
+	assert.True(t, Validate(rfcSecret, "081804", now))
+	assert.True(t, Validate(rfcSecret, "081804", now.Add(Period)), "Expected previous period to be accepted")
+	assert.True(t, Validate(rfcSecret, "081804", now.Add(-Period)), "Expected next period to be accepted")
+	assert.False(t, Validate(rfcSecret, "081804", now.Add(3*Period)))
+	assert.False(t, Validate(rfcSecret, "000000", now))
+	assert.False(t, Validate(rfcSecret, "81804", now))
+	assert.False(t, Validate("not base32!", "081804", now))
+}
+
+func TestMatch(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	counter := now.Unix() / int64(Period.Seconds())
+
+	matched, ok := Match(rfcSecret, "081804", now)
+	assert.True(t, ok)
+	assert.Equal(t, counter, matched)
+
+	matched, ok = Match(rfcSecret, "081804", now.Add(Period))
+	assert.True(t, ok)
+	assert.Equal(t, counter, matched, "Expected the counter of the period the code belongs to")
+
+	_, ok = Match(rfcSecret, "000000", now)
+	assert.False(t, ok)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	assert.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	code, err := Code(secret, time.Now())
+	assert.NoError(t, err)
+	assert.True(t, Validate(secret, code, time.Now()))
+
+	other, err := GenerateSecret()
+	assert.NoError(t, err)
+	assert.NotEqual(t, secret, other)
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("Luiza Labs", "user@example.com", "JBSWY3DPEHPK3PXP")
+
+	parsed, err := url.Parse(uri)
+	assert.NoError(t, err)
+	assert.Equal(t, "otpauth", parsed.Scheme)
+	assert.Equal(t, "totp", parsed.Host)
+	assert.Equal(t, "/Luiza Labs:user@example.com", parsed.Path)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", parsed.Query().Get("secret"))
+	assert.Equal(t, "Luiza Labs", parsed.Query().Get("issuer"))
+	assert.Equal(t, "6", parsed.Query().Get("digits"))
+	assert.Equal(t, "30", parsed.Query().Get("period"))
+}